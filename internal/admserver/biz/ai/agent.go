@@ -9,11 +9,14 @@ import (
 	"github.com/jinzhu/copier"
 	"gorm.io/gorm"
 
+	"github.com/bingo-project/bingo/internal/pkg/ai"
 	"github.com/bingo-project/bingo/internal/pkg/errno"
 	"github.com/bingo-project/bingo/internal/pkg/log"
 	"github.com/bingo-project/bingo/internal/pkg/model"
 	"github.com/bingo-project/bingo/internal/pkg/store"
+	aipkg "github.com/bingo-project/bingo/pkg/ai"
 	v1 "github.com/bingo-project/bingo/pkg/api/apiserver/v1"
+	"github.com/bingo-project/bingo/pkg/contextx"
 )
 
 // AiAgentBiz defines AI agent management interface for admin.
@@ -38,20 +41,48 @@ func NewAiAgent(ds store.IStore) AiAgentBiz {
 // toAgentInfo converts model.AiAgentM to v1.AiAgentInfo.
 func toAgentInfo(m *model.AiAgentM) *v1.AiAgentInfo {
 	return &v1.AiAgentInfo{
-		AgentID:      m.AgentID,
-		Name:         m.Name,
-		Description:  m.Description,
-		Icon:         m.Icon,
-		Category:     string(m.Category),
-		SystemPrompt: m.SystemPrompt,
-		Model:        m.Model,
-		Temperature:  m.Temperature,
-		MaxTokens:    m.MaxTokens,
-		Sort:         m.Sort,
-		Status:       string(m.Status),
+		AgentID:        m.AgentID,
+		Name:           m.Name,
+		Description:    m.Description,
+		Icon:           m.Icon,
+		Category:       string(m.Category),
+		SystemPrompt:   m.SystemPrompt,
+		Model:          m.Model,
+		Temperature:    m.Temperature,
+		MaxTokens:      m.MaxTokens,
+		Tools:          m.Tools,
+		EmbeddingModel: m.EmbeddingModel,
+		Sort:           m.Sort,
+		Status:         string(m.Status),
+		Version:        m.PublishedVersion,
+
+		CacheTTL:               m.CacheTTL,
+		SemanticCacheTTL:       m.SemanticCacheTTL,
+		SemanticCacheThreshold: m.SemanticCacheThreshold,
+		Guardrails:             fromGuardrailRules(m.Guardrails),
 	}
 }
 
+// toGuardrailRules converts request guardrails to model rules.
+func toGuardrailRules(rules []v1.AiGuardrailRule) []model.AiGuardrailRule {
+	ret := make([]model.AiGuardrailRule, len(rules))
+	for i, r := range rules {
+		ret[i] = model.AiGuardrailRule(r)
+	}
+
+	return ret
+}
+
+// fromGuardrailRules converts model guardrails to API rules.
+func fromGuardrailRules(rules []model.AiGuardrailRule) []v1.AiGuardrailRule {
+	ret := make([]v1.AiGuardrailRule, len(rules))
+	for i, r := range rules {
+		ret[i] = v1.AiGuardrailRule(r)
+	}
+
+	return ret
+}
+
 func (b *aiAgentBiz) Create(ctx context.Context, req *v1.CreateAiAgentRequest) (*v1.AiAgentInfo, error) {
 	// Check if agent already exists
 	existing, err := b.ds.AiAgents().GetByAgentID(ctx, req.AgentID)
@@ -59,6 +90,13 @@ func (b *aiAgentBiz) Create(ctx context.Context, req *v1.CreateAiAgentRequest) (
 		return nil, errno.ErrResourceAlreadyExists.WithMessage("agent_id already exists: %s", req.AgentID)
 	}
 
+	if err := b.validateEmbeddingModel(ctx, req.EmbeddingModel); err != nil {
+		return nil, err
+	}
+	if err := b.validateGuardrails(ctx, req.Guardrails); err != nil {
+		return nil, err
+	}
+
 	// Set default category if not provided
 	category := model.AiAgentCategoryGeneral
 	if req.Category != "" {
@@ -66,20 +104,42 @@ func (b *aiAgentBiz) Create(ctx context.Context, req *v1.CreateAiAgentRequest) (
 	}
 
 	agent := &model.AiAgentM{
-		AgentID:      req.AgentID,
-		Name:         req.Name,
-		Description:  req.Description,
-		Icon:         req.Icon,
-		Category:     category,
-		SystemPrompt: req.SystemPrompt,
-		Model:        req.Model,
-		Temperature:  req.Temperature,
-		MaxTokens:    req.MaxTokens,
-		Sort:         req.Sort,
-		Status:       model.AiAgentStatusActive,
-	}
-
-	if err := b.ds.AiAgents().Create(ctx, agent); err != nil {
+		AgentID:          req.AgentID,
+		Name:             req.Name,
+		Description:      req.Description,
+		Icon:             req.Icon,
+		Category:         category,
+		SystemPrompt:     req.SystemPrompt,
+		Model:            req.Model,
+		Temperature:      req.Temperature,
+		MaxTokens:        req.MaxTokens,
+		Tools:            req.Tools,
+		EmbeddingModel:   req.EmbeddingModel,
+		Sort:             req.Sort,
+		Status:           model.AiAgentStatusActive,
+		PublishedVersion: 1,
+
+		CacheTTL:               req.CacheTTL,
+		SemanticCacheTTL:       req.SemanticCacheTTL,
+		SemanticCacheThreshold: model.DefaultSemanticCacheThreshold,
+		Guardrails:             toGuardrailRules(req.Guardrails),
+	}
+	if req.SemanticCacheThreshold > 0 {
+		agent.SemanticCacheThreshold = req.SemanticCacheThreshold
+	}
+
+	// The first version is published with the agent
+	version := model.NewAiAgentVersion(agent, 1)
+	version.Author = contextx.Username(ctx)
+	version.Note = req.Note
+	err = b.ds.TX(ctx, func(ctx context.Context) error {
+		if err := b.ds.AiAgents().Create(ctx, agent); err != nil {
+			return err
+		}
+
+		return b.ds.AiAgentVersion().Create(ctx, version)
+	})
+	if err != nil {
 		return nil, errno.ErrDBWrite.WithMessage("create ai agent: %v", err)
 	}
 
@@ -139,20 +199,57 @@ func (b *aiAgentBiz) Update(ctx context.Context, agentID string, req *v1.UpdateA
 		return nil, errno.ErrDBRead.WithMessage("get ai agent: %v", err)
 	}
 
-	// Update fields
-	_ = copier.CopyWithOption(agent, req, copier.Option{IgnoreEmpty: true})
-	if req.Category != "" {
-		agent.Category = model.AiAgentCategory(req.Category)
+	if err := b.validateEmbeddingModel(ctx, req.EmbeddingModel); err != nil {
+		return nil, err
 	}
-	if req.Status != "" {
-		agent.Status = model.AiAgentStatus(req.Status)
+	if err := b.validateGuardrails(ctx, req.Guardrails); err != nil {
+		return nil, err
+	}
+
+	// Changed prompt or generation settings are saved as a new published version
+	version := model.NewAiAgentVersion(agent, 0)
+	_ = copier.CopyWithOption(version, req, copier.Option{IgnoreEmpty: true})
+	if req.Tools != nil {
+		version.Tools = req.Tools
 	}
+	changed := !version.SameSettings(model.NewAiAgentVersion(agent, 0))
+	version.Author = contextx.Username(ctx)
+
+	err = b.ds.TX(ctx, func(ctx context.Context) error {
+		if changed {
+			if err := saveAgentVersion(ctx, b.ds, agent, version, true); err != nil {
+				return err
+			}
+		}
 
-	if err := b.ds.AiAgents().Update(ctx, agent); err != nil {
+		// Update other fields
+		_ = copier.CopyWithOption(agent, req, copier.Option{IgnoreEmpty: true})
+		if req.Category != "" {
+			agent.Category = model.AiAgentCategory(req.Category)
+		}
+		if req.Status != "" {
+			agent.Status = model.AiAgentStatus(req.Status)
+		}
+		if req.CacheTTL != nil {
+			agent.CacheTTL = *req.CacheTTL
+		}
+		if req.SemanticCacheTTL != nil {
+			agent.SemanticCacheTTL = *req.SemanticCacheTTL
+		}
+		if req.SemanticCacheThreshold != nil {
+			agent.SemanticCacheThreshold = *req.SemanticCacheThreshold
+		}
+		if req.Guardrails != nil {
+			agent.Guardrails = toGuardrailRules(req.Guardrails)
+		}
+
+		return b.ds.AiAgents().Update(ctx, agent)
+	})
+	if err != nil {
 		return nil, errno.ErrDBWrite.WithMessage("update ai agent: %v", err)
 	}
 
-	log.C(ctx).Infow("ai agent updated", "agent_id", agent.AgentID)
+	log.C(ctx).Infow("ai agent updated", "agent_id", agent.AgentID, "version", agent.PublishedVersion)
 
 	return toAgentInfo(agent), nil
 }
@@ -176,3 +273,37 @@ func (b *aiAgentBiz) Delete(ctx context.Context, agentID string) error {
 
 	return nil
 }
+
+// validateEmbeddingModel checks that the knowledge base model, if set, is an active embedding model.
+func (b *aiAgentBiz) validateEmbeddingModel(ctx context.Context, name string) error {
+	if name == "" {
+		return nil
+	}
+
+	m, err := b.ds.AiModel().FindActiveByModel(ctx, name)
+	if err != nil {
+		return errno.ErrAIModelNotFound.WithMessage("embedding model not found: %s", name)
+	}
+	if m.Type != model.AiModelTypeEmbedding {
+		return errno.ErrAIEmbeddingNotSupported.WithMessage("model %s is not an embedding model", name)
+	}
+
+	return nil
+}
+
+// validateGuardrails checks the agent's guardrail rules and that their moderation models are active.
+func (b *aiAgentBiz) validateGuardrails(ctx context.Context, rules []v1.AiGuardrailRule) error {
+	for i, rule := range toGuardrailRules(rules) {
+		if err := ai.ValidateGuardrail(rule); err != nil {
+			return errno.ErrInvalidArgument.WithMessage("guardrails[%d]: %v", i, err)
+		}
+		if rule.Type != aipkg.GuardModeration {
+			continue
+		}
+		if _, err := b.ds.AiModel().FindActiveByModel(ctx, rule.Model); err != nil {
+			return errno.ErrAIModelNotFound.WithMessage("moderation model not found: %s", rule.Model)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,309 @@
+// ABOUTME: AI agent version business logic for admin management.
+// ABOUTME: Lists, creates, publishes and diffs immutable agent versions; publishing an older version rolls back.
+package ai
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/jinzhu/copier"
+	"gorm.io/gorm"
+
+	"github.com/bingo-project/bingo/internal/pkg/errno"
+	"github.com/bingo-project/bingo/internal/pkg/log"
+	"github.com/bingo-project/bingo/internal/pkg/model"
+	"github.com/bingo-project/bingo/internal/pkg/store"
+	v1 "github.com/bingo-project/bingo/pkg/api/apiserver/v1"
+	"github.com/bingo-project/bingo/pkg/contextx"
+	"github.com/bingo-project/bingo/pkg/store/where"
+)
+
+// Prompt line diff ops.
+const (
+	promptDiffEqual  = "equal"
+	promptDiffInsert = "insert"
+	promptDiffDelete = "delete"
+)
+
+// AiAgentVersionBiz defines AI agent version management interface for admin.
+type AiAgentVersionBiz interface {
+	List(ctx context.Context, agentID string, req *v1.ListAiAgentVersionRequest) (*v1.ListAiAgentVersionResponse, error)
+	Get(ctx context.Context, agentID string, version int) (*v1.AiAgentVersionInfo, error)
+	Create(ctx context.Context, agentID string, req *v1.CreateAiAgentVersionRequest) (*v1.AiAgentVersionInfo, error)
+	Publish(ctx context.Context, agentID string, version int) (*v1.AiAgentInfo, error)
+	Diff(ctx context.Context, agentID string, req *v1.DiffAiAgentVersionRequest) (*v1.AiAgentVersionDiff, error)
+}
+
+type aiAgentVersionBiz struct {
+	ds store.IStore
+}
+
+var _ AiAgentVersionBiz = (*aiAgentVersionBiz)(nil)
+
+func NewAiAgentVersion(ds store.IStore) AiAgentVersionBiz {
+	return &aiAgentVersionBiz{ds: ds}
+}
+
+// toAgentVersionInfo converts model.AiAgentVersionM to v1.AiAgentVersionInfo.
+func toAgentVersionInfo(m *model.AiAgentVersionM, published int) *v1.AiAgentVersionInfo {
+	return &v1.AiAgentVersionInfo{
+		AgentID:      m.AgentID,
+		Version:      m.Version,
+		SystemPrompt: m.SystemPrompt,
+		Model:        m.Model,
+		Temperature:  m.Temperature,
+		MaxTokens:    m.MaxTokens,
+		Tools:        m.Tools,
+		Author:       m.Author,
+		Note:         m.Note,
+		Published:    m.Version == published,
+		CreatedAt:    m.CreatedAt,
+	}
+}
+
+func (b *aiAgentVersionBiz) List(ctx context.Context, agentID string, req *v1.ListAiAgentVersionRequest) (*v1.ListAiAgentVersionResponse, error) {
+	agent, err := b.getAgent(ctx, agentID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Default pagination
+	page := 1
+	pageSize := 20
+	if req.Page > 0 {
+		page = req.Page
+	}
+	if req.PageSize > 0 {
+		pageSize = req.PageSize
+	}
+
+	total, rows, err := b.ds.AiAgentVersion().List(ctx, where.P(page, pageSize).F("agent_id", agentID))
+	if err != nil {
+		return nil, errno.ErrDBRead.WithMessage("list ai agent versions: %v", err)
+	}
+
+	data := make([]v1.AiAgentVersionInfo, len(rows))
+	for i, row := range rows {
+		data[i] = *toAgentVersionInfo(row, agent.PublishedVersion)
+	}
+
+	return &v1.ListAiAgentVersionResponse{
+		Total: total,
+		Data:  data,
+	}, nil
+}
+
+func (b *aiAgentVersionBiz) Get(ctx context.Context, agentID string, version int) (*v1.AiAgentVersionInfo, error) {
+	agent, err := b.getAgent(ctx, agentID)
+	if err != nil {
+		return nil, err
+	}
+
+	v, err := b.getVersion(ctx, agentID, version)
+	if err != nil {
+		return nil, err
+	}
+
+	return toAgentVersionInfo(v, agent.PublishedVersion), nil
+}
+
+func (b *aiAgentVersionBiz) Create(ctx context.Context, agentID string, req *v1.CreateAiAgentVersionRequest) (*v1.AiAgentVersionInfo, error) {
+	agent, err := b.getAgent(ctx, agentID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Start from the published settings
+	v := model.NewAiAgentVersion(agent, 0)
+	_ = copier.CopyWithOption(v, req, copier.Option{IgnoreEmpty: true})
+	if req.Tools != nil {
+		v.Tools = req.Tools
+	}
+	v.Author = contextx.Username(ctx)
+
+	err = b.ds.TX(ctx, func(ctx context.Context) error {
+		if err := saveAgentVersion(ctx, b.ds, agent, v, req.Publish); err != nil {
+			return err
+		}
+
+		return b.ds.AiAgents().Update(ctx, agent)
+	})
+	if err != nil {
+		return nil, errno.ErrDBWrite.WithMessage("create ai agent version: %v", err)
+	}
+
+	log.C(ctx).Infow("ai agent version created", "agent_id", agentID, "version", v.Version,
+		"published", req.Publish, "admin", v.Author)
+
+	return toAgentVersionInfo(v, agent.PublishedVersion), nil
+}
+
+// Publish makes the agent serve a version. Publishing an earlier version rolls the agent back.
+func (b *aiAgentVersionBiz) Publish(ctx context.Context, agentID string, version int) (*v1.AiAgentInfo, error) {
+	agent, err := b.getAgent(ctx, agentID)
+	if err != nil {
+		return nil, err
+	}
+
+	v, err := b.getVersion(ctx, agentID, version)
+	if err != nil {
+		return nil, err
+	}
+
+	previous := agent.PublishedVersion
+	v.ApplyTo(agent)
+	if err := b.ds.AiAgents().Update(ctx, agent, "system_prompt", "model", "temperature", "max_tokens", "tools", "published_version"); err != nil {
+		return nil, errno.ErrDBWrite.WithMessage("publish ai agent version: %v", err)
+	}
+
+	log.C(ctx).Infow("ai agent version published", "agent_id", agentID, "version", version,
+		"previous", previous, "admin", contextx.Username(ctx))
+
+	return toAgentInfo(agent), nil
+}
+
+func (b *aiAgentVersionBiz) Diff(ctx context.Context, agentID string, req *v1.DiffAiAgentVersionRequest) (*v1.AiAgentVersionDiff, error) {
+	from, err := b.getVersion(ctx, agentID, req.From)
+	if err != nil {
+		return nil, err
+	}
+	to, err := b.getVersion(ctx, agentID, req.To)
+	if err != nil {
+		return nil, err
+	}
+
+	return diffAgentVersions(from, to), nil
+}
+
+func (b *aiAgentVersionBiz) getAgent(ctx context.Context, agentID string) (*model.AiAgentM, error) {
+	agent, err := b.ds.AiAgents().GetByAgentID(ctx, agentID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errno.ErrAIRoleNotFound
+		}
+
+		return nil, errno.ErrDBRead.WithMessage("get ai agent: %v", err)
+	}
+
+	return agent, nil
+}
+
+func (b *aiAgentVersionBiz) getVersion(ctx context.Context, agentID string, version int) (*model.AiAgentVersionM, error) {
+	v, err := b.ds.AiAgentVersion().GetVersion(ctx, agentID, version)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errno.ErrAIAgentVersionNotFound
+		}
+
+		return nil, errno.ErrDBRead.WithMessage("get ai agent version: %v", err)
+	}
+
+	return v, nil
+}
+
+// saveAgentVersion stores v as the next version of agent, applying it to agent when published.
+// Agents created before versioning first get their current settings saved as version 1.
+// The caller saves agent, within the same transaction.
+func saveAgentVersion(ctx context.Context, ds store.IStore, agent *model.AiAgentM, v *model.AiAgentVersionM, publish bool) error {
+	latest, err := ds.AiAgentVersion().LatestVersion(ctx, agent.AgentID)
+	if err != nil {
+		return err
+	}
+	if latest == 0 {
+		base := model.NewAiAgentVersion(agent, 1)
+		base.Note = "Settings before versioning"
+		if err := ds.AiAgentVersion().Create(ctx, base); err != nil {
+			return err
+		}
+		agent.PublishedVersion = base.Version
+		latest = base.Version
+	}
+
+	v.AgentID = agent.AgentID
+	v.Version = latest + 1
+	if err := ds.AiAgentVersion().Create(ctx, v); err != nil {
+		return err
+	}
+	if publish {
+		v.ApplyTo(agent)
+	}
+
+	return nil
+}
+
+// diffAgentVersions compares the settings of two agent versions.
+func diffAgentVersions(from, to *model.AiAgentVersionM) *v1.AiAgentVersionDiff {
+	diff := &v1.AiAgentVersionDiff{
+		AgentID:      from.AgentID,
+		From:         from.Version,
+		To:           to.Version,
+		Changes:      []v1.AiAgentFieldChange{},
+		SystemPrompt: []v1.AiAgentPromptDiffOp{},
+	}
+
+	if from.Model != to.Model {
+		diff.Changes = append(diff.Changes, v1.AiAgentFieldChange{Field: "model", From: from.Model, To: to.Model})
+	}
+	if from.Temperature != to.Temperature {
+		diff.Changes = append(diff.Changes, v1.AiAgentFieldChange{Field: "temperature", From: from.Temperature, To: to.Temperature})
+	}
+	if from.MaxTokens != to.MaxTokens {
+		diff.Changes = append(diff.Changes, v1.AiAgentFieldChange{Field: "maxTokens", From: from.MaxTokens, To: to.MaxTokens})
+	}
+	if !slices.Equal(from.Tools, to.Tools) {
+		diff.Changes = append(diff.Changes, v1.AiAgentFieldChange{Field: "tools", From: []string(from.Tools), To: []string(to.Tools)})
+	}
+	if from.SystemPrompt != to.SystemPrompt {
+		diff.SystemPrompt = diffLines(from.SystemPrompt, to.SystemPrompt)
+	}
+
+	return diff
+}
+
+// diffLines returns a line diff of two texts based on their longest common subsequence.
+func diffLines(a, b string) []v1.AiAgentPromptDiffOp {
+	x := strings.Split(a, "\n")
+	y := strings.Split(b, "\n")
+
+	// lcs[i][j] is the LCS length of x[i:] and y[j:]
+	lcs := make([][]int, len(x)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(y)+1)
+	}
+	for i := len(x) - 1; i >= 0; i-- {
+		for j := len(y) - 1; j >= 0; j-- {
+			if x[i] == y[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var ops []v1.AiAgentPromptDiffOp
+	i, j := 0, 0
+	for i < len(x) && j < len(y) {
+		switch {
+		case x[i] == y[j]:
+			ops = append(ops, v1.AiAgentPromptDiffOp{Op: promptDiffEqual, Text: x[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			ops = append(ops, v1.AiAgentPromptDiffOp{Op: promptDiffDelete, Text: x[i]})
+			i++
+		default:
+			ops = append(ops, v1.AiAgentPromptDiffOp{Op: promptDiffInsert, Text: y[j]})
+			j++
+		}
+	}
+	for ; i < len(x); i++ {
+		ops = append(ops, v1.AiAgentPromptDiffOp{Op: promptDiffDelete, Text: x[i]})
+	}
+	for ; j < len(y); j++ {
+		ops = append(ops, v1.AiAgentPromptDiffOp{Op: promptDiffInsert, Text: y[j]})
+	}
+
+	return ops
+}
@@ -0,0 +1,67 @@
+package ai
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bingo-project/bingo/internal/pkg/model"
+	v1 "github.com/bingo-project/bingo/pkg/api/apiserver/v1"
+)
+
+func TestDiffLines(t *testing.T) {
+	tests := []struct {
+		name string
+		a    string
+		b    string
+		want []v1.AiAgentPromptDiffOp
+	}{
+		{
+			name: "unchanged",
+			a:    "one\ntwo",
+			b:    "one\ntwo",
+			want: []v1.AiAgentPromptDiffOp{{Op: "equal", Text: "one"}, {Op: "equal", Text: "two"}},
+		},
+		{
+			name: "replaced line",
+			a:    "You are a tutor.\nBe brief.",
+			b:    "You are a tutor.\nBe thorough.",
+			want: []v1.AiAgentPromptDiffOp{
+				{Op: "equal", Text: "You are a tutor."},
+				{Op: "delete", Text: "Be brief."},
+				{Op: "insert", Text: "Be thorough."},
+			},
+		},
+		{
+			name: "inserted and deleted lines",
+			a:    "a\nb\nc",
+			b:    "x\na\nc",
+			want: []v1.AiAgentPromptDiffOp{
+				{Op: "insert", Text: "x"},
+				{Op: "equal", Text: "a"},
+				{Op: "delete", Text: "b"},
+				{Op: "equal", Text: "c"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, diffLines(tt.a, tt.b))
+		})
+	}
+}
+
+func TestDiffAgentVersions(t *testing.T) {
+	from := &model.AiAgentVersionM{AgentID: "tutor", Version: 1, SystemPrompt: "Hi", Model: "gpt-4o", Temperature: 0.7, MaxTokens: 2000, Tools: []string{"get_user_profile"}}
+	to := &model.AiAgentVersionM{AgentID: "tutor", Version: 2, SystemPrompt: "Hi", Model: "gpt-4o", Temperature: 0.2, MaxTokens: 2000}
+
+	diff := diffAgentVersions(from, to)
+	assert.Equal(t, 1, diff.From)
+	assert.Equal(t, 2, diff.To)
+	assert.Equal(t, []v1.AiAgentFieldChange{
+		{Field: "temperature", From: 0.7, To: 0.2},
+		{Field: "tools", From: []string{"get_user_profile"}, To: []string(nil)},
+	}, diff.Changes)
+	assert.Empty(t, diff.SystemPrompt)
+}
@@ -0,0 +1,340 @@
+// ABOUTME: AI experiment business logic for admin management.
+// ABOUTME: Manages A/B experiments splitting an agent's users across arms and reports how the arms compare.
+package ai
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/bingo-project/bingo/internal/pkg/errno"
+	"github.com/bingo-project/bingo/internal/pkg/log"
+	"github.com/bingo-project/bingo/internal/pkg/model"
+	"github.com/bingo-project/bingo/internal/pkg/store"
+	v1 "github.com/bingo-project/bingo/pkg/api/apiserver/v1"
+	"github.com/bingo-project/bingo/pkg/contextx"
+	"github.com/bingo-project/bingo/pkg/store/where"
+)
+
+// AiExperimentBiz defines AI experiment management interface for admin.
+type AiExperimentBiz interface {
+	Create(ctx context.Context, req *v1.CreateAiExperimentRequest) (*v1.AiExperimentInfo, error)
+	Get(ctx context.Context, id uint64) (*v1.AiExperimentInfo, error)
+	List(ctx context.Context, req *v1.ListAiExperimentRequest) (*v1.ListAiExperimentResponse, error)
+	Update(ctx context.Context, id uint64, req *v1.UpdateAiExperimentRequest) (*v1.AiExperimentInfo, error)
+	Delete(ctx context.Context, id uint64) error
+	Start(ctx context.Context, id uint64) (*v1.AiExperimentInfo, error)
+	Stop(ctx context.Context, id uint64) (*v1.AiExperimentInfo, error)
+	Report(ctx context.Context, id uint64) (*v1.AiExperimentReport, error)
+}
+
+type aiExperimentBiz struct {
+	ds store.IStore
+}
+
+var _ AiExperimentBiz = (*aiExperimentBiz)(nil)
+
+func NewAiExperiment(ds store.IStore) AiExperimentBiz {
+	return &aiExperimentBiz{ds: ds}
+}
+
+// toExperimentInfo converts model.AiExperimentM to v1.AiExperimentInfo.
+func toExperimentInfo(m *model.AiExperimentM) *v1.AiExperimentInfo {
+	arms := make([]v1.AiExperimentArm, len(m.Arms))
+	for i, arm := range m.Arms {
+		arms[i] = v1.AiExperimentArm(arm)
+	}
+
+	return &v1.AiExperimentInfo{
+		ID:        m.ID,
+		Name:      m.Name,
+		AgentID:   m.AgentID,
+		Status:    string(m.Status),
+		Arms:      arms,
+		StartedAt: m.StartedAt,
+		StoppedAt: m.StoppedAt,
+		CreatedAt: m.CreatedAt,
+		UpdatedAt: m.UpdatedAt,
+	}
+}
+
+// toExperimentArms converts request arms to model arms.
+func toExperimentArms(arms []v1.AiExperimentArm) []model.AiExperimentArm {
+	ret := make([]model.AiExperimentArm, len(arms))
+	for i, arm := range arms {
+		ret[i] = model.AiExperimentArm(arm)
+	}
+
+	return ret
+}
+
+func (b *aiExperimentBiz) Create(ctx context.Context, req *v1.CreateAiExperimentRequest) (*v1.AiExperimentInfo, error) {
+	if _, err := b.ds.AiAgents().GetByAgentID(ctx, req.AgentID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errno.ErrAIRoleNotFound
+		}
+
+		return nil, errno.ErrDBRead.WithMessage("get ai agent: %v", err)
+	}
+
+	exp := &model.AiExperimentM{
+		Name:    req.Name,
+		AgentID: req.AgentID,
+		Status:  model.AiExperimentStatusDraft,
+		Arms:    toExperimentArms(req.Arms),
+	}
+	if err := b.validateArms(ctx, exp); err != nil {
+		return nil, err
+	}
+
+	if err := b.ds.AiExperiment().Create(ctx, exp); err != nil {
+		return nil, errno.ErrDBWrite.WithMessage("create ai experiment: %v", err)
+	}
+
+	log.C(ctx).Infow("ai experiment created", "id", exp.ID, "agent_id", exp.AgentID, "admin", contextx.Username(ctx))
+
+	return toExperimentInfo(exp), nil
+}
+
+func (b *aiExperimentBiz) Get(ctx context.Context, id uint64) (*v1.AiExperimentInfo, error) {
+	exp, err := b.get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return toExperimentInfo(exp), nil
+}
+
+func (b *aiExperimentBiz) List(ctx context.Context, req *v1.ListAiExperimentRequest) (*v1.ListAiExperimentResponse, error) {
+	// Default pagination
+	page := 1
+	pageSize := 20
+	if req.Page > 0 {
+		page = req.Page
+	}
+	if req.PageSize > 0 {
+		pageSize = req.PageSize
+	}
+
+	opts := where.P(page, pageSize)
+	if req.AgentID != "" {
+		opts = opts.F("agent_id", req.AgentID)
+	}
+	if req.Status != "" {
+		opts = opts.F("status", req.Status)
+	}
+
+	total, rows, err := b.ds.AiExperiment().List(ctx, opts)
+	if err != nil {
+		return nil, errno.ErrDBRead.WithMessage("list ai experiments: %v", err)
+	}
+
+	data := make([]v1.AiExperimentInfo, len(rows))
+	for i, row := range rows {
+		data[i] = *toExperimentInfo(row)
+	}
+
+	return &v1.ListAiExperimentResponse{
+		Total: total,
+		Data:  data,
+	}, nil
+}
+
+// Update renames an experiment or replaces its arms. Arms are fixed once the experiment
+// starts, so users keep their assignment and the report stays comparable.
+func (b *aiExperimentBiz) Update(ctx context.Context, id uint64, req *v1.UpdateAiExperimentRequest) (*v1.AiExperimentInfo, error) {
+	exp, err := b.get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		exp.Name = *req.Name
+	}
+	if req.Arms != nil {
+		if exp.Status != model.AiExperimentStatusDraft {
+			return nil, errno.ErrAIExperimentState.WithMessage("arms of a %s experiment cannot be changed", exp.Status)
+		}
+		exp.Arms = toExperimentArms(req.Arms)
+		if err := b.validateArms(ctx, exp); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := b.ds.AiExperiment().Update(ctx, exp, "name", "arms"); err != nil {
+		return nil, errno.ErrDBWrite.WithMessage("update ai experiment: %v", err)
+	}
+
+	return toExperimentInfo(exp), nil
+}
+
+func (b *aiExperimentBiz) Delete(ctx context.Context, id uint64) error {
+	exp, err := b.get(ctx, id)
+	if err != nil {
+		return err
+	}
+	if exp.Status == model.AiExperimentStatusRunning {
+		return errno.ErrAIExperimentState.WithMessage("stop the experiment before deleting it")
+	}
+
+	if err := b.ds.AiExperiment().Delete(ctx, where.F("id", id)); err != nil {
+		return errno.ErrDBWrite.WithMessage("delete ai experiment: %v", err)
+	}
+
+	log.C(ctx).Infow("ai experiment deleted", "id", id, "admin", contextx.Username(ctx))
+
+	return nil
+}
+
+// Start starts routing the agent's users across the arms of a draft experiment.
+func (b *aiExperimentBiz) Start(ctx context.Context, id uint64) (*v1.AiExperimentInfo, error) {
+	exp, err := b.get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if exp.Status != model.AiExperimentStatusDraft {
+		return nil, errno.ErrAIExperimentState.WithMessage("only a draft experiment can be started, got %s", exp.Status)
+	}
+
+	running, err := b.ds.AiExperiment().GetRunning(ctx, exp.AgentID)
+	if err == nil {
+		return nil, errno.ErrAIExperimentState.WithMessage("agent %s already runs experiment %d", exp.AgentID, running.ID)
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errno.ErrDBRead.WithMessage("get running ai experiment: %v", err)
+	}
+
+	// Models may have been disabled since the experiment was created
+	if err := b.validateArms(ctx, exp); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	exp.Status = model.AiExperimentStatusRunning
+	exp.StartedAt = &now
+	if err := b.ds.AiExperiment().Update(ctx, exp, "status", "started_at"); err != nil {
+		return nil, errno.ErrDBWrite.WithMessage("start ai experiment: %v", err)
+	}
+
+	log.C(ctx).Infow("ai experiment started", "id", id, "agent_id", exp.AgentID, "admin", contextx.Username(ctx))
+
+	return toExperimentInfo(exp), nil
+}
+
+// Stop stops a running experiment, the agent serves its published version to all users again.
+func (b *aiExperimentBiz) Stop(ctx context.Context, id uint64) (*v1.AiExperimentInfo, error) {
+	exp, err := b.get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if exp.Status != model.AiExperimentStatusRunning {
+		return nil, errno.ErrAIExperimentState.WithMessage("only a running experiment can be stopped, got %s", exp.Status)
+	}
+
+	now := time.Now()
+	exp.Status = model.AiExperimentStatusStopped
+	exp.StoppedAt = &now
+	if err := b.ds.AiExperiment().Update(ctx, exp, "status", "stopped_at"); err != nil {
+		return nil, errno.ErrDBWrite.WithMessage("stop ai experiment: %v", err)
+	}
+
+	log.C(ctx).Infow("ai experiment stopped", "id", id, "agent_id", exp.AgentID, "admin", contextx.Username(ctx))
+
+	return toExperimentInfo(exp), nil
+}
+
+// Report compares the arms of an experiment on usage, latency, errors and user feedback.
+func (b *aiExperimentBiz) Report(ctx context.Context, id uint64) (*v1.AiExperimentReport, error) {
+	exp, err := b.get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	usage, err := b.ds.AiUsage().Summarize(ctx, store.AiUsageGroupByArm, where.F("experiment_id", id))
+	if err != nil {
+		return nil, errno.ErrDBRead.WithMessage("summarize ai experiment usage: %v", err)
+	}
+	feedback, err := b.ds.AiFeedback().Summarize(ctx, store.AiFeedbackGroupByArm, where.F("experiment_id", id))
+	if err != nil {
+		return nil, errno.ErrDBRead.WithMessage("summarize ai experiment feedback: %v", err)
+	}
+
+	return &v1.AiExperimentReport{
+		Experiment: *toExperimentInfo(exp),
+		Arms:       buildArmReports(exp.Arms, usage, feedback),
+	}, nil
+}
+
+func (b *aiExperimentBiz) get(ctx context.Context, id uint64) (*model.AiExperimentM, error) {
+	exp, err := b.ds.AiExperiment().Get(ctx, where.F("id", id))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errno.ErrAIExperimentNotFound
+		}
+
+		return nil, errno.ErrDBRead.WithMessage("get ai experiment: %v", err)
+	}
+
+	return exp, nil
+}
+
+// validateArms checks the arm models are active and the arm versions exist.
+func (b *aiExperimentBiz) validateArms(ctx context.Context, exp *model.AiExperimentM) error {
+	for _, arm := range exp.Arms {
+		if arm.Model != "" {
+			if _, err := b.ds.AiModel().FindActiveByModel(ctx, arm.Model); err != nil {
+				return errno.ErrAIModelNotFound.WithMessage("arm %s: model %s is not active", arm.Key, arm.Model)
+			}
+		}
+		if arm.AgentVersion > 0 {
+			if _, err := b.ds.AiAgentVersion().GetVersion(ctx, exp.AgentID, arm.AgentVersion); err != nil {
+				return errno.ErrAIAgentVersionNotFound.WithMessage("arm %s: agent version %d not found", arm.Key, arm.AgentVersion)
+			}
+		}
+	}
+
+	return nil
+}
+
+// buildArmReports joins the usage and feedback aggregates of each arm, in the order of the arms.
+// Arms without traffic are reported with zero counts.
+func buildArmReports(arms []model.AiExperimentArm, usage []*model.AiUsageSummary, feedback []*model.AiFeedbackSummary) []v1.AiExperimentArmReport {
+	usageByArm := make(map[string]*model.AiUsageSummary, len(usage))
+	for _, row := range usage {
+		usageByArm[row.Key] = row
+	}
+	feedbackByArm := make(map[string]*model.AiFeedbackSummary, len(feedback))
+	for _, row := range feedback {
+		feedbackByArm[row.Key] = row
+	}
+
+	reports := make([]v1.AiExperimentArmReport, len(arms))
+	for i, arm := range arms {
+		r := v1.AiExperimentArmReport{Key: arm.Key, Weight: arm.Weight}
+		if u, ok := usageByArm[arm.Key]; ok {
+			r.Requests = u.Requests
+			r.Errors = u.Errors
+			r.PromptTokens = u.PromptTokens
+			r.CompletionTokens = u.CompletionTokens
+			r.TotalTokens = u.TotalTokens
+			r.AvgLatencyMs = u.AvgLatencyMs
+			r.Cost = u.Cost
+			if u.Requests > 0 {
+				r.ErrorRate = float64(u.Errors) / float64(u.Requests)
+				r.AvgTokens = float64(u.TotalTokens) / float64(u.Requests)
+			}
+		}
+		if f, ok := feedbackByArm[arm.Key]; ok {
+			item := toFeedbackSummaryItem(arm.Key, f.Up, f.Down)
+			r.Ratings = item.Ratings
+			r.Up = item.Up
+			r.Down = item.Down
+			r.SatisfyRate = item.SatisfyRate
+		}
+		reports[i] = r
+	}
+
+	return reports
+}
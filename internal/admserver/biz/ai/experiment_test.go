@@ -0,0 +1,50 @@
+package ai
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bingo-project/bingo/internal/pkg/model"
+)
+
+func TestBuildArmReports(t *testing.T) {
+	arms := []model.AiExperimentArm{
+		{Key: "control", Weight: 50},
+		{Key: "treatment", Weight: 50},
+		{Key: "idle", Weight: 10},
+	}
+	usage := []*model.AiUsageSummary{
+		{Key: "control", Requests: 10, Errors: 1, TotalTokens: 1000, AvgLatencyMs: 800, Cost: 0.5},
+		{Key: "treatment", Requests: 4, Errors: 0, TotalTokens: 600, AvgLatencyMs: 1200, Cost: 0.2},
+		{Key: "removed", Requests: 3},
+	}
+	feedback := []*model.AiFeedbackSummary{
+		{Key: "treatment", Ratings: 4, Up: 3, Down: 1},
+	}
+
+	reports := buildArmReports(arms, usage, feedback)
+	require.Len(t, reports, 3)
+
+	control := reports[0]
+	assert.Equal(t, "control", control.Key)
+	assert.Equal(t, int64(10), control.Requests)
+	assert.InDelta(t, 0.1, control.ErrorRate, 1e-9)
+	assert.InDelta(t, 100, control.AvgTokens, 1e-9)
+	assert.InDelta(t, 800, control.AvgLatencyMs, 1e-9)
+	assert.Zero(t, control.Ratings)
+	assert.Zero(t, control.SatisfyRate)
+
+	treatment := reports[1]
+	assert.Equal(t, "treatment", treatment.Key)
+	assert.Zero(t, treatment.ErrorRate)
+	assert.Equal(t, int64(4), treatment.Ratings)
+	assert.InDelta(t, 0.75, treatment.SatisfyRate, 1e-9)
+
+	idle := reports[2]
+	assert.Equal(t, "idle", idle.Key)
+	assert.Equal(t, 10, idle.Weight)
+	assert.Zero(t, idle.Requests)
+	assert.Zero(t, idle.ErrorRate)
+}
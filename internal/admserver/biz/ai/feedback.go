@@ -0,0 +1,221 @@
+// ABOUTME: AI reply feedback business logic for admin analytics.
+// ABOUTME: Lists ratings, drills into rated conversations and aggregates them by model, agent or day.
+package ai
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/bingo-project/bingo/internal/pkg/errno"
+	"github.com/bingo-project/bingo/internal/pkg/log"
+	"github.com/bingo-project/bingo/internal/pkg/model"
+	"github.com/bingo-project/bingo/internal/pkg/store"
+	v1 "github.com/bingo-project/bingo/pkg/api/apiserver/v1"
+	"github.com/bingo-project/bingo/pkg/contextx"
+	"github.com/bingo-project/bingo/pkg/store/where"
+)
+
+// AiFeedbackBiz defines AI reply feedback analytics for admin.
+type AiFeedbackBiz interface {
+	List(ctx context.Context, req *v1.ListAiFeedbackRequest) (*v1.ListAiFeedbackResponse, error)
+	Get(ctx context.Context, id uint64) (*v1.AiFeedbackDetail, error)
+	Summary(ctx context.Context, req *v1.AiFeedbackSummaryRequest) (*v1.AiFeedbackSummaryResponse, error)
+}
+
+type aiFeedbackBiz struct {
+	ds store.IStore
+}
+
+var _ AiFeedbackBiz = (*aiFeedbackBiz)(nil)
+
+func NewAiFeedback(ds store.IStore) AiFeedbackBiz {
+	return &aiFeedbackBiz{ds: ds}
+}
+
+// toFeedbackInfo converts model.AiFeedbackM to v1.AiFeedbackInfo.
+func toFeedbackInfo(m *model.AiFeedbackM) v1.AiFeedbackInfo {
+	reasons := []string(m.Reasons)
+	if reasons == nil {
+		reasons = []string{}
+	}
+
+	return v1.AiFeedbackInfo{
+		ID:        m.ID,
+		UID:       m.UID,
+		SessionID: m.SessionID,
+		MessageID: m.MessageID,
+		Rating:    m.Rating,
+		Reasons:   reasons,
+		Comment:   m.Comment,
+		Model:     m.Model,
+		AgentID:   m.AgentID,
+		CreatedAt: m.CreatedAt,
+		UpdatedAt: m.UpdatedAt,
+	}
+}
+
+func (b *aiFeedbackBiz) List(ctx context.Context, req *v1.ListAiFeedbackRequest) (*v1.ListAiFeedbackResponse, error) {
+	// Default pagination
+	page := 1
+	pageSize := 20
+	if req.Page > 0 {
+		page = req.Page
+	}
+	if req.PageSize > 0 {
+		pageSize = req.PageSize
+	}
+
+	// Build where clause
+	opts := where.P(page, pageSize)
+	switch req.Rating {
+	case "up":
+		opts = opts.F("rating", model.AiFeedbackRatingUp)
+	case "down":
+		opts = opts.F("rating", model.AiFeedbackRatingDown)
+	}
+	if req.Model != "" {
+		opts = opts.F("model", req.Model)
+	}
+	if req.AgentID != "" {
+		opts = opts.F("agent_id", req.AgentID)
+	}
+	if req.UID != "" {
+		opts = opts.F("uid", req.UID)
+	}
+	if req.From != nil {
+		opts = opts.Q("created_at >= ?", *req.From)
+	}
+	if req.To != nil {
+		opts = opts.Q("created_at < ?", req.To.AddDate(0, 0, 1))
+	}
+
+	total, rows, err := b.ds.AiFeedback().List(ctx, opts)
+	if err != nil {
+		return nil, errno.ErrDBRead.WithMessage("list ai feedback: %v", err)
+	}
+
+	data := make([]v1.AiFeedbackInfo, len(rows))
+	for i, row := range rows {
+		data[i] = toFeedbackInfo(row)
+	}
+
+	return &v1.ListAiFeedbackResponse{
+		Total: total,
+		Data:  data,
+	}, nil
+}
+
+func (b *aiFeedbackBiz) Get(ctx context.Context, id uint64) (*v1.AiFeedbackDetail, error) {
+	feedback, err := b.ds.AiFeedback().Get(ctx, where.F("id", id))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errno.ErrAIFeedbackNotFound
+		}
+
+		return nil, errno.ErrDBRead.WithMessage("get ai feedback: %v", err)
+	}
+
+	// Reading the rated conversation is audited like conversation search
+	log.C(ctx).Infow("Admin viewed a rated AI conversation",
+		"audit", true,
+		"admin", contextx.Username(ctx),
+		"client_ip", contextx.ClientIP(ctx),
+		"uid", feedback.UID,
+		"session_id", feedback.SessionID,
+		"feedback_id", feedback.ID,
+	)
+
+	detail := &v1.AiFeedbackDetail{AiFeedbackInfo: toFeedbackInfo(feedback)}
+	session, err := b.ds.AiSession().GetBySessionID(ctx, feedback.SessionID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, errno.ErrDBRead.WithMessage("get session: %v", err)
+	}
+	if err == nil {
+		detail.SessionTitle = session.Title
+	}
+
+	messages, err := b.ds.AiMessage().ListBranch(ctx, feedback.SessionID, feedback.MessageID, 0)
+	if err != nil {
+		return nil, errno.ErrDBRead.WithMessage("list messages: %v", err)
+	}
+	slices.Reverse(messages)
+
+	detail.Messages = make([]v1.AiFeedbackMessage, len(messages))
+	for i, m := range messages {
+		detail.Messages[i] = v1.AiFeedbackMessage{
+			ID:        m.ID,
+			Role:      m.Role,
+			Content:   m.Content,
+			Model:     m.Model,
+			CreatedAt: m.CreatedAt,
+		}
+	}
+
+	return detail, nil
+}
+
+func (b *aiFeedbackBiz) Summary(ctx context.Context, req *v1.AiFeedbackSummaryRequest) (*v1.AiFeedbackSummaryResponse, error) {
+	// Default range is the current month
+	now := time.Now()
+	from := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	to := now
+	if req.From != nil {
+		from = *req.From
+	}
+	if req.To != nil {
+		to = *req.To
+	}
+	if to.Before(from) {
+		return nil, errno.ErrInvalidArgument.WithMessage("to must not be before from")
+	}
+
+	opts := where.NewWhere().
+		Q("created_at >= ?", from).
+		Q("created_at < ?", time.Date(to.Year(), to.Month(), to.Day()+1, 0, 0, 0, 0, to.Location()))
+	if req.Model != "" {
+		opts = opts.F("model", req.Model)
+	}
+	if req.AgentID != "" {
+		opts = opts.F("agent_id", req.AgentID)
+	}
+
+	rows, err := b.ds.AiFeedback().Summarize(ctx, req.GroupBy, opts)
+	if err != nil {
+		return nil, errno.ErrDBRead.WithMessage("summarize ai feedback: %v", err)
+	}
+
+	resp := &v1.AiFeedbackSummaryResponse{
+		GroupBy: req.GroupBy,
+		From:    from.Format(usageDayLayout),
+		To:      to.Format(usageDayLayout),
+		Data:    make([]v1.AiFeedbackSummaryItem, len(rows)),
+	}
+	var up, down int64
+	for i, row := range rows {
+		resp.Data[i] = toFeedbackSummaryItem(row.Key, row.Up, row.Down)
+		up += row.Up
+		down += row.Down
+	}
+	resp.Total = toFeedbackSummaryItem("", up, down)
+
+	return resp, nil
+}
+
+// toFeedbackSummaryItem builds the aggregate of a group from its thumbs up and down counts.
+func toFeedbackSummaryItem(key string, up int64, down int64) v1.AiFeedbackSummaryItem {
+	item := v1.AiFeedbackSummaryItem{
+		Key:     key,
+		Ratings: up + down,
+		Up:      up,
+		Down:    down,
+	}
+	if item.Ratings > 0 {
+		item.SatisfyRate = float64(up) / float64(item.Ratings)
+	}
+
+	return item
+}
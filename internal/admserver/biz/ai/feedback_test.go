@@ -0,0 +1,33 @@
+package ai
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToFeedbackSummaryItem(t *testing.T) {
+	tests := []struct {
+		name        string
+		up          int64
+		down        int64
+		wantRatings int64
+		wantRate    float64
+	}{
+		{name: "no ratings", up: 0, down: 0, wantRatings: 0, wantRate: 0},
+		{name: "all up", up: 4, down: 0, wantRatings: 4, wantRate: 1},
+		{name: "mixed", up: 3, down: 1, wantRatings: 4, wantRate: 0.75},
+		{name: "all down", up: 0, down: 2, wantRatings: 2, wantRate: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := toFeedbackSummaryItem("glm-4-flash", tt.up, tt.down)
+			assert.Equal(t, "glm-4-flash", item.Key)
+			assert.Equal(t, tt.wantRatings, item.Ratings)
+			assert.Equal(t, tt.up, item.Up)
+			assert.Equal(t, tt.down, item.Down)
+			assert.InDelta(t, tt.wantRate, item.SatisfyRate, 1e-9)
+		})
+	}
+}
@@ -0,0 +1,145 @@
+// ABOUTME: AI guardrail event business logic for admin review.
+// ABOUTME: Lists guardrail matches and records whether reviewers confirm or dismiss them.
+package ai
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/bingo-project/bingo/internal/pkg/errno"
+	"github.com/bingo-project/bingo/internal/pkg/log"
+	"github.com/bingo-project/bingo/internal/pkg/model"
+	"github.com/bingo-project/bingo/internal/pkg/store"
+	v1 "github.com/bingo-project/bingo/pkg/api/apiserver/v1"
+	"github.com/bingo-project/bingo/pkg/contextx"
+	"github.com/bingo-project/bingo/pkg/store/where"
+)
+
+// AiGuardrailEventBiz defines AI guardrail event review interface for admin.
+type AiGuardrailEventBiz interface {
+	List(ctx context.Context, req *v1.ListAiGuardrailEventRequest) (*v1.ListAiGuardrailEventResponse, error)
+	Get(ctx context.Context, id uint64) (*v1.AiGuardrailEventInfo, error)
+	Review(ctx context.Context, id uint64, req *v1.ReviewAiGuardrailEventRequest) (*v1.AiGuardrailEventInfo, error)
+}
+
+type aiGuardrailEventBiz struct {
+	ds store.IStore
+}
+
+var _ AiGuardrailEventBiz = (*aiGuardrailEventBiz)(nil)
+
+func NewAiGuardrailEvent(ds store.IStore) AiGuardrailEventBiz {
+	return &aiGuardrailEventBiz{ds: ds}
+}
+
+// toGuardrailEventInfo converts model.AiGuardrailEventM to v1.AiGuardrailEventInfo.
+func toGuardrailEventInfo(m *model.AiGuardrailEventM) *v1.AiGuardrailEventInfo {
+	return &v1.AiGuardrailEventInfo{
+		ID:         m.ID,
+		UID:        m.UID,
+		SessionID:  m.SessionID,
+		AgentID:    m.AgentID,
+		Model:      m.Model,
+		Stage:      m.Stage,
+		Guard:      m.Guard,
+		Rule:       m.Rule,
+		Action:     m.Action,
+		Excerpt:    m.Excerpt,
+		Status:     string(m.Status),
+		ReviewedBy: m.ReviewedBy,
+		ReviewNote: m.ReviewNote,
+		ReviewedAt: m.ReviewedAt,
+		CreatedAt:  m.CreatedAt,
+	}
+}
+
+func (b *aiGuardrailEventBiz) List(ctx context.Context, req *v1.ListAiGuardrailEventRequest) (*v1.ListAiGuardrailEventResponse, error) {
+	// Default pagination
+	page := 1
+	pageSize := 20
+	if req.Page > 0 {
+		page = req.Page
+	}
+	if req.PageSize > 0 {
+		pageSize = req.PageSize
+	}
+
+	opts := where.P(page, pageSize)
+	if req.AgentID != "" {
+		opts = opts.F("agent_id", req.AgentID)
+	}
+	if req.Stage != "" {
+		opts = opts.F("stage", req.Stage)
+	}
+	if req.Guard != "" {
+		opts = opts.F("guard", req.Guard)
+	}
+	if req.Action != "" {
+		opts = opts.F("action", req.Action)
+	}
+	if req.Status != "" {
+		opts = opts.F("status", req.Status)
+	}
+
+	total, rows, err := b.ds.AiGuardrailEvent().List(ctx, opts)
+	if err != nil {
+		return nil, errno.ErrDBRead.WithMessage("list ai guardrail events: %v", err)
+	}
+
+	data := make([]v1.AiGuardrailEventInfo, len(rows))
+	for i, row := range rows {
+		data[i] = *toGuardrailEventInfo(row)
+	}
+
+	return &v1.ListAiGuardrailEventResponse{
+		Total: total,
+		Data:  data,
+	}, nil
+}
+
+func (b *aiGuardrailEventBiz) Get(ctx context.Context, id uint64) (*v1.AiGuardrailEventInfo, error) {
+	event, err := b.get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return toGuardrailEventInfo(event), nil
+}
+
+// Review confirms or dismisses an event. A later review overwrites an earlier one.
+func (b *aiGuardrailEventBiz) Review(ctx context.Context, id uint64, req *v1.ReviewAiGuardrailEventRequest) (*v1.AiGuardrailEventInfo, error) {
+	event, err := b.get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	event.Status = model.AiGuardrailEventStatus(req.Status)
+	event.ReviewedBy = contextx.Username(ctx)
+	event.ReviewNote = req.Note
+	event.ReviewedAt = &now
+	if err := b.ds.AiGuardrailEvent().Update(ctx, event, "status", "reviewed_by", "review_note", "reviewed_at"); err != nil {
+		return nil, errno.ErrDBWrite.WithMessage("review ai guardrail event: %v", err)
+	}
+
+	log.C(ctx).Infow("ai guardrail event reviewed", "id", id, "agent_id", event.AgentID,
+		"status", event.Status, "admin", event.ReviewedBy)
+
+	return toGuardrailEventInfo(event), nil
+}
+
+func (b *aiGuardrailEventBiz) get(ctx context.Context, id uint64) (*model.AiGuardrailEventM, error) {
+	event, err := b.ds.AiGuardrailEvent().Get(ctx, where.F("id", id))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errno.ErrAIGuardrailEventNotFound
+		}
+
+		return nil, errno.ErrDBRead.WithMessage("get ai guardrail event: %v", err)
+	}
+
+	return event, nil
+}
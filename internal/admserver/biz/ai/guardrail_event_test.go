@@ -0,0 +1,40 @@
+package ai
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bingo-project/bingo/internal/pkg/model"
+	mockstore "github.com/bingo-project/bingo/internal/pkg/testing/mock/store"
+	v1 "github.com/bingo-project/bingo/pkg/api/apiserver/v1"
+	"github.com/bingo-project/bingo/pkg/contextx"
+)
+
+func TestAiGuardrailEventBiz_Review(t *testing.T) {
+	ctx := contextx.WithUsername(context.Background(), "reviewer")
+	ds := mockstore.NewStore()
+	require.NoError(t, ds.AiGuardrailEvent().CreateInBatch(ctx, []*model.AiGuardrailEventM{{
+		AgentID: "medical",
+		Stage:   model.AiGuardrailStageOutput,
+		Guard:   "blocklist",
+		Rule:    "lethal dose",
+		Action:  "block",
+		Status:  model.AiGuardrailEventStatusPending,
+	}}, 1))
+
+	b := NewAiGuardrailEvent(ds)
+	info, err := b.Review(ctx, 1, &v1.ReviewAiGuardrailEventRequest{Status: "confirmed", Note: "correctly blocked"})
+	require.NoError(t, err)
+	assert.Equal(t, "confirmed", info.Status)
+	assert.Equal(t, "reviewer", info.ReviewedBy)
+	assert.Equal(t, "correctly blocked", info.ReviewNote)
+	require.NotNil(t, info.ReviewedAt)
+
+	list, err := b.List(ctx, &v1.ListAiGuardrailEventRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), list.Total)
+	assert.Equal(t, "confirmed", list.Data[0].Status)
+}
@@ -0,0 +1,145 @@
+// ABOUTME: AI agent knowledge base business logic for admin management.
+// ABOUTME: Adds, lists and removes documents indexed for agent retrieval.
+package ai
+
+import (
+	"context"
+	"errors"
+	"unicode/utf8"
+
+	"gorm.io/gorm"
+
+	"github.com/bingo-project/bingo/internal/pkg/ai"
+	"github.com/bingo-project/bingo/internal/pkg/errno"
+	"github.com/bingo-project/bingo/internal/pkg/log"
+	"github.com/bingo-project/bingo/internal/pkg/model"
+	"github.com/bingo-project/bingo/internal/pkg/store"
+	aipkg "github.com/bingo-project/bingo/pkg/ai"
+	v1 "github.com/bingo-project/bingo/pkg/api/apiserver/v1"
+	"github.com/bingo-project/bingo/pkg/store/where"
+)
+
+// AiKnowledgeBiz defines AI agent knowledge base management interface for admin.
+type AiKnowledgeBiz interface {
+	Create(ctx context.Context, agentID string, req *v1.CreateAiKnowledgeDocRequest) (*v1.AiKnowledgeDocInfo, error)
+	List(ctx context.Context, agentID string) (*v1.ListAiKnowledgeDocResponse, error)
+	Delete(ctx context.Context, agentID string, docID uint64) error
+}
+
+type aiKnowledgeBiz struct {
+	ds store.IStore
+	kb *ai.KnowledgeBase
+}
+
+var _ AiKnowledgeBiz = (*aiKnowledgeBiz)(nil)
+
+func NewAiKnowledge(ds store.IStore, registry *aipkg.Registry) AiKnowledgeBiz {
+	return &aiKnowledgeBiz{ds: ds, kb: ai.NewKnowledgeBase(ds, registry)}
+}
+
+// toKnowledgeDocInfo converts model.AiKnowledgeDocM to v1.AiKnowledgeDocInfo.
+func toKnowledgeDocInfo(m *model.AiKnowledgeDocM) *v1.AiKnowledgeDocInfo {
+	return &v1.AiKnowledgeDocInfo{
+		ID:             m.ID,
+		AgentID:        m.AgentID,
+		Title:          m.Title,
+		EmbeddingModel: m.EmbeddingModel,
+		ChunkCount:     m.ChunkCount,
+		Chars:          m.Chars,
+		Status:         string(m.Status),
+		Error:          m.Error,
+		CreatedAt:      m.CreatedAt,
+		UpdatedAt:      m.UpdatedAt,
+	}
+}
+
+func (b *aiKnowledgeBiz) Create(ctx context.Context, agentID string, req *v1.CreateAiKnowledgeDocRequest) (*v1.AiKnowledgeDocInfo, error) {
+	agent, err := b.getAgent(ctx, agentID)
+	if err != nil {
+		return nil, err
+	}
+	if agent.EmbeddingModel == "" {
+		return nil, errno.ErrInvalidArgument.WithMessage("agent %s has no embedding model", agentID)
+	}
+
+	doc := &model.AiKnowledgeDocM{
+		AgentID:        agent.AgentID,
+		Title:          req.Title,
+		EmbeddingModel: agent.EmbeddingModel,
+		Chars:          utf8.RuneCountInString(req.Content),
+		Status:         model.AiKnowledgeDocStatusIndexing,
+	}
+	if err := b.ds.AiKnowledgeDoc().Create(ctx, doc); err != nil {
+		return nil, errno.ErrDBWrite.WithMessage("create knowledge doc: %v", err)
+	}
+
+	// Index synchronously, a failed document is kept so the error is visible
+	if err := b.kb.Index(ctx, doc, req.Content); err != nil {
+		log.C(ctx).Errorw("Failed to index knowledge doc", "agent_id", agentID, "doc_id", doc.ID, "err", err)
+
+		return nil, errno.ErrAIProviderError.WithMessage("index knowledge doc: %v", err)
+	}
+
+	log.C(ctx).Infow("knowledge doc indexed", "agent_id", agentID, "doc_id", doc.ID, "chunks", doc.ChunkCount)
+
+	return toKnowledgeDocInfo(doc), nil
+}
+
+func (b *aiKnowledgeBiz) List(ctx context.Context, agentID string) (*v1.ListAiKnowledgeDocResponse, error) {
+	if _, err := b.getAgent(ctx, agentID); err != nil {
+		return nil, err
+	}
+
+	docs, err := b.ds.AiKnowledgeDoc().ListByAgentID(ctx, agentID)
+	if err != nil {
+		return nil, errno.ErrDBRead.WithMessage("list knowledge docs: %v", err)
+	}
+
+	data := make([]v1.AiKnowledgeDocInfo, len(docs))
+	for i, d := range docs {
+		data[i] = *toKnowledgeDocInfo(d)
+	}
+
+	return &v1.ListAiKnowledgeDocResponse{
+		Total: int64(len(docs)),
+		Data:  data,
+	}, nil
+}
+
+func (b *aiKnowledgeBiz) Delete(ctx context.Context, agentID string, docID uint64) error {
+	doc, err := b.ds.AiKnowledgeDoc().Get(ctx, where.F("id", docID))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errno.ErrAIKnowledgeDocNotFound
+		}
+
+		return errno.ErrDBRead.WithMessage("get knowledge doc: %v", err)
+	}
+	if doc.AgentID != agentID {
+		return errno.ErrAIKnowledgeDocNotFound
+	}
+
+	if err := b.ds.AiKnowledgeChunk().DeleteByDocID(ctx, doc.ID); err != nil {
+		return errno.ErrDBWrite.WithMessage("delete knowledge chunks: %v", err)
+	}
+	if err := b.ds.AiKnowledgeDoc().Delete(ctx, where.F("id", doc.ID)); err != nil {
+		return errno.ErrDBWrite.WithMessage("delete knowledge doc: %v", err)
+	}
+
+	log.C(ctx).Infow("knowledge doc deleted", "agent_id", agentID, "doc_id", doc.ID)
+
+	return nil
+}
+
+func (b *aiKnowledgeBiz) getAgent(ctx context.Context, agentID string) (*model.AiAgentM, error) {
+	agent, err := b.ds.AiAgents().GetByAgentID(ctx, agentID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errno.ErrAIRoleNotFound
+		}
+
+		return nil, errno.ErrDBRead.WithMessage("get ai agent: %v", err)
+	}
+
+	return agent, nil
+}
@@ -42,6 +42,7 @@ func toModelInfo(m *model.AiModelM) *v1.AiModelInfo {
 		ProviderName:  m.ProviderName,
 		Model:         m.Model,
 		DisplayName:   m.DisplayName,
+		Type:          string(m.Type),
 		MaxTokens:     m.MaxTokens,
 		InputPrice:    m.InputPrice,
 		OutputPrice:   m.OutputPrice,
@@ -68,6 +69,11 @@ func (b *aiModelBiz) Create(ctx context.Context, req *v1.CreateAiModelRequest) (
 		status = model.AiModelStatus(req.Status)
 	}
 
+	modelType := model.AiModelTypeChat
+	if req.Type != "" {
+		modelType = model.AiModelType(req.Type)
+	}
+
 	maxTokens := 4096
 	if req.MaxTokens > 0 {
 		maxTokens = req.MaxTokens
@@ -77,6 +83,7 @@ func (b *aiModelBiz) Create(ctx context.Context, req *v1.CreateAiModelRequest) (
 		ProviderName:  req.ProviderName,
 		Model:         req.Model,
 		DisplayName:   req.DisplayName,
+		Type:          modelType,
 		MaxTokens:     maxTokens,
 		InputPrice:    req.InputPrice,
 		OutputPrice:   req.OutputPrice,
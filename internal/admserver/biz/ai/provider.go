@@ -1,52 +1,115 @@
 // ABOUTME: AI Provider business logic for admin management.
-// ABOUTME: Provides list/get/update operations for AI Provider resources.
+// ABOUTME: Provides CRUD operations with encrypted credentials and local model import for AI Provider resources.
 package ai
 
 import (
 	"context"
 	"errors"
+	"net/url"
 
 	"gorm.io/gorm"
 
+	"github.com/bingo-project/bingo/internal/pkg/ai"
 	"github.com/bingo-project/bingo/internal/pkg/errno"
+	"github.com/bingo-project/bingo/internal/pkg/facade"
 	"github.com/bingo-project/bingo/internal/pkg/log"
 	"github.com/bingo-project/bingo/internal/pkg/model"
 	"github.com/bingo-project/bingo/internal/pkg/store"
+	aipkg "github.com/bingo-project/bingo/pkg/ai"
 	v1 "github.com/bingo-project/bingo/pkg/api/apiserver/v1"
 	"github.com/bingo-project/bingo/pkg/store/where"
 )
 
 // AiProviderBiz defines AI provider management interface for admin.
 type AiProviderBiz interface {
+	Create(ctx context.Context, req *v1.CreateAiProviderRequest) (*v1.AiProviderInfo, error)
 	List(ctx context.Context, req *v1.ListAiProviderRequest) (*v1.ListAiProviderResponse, error)
 	Get(ctx context.Context, id uint) (*v1.AiProviderInfo, error)
 	Update(ctx context.Context, id uint, req *v1.UpdateAiProviderRequest) (*v1.AiProviderInfo, error)
+	Delete(ctx context.Context, id uint) error
+	ListLocalModels(ctx context.Context, id uint) (*v1.ListAiLocalModelResponse, error)
+	ImportLocalModels(ctx context.Context, id uint, req *v1.ImportAiLocalModelRequest) (*v1.ImportAiLocalModelResponse, error)
 }
 
 type aiProviderBiz struct {
-	ds store.IStore
+	ds       store.IStore
+	registry *aipkg.Registry
 }
 
 var _ AiProviderBiz = (*aiProviderBiz)(nil)
 
-func NewAiProvider(ds store.IStore) AiProviderBiz {
-	return &aiProviderBiz{ds: ds}
+func NewAiProvider(ds store.IStore, registry *aipkg.Registry) AiProviderBiz {
+	return &aiProviderBiz{ds: ds, registry: registry}
 }
 
-// toProviderInfo converts model.AiProviderM to v1.AiProviderInfo.
+// toProviderInfo converts model.AiProviderM to v1.AiProviderInfo with the API key masked.
 func toProviderInfo(m *model.AiProviderM) *v1.AiProviderInfo {
+	var apiKey string
+	if m.APIKey != "" {
+		apiKey = maskEncryptedKey(m.APIKey)
+	}
+
 	return &v1.AiProviderInfo{
 		ID:          m.ID,
 		Name:        m.Name,
 		DisplayName: m.DisplayName,
+		BaseURL:     m.BaseURL,
+		APIKey:      apiKey,
 		Status:      string(m.Status),
 		IsDefault:   m.IsDefault,
 		Sort:        m.Sort,
+		KeyStrategy: m.KeyStrategy,
 		CreatedAt:   m.CreatedAt,
 		UpdatedAt:   m.UpdatedAt,
 	}
 }
 
+func (b *aiProviderBiz) Create(ctx context.Context, req *v1.CreateAiProviderRequest) (*v1.AiProviderInfo, error) {
+	if ai.IsReservedProvider(req.Name) {
+		return nil, errno.ErrInvalidArgument.WithMessage("provider name %s is reserved for development builds", req.Name)
+	}
+	existing, err := b.ds.AiProvider().GetByName(ctx, req.Name)
+	if err == nil && existing != nil {
+		return nil, errno.ErrResourceAlreadyExists.WithMessage("ai provider already exists: %s", req.Name)
+	}
+	if req.BaseURL == "" && ai.NeedsBaseURL(req.Name) {
+		return nil, errno.ErrInvalidArgument.WithMessage("provider %s has no built-in endpoint, baseURL is required", req.Name)
+	}
+
+	status := model.AiProviderStatusActive
+	if req.Status != "" {
+		status = model.AiProviderStatus(req.Status)
+	}
+	displayName := req.DisplayName
+	if displayName == "" {
+		displayName = req.Name
+	}
+
+	provider := &model.AiProviderM{
+		Name:        req.Name,
+		DisplayName: displayName,
+		BaseURL:     req.BaseURL,
+		Status:      status,
+		IsDefault:   req.IsDefault,
+		Sort:        req.Sort,
+		KeyStrategy: req.KeyStrategy,
+	}
+	if req.APIKey != "" {
+		if provider.APIKey, err = facade.AES.EncryptString(req.APIKey); err != nil {
+			return nil, errno.ErrInternal.WithMessage("encrypt ai provider key: %v", err)
+		}
+	}
+
+	if err := b.ds.AiProvider().Create(ctx, provider); err != nil {
+		return nil, errno.ErrDBWrite.WithMessage("create ai provider: %v", err)
+	}
+
+	log.C(ctx).Infow("ai provider created", "id", provider.ID, "name", provider.Name)
+	reloadProviders(ctx)
+
+	return toProviderInfo(provider), nil
+}
+
 func (b *aiProviderBiz) List(ctx context.Context, req *v1.ListAiProviderRequest) (*v1.ListAiProviderResponse, error) {
 	var providers []*model.AiProviderM
 	var err error
@@ -110,11 +173,30 @@ func (b *aiProviderBiz) Update(ctx context.Context, id uint, req *v1.UpdateAiPro
 
 		return nil, errno.ErrDBRead.WithMessage("get ai provider: %v", err)
 	}
+	if ai.IsReservedProvider(provider.Name) {
+		return nil, errno.ErrInvalidArgument.WithMessage("provider name %s is reserved for development builds", provider.Name)
+	}
 
 	// Update fields
 	if req.DisplayName != "" {
 		provider.DisplayName = req.DisplayName
 	}
+	if req.BaseURL != nil {
+		if *req.BaseURL != "" {
+			if u, err := url.ParseRequestURI(*req.BaseURL); err != nil || u.Host == "" {
+				return nil, errno.ErrInvalidArgument.WithMessage("invalid baseURL: %s", *req.BaseURL)
+			}
+		}
+		provider.BaseURL = *req.BaseURL
+	}
+	if req.APIKey != nil {
+		provider.APIKey = ""
+		if *req.APIKey != "" {
+			if provider.APIKey, err = facade.AES.EncryptString(*req.APIKey); err != nil {
+				return nil, errno.ErrInternal.WithMessage("encrypt ai provider key: %v", err)
+			}
+		}
+	}
 	if req.Status != "" {
 		provider.Status = model.AiProviderStatus(req.Status)
 	}
@@ -124,12 +206,194 @@ func (b *aiProviderBiz) Update(ctx context.Context, id uint, req *v1.UpdateAiPro
 	if req.Sort != nil {
 		provider.Sort = *req.Sort
 	}
+	if req.KeyStrategy != "" {
+		provider.KeyStrategy = req.KeyStrategy
+	}
 
 	if err := b.ds.AiProvider().Update(ctx, provider); err != nil {
 		return nil, errno.ErrDBWrite.WithMessage("update ai provider: %v", err)
 	}
 
 	log.C(ctx).Infow("ai provider updated", "id", provider.ID, "name", provider.Name)
+	reloadProviders(ctx)
 
 	return toProviderInfo(provider), nil
 }
+
+// Delete removes a provider and its API keys. Providers with models are kept, their models
+// must be deleted first.
+func (b *aiProviderBiz) Delete(ctx context.Context, id uint) error {
+	provider, err := b.ds.AiProvider().Get(ctx, where.F("id", id))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return errno.ErrAIProviderNotFound
+		}
+
+		return errno.ErrDBRead.WithMessage("get ai provider: %v", err)
+	}
+
+	count, _, err := b.ds.AiModel().List(ctx, where.F("provider_name", provider.Name))
+	if err != nil {
+		return errno.ErrDBRead.WithMessage("list ai models: %v", err)
+	}
+	if count > 0 {
+		return errno.ErrAIProviderInUse.WithMessage("ai provider %s still has %d models", provider.Name, count)
+	}
+
+	err = b.ds.TX(ctx, func(ctx context.Context) error {
+		if err := b.ds.AiProviderKey().Delete(ctx, where.F("provider_name", provider.Name)); err != nil {
+			return err
+		}
+
+		return b.ds.AiProvider().Delete(ctx, where.F("id", provider.ID))
+	})
+	if err != nil {
+		return errno.ErrDBWrite.WithMessage("delete ai provider: %v", err)
+	}
+
+	log.C(ctx).Infow("ai provider deleted", "id", provider.ID, "name", provider.Name)
+	reloadProviders(ctx)
+
+	return nil
+}
+
+// maxModelNameLen matches the size of ai_model.model and display_name.
+const maxModelNameLen = 64
+
+func (b *aiProviderBiz) ListLocalModels(ctx context.Context, id uint) (*v1.ListAiLocalModelResponse, error) {
+	provider, served, err := b.discoverModels(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := b.existingModels(ctx, provider.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	data := make([]v1.AiLocalModelInfo, len(served))
+	for i, m := range served {
+		data[i] = v1.AiLocalModelInfo{
+			Model:     m.ID,
+			Type:      m.Type,
+			MaxTokens: m.MaxTokens,
+			Imported:  existing[m.ID],
+		}
+	}
+
+	return &v1.ListAiLocalModelResponse{
+		Total: int64(len(data)),
+		Data:  data,
+	}, nil
+}
+
+func (b *aiProviderBiz) ImportLocalModels(ctx context.Context, id uint, req *v1.ImportAiLocalModelRequest) (*v1.ImportAiLocalModelResponse, error) {
+	provider, served, err := b.discoverModels(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := b.existingModels(ctx, provider.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	// Import all served models unless specific ones are requested
+	wanted := make(map[string]bool, len(req.Models))
+	for _, name := range req.Models {
+		wanted[name] = true
+	}
+
+	status := model.AiModelStatusActive
+	if req.Status != "" {
+		status = model.AiModelStatus(req.Status)
+	}
+
+	resp := &v1.ImportAiLocalModelResponse{
+		Imported: make([]v1.AiModelInfo, 0),
+		Skipped:  make([]string, 0),
+	}
+	for _, m := range served {
+		if len(wanted) > 0 && !wanted[m.ID] {
+			continue
+		}
+		delete(wanted, m.ID)
+		if existing[m.ID] || len(m.ID) > maxModelNameLen {
+			resp.Skipped = append(resp.Skipped, m.ID)
+
+			continue
+		}
+
+		maxTokens := m.MaxTokens
+		if maxTokens <= 0 {
+			maxTokens = 4096
+		}
+		aiModel := &model.AiModelM{
+			ProviderName: provider.Name,
+			Model:        m.ID,
+			DisplayName:  m.ID,
+			Type:         model.AiModelType(m.Type),
+			MaxTokens:    maxTokens,
+			Status:       status,
+		}
+		if aiModel.Type == "" {
+			aiModel.Type = model.AiModelTypeChat
+		}
+		if err := b.ds.AiModel().Create(ctx, aiModel); err != nil {
+			return nil, errno.ErrDBWrite.WithMessage("create ai model: %v", err)
+		}
+		resp.Imported = append(resp.Imported, *toModelInfo(aiModel))
+	}
+
+	// Requested models the server does not serve
+	for _, name := range req.Models {
+		if wanted[name] {
+			resp.Skipped = append(resp.Skipped, name)
+		}
+	}
+
+	log.C(ctx).Infow("ai local models imported", "provider", provider.Name,
+		"imported", len(resp.Imported), "skipped", len(resp.Skipped))
+
+	return resp, nil
+}
+
+// discoverModels queries a loaded provider for the models it serves.
+func (b *aiProviderBiz) discoverModels(ctx context.Context, id uint) (*model.AiProviderM, []aipkg.ModelInfo, error) {
+	provider, err := b.ds.AiProvider().Get(ctx, where.F("id", id))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, errno.ErrAIProviderNotFound
+		}
+
+		return nil, nil, errno.ErrDBRead.WithMessage("get ai provider: %v", err)
+	}
+
+	lister, ok := b.registry.GetModelLister(provider.Name)
+	if !ok {
+		return nil, nil, errno.ErrAIModelDiscoveryNotSupported.WithMessage(
+			"provider %s is not loaded or does not support model discovery", provider.Name)
+	}
+
+	served, err := lister.ListModels(ctx)
+	if err != nil {
+		return nil, nil, errno.ErrAIProviderError.WithMessage("list models of %s: %v", provider.Name, err)
+	}
+
+	return provider, served, nil
+}
+
+// existingModels returns the set of model IDs already configured for a provider, disabled ones included.
+func (b *aiProviderBiz) existingModels(ctx context.Context, providerName string) (map[string]bool, error) {
+	_, models, err := b.ds.AiModel().List(ctx, where.F("provider_name", providerName))
+	if err != nil {
+		return nil, errno.ErrDBRead.WithMessage("list ai models: %v", err)
+	}
+
+	result := make(map[string]bool, len(models))
+	for _, m := range models {
+		result[m.Model] = true
+	}
+
+	return result, nil
+}
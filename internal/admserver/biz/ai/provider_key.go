@@ -0,0 +1,226 @@
+// ABOUTME: AI provider API key business logic for admin management.
+// ABOUTME: Adds, updates and removes the keys a provider is balanced across and reloads providers.
+package ai
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+
+	"github.com/bingo-project/bingo/internal/pkg/ai"
+	"github.com/bingo-project/bingo/internal/pkg/errno"
+	"github.com/bingo-project/bingo/internal/pkg/facade"
+	"github.com/bingo-project/bingo/internal/pkg/log"
+	"github.com/bingo-project/bingo/internal/pkg/model"
+	"github.com/bingo-project/bingo/internal/pkg/store"
+	v1 "github.com/bingo-project/bingo/pkg/api/apiserver/v1"
+	"github.com/bingo-project/bingo/pkg/store/where"
+)
+
+// AiProviderKeyBiz defines AI provider API key management interface for admin.
+type AiProviderKeyBiz interface {
+	List(ctx context.Context, providerID uint) (*v1.ListAiProviderKeyResponse, error)
+	Create(ctx context.Context, providerID uint, req *v1.CreateAiProviderKeyRequest) (*v1.AiProviderKeyInfo, error)
+	Update(ctx context.Context, providerID uint, keyID uint, req *v1.UpdateAiProviderKeyRequest) (*v1.AiProviderKeyInfo, error)
+	Delete(ctx context.Context, providerID uint, keyID uint) error
+}
+
+type aiProviderKeyBiz struct {
+	ds store.IStore
+}
+
+var _ AiProviderKeyBiz = (*aiProviderKeyBiz)(nil)
+
+func NewAiProviderKey(ds store.IStore) AiProviderKeyBiz {
+	return &aiProviderKeyBiz{ds: ds}
+}
+
+// toProviderKeyInfo converts model.AiProviderKeyM to v1.AiProviderKeyInfo with the key masked.
+func toProviderKeyInfo(m *model.AiProviderKeyM) *v1.AiProviderKeyInfo {
+	return &v1.AiProviderKeyInfo{
+		ID:           m.ID,
+		ProviderName: m.ProviderName,
+		Name:         m.Name,
+		APIKey:       maskEncryptedKey(m.APIKey),
+		Weight:       m.Weight,
+		Status:       string(m.Status),
+		CreatedAt:    m.CreatedAt,
+		UpdatedAt:    m.UpdatedAt,
+	}
+}
+
+func (b *aiProviderKeyBiz) List(ctx context.Context, providerID uint) (*v1.ListAiProviderKeyResponse, error) {
+	provider, err := b.getProvider(ctx, providerID)
+	if err != nil {
+		return nil, err
+	}
+
+	_, keys, err := b.ds.AiProviderKey().List(ctx, where.F("provider_name", provider.Name))
+	if err != nil {
+		return nil, errno.ErrDBRead.WithMessage("list ai provider keys: %v", err)
+	}
+
+	data := make([]v1.AiProviderKeyInfo, len(keys))
+	for i, k := range keys {
+		data[i] = *toProviderKeyInfo(k)
+	}
+
+	return &v1.ListAiProviderKeyResponse{
+		Total: int64(len(keys)),
+		Data:  data,
+	}, nil
+}
+
+func (b *aiProviderKeyBiz) Create(ctx context.Context, providerID uint, req *v1.CreateAiProviderKeyRequest) (*v1.AiProviderKeyInfo, error) {
+	provider, err := b.getProvider(ctx, providerID)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := b.ds.AiProviderKey().GetByName(ctx, provider.Name, req.Name)
+	if err == nil && existing != nil {
+		return nil, errno.ErrResourceAlreadyExists.WithMessage("ai provider key already exists: %s", req.Name)
+	}
+
+	encrypted, err := facade.AES.EncryptString(req.APIKey)
+	if err != nil {
+		return nil, errno.ErrInternal.WithMessage("encrypt ai provider key: %v", err)
+	}
+
+	weight := 1
+	if req.Weight > 0 {
+		weight = req.Weight
+	}
+	status := model.AiProviderKeyStatusActive
+	if req.Status != "" {
+		status = model.AiProviderKeyStatus(req.Status)
+	}
+
+	key := &model.AiProviderKeyM{
+		ProviderName: provider.Name,
+		Name:         req.Name,
+		APIKey:       encrypted,
+		Weight:       weight,
+		Status:       status,
+	}
+	if err := b.ds.AiProviderKey().Create(ctx, key); err != nil {
+		return nil, errno.ErrDBWrite.WithMessage("create ai provider key: %v", err)
+	}
+
+	log.C(ctx).Infow("ai provider key created", "id", key.ID, "provider", key.ProviderName, "name", key.Name)
+	reloadProviders(ctx)
+
+	return toProviderKeyInfo(key), nil
+}
+
+func (b *aiProviderKeyBiz) Update(ctx context.Context, providerID uint, keyID uint, req *v1.UpdateAiProviderKeyRequest) (*v1.AiProviderKeyInfo, error) {
+	key, err := b.getKey(ctx, providerID, keyID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.APIKey != "" {
+		encrypted, err := facade.AES.EncryptString(req.APIKey)
+		if err != nil {
+			return nil, errno.ErrInternal.WithMessage("encrypt ai provider key: %v", err)
+		}
+		key.APIKey = encrypted
+	}
+	if req.Weight != nil {
+		key.Weight = *req.Weight
+	}
+	if req.Status != "" {
+		key.Status = model.AiProviderKeyStatus(req.Status)
+	}
+
+	if err := b.ds.AiProviderKey().Update(ctx, key); err != nil {
+		return nil, errno.ErrDBWrite.WithMessage("update ai provider key: %v", err)
+	}
+
+	log.C(ctx).Infow("ai provider key updated", "id", key.ID, "provider", key.ProviderName, "name", key.Name)
+	reloadProviders(ctx)
+
+	return toProviderKeyInfo(key), nil
+}
+
+func (b *aiProviderKeyBiz) Delete(ctx context.Context, providerID uint, keyID uint) error {
+	key, err := b.getKey(ctx, providerID, keyID)
+	if err != nil {
+		return err
+	}
+
+	if err := b.ds.AiProviderKey().Delete(ctx, where.F("id", key.ID)); err != nil {
+		return errno.ErrDBWrite.WithMessage("delete ai provider key: %v", err)
+	}
+
+	log.C(ctx).Infow("ai provider key deleted", "id", key.ID, "provider", key.ProviderName, "name", key.Name)
+	reloadProviders(ctx)
+
+	return nil
+}
+
+func (b *aiProviderKeyBiz) getProvider(ctx context.Context, id uint) (*model.AiProviderM, error) {
+	provider, err := b.ds.AiProvider().Get(ctx, where.F("id", id))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errno.ErrAIProviderNotFound
+		}
+
+		return nil, errno.ErrDBRead.WithMessage("get ai provider: %v", err)
+	}
+
+	return provider, nil
+}
+
+// getKey returns a key of the provider, keys of other providers are not found.
+func (b *aiProviderKeyBiz) getKey(ctx context.Context, providerID uint, keyID uint) (*model.AiProviderKeyM, error) {
+	provider, err := b.getProvider(ctx, providerID)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := b.ds.AiProviderKey().Get(ctx, where.F("id", keyID))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errno.ErrAIProviderKeyNotFound
+		}
+
+		return nil, errno.ErrDBRead.WithMessage("get ai provider key: %v", err)
+	}
+	if key.ProviderName != provider.Name {
+		return nil, errno.ErrAIProviderKeyNotFound
+	}
+
+	return key, nil
+}
+
+// reloadProviders asks every server to reload AI providers. A failure is only logged, the
+// change is picked up by the next reload.
+func reloadProviders(ctx context.Context) {
+	if err := ai.TriggerReload(ctx, facade.Redis); err != nil {
+		log.C(ctx).Warnw("Failed to trigger ai provider reload", "err", err)
+	}
+}
+
+// maskEncryptedKey decrypts a stored API key and masks all but its first 3 and last 4 characters.
+func maskEncryptedKey(encrypted string) string {
+	if facade.AES == nil {
+		return "****"
+	}
+	key, err := facade.AES.DecryptString(encrypted)
+	if err != nil {
+		return "****"
+	}
+
+	return maskAPIKey(key)
+}
+
+// maskAPIKey masks all but the first 3 and last 4 characters of an API key.
+func maskAPIKey(key string) string {
+	if len(key) <= 12 {
+		return "****"
+	}
+
+	return key[:3] + "****" + key[len(key)-4:]
+}
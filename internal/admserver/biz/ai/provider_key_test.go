@@ -0,0 +1,68 @@
+package ai
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bingo-project/component-base/crypt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bingo-project/bingo/internal/pkg/errno"
+	"github.com/bingo-project/bingo/internal/pkg/facade"
+	"github.com/bingo-project/bingo/internal/pkg/model"
+	mockstore "github.com/bingo-project/bingo/internal/pkg/testing/mock/store"
+	v1 "github.com/bingo-project/bingo/pkg/api/apiserver/v1"
+)
+
+func TestAiProviderKeyBiz(t *testing.T) {
+	original := facade.AES
+	facade.AES = crypt.NewAES("0123456789abcdef0123456789abcdef")
+	defer func() { facade.AES = original }()
+
+	ctx := context.Background()
+	ds := mockstore.NewStore()
+	require.NoError(t, ds.AiProvider().Create(ctx, &model.AiProviderM{Name: "openai", Status: model.AiProviderStatusActive}))
+
+	b := NewAiProviderKey(ds)
+	info, err := b.Create(ctx, 1, &v1.CreateAiProviderKeyRequest{Name: "team-b", APIKey: "sk-proj-1234567890abcd", Weight: 3})
+	require.NoError(t, err)
+	assert.Equal(t, "sk-****abcd", info.APIKey)
+	assert.Equal(t, 3, info.Weight)
+	assert.Equal(t, "active", info.Status)
+
+	stored, err := ds.AiProviderKey().GetByName(ctx, "openai", "team-b")
+	require.NoError(t, err)
+	assert.NotContains(t, stored.APIKey, "sk-proj", "keys are stored encrypted")
+
+	_, err = b.Create(ctx, 1, &v1.CreateAiProviderKeyRequest{Name: "team-b", APIKey: "sk-other"})
+	assert.ErrorIs(t, err, errno.ErrResourceAlreadyExists)
+
+	weight := 5
+	info, err = b.Update(ctx, 1, info.ID, &v1.UpdateAiProviderKeyRequest{APIKey: "sk-proj-0987654321wxyz", Weight: &weight, Status: "disabled"})
+	require.NoError(t, err)
+	assert.Equal(t, "sk-****wxyz", info.APIKey)
+	assert.Equal(t, 5, info.Weight)
+	assert.Equal(t, "disabled", info.Status)
+
+	list, err := b.List(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), list.Total)
+
+	// Keys of another provider are not found
+	stored.ProviderName = "deepseek"
+	_, err = b.Update(ctx, 1, info.ID, &v1.UpdateAiProviderKeyRequest{Weight: &weight})
+	assert.ErrorIs(t, err, errno.ErrAIProviderKeyNotFound)
+	stored.ProviderName = "openai"
+
+	require.NoError(t, b.Delete(ctx, 1, info.ID))
+	list, err = b.List(ctx, 1)
+	require.NoError(t, err)
+	assert.Zero(t, list.Total)
+}
+
+func TestMaskAPIKey(t *testing.T) {
+	assert.Equal(t, "sk-****cdef", maskAPIKey("sk-1234567890abcdef"))
+	assert.Equal(t, "****", maskAPIKey("short-key"))
+	assert.Equal(t, "****", maskEncryptedKey("not-encrypted"))
+}
@@ -0,0 +1,97 @@
+package ai
+
+import (
+	"context"
+	"testing"
+
+	"github.com/bingo-project/component-base/crypt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bingo-project/bingo/internal/pkg/errno"
+	"github.com/bingo-project/bingo/internal/pkg/facade"
+	"github.com/bingo-project/bingo/internal/pkg/model"
+	mockstore "github.com/bingo-project/bingo/internal/pkg/testing/mock/store"
+	v1 "github.com/bingo-project/bingo/pkg/api/apiserver/v1"
+)
+
+func TestAiProviderBiz_Credentials(t *testing.T) {
+	original := facade.AES
+	facade.AES = crypt.NewAES("0123456789abcdef0123456789abcdef")
+	defer func() { facade.AES = original }()
+
+	ctx := context.Background()
+	ds := mockstore.NewStore()
+	b := NewAiProvider(ds, nil)
+
+	_, err := b.Create(ctx, &v1.CreateAiProviderRequest{Name: "vllm"})
+	assert.ErrorIs(t, err, errno.ErrInvalidArgument, "providers without a built-in endpoint need a baseURL")
+
+	info, err := b.Create(ctx, &v1.CreateAiProviderRequest{Name: "openai", APIKey: "sk-proj-1234567890abcd"})
+	require.NoError(t, err)
+	assert.Equal(t, "openai", info.DisplayName)
+	assert.Equal(t, "active", info.Status)
+	assert.Equal(t, "sk-****abcd", info.APIKey)
+
+	stored, err := ds.AiProvider().GetByName(ctx, "openai")
+	require.NoError(t, err)
+	assert.NotContains(t, stored.APIKey, "sk-proj", "keys are stored encrypted")
+
+	_, err = b.Create(ctx, &v1.CreateAiProviderRequest{Name: "openai"})
+	assert.ErrorIs(t, err, errno.ErrResourceAlreadyExists)
+
+	baseURL, apiKey := "https://gateway.example.com/v1", "sk-proj-0987654321wxyz"
+	info, err = b.Update(ctx, info.ID, &v1.UpdateAiProviderRequest{BaseURL: &baseURL, APIKey: &apiKey})
+	require.NoError(t, err)
+	assert.Equal(t, baseURL, info.BaseURL)
+	assert.Equal(t, "sk-****wxyz", info.APIKey)
+
+	invalid := "gateway"
+	_, err = b.Update(ctx, info.ID, &v1.UpdateAiProviderRequest{BaseURL: &invalid})
+	assert.ErrorIs(t, err, errno.ErrInvalidArgument)
+
+	// An empty key clears the stored one
+	empty := ""
+	info, err = b.Update(ctx, info.ID, &v1.UpdateAiProviderRequest{APIKey: &empty})
+	require.NoError(t, err)
+	assert.Empty(t, info.APIKey)
+	assert.Empty(t, stored.APIKey)
+}
+
+func TestAiProviderBiz_ReservedName(t *testing.T) {
+	ctx := context.Background()
+	ds := mockstore.NewStore()
+	b := NewAiProvider(ds, nil)
+
+	_, err := b.Create(ctx, &v1.CreateAiProviderRequest{Name: "fake", BaseURL: "/etc/passwd"})
+	assert.ErrorIs(t, err, errno.ErrInvalidArgument, "the fake provider cannot be configured by admins")
+
+	// Rows created before the name was reserved cannot be pointed at a cassette either
+	require.NoError(t, ds.AiProvider().Create(ctx, &model.AiProviderM{Name: "fake", Status: model.AiProviderStatusActive}))
+	cassette := "/etc/passwd"
+	_, err = b.Update(ctx, 1, &v1.UpdateAiProviderRequest{BaseURL: &cassette})
+	assert.ErrorIs(t, err, errno.ErrInvalidArgument)
+}
+
+func TestAiProviderBiz_Delete(t *testing.T) {
+	ctx := context.Background()
+	ds := mockstore.NewStore()
+	require.NoError(t, ds.AiProvider().Create(ctx, &model.AiProviderM{Name: "openai", Status: model.AiProviderStatusActive}))
+	require.NoError(t, ds.AiProviderKey().Create(ctx, &model.AiProviderKeyM{ProviderName: "openai", Name: "team-b", APIKey: "encrypted"}))
+	require.NoError(t, ds.AiModel().Create(ctx, &model.AiModelM{ProviderName: "openai", Model: "gpt-4o"}))
+	b := NewAiProvider(ds, nil)
+
+	// Providers with models are kept
+	assert.ErrorIs(t, b.Delete(ctx, 1), errno.ErrAIProviderInUse)
+
+	require.NoError(t, ds.AiModel().Delete(ctx, nil))
+	require.NoError(t, b.Delete(ctx, 1))
+	_, providers, err := ds.AiProvider().List(ctx, nil)
+	require.NoError(t, err)
+	assert.Empty(t, providers)
+	_, keys, err := ds.AiProviderKey().List(ctx, nil)
+	require.NoError(t, err)
+	assert.Empty(t, keys)
+
+	assert.ErrorIs(t, b.Delete(ctx, 1), errno.ErrAIProviderNotFound)
+}
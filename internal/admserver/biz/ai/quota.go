@@ -1,5 +1,5 @@
 // ABOUTME: AI Quota business logic for admin management.
-// ABOUTME: Provides list/get/update/reset operations for user quota resources.
+// ABOUTME: Provides list/get/update/reset operations for user quotas and list/update for quota tiers.
 package ai
 
 import (
@@ -8,7 +8,9 @@ import (
 
 	"gorm.io/gorm"
 
+	"github.com/bingo-project/bingo/internal/pkg/ai"
 	"github.com/bingo-project/bingo/internal/pkg/errno"
+	"github.com/bingo-project/bingo/internal/pkg/facade"
 	"github.com/bingo-project/bingo/internal/pkg/log"
 	"github.com/bingo-project/bingo/internal/pkg/model"
 	"github.com/bingo-project/bingo/internal/pkg/store"
@@ -22,6 +24,8 @@ type AiQuotaBiz interface {
 	Get(ctx context.Context, uid string) (*v1.AiUserQuotaInfo, error)
 	Update(ctx context.Context, uid string, req *v1.UpdateAiUserQuotaRequest) (*v1.AiUserQuotaInfo, error)
 	ResetDailyTokens(ctx context.Context, uid string) error
+	ListTiers(ctx context.Context) ([]v1.AiQuotaTierInfo, error)
+	UpdateTier(ctx context.Context, tier string, req *v1.UpdateAiQuotaTierRequest) (*v1.AiQuotaTierInfo, error)
 }
 
 type aiQuotaBiz struct {
@@ -123,6 +127,11 @@ func (b *aiQuotaBiz) Update(ctx context.Context, uid string, req *v1.UpdateAiUse
 		return nil, errno.ErrDBWrite.WithMessage("update ai user quota: %v", err)
 	}
 
+	// Drop the cached rate limit so the new tier or override applies immediately
+	if err := ai.NewRPMResolver(b.ds, facade.Redis).Invalidate(ctx, quota.UID); err != nil {
+		log.C(ctx).Warnw("Failed to invalidate cached ai rpm limit", "uid", quota.UID, "err", err)
+	}
+
 	log.C(ctx).Infow("ai user quota updated", "uid", quota.UID, "tier", quota.Tier)
 
 	return toQuotaInfo(quota), nil
@@ -142,3 +151,63 @@ func (b *aiQuotaBiz) ResetDailyTokens(ctx context.Context, uid string) error {
 
 	return nil
 }
+
+// toQuotaTierInfo converts model.AiQuotaTierM to v1.AiQuotaTierInfo.
+func toQuotaTierInfo(m *model.AiQuotaTierM) *v1.AiQuotaTierInfo {
+	return &v1.AiQuotaTierInfo{
+		Tier:        m.Tier,
+		DisplayName: m.DisplayName,
+		RPM:         m.RPM,
+		TPD:         m.TPD,
+		CreatedAt:   m.CreatedAt,
+		UpdatedAt:   m.UpdatedAt,
+	}
+}
+
+func (b *aiQuotaBiz) ListTiers(ctx context.Context) ([]v1.AiQuotaTierInfo, error) {
+	_, tiers, err := b.ds.AiQuotaTier().List(ctx, nil)
+	if err != nil {
+		return nil, errno.ErrDBRead.WithMessage("list ai quota tiers: %v", err)
+	}
+
+	data := make([]v1.AiQuotaTierInfo, len(tiers))
+	for i, t := range tiers {
+		data[i] = *toQuotaTierInfo(t)
+	}
+
+	return data, nil
+}
+
+func (b *aiQuotaBiz) UpdateTier(ctx context.Context, tier string, req *v1.UpdateAiQuotaTierRequest) (*v1.AiQuotaTierInfo, error) {
+	t, err := b.ds.AiQuotaTier().GetByTier(ctx, tier)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errno.ErrNotFound.WithMessage("quota tier not found")
+		}
+
+		return nil, errno.ErrDBRead.WithMessage("get ai quota tier: %v", err)
+	}
+
+	if req.DisplayName != "" {
+		t.DisplayName = req.DisplayName
+	}
+	if req.RPM != nil {
+		t.RPM = *req.RPM
+	}
+	if req.TPD != nil {
+		t.TPD = *req.TPD
+	}
+
+	if err := b.ds.AiQuotaTier().Update(ctx, t); err != nil {
+		return nil, errno.ErrDBWrite.WithMessage("update ai quota tier: %v", err)
+	}
+
+	// Limits are cached per user, drop them all so the tier's users get the new limit immediately
+	if err := ai.NewRPMResolver(b.ds, facade.Redis).InvalidateAll(ctx); err != nil {
+		log.C(ctx).Warnw("Failed to invalidate cached ai rpm limits", "tier", t.Tier, "err", err)
+	}
+
+	log.C(ctx).Infow("ai quota tier updated", "tier", t.Tier, "rpm", t.RPM, "tpd", t.TPD)
+
+	return toQuotaTierInfo(t), nil
+}
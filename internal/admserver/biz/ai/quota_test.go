@@ -0,0 +1,35 @@
+package ai
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bingo-project/bingo/internal/pkg/errno"
+	"github.com/bingo-project/bingo/internal/pkg/model"
+	mockstore "github.com/bingo-project/bingo/internal/pkg/testing/mock/store"
+	v1 "github.com/bingo-project/bingo/pkg/api/apiserver/v1"
+)
+
+func TestAiQuotaBiz_UpdateTier(t *testing.T) {
+	ctx := context.Background()
+	ds := mockstore.NewStore()
+	require.NoError(t, ds.AiQuotaTier().Create(ctx, &model.AiQuotaTierM{Tier: model.AiQuotaTierPro, DisplayName: "Pro Tier", RPM: 60, TPD: 1000000}))
+	b := NewAiQuota(ds)
+
+	rpm := 120
+	info, err := b.UpdateTier(ctx, model.AiQuotaTierPro, &v1.UpdateAiQuotaTierRequest{RPM: &rpm})
+	require.NoError(t, err)
+	assert.Equal(t, 120, info.RPM)
+	assert.Equal(t, 1000000, info.TPD)
+
+	tiers, err := b.ListTiers(ctx)
+	require.NoError(t, err)
+	require.Len(t, tiers, 1)
+	assert.Equal(t, 120, tiers[0].RPM)
+
+	_, err = b.UpdateTier(ctx, "missing", &v1.UpdateAiQuotaTierRequest{RPM: &rpm})
+	assert.ErrorIs(t, err, errno.ErrNotFound)
+}
@@ -0,0 +1,93 @@
+// ABOUTME: AI conversation search business logic for admin support.
+// ABOUTME: Searches sessions and messages across users and records every search in the audit log.
+package ai
+
+import (
+	"context"
+
+	"github.com/bingo-project/bingo/internal/pkg/ai"
+	"github.com/bingo-project/bingo/internal/pkg/errno"
+	"github.com/bingo-project/bingo/internal/pkg/log"
+	"github.com/bingo-project/bingo/internal/pkg/store"
+	v1 "github.com/bingo-project/bingo/pkg/api/apiserver/v1"
+	"github.com/bingo-project/bingo/pkg/contextx"
+)
+
+// AiSearchBiz defines conversation search across users for admin.
+type AiSearchBiz interface {
+	Search(ctx context.Context, req *v1.AdminAiSearchRequest) (*v1.AiSearchResponse, error)
+}
+
+type aiSearchBiz struct {
+	searcher ai.Searcher
+}
+
+var _ AiSearchBiz = (*aiSearchBiz)(nil)
+
+func NewAiSearch(ds store.IStore) AiSearchBiz {
+	return &aiSearchBiz{searcher: ai.NewFulltextSearcher(ds)}
+}
+
+func (b *aiSearchBiz) Search(ctx context.Context, req *v1.AdminAiSearchRequest) (*v1.AiSearchResponse, error) {
+	// Default pagination
+	page := 1
+	pageSize := 20
+	if req.Page > 0 {
+		page = req.Page
+	}
+	if req.PageSize > 0 {
+		pageSize = req.PageSize
+	}
+
+	total, hits, err := b.searcher.Search(ctx, ai.SearchQuery{
+		UID:    req.UID,
+		Text:   req.Q,
+		Offset: (page - 1) * pageSize,
+		Limit:  pageSize,
+	})
+
+	// Reading other users' conversations is audited, failed searches included
+	log.C(ctx).Infow("Admin searched AI conversations",
+		"audit", true,
+		"admin", contextx.Username(ctx),
+		"client_ip", contextx.ClientIP(ctx),
+		"uid", req.UID,
+		"q", req.Q,
+		"page", page,
+		"total", total,
+		"err", err,
+	)
+	if err != nil {
+		return nil, errno.ErrDBRead.WithMessage("%v", err)
+	}
+
+	resp := &v1.AiSearchResponse{
+		Total: total,
+		Data:  make([]v1.AiSearchHit, len(hits)),
+	}
+	for i, h := range hits {
+		resp.Data[i] = v1.AiSearchHit{
+			SessionID:       h.SessionID,
+			UID:             h.UID,
+			Title:           h.Title,
+			TitleHighlights: toSearchHighlights(h.TitleHighlights),
+			MessageID:       h.MessageID,
+			Role:            h.Role,
+			Snippet:         h.Snippet,
+			Highlights:      toSearchHighlights(h.Highlights),
+			CreatedAt:       h.CreatedAt,
+		}
+	}
+
+	return resp, nil
+}
+
+// toSearchHighlights converts ai.Highlight matches to v1.AiSearchHighlight.
+func toSearchHighlights(hl []ai.Highlight) []v1.AiSearchHighlight {
+	out := make([]v1.AiSearchHighlight, len(hl))
+	for i, h := range hl {
+		out[i] = v1.AiSearchHighlight{Start: h.Start, End: h.End}
+	}
+
+	return out
+}
@@ -0,0 +1,160 @@
+// ABOUTME: AI Usage business logic for admin management.
+// ABOUTME: Provides ledger listing and spend aggregates by user, model or day.
+package ai
+
+import (
+	"context"
+	"time"
+
+	"github.com/bingo-project/bingo/internal/pkg/errno"
+	"github.com/bingo-project/bingo/internal/pkg/model"
+	"github.com/bingo-project/bingo/internal/pkg/store"
+	v1 "github.com/bingo-project/bingo/pkg/api/apiserver/v1"
+	"github.com/bingo-project/bingo/pkg/store/where"
+)
+
+// usageDayLayout is the day format of usage range parameters and keys.
+const usageDayLayout = "2006-01-02"
+
+// AiUsageBiz defines AI usage ledger interface for admin.
+type AiUsageBiz interface {
+	List(ctx context.Context, req *v1.ListAiUsageRequest) (*v1.ListAiUsageResponse, error)
+	Summary(ctx context.Context, req *v1.AiUsageSummaryRequest) (*v1.AiUsageSummaryResponse, error)
+}
+
+type aiUsageBiz struct {
+	ds store.IStore
+}
+
+var _ AiUsageBiz = (*aiUsageBiz)(nil)
+
+func NewAiUsage(ds store.IStore) AiUsageBiz {
+	return &aiUsageBiz{ds: ds}
+}
+
+// toUsageInfo converts model.AiUsageM to v1.AiUsageInfo.
+func toUsageInfo(m *model.AiUsageM) v1.AiUsageInfo {
+	return v1.AiUsageInfo{
+		ID:               m.ID,
+		UID:              m.UID,
+		SessionID:        m.SessionID,
+		AgentID:          m.AgentID,
+		ProviderName:     m.ProviderName,
+		Model:            m.Model,
+		Stream:           m.Stream,
+		Fallback:         m.Fallback,
+		PromptTokens:     m.PromptTokens,
+		CompletionTokens: m.CompletionTokens,
+		TotalTokens:      m.TotalTokens,
+		Cost:             m.Cost,
+		LatencyMs:        m.LatencyMs,
+		Status:           m.Status,
+		Error:            m.Error,
+		CreatedAt:        m.CreatedAt,
+	}
+}
+
+func (b *aiUsageBiz) List(ctx context.Context, req *v1.ListAiUsageRequest) (*v1.ListAiUsageResponse, error) {
+	// Default pagination
+	page := 1
+	pageSize := 20
+	if req.Page > 0 {
+		page = req.Page
+	}
+	if req.PageSize > 0 {
+		pageSize = req.PageSize
+	}
+
+	// Build where clause
+	opts := where.P(page, pageSize)
+	if req.UID != "" {
+		opts = opts.F("uid", req.UID)
+	}
+	if req.Model != "" {
+		opts = opts.F("model", req.Model)
+	}
+	if req.ProviderName != "" {
+		opts = opts.F("provider_name", req.ProviderName)
+	}
+	if req.Status != "" {
+		opts = opts.F("status", req.Status)
+	}
+	if req.From != nil {
+		opts = opts.Q("created_at >= ?", *req.From)
+	}
+	if req.To != nil {
+		opts = opts.Q("created_at < ?", req.To.AddDate(0, 0, 1))
+	}
+
+	total, rows, err := b.ds.AiUsage().List(ctx, opts)
+	if err != nil {
+		return nil, errno.ErrDBRead.WithMessage("list ai usage: %v", err)
+	}
+
+	data := make([]v1.AiUsageInfo, len(rows))
+	for i, row := range rows {
+		data[i] = toUsageInfo(row)
+	}
+
+	return &v1.ListAiUsageResponse{
+		Total: total,
+		Data:  data,
+	}, nil
+}
+
+func (b *aiUsageBiz) Summary(ctx context.Context, req *v1.AiUsageSummaryRequest) (*v1.AiUsageSummaryResponse, error) {
+	// Default range is the current month
+	now := time.Now()
+	from := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	to := now
+	if req.From != nil {
+		from = *req.From
+	}
+	if req.To != nil {
+		to = *req.To
+	}
+	if to.Before(from) {
+		return nil, errno.ErrInvalidArgument.WithMessage("to must not be before from")
+	}
+
+	opts := where.NewWhere().
+		Q("created_at >= ?", from).
+		Q("created_at < ?", time.Date(to.Year(), to.Month(), to.Day()+1, 0, 0, 0, 0, to.Location()))
+	if req.UID != "" {
+		opts = opts.F("uid", req.UID)
+	}
+	if req.Model != "" {
+		opts = opts.F("model", req.Model)
+	}
+
+	rows, err := b.ds.AiUsage().Summarize(ctx, req.GroupBy, opts)
+	if err != nil {
+		return nil, errno.ErrDBRead.WithMessage("summarize ai usage: %v", err)
+	}
+
+	resp := &v1.AiUsageSummaryResponse{
+		GroupBy: req.GroupBy,
+		From:    from.Format(usageDayLayout),
+		To:      to.Format(usageDayLayout),
+		Data:    make([]v1.AiUsageSummaryItem, len(rows)),
+	}
+	for i, row := range rows {
+		resp.Data[i] = v1.AiUsageSummaryItem{
+			Key:              row.Key,
+			Requests:         row.Requests,
+			Errors:           row.Errors,
+			PromptTokens:     row.PromptTokens,
+			CompletionTokens: row.CompletionTokens,
+			TotalTokens:      row.TotalTokens,
+			Cost:             row.Cost,
+		}
+		resp.Total.Requests += row.Requests
+		resp.Total.Errors += row.Errors
+		resp.Total.PromptTokens += row.PromptTokens
+		resp.Total.CompletionTokens += row.CompletionTokens
+		resp.Total.TotalTokens += row.TotalTokens
+		resp.Total.Cost += row.Cost
+	}
+
+	return resp, nil
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/bingo-project/bingo/internal/admserver/biz/syscfg"
 	"github.com/bingo-project/bingo/internal/admserver/biz/system"
 	"github.com/bingo-project/bingo/internal/admserver/biz/user"
+	aipkg "github.com/bingo-project/bingo/internal/pkg/ai"
 	"github.com/bingo-project/bingo/internal/pkg/store"
 )
 
@@ -23,9 +24,17 @@ type IBiz interface {
 	Users() user.UserBiz
 
 	AiAgents() ai.AiAgentBiz
+	AiAgentVersions() ai.AiAgentVersionBiz
+	AiExperiments() ai.AiExperimentBiz
+	AiGuardrailEvents() ai.AiGuardrailEventBiz
 	AiProviders() ai.AiProviderBiz
+	AiProviderKeys() ai.AiProviderKeyBiz
 	AiModels() ai.AiModelBiz
 	AiQuotas() ai.AiQuotaBiz
+	AiKnowledge() ai.AiKnowledgeBiz
+	AiUsage() ai.AiUsageBiz
+	AiSearch() ai.AiSearchBiz
+	AiFeedback() ai.AiFeedbackBiz
 
 	Servers() syscfg.ServerBiz
 	Email() common.EmailBiz
@@ -78,7 +87,11 @@ func (b *biz) AiAgents() ai.AiAgentBiz {
 }
 
 func (b *biz) AiProviders() ai.AiProviderBiz {
-	return ai.NewAiProvider(b.ds)
+	return ai.NewAiProvider(b.ds, aipkg.GetRegistry())
+}
+
+func (b *biz) AiProviderKeys() ai.AiProviderKeyBiz {
+	return ai.NewAiProviderKey(b.ds)
 }
 
 func (b *biz) AiModels() ai.AiModelBiz {
@@ -89,6 +102,34 @@ func (b *biz) AiQuotas() ai.AiQuotaBiz {
 	return ai.NewAiQuota(b.ds)
 }
 
+func (b *biz) AiKnowledge() ai.AiKnowledgeBiz {
+	return ai.NewAiKnowledge(b.ds, aipkg.GetRegistry())
+}
+
+func (b *biz) AiUsage() ai.AiUsageBiz {
+	return ai.NewAiUsage(b.ds)
+}
+
+func (b *biz) AiSearch() ai.AiSearchBiz {
+	return ai.NewAiSearch(b.ds)
+}
+
+func (b *biz) AiFeedback() ai.AiFeedbackBiz {
+	return ai.NewAiFeedback(b.ds)
+}
+
+func (b *biz) AiAgentVersions() ai.AiAgentVersionBiz {
+	return ai.NewAiAgentVersion(b.ds)
+}
+
+func (b *biz) AiExperiments() ai.AiExperimentBiz {
+	return ai.NewAiExperiment(b.ds)
+}
+
+func (b *biz) AiGuardrailEvents() ai.AiGuardrailEventBiz {
+	return ai.NewAiGuardrailEvent(b.ds)
+}
+
 func (b *biz) Servers() syscfg.ServerBiz {
 	return syscfg.NewServer(b.ds)
 }
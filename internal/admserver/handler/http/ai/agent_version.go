@@ -0,0 +1,147 @@
+// ABOUTME: HTTP handlers for AI agent versions in admin panel.
+// ABOUTME: Provides endpoints to list, create, publish (roll back) and diff agent versions.
+package ai
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/bingo-project/bingo/internal/admserver/biz"
+	"github.com/bingo-project/bingo/internal/pkg/core"
+	"github.com/bingo-project/bingo/internal/pkg/errno"
+	"github.com/bingo-project/bingo/internal/pkg/store"
+	v1 "github.com/bingo-project/bingo/pkg/api/apiserver/v1"
+)
+
+type AgentVersionHandler struct {
+	b biz.IBiz
+}
+
+func NewAgentVersionHandler(ds store.IStore) *AgentVersionHandler {
+	return &AgentVersionHandler{b: biz.NewBiz(ds)}
+}
+
+// List
+// @Summary    List AI agent versions
+// @Security   Bearer
+// @Tags       AI
+// @Accept     application/json
+// @Produce    json
+// @Param      id        path      string  true   "Agent ID"
+// @Param      page      query     int     false  "Page number"
+// @Param      pageSize  query     int     false  "Page size"
+// @Success    200       {object}  v1.ListAiAgentVersionResponse
+// @Failure    400       {object}  core.ErrResponse
+// @Failure    404       {object}  core.ErrResponse
+// @Router     /v1/ai/agents/{id}/versions [GET].
+func (h *AgentVersionHandler) List(c *gin.Context) {
+	var req v1.ListAiAgentVersionRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		core.Response(c, nil, errno.ErrInvalidArgument.WithMessage("%s", err.Error()))
+
+		return
+	}
+
+	resp, err := h.b.AiAgentVersions().List(c, c.Param("id"), &req)
+	core.Response(c, resp, err)
+}
+
+// Create
+// @Summary    Create AI agent version
+// @Security   Bearer
+// @Tags       AI
+// @Accept     application/json
+// @Produce    json
+// @Param      id       path      string                          true  "Agent ID"
+// @Param      request  body      v1.CreateAiAgentVersionRequest  true  "Param"
+// @Success    200      {object}  v1.AiAgentVersionInfo
+// @Failure    400      {object}  core.ErrResponse
+// @Failure    404      {object}  core.ErrResponse
+// @Failure    500      {object}  core.ErrResponse
+// @Router     /v1/ai/agents/{id}/versions [POST].
+func (h *AgentVersionHandler) Create(c *gin.Context) {
+	var req v1.CreateAiAgentVersionRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		core.Response(c, nil, errno.ErrInvalidArgument.WithMessage("%s", err.Error()))
+
+		return
+	}
+
+	resp, err := h.b.AiAgentVersions().Create(c, c.Param("id"), &req)
+	core.Response(c, resp, err)
+}
+
+// Get
+// @Summary    Get AI agent version
+// @Security   Bearer
+// @Tags       AI
+// @Accept     application/json
+// @Produce    json
+// @Param      id       path      string  true  "Agent ID"
+// @Param      version  path      int     true  "Version"
+// @Success    200      {object}  v1.AiAgentVersionInfo
+// @Failure    400      {object}  core.ErrResponse
+// @Failure    404      {object}  core.ErrResponse
+// @Router     /v1/ai/agents/{id}/versions/{version} [GET].
+func (h *AgentVersionHandler) Get(c *gin.Context) {
+	version, err := strconv.Atoi(c.Param("version"))
+	if err != nil {
+		core.Response(c, nil, errno.ErrInvalidArgument.WithMessage("invalid version"))
+
+		return
+	}
+
+	resp, err := h.b.AiAgentVersions().Get(c, c.Param("id"), version)
+	core.Response(c, resp, err)
+}
+
+// Publish
+// @Summary    Publish AI agent version, publishing an earlier version rolls back
+// @Security   Bearer
+// @Tags       AI
+// @Accept     application/json
+// @Produce    json
+// @Param      id       path      string  true  "Agent ID"
+// @Param      version  path      int     true  "Version"
+// @Success    200      {object}  v1.AiAgentInfo
+// @Failure    400      {object}  core.ErrResponse
+// @Failure    404      {object}  core.ErrResponse
+// @Failure    500      {object}  core.ErrResponse
+// @Router     /v1/ai/agents/{id}/versions/{version}/publish [POST].
+func (h *AgentVersionHandler) Publish(c *gin.Context) {
+	version, err := strconv.Atoi(c.Param("version"))
+	if err != nil {
+		core.Response(c, nil, errno.ErrInvalidArgument.WithMessage("invalid version"))
+
+		return
+	}
+
+	resp, err := h.b.AiAgentVersions().Publish(c, c.Param("id"), version)
+	core.Response(c, resp, err)
+}
+
+// Diff
+// @Summary    Diff two AI agent versions
+// @Security   Bearer
+// @Tags       AI
+// @Accept     application/json
+// @Produce    json
+// @Param      id    path      string  true  "Agent ID"
+// @Param      from  query     int     true  "Base version"
+// @Param      to    query     int     true  "Compared version"
+// @Success    200   {object}  v1.AiAgentVersionDiff
+// @Failure    400   {object}  core.ErrResponse
+// @Failure    404   {object}  core.ErrResponse
+// @Router     /v1/ai/agents/{id}/versions/diff [GET].
+func (h *AgentVersionHandler) Diff(c *gin.Context) {
+	var req v1.DiffAiAgentVersionRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		core.Response(c, nil, errno.ErrInvalidArgument.WithMessage("%s", err.Error()))
+
+		return
+	}
+
+	resp, err := h.b.AiAgentVersions().Diff(c, c.Param("id"), &req)
+	core.Response(c, resp, err)
+}
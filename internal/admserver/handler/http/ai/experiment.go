@@ -0,0 +1,224 @@
+// ABOUTME: HTTP handlers for AI experiments in admin panel.
+// ABOUTME: Provides endpoints to manage A/B experiments on agents and report how their arms compare.
+package ai
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/bingo-project/bingo/internal/admserver/biz"
+	"github.com/bingo-project/bingo/internal/pkg/core"
+	"github.com/bingo-project/bingo/internal/pkg/errno"
+	"github.com/bingo-project/bingo/internal/pkg/store"
+	v1 "github.com/bingo-project/bingo/pkg/api/apiserver/v1"
+)
+
+type ExperimentHandler struct {
+	b biz.IBiz
+}
+
+func NewExperimentHandler(ds store.IStore) *ExperimentHandler {
+	return &ExperimentHandler{b: biz.NewBiz(ds)}
+}
+
+// List
+// @Summary    List AI experiments
+// @Security   Bearer
+// @Tags       AI
+// @Accept     application/json
+// @Produce    json
+// @Param      page      query     int     false  "Page number"
+// @Param      pageSize  query     int     false  "Page size"
+// @Param      agentId   query     string  false  "Agent ID"
+// @Param      status    query     string  false  "Status: draft, running or stopped"
+// @Success    200       {object}  v1.ListAiExperimentResponse
+// @Failure    400       {object}  core.ErrResponse
+// @Failure    500       {object}  core.ErrResponse
+// @Router     /v1/ai/experiments [GET].
+func (h *ExperimentHandler) List(c *gin.Context) {
+	var req v1.ListAiExperimentRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		core.Response(c, nil, errno.ErrInvalidArgument.WithMessage("%s", err.Error()))
+
+		return
+	}
+
+	resp, err := h.b.AiExperiments().List(c, &req)
+	core.Response(c, resp, err)
+}
+
+// Create
+// @Summary    Create AI experiment
+// @Security   Bearer
+// @Tags       AI
+// @Accept     application/json
+// @Produce    json
+// @Param      request  body      v1.CreateAiExperimentRequest  true  "Param"
+// @Success    200      {object}  v1.AiExperimentInfo
+// @Failure    400      {object}  core.ErrResponse
+// @Failure    404      {object}  core.ErrResponse
+// @Failure    500      {object}  core.ErrResponse
+// @Router     /v1/ai/experiments [POST].
+func (h *ExperimentHandler) Create(c *gin.Context) {
+	var req v1.CreateAiExperimentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		core.Response(c, nil, errno.ErrInvalidArgument.WithMessage("%s", err.Error()))
+
+		return
+	}
+
+	resp, err := h.b.AiExperiments().Create(c, &req)
+	core.Response(c, resp, err)
+}
+
+// Get
+// @Summary    Get AI experiment
+// @Security   Bearer
+// @Tags       AI
+// @Accept     application/json
+// @Produce    json
+// @Param      id   path      int  true  "Experiment ID"
+// @Success    200  {object}  v1.AiExperimentInfo
+// @Failure    400  {object}  core.ErrResponse
+// @Failure    404  {object}  core.ErrResponse
+// @Router     /v1/ai/experiments/{id} [GET].
+func (h *ExperimentHandler) Get(c *gin.Context) {
+	id, ok := experimentID(c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.b.AiExperiments().Get(c, id)
+	core.Response(c, resp, err)
+}
+
+// Update
+// @Summary    Update AI experiment, arms can only be changed before it starts
+// @Security   Bearer
+// @Tags       AI
+// @Accept     application/json
+// @Produce    json
+// @Param      id       path      int                           true  "Experiment ID"
+// @Param      request  body      v1.UpdateAiExperimentRequest  true  "Param"
+// @Success    200      {object}  v1.AiExperimentInfo
+// @Failure    400      {object}  core.ErrResponse
+// @Failure    404      {object}  core.ErrResponse
+// @Failure    500      {object}  core.ErrResponse
+// @Router     /v1/ai/experiments/{id} [PUT].
+func (h *ExperimentHandler) Update(c *gin.Context) {
+	id, ok := experimentID(c)
+	if !ok {
+		return
+	}
+
+	var req v1.UpdateAiExperimentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		core.Response(c, nil, errno.ErrInvalidArgument.WithMessage("%s", err.Error()))
+
+		return
+	}
+
+	resp, err := h.b.AiExperiments().Update(c, id, &req)
+	core.Response(c, resp, err)
+}
+
+// Delete
+// @Summary    Delete AI experiment
+// @Security   Bearer
+// @Tags       AI
+// @Accept     application/json
+// @Produce    json
+// @Param      id   path      int  true  "Experiment ID"
+// @Success    200  {object}  nil
+// @Failure    400  {object}  core.ErrResponse
+// @Failure    404  {object}  core.ErrResponse
+// @Failure    500  {object}  core.ErrResponse
+// @Router     /v1/ai/experiments/{id} [DELETE].
+func (h *ExperimentHandler) Delete(c *gin.Context) {
+	id, ok := experimentID(c)
+	if !ok {
+		return
+	}
+
+	err := h.b.AiExperiments().Delete(c, id)
+	core.Response(c, nil, err)
+}
+
+// Start
+// @Summary    Start AI experiment
+// @Security   Bearer
+// @Tags       AI
+// @Accept     application/json
+// @Produce    json
+// @Param      id   path      int  true  "Experiment ID"
+// @Success    200  {object}  v1.AiExperimentInfo
+// @Failure    400  {object}  core.ErrResponse
+// @Failure    404  {object}  core.ErrResponse
+// @Failure    500  {object}  core.ErrResponse
+// @Router     /v1/ai/experiments/{id}/start [POST].
+func (h *ExperimentHandler) Start(c *gin.Context) {
+	id, ok := experimentID(c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.b.AiExperiments().Start(c, id)
+	core.Response(c, resp, err)
+}
+
+// Stop
+// @Summary    Stop AI experiment
+// @Security   Bearer
+// @Tags       AI
+// @Accept     application/json
+// @Produce    json
+// @Param      id   path      int  true  "Experiment ID"
+// @Success    200  {object}  v1.AiExperimentInfo
+// @Failure    400  {object}  core.ErrResponse
+// @Failure    404  {object}  core.ErrResponse
+// @Failure    500  {object}  core.ErrResponse
+// @Router     /v1/ai/experiments/{id}/stop [POST].
+func (h *ExperimentHandler) Stop(c *gin.Context) {
+	id, ok := experimentID(c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.b.AiExperiments().Stop(c, id)
+	core.Response(c, resp, err)
+}
+
+// Report
+// @Summary    Compare the arms of an AI experiment
+// @Security   Bearer
+// @Tags       AI
+// @Accept     application/json
+// @Produce    json
+// @Param      id   path      int  true  "Experiment ID"
+// @Success    200  {object}  v1.AiExperimentReport
+// @Failure    400  {object}  core.ErrResponse
+// @Failure    404  {object}  core.ErrResponse
+// @Failure    500  {object}  core.ErrResponse
+// @Router     /v1/ai/experiments/{id}/report [GET].
+func (h *ExperimentHandler) Report(c *gin.Context) {
+	id, ok := experimentID(c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.b.AiExperiments().Report(c, id)
+	core.Response(c, resp, err)
+}
+
+// experimentID parses the experiment ID path param, responding with an error when invalid.
+func experimentID(c *gin.Context) (uint64, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		core.Response(c, nil, errno.ErrInvalidArgument.WithMessage("invalid experiment id"))
+
+		return 0, false
+	}
+
+	return id, true
+}
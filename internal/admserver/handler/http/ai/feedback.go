@@ -0,0 +1,104 @@
+// ABOUTME: HTTP handlers for AI reply feedback in admin panel.
+// ABOUTME: Provides rating listing, rated conversation drill-down and rating aggregate endpoints.
+package ai
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/bingo-project/bingo/internal/admserver/biz"
+	"github.com/bingo-project/bingo/internal/pkg/core"
+	"github.com/bingo-project/bingo/internal/pkg/errno"
+	"github.com/bingo-project/bingo/internal/pkg/store"
+	v1 "github.com/bingo-project/bingo/pkg/api/apiserver/v1"
+)
+
+type FeedbackHandler struct {
+	b biz.IBiz
+}
+
+func NewFeedbackHandler(ds store.IStore) *FeedbackHandler {
+	return &FeedbackHandler{b: biz.NewBiz(ds)}
+}
+
+// List
+// @Summary    List AI reply ratings
+// @Security   Bearer
+// @Tags       AI
+// @Accept     application/json
+// @Produce    json
+// @Param      rating    query     string  false  "Filter by rating" Enums(up, down)
+// @Param      model     query     string  false  "Filter by model"
+// @Param      agentId   query     string  false  "Filter by agent"
+// @Param      uid       query     string  false  "Filter by UID"
+// @Param      from      query     string  false  "First day (inclusive)" format(date)
+// @Param      to        query     string  false  "Last day (inclusive)" format(date)
+// @Param      page      query     int     false  "Page number" minimum(1)
+// @Param      pageSize  query     int     false  "Page size" minimum(1) maximum(100)
+// @Success    200       {object}  v1.ListAiFeedbackResponse
+// @Failure    400       {object}  core.ErrResponse
+// @Failure    500       {object}  core.ErrResponse
+// @Router     /v1/ai/feedback [GET].
+func (h *FeedbackHandler) List(c *gin.Context) {
+	var req v1.ListAiFeedbackRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		core.Response(c, nil, errno.ErrInvalidArgument.WithMessage("%s", err.Error()))
+
+		return
+	}
+
+	resp, err := h.b.AiFeedback().List(c, &req)
+	core.Response(c, resp, err)
+}
+
+// Get
+// @Summary    Get a rating with the conversation leading to the rated reply
+// @Security   Bearer
+// @Tags       AI
+// @Accept     application/json
+// @Produce    json
+// @Param      id   path      int  true  "Feedback ID"
+// @Success    200  {object}  v1.AiFeedbackDetail
+// @Failure    400  {object}  core.ErrResponse
+// @Failure    404  {object}  core.ErrResponse
+// @Failure    500  {object}  core.ErrResponse
+// @Router     /v1/ai/feedback/{id} [GET].
+func (h *FeedbackHandler) Get(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		core.Response(c, nil, errno.ErrInvalidArgument.WithMessage("invalid feedback id"))
+
+		return
+	}
+
+	resp, err := h.b.AiFeedback().Get(c, id)
+	core.Response(c, resp, err)
+}
+
+// Summary
+// @Summary    Aggregate AI reply ratings by model, agent or day
+// @Security   Bearer
+// @Tags       AI
+// @Accept     application/json
+// @Produce    json
+// @Param      groupBy  query     string  true   "Group key" Enums(model, agent, day)
+// @Param      model    query     string  false  "Filter by model"
+// @Param      agentId  query     string  false  "Filter by agent"
+// @Param      from     query     string  false  "First day (inclusive), defaults to the start of the month" format(date)
+// @Param      to       query     string  false  "Last day (inclusive), defaults to today" format(date)
+// @Success    200      {object}  v1.AiFeedbackSummaryResponse
+// @Failure    400      {object}  core.ErrResponse
+// @Failure    500      {object}  core.ErrResponse
+// @Router     /v1/ai/feedback/summary [GET].
+func (h *FeedbackHandler) Summary(c *gin.Context) {
+	var req v1.AiFeedbackSummaryRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		core.Response(c, nil, errno.ErrInvalidArgument.WithMessage("%s", err.Error()))
+
+		return
+	}
+
+	resp, err := h.b.AiFeedback().Summary(c, &req)
+	core.Response(c, resp, err)
+}
@@ -0,0 +1,114 @@
+// ABOUTME: HTTP handlers for AI guardrail events in admin panel.
+// ABOUTME: Provides endpoints to list flagged guardrail matches and review them.
+package ai
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/bingo-project/bingo/internal/admserver/biz"
+	"github.com/bingo-project/bingo/internal/pkg/core"
+	"github.com/bingo-project/bingo/internal/pkg/errno"
+	"github.com/bingo-project/bingo/internal/pkg/store"
+	v1 "github.com/bingo-project/bingo/pkg/api/apiserver/v1"
+)
+
+type GuardrailEventHandler struct {
+	b biz.IBiz
+}
+
+func NewGuardrailEventHandler(ds store.IStore) *GuardrailEventHandler {
+	return &GuardrailEventHandler{b: biz.NewBiz(ds)}
+}
+
+// List
+// @Summary    List AI guardrail events
+// @Security   Bearer
+// @Tags       AI
+// @Accept     application/json
+// @Produce    json
+// @Param      page      query     int     false  "Page number"
+// @Param      pageSize  query     int     false  "Page size"
+// @Param      agentId   query     string  false  "Agent ID"
+// @Param      stage     query     string  false  "Stage: input or output"
+// @Param      guard     query     string  false  "Guard: blocklist, pii, injection or moderation"
+// @Param      action    query     string  false  "Action: block, redact or flag"
+// @Param      status    query     string  false  "Status: pending, confirmed or dismissed"
+// @Success    200       {object}  v1.ListAiGuardrailEventResponse
+// @Failure    400       {object}  core.ErrResponse
+// @Failure    500       {object}  core.ErrResponse
+// @Router     /v1/ai/guardrail-events [GET].
+func (h *GuardrailEventHandler) List(c *gin.Context) {
+	var req v1.ListAiGuardrailEventRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		core.Response(c, nil, errno.ErrInvalidArgument.WithMessage("%s", err.Error()))
+
+		return
+	}
+
+	resp, err := h.b.AiGuardrailEvents().List(c, &req)
+	core.Response(c, resp, err)
+}
+
+// Get
+// @Summary    Get AI guardrail event
+// @Security   Bearer
+// @Tags       AI
+// @Accept     application/json
+// @Produce    json
+// @Param      id   path      int  true  "Event ID"
+// @Success    200  {object}  v1.AiGuardrailEventInfo
+// @Failure    400  {object}  core.ErrResponse
+// @Failure    404  {object}  core.ErrResponse
+// @Router     /v1/ai/guardrail-events/{id} [GET].
+func (h *GuardrailEventHandler) Get(c *gin.Context) {
+	id, ok := guardrailEventID(c)
+	if !ok {
+		return
+	}
+
+	resp, err := h.b.AiGuardrailEvents().Get(c, id)
+	core.Response(c, resp, err)
+}
+
+// Review
+// @Summary    Confirm or dismiss AI guardrail event
+// @Security   Bearer
+// @Tags       AI
+// @Accept     application/json
+// @Produce    json
+// @Param      id       path      int                               true  "Event ID"
+// @Param      request  body      v1.ReviewAiGuardrailEventRequest  true  "Param"
+// @Success    200      {object}  v1.AiGuardrailEventInfo
+// @Failure    400      {object}  core.ErrResponse
+// @Failure    404      {object}  core.ErrResponse
+// @Failure    500      {object}  core.ErrResponse
+// @Router     /v1/ai/guardrail-events/{id}/review [PUT].
+func (h *GuardrailEventHandler) Review(c *gin.Context) {
+	id, ok := guardrailEventID(c)
+	if !ok {
+		return
+	}
+
+	var req v1.ReviewAiGuardrailEventRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		core.Response(c, nil, errno.ErrInvalidArgument.WithMessage("%s", err.Error()))
+
+		return
+	}
+
+	resp, err := h.b.AiGuardrailEvents().Review(c, id, &req)
+	core.Response(c, resp, err)
+}
+
+func guardrailEventID(c *gin.Context) (uint64, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		core.Response(c, nil, errno.ErrInvalidArgument.WithMessage("invalid guardrail event id"))
+
+		return 0, false
+	}
+
+	return id, true
+}
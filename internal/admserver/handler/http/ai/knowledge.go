@@ -0,0 +1,87 @@
+// ABOUTME: HTTP handlers for AI agent knowledge base management in admin panel.
+// ABOUTME: Provides endpoints to add, list and delete agent knowledge documents.
+package ai
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/bingo-project/bingo/internal/admserver/biz"
+	"github.com/bingo-project/bingo/internal/pkg/core"
+	"github.com/bingo-project/bingo/internal/pkg/errno"
+	"github.com/bingo-project/bingo/internal/pkg/store"
+	v1 "github.com/bingo-project/bingo/pkg/api/apiserver/v1"
+)
+
+type KnowledgeHandler struct {
+	b biz.IBiz
+}
+
+func NewKnowledgeHandler(ds store.IStore) *KnowledgeHandler {
+	return &KnowledgeHandler{b: biz.NewBiz(ds)}
+}
+
+// Create
+// @Summary    Add a document to the AI agent knowledge base
+// @Security   Bearer
+// @Tags       AI
+// @Accept     application/json
+// @Produce    json
+// @Param      id       path      string                          true  "Agent ID"
+// @Param      request  body      v1.CreateAiKnowledgeDocRequest  true  "Param"
+// @Success    200      {object}  v1.AiKnowledgeDocInfo
+// @Failure    400      {object}  core.ErrResponse
+// @Failure    404      {object}  core.ErrResponse
+// @Failure    500      {object}  core.ErrResponse
+// @Router     /v1/ai/agents/{id}/knowledge [POST].
+func (h *KnowledgeHandler) Create(c *gin.Context) {
+	var req v1.CreateAiKnowledgeDocRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		core.Response(c, nil, errno.ErrInvalidArgument.WithMessage("%s", err.Error()))
+
+		return
+	}
+
+	doc, err := h.b.AiKnowledge().Create(c, c.Param("id"), &req)
+	core.Response(c, doc, err)
+}
+
+// List
+// @Summary    List AI agent knowledge base documents
+// @Security   Bearer
+// @Tags       AI
+// @Accept     application/json
+// @Produce    json
+// @Param      id  path      string  true  "Agent ID"
+// @Success    200  {object}  v1.ListAiKnowledgeDocResponse
+// @Failure    404  {object}  core.ErrResponse
+// @Router     /v1/ai/agents/{id}/knowledge [GET].
+func (h *KnowledgeHandler) List(c *gin.Context) {
+	docs, err := h.b.AiKnowledge().List(c, c.Param("id"))
+	core.Response(c, docs, err)
+}
+
+// Delete
+// @Summary    Delete an AI agent knowledge base document
+// @Security   Bearer
+// @Tags       AI
+// @Accept     application/json
+// @Produce    json
+// @Param      id     path  string  true  "Agent ID"
+// @Param      docId  path  int     true  "Document ID"
+// @Success    200  {object}  nil
+// @Failure    404  {object}  core.ErrResponse
+// @Failure    500  {object}  core.ErrResponse
+// @Router     /v1/ai/agents/{id}/knowledge/{docId} [DELETE].
+func (h *KnowledgeHandler) Delete(c *gin.Context) {
+	docID, err := strconv.ParseUint(c.Param("docId"), 10, 64)
+	if err != nil {
+		core.Response(c, nil, errno.ErrInvalidArgument.WithMessage("invalid document id"))
+
+		return
+	}
+
+	err = h.b.AiKnowledge().Delete(c, c.Param("id"), docID)
+	core.Response(c, nil, err)
+}
@@ -1,5 +1,5 @@
 // ABOUTME: HTTP handlers for AI Provider management in admin panel.
-// ABOUTME: Provides list/get/update endpoints for AI Provider resources.
+// ABOUTME: Provides CRUD and local model import endpoints for AI Provider resources.
 package ai
 
 import (
@@ -22,6 +22,29 @@ func NewProviderHandler(ds store.IStore) *ProviderHandler {
 	return &ProviderHandler{b: biz.NewBiz(ds)}
 }
 
+// Create
+// @Summary    Create AI provider
+// @Security   Bearer
+// @Tags       AI
+// @Accept     application/json
+// @Produce    json
+// @Param      request  body      v1.CreateAiProviderRequest   true  "Create request"
+// @Success    200      {object}  v1.AiProviderInfo
+// @Failure    400      {object}  core.ErrResponse
+// @Failure    500      {object}  core.ErrResponse
+// @Router     /v1/ai/providers [POST].
+func (h *ProviderHandler) Create(c *gin.Context) {
+	var req v1.CreateAiProviderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		core.Response(c, nil, errno.ErrInvalidArgument.WithMessage("%s", err.Error()))
+
+		return
+	}
+
+	provider, err := h.b.AiProviders().Create(c, &req)
+	core.Response(c, provider, err)
+}
+
 // List
 // @Summary    List AI providers
 // @Security   Bearer
@@ -101,3 +124,83 @@ func (h *ProviderHandler) Update(c *gin.Context) {
 	provider, err := h.b.AiProviders().Update(c, uint(id), &req)
 	core.Response(c, provider, err)
 }
+
+// Delete
+// @Summary    Delete AI provider
+// @Security   Bearer
+// @Tags       AI
+// @Accept     application/json
+// @Produce    json
+// @Param      id   path      int  true  "Provider ID"
+// @Success    200  {object}  nil
+// @Failure    400  {object}  core.ErrResponse
+// @Failure    404  {object}  core.ErrResponse
+// @Failure    500  {object}  core.ErrResponse
+// @Router     /v1/ai/providers/{id} [DELETE].
+func (h *ProviderHandler) Delete(c *gin.Context) {
+	id, ok := providerID(c)
+	if !ok {
+		return
+	}
+
+	err := h.b.AiProviders().Delete(c, id)
+	core.Response(c, nil, err)
+}
+
+// ListLocalModels
+// @Summary    List models served by a self-hosted AI provider
+// @Security   Bearer
+// @Tags       AI
+// @Accept     application/json
+// @Produce    json
+// @Param      id   path      int  true  "Provider ID"
+// @Success    200  {object}  v1.ListAiLocalModelResponse
+// @Failure    400  {object}  core.ErrResponse
+// @Failure    404  {object}  core.ErrResponse
+// @Failure    500  {object}  core.ErrResponse
+// @Router     /v1/ai/providers/{id}/local-models [GET].
+func (h *ProviderHandler) ListLocalModels(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		core.Response(c, nil, errno.ErrInvalidArgument.WithMessage("invalid provider id"))
+
+		return
+	}
+
+	models, err := h.b.AiProviders().ListLocalModels(c, uint(id))
+	core.Response(c, models, err)
+}
+
+// ImportLocalModels
+// @Summary    Import models served by a self-hosted AI provider
+// @Security   Bearer
+// @Tags       AI
+// @Accept     application/json
+// @Produce    json
+// @Param      id       path      int                            true  "Provider ID"
+// @Param      request  body      v1.ImportAiLocalModelRequest   true  "Import request"
+// @Success    200      {object}  v1.ImportAiLocalModelResponse
+// @Failure    400      {object}  core.ErrResponse
+// @Failure    404      {object}  core.ErrResponse
+// @Failure    500      {object}  core.ErrResponse
+// @Router     /v1/ai/providers/{id}/local-models/import [POST].
+func (h *ProviderHandler) ImportLocalModels(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := strconv.ParseUint(idStr, 10, 32)
+	if err != nil {
+		core.Response(c, nil, errno.ErrInvalidArgument.WithMessage("invalid provider id"))
+
+		return
+	}
+
+	var req v1.ImportAiLocalModelRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		core.Response(c, nil, errno.ErrInvalidArgument.WithMessage("%s", err.Error()))
+
+		return
+	}
+
+	resp, err := h.b.AiProviders().ImportLocalModels(c, uint(id), &req)
+	core.Response(c, resp, err)
+}
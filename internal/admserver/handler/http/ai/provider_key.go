@@ -0,0 +1,152 @@
+// ABOUTME: HTTP handlers for AI provider API keys in admin panel.
+// ABOUTME: Provides endpoints to list, add, update and remove the keys a provider is balanced across.
+package ai
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/bingo-project/bingo/internal/admserver/biz"
+	"github.com/bingo-project/bingo/internal/pkg/core"
+	"github.com/bingo-project/bingo/internal/pkg/errno"
+	"github.com/bingo-project/bingo/internal/pkg/store"
+	v1 "github.com/bingo-project/bingo/pkg/api/apiserver/v1"
+)
+
+type ProviderKeyHandler struct {
+	b biz.IBiz
+}
+
+func NewProviderKeyHandler(ds store.IStore) *ProviderKeyHandler {
+	return &ProviderKeyHandler{b: biz.NewBiz(ds)}
+}
+
+// List
+// @Summary    List AI provider API keys
+// @Security   Bearer
+// @Tags       AI
+// @Accept     application/json
+// @Produce    json
+// @Param      id   path      int  true  "Provider ID"
+// @Success    200  {object}  v1.ListAiProviderKeyResponse
+// @Failure    400  {object}  core.ErrResponse
+// @Failure    404  {object}  core.ErrResponse
+// @Router     /v1/ai/providers/{id}/keys [GET].
+func (h *ProviderKeyHandler) List(c *gin.Context) {
+	id, ok := providerID(c)
+	if !ok {
+		return
+	}
+
+	keys, err := h.b.AiProviderKeys().List(c, id)
+	core.Response(c, keys, err)
+}
+
+// Create
+// @Summary    Add an API key to an AI provider
+// @Security   Bearer
+// @Tags       AI
+// @Accept     application/json
+// @Produce    json
+// @Param      id       path      int                              true  "Provider ID"
+// @Param      request  body      v1.CreateAiProviderKeyRequest    true  "Create request"
+// @Success    200      {object}  v1.AiProviderKeyInfo
+// @Failure    400      {object}  core.ErrResponse
+// @Failure    404      {object}  core.ErrResponse
+// @Failure    409      {object}  core.ErrResponse
+// @Failure    500      {object}  core.ErrResponse
+// @Router     /v1/ai/providers/{id}/keys [POST].
+func (h *ProviderKeyHandler) Create(c *gin.Context) {
+	id, ok := providerID(c)
+	if !ok {
+		return
+	}
+
+	var req v1.CreateAiProviderKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		core.Response(c, nil, errno.ErrInvalidArgument.WithMessage("%s", err.Error()))
+
+		return
+	}
+
+	key, err := h.b.AiProviderKeys().Create(c, id, &req)
+	core.Response(c, key, err)
+}
+
+// Update
+// @Summary    Update an AI provider API key
+// @Security   Bearer
+// @Tags       AI
+// @Accept     application/json
+// @Produce    json
+// @Param      id       path      int                              true  "Provider ID"
+// @Param      keyId    path      int                              true  "Key ID"
+// @Param      request  body      v1.UpdateAiProviderKeyRequest    true  "Update request"
+// @Success    200      {object}  v1.AiProviderKeyInfo
+// @Failure    400      {object}  core.ErrResponse
+// @Failure    404      {object}  core.ErrResponse
+// @Failure    500      {object}  core.ErrResponse
+// @Router     /v1/ai/providers/{id}/keys/{keyId} [PUT].
+func (h *ProviderKeyHandler) Update(c *gin.Context) {
+	id, ok := providerID(c)
+	if !ok {
+		return
+	}
+	keyID, err := strconv.ParseUint(c.Param("keyId"), 10, 32)
+	if err != nil {
+		core.Response(c, nil, errno.ErrInvalidArgument.WithMessage("invalid key id"))
+
+		return
+	}
+
+	var req v1.UpdateAiProviderKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		core.Response(c, nil, errno.ErrInvalidArgument.WithMessage("%s", err.Error()))
+
+		return
+	}
+
+	key, err := h.b.AiProviderKeys().Update(c, id, uint(keyID), &req)
+	core.Response(c, key, err)
+}
+
+// Delete
+// @Summary    Remove an API key from an AI provider
+// @Security   Bearer
+// @Tags       AI
+// @Accept     application/json
+// @Produce    json
+// @Param      id     path  int  true  "Provider ID"
+// @Param      keyId  path  int  true  "Key ID"
+// @Success    200  {object}  nil
+// @Failure    400  {object}  core.ErrResponse
+// @Failure    404  {object}  core.ErrResponse
+// @Failure    500  {object}  core.ErrResponse
+// @Router     /v1/ai/providers/{id}/keys/{keyId} [DELETE].
+func (h *ProviderKeyHandler) Delete(c *gin.Context) {
+	id, ok := providerID(c)
+	if !ok {
+		return
+	}
+	keyID, err := strconv.ParseUint(c.Param("keyId"), 10, 32)
+	if err != nil {
+		core.Response(c, nil, errno.ErrInvalidArgument.WithMessage("invalid key id"))
+
+		return
+	}
+
+	err = h.b.AiProviderKeys().Delete(c, id, uint(keyID))
+	core.Response(c, nil, err)
+}
+
+func providerID(c *gin.Context) (uint, bool) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		core.Response(c, nil, errno.ErrInvalidArgument.WithMessage("invalid provider id"))
+
+		return 0, false
+	}
+
+	return uint(id), true
+}
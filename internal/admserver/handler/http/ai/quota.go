@@ -1,5 +1,5 @@
 // ABOUTME: HTTP handlers for AI User Quota management in admin panel.
-// ABOUTME: Provides list/get/update/reset endpoints for user quota resources.
+// ABOUTME: Provides list/get/update/reset endpoints for user quotas and list/update for quota tiers.
 package ai
 
 import (
@@ -124,3 +124,42 @@ func (h *QuotaHandler) ResetDailyTokens(c *gin.Context) {
 	err := h.b.AiQuotas().ResetDailyTokens(c, uid)
 	core.Response(c, nil, err)
 }
+
+// ListTiers
+// @Summary    List quota tiers
+// @Security   Bearer
+// @Tags       AI
+// @Accept     application/json
+// @Produce    json
+// @Success    200  {array}   v1.AiQuotaTierInfo
+// @Failure    500  {object}  core.ErrResponse
+// @Router     /v1/ai/quota-tiers [GET].
+func (h *QuotaHandler) ListTiers(c *gin.Context) {
+	tiers, err := h.b.AiQuotas().ListTiers(c)
+	core.Response(c, tiers, err)
+}
+
+// UpdateTier
+// @Summary    Update quota tier
+// @Security   Bearer
+// @Tags       AI
+// @Accept     application/json
+// @Produce    json
+// @Param      tier     path      string                       true  "Tier"
+// @Param      request  body      v1.UpdateAiQuotaTierRequest  true  "Update request"
+// @Success    200      {object}  v1.AiQuotaTierInfo
+// @Failure    400      {object}  core.ErrResponse
+// @Failure    404      {object}  core.ErrResponse
+// @Failure    500      {object}  core.ErrResponse
+// @Router     /v1/ai/quota-tiers/{tier} [PUT].
+func (h *QuotaHandler) UpdateTier(c *gin.Context) {
+	var req v1.UpdateAiQuotaTierRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		core.Response(c, nil, errno.ErrInvalidArgument.WithMessage("%s", err.Error()))
+
+		return
+	}
+
+	tier, err := h.b.AiQuotas().UpdateTier(c, c.Param("tier"), &req)
+	core.Response(c, tier, err)
+}
@@ -0,0 +1,47 @@
+// ABOUTME: HTTP handler for AI conversation search in admin panel.
+// ABOUTME: Searches session titles and messages across users for support investigations.
+package ai
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/bingo-project/bingo/internal/admserver/biz"
+	"github.com/bingo-project/bingo/internal/pkg/core"
+	"github.com/bingo-project/bingo/internal/pkg/errno"
+	"github.com/bingo-project/bingo/internal/pkg/store"
+	v1 "github.com/bingo-project/bingo/pkg/api/apiserver/v1"
+)
+
+type SearchHandler struct {
+	b biz.IBiz
+}
+
+func NewSearchHandler(ds store.IStore) *SearchHandler {
+	return &SearchHandler{b: biz.NewBiz(ds)}
+}
+
+// Search
+// @Summary    Search AI session titles and messages across users
+// @Security   Bearer
+// @Tags       AI
+// @Accept     application/json
+// @Produce    json
+// @Param      q         query     string  true   "Search words, all must match"
+// @Param      uid       query     string  false  "Filter by UID"
+// @Param      page      query     int     false  "Page number" minimum(1)
+// @Param      pageSize  query     int     false  "Page size" minimum(1) maximum(100)
+// @Success    200       {object}  v1.AiSearchResponse
+// @Failure    400       {object}  core.ErrResponse
+// @Failure    500       {object}  core.ErrResponse
+// @Router     /v1/ai/search [GET].
+func (h *SearchHandler) Search(c *gin.Context) {
+	var req v1.AdminAiSearchRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		core.Response(c, nil, errno.ErrInvalidArgument.WithMessage("%s", err.Error()))
+
+		return
+	}
+
+	resp, err := h.b.AiSearch().Search(c, &req)
+	core.Response(c, resp, err)
+}
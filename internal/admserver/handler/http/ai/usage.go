@@ -0,0 +1,78 @@
+// ABOUTME: HTTP handlers for the AI usage ledger in admin panel.
+// ABOUTME: Provides ledger listing and spend aggregate endpoints.
+package ai
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/bingo-project/bingo/internal/admserver/biz"
+	"github.com/bingo-project/bingo/internal/pkg/core"
+	"github.com/bingo-project/bingo/internal/pkg/errno"
+	"github.com/bingo-project/bingo/internal/pkg/store"
+	v1 "github.com/bingo-project/bingo/pkg/api/apiserver/v1"
+)
+
+type UsageHandler struct {
+	b biz.IBiz
+}
+
+func NewUsageHandler(ds store.IStore) *UsageHandler {
+	return &UsageHandler{b: biz.NewBiz(ds)}
+}
+
+// List
+// @Summary    List AI usage ledger entries
+// @Security   Bearer
+// @Tags       AI
+// @Accept     application/json
+// @Produce    json
+// @Param      uid           query     string  false  "Filter by UID"
+// @Param      model         query     string  false  "Filter by model"
+// @Param      providerName  query     string  false  "Filter by provider"
+// @Param      status        query     string  false  "Filter by status" Enums(success, error)
+// @Param      from          query     string  false  "First day (inclusive)" format(date)
+// @Param      to            query     string  false  "Last day (inclusive)" format(date)
+// @Param      page          query     int     false  "Page number" minimum(1)
+// @Param      pageSize      query     int     false  "Page size" minimum(1) maximum(100)
+// @Success    200           {object}  v1.ListAiUsageResponse
+// @Failure    400           {object}  core.ErrResponse
+// @Failure    500           {object}  core.ErrResponse
+// @Router     /v1/ai/usage [GET].
+func (h *UsageHandler) List(c *gin.Context) {
+	var req v1.ListAiUsageRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		core.Response(c, nil, errno.ErrInvalidArgument.WithMessage("%s", err.Error()))
+
+		return
+	}
+
+	resp, err := h.b.AiUsage().List(c, &req)
+	core.Response(c, resp, err)
+}
+
+// Summary
+// @Summary    Aggregate AI usage and cost by user, model or day
+// @Security   Bearer
+// @Tags       AI
+// @Accept     application/json
+// @Produce    json
+// @Param      groupBy  query     string  true   "Group key" Enums(user, model, day)
+// @Param      uid      query     string  false  "Filter by UID"
+// @Param      model    query     string  false  "Filter by model"
+// @Param      from     query     string  false  "First day (inclusive), defaults to the start of the month" format(date)
+// @Param      to       query     string  false  "Last day (inclusive), defaults to today" format(date)
+// @Success    200      {object}  v1.AiUsageSummaryResponse
+// @Failure    400      {object}  core.ErrResponse
+// @Failure    500      {object}  core.ErrResponse
+// @Router     /v1/ai/usage/summary [GET].
+func (h *UsageHandler) Summary(c *gin.Context) {
+	var req v1.AiUsageSummaryRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		core.Response(c, nil, errno.ErrInvalidArgument.WithMessage("%s", err.Error()))
+
+		return
+	}
+
+	resp, err := h.b.AiUsage().Summary(c, &req)
+	core.Response(c, resp, err)
+}
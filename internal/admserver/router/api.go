@@ -97,11 +97,53 @@ func MapApiRouters(g *gin.Engine) {
 	v1.PUT("ai/agents/:id", aiAgentHandler.Update)
 	v1.DELETE("ai/agents/:id", aiAgentHandler.Delete)
 
+	// AI Agent Version
+	aiAgentVersionHandler := ai.NewAgentVersionHandler(store.S)
+	v1.GET("ai/agents/:id/versions", aiAgentVersionHandler.List)
+	v1.POST("ai/agents/:id/versions", aiAgentVersionHandler.Create)
+	v1.GET("ai/agents/:id/versions/diff", aiAgentVersionHandler.Diff)
+	v1.GET("ai/agents/:id/versions/:version", aiAgentVersionHandler.Get)
+	v1.POST("ai/agents/:id/versions/:version/publish", aiAgentVersionHandler.Publish) // 发布旧版本即回滚
+
+	// AI Experiment
+	aiExperimentHandler := ai.NewExperimentHandler(store.S)
+	v1.GET("ai/experiments", aiExperimentHandler.List)
+	v1.POST("ai/experiments", aiExperimentHandler.Create)
+	v1.GET("ai/experiments/:id", aiExperimentHandler.Get)
+	v1.PUT("ai/experiments/:id", aiExperimentHandler.Update)
+	v1.DELETE("ai/experiments/:id", aiExperimentHandler.Delete)
+	v1.POST("ai/experiments/:id/start", aiExperimentHandler.Start)
+	v1.POST("ai/experiments/:id/stop", aiExperimentHandler.Stop)
+	v1.GET("ai/experiments/:id/report", aiExperimentHandler.Report) // 各组用量、延迟、错误率与满意度对比
+
+	// AI Guardrail Event
+	aiGuardrailEventHandler := ai.NewGuardrailEventHandler(store.S)
+	v1.GET("ai/guardrail-events", aiGuardrailEventHandler.List)
+	v1.GET("ai/guardrail-events/:id", aiGuardrailEventHandler.Get)
+	v1.PUT("ai/guardrail-events/:id/review", aiGuardrailEventHandler.Review) // 确认或驳回内容安全事件
+
+	// AI Knowledge
+	aiKnowledgeHandler := ai.NewKnowledgeHandler(store.S)
+	v1.GET("ai/agents/:id/knowledge", aiKnowledgeHandler.List)
+	v1.POST("ai/agents/:id/knowledge", aiKnowledgeHandler.Create)
+	v1.DELETE("ai/agents/:id/knowledge/:docId", aiKnowledgeHandler.Delete)
+
 	// AI Provider
 	aiProviderHandler := ai.NewProviderHandler(store.S)
 	v1.GET("ai/providers", aiProviderHandler.List)
+	v1.POST("ai/providers", aiProviderHandler.Create)
 	v1.GET("ai/providers/:id", aiProviderHandler.Get)
 	v1.PUT("ai/providers/:id", aiProviderHandler.Update)
+	v1.DELETE("ai/providers/:id", aiProviderHandler.Delete) // 仍有模型的 Provider 不能删除
+	v1.GET("ai/providers/:id/local-models", aiProviderHandler.ListLocalModels)
+	v1.POST("ai/providers/:id/local-models/import", aiProviderHandler.ImportLocalModels)
+
+	// AI Provider Key
+	aiProviderKeyHandler := ai.NewProviderKeyHandler(store.S)
+	v1.GET("ai/providers/:id/keys", aiProviderKeyHandler.List)
+	v1.POST("ai/providers/:id/keys", aiProviderKeyHandler.Create) // 新增 Key 后各服务自动重载
+	v1.PUT("ai/providers/:id/keys/:keyId", aiProviderKeyHandler.Update)
+	v1.DELETE("ai/providers/:id/keys/:keyId", aiProviderKeyHandler.Delete)
 
 	// AI Model
 	aiModelHandler := ai.NewModelHandler(store.S)
@@ -117,6 +159,23 @@ func MapApiRouters(g *gin.Engine) {
 	v1.GET("ai/quotas/:uid", aiQuotaHandler.Get)
 	v1.PUT("ai/quotas/:uid", aiQuotaHandler.Update)
 	v1.POST("ai/quotas/:uid/reset-daily", aiQuotaHandler.ResetDailyTokens)
+	v1.GET("ai/quota-tiers", aiQuotaHandler.ListTiers)
+	v1.PUT("ai/quota-tiers/:tier", aiQuotaHandler.UpdateTier)
+
+	// AI Usage
+	aiUsageHandler := ai.NewUsageHandler(store.S)
+	v1.GET("ai/usage", aiUsageHandler.List)
+	v1.GET("ai/usage/summary", aiUsageHandler.Summary)
+
+	// AI conversation search
+	aiSearchHandler := ai.NewSearchHandler(store.S)
+	v1.GET("ai/search", aiSearchHandler.Search)
+
+	// AI reply feedback
+	aiFeedbackHandler := ai.NewFeedbackHandler(store.S)
+	v1.GET("ai/feedback", aiFeedbackHandler.List)
+	v1.GET("ai/feedback/summary", aiFeedbackHandler.Summary)
+	v1.GET("ai/feedback/:id", aiFeedbackHandler.Get)
 
 	// AI Health
 	registry := aipkg.GetRegistry()
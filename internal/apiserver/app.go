@@ -63,12 +63,5 @@ func initConfig() {
 	_ = store.NewStore(bootstrap.InitDB())
 
 	// Init AI (optional, logs error if fails)
-	creds := make(map[string]ai.Credential)
-	for name, cred := range facade.Config.AI.Credentials {
-		creds[name] = ai.Credential{
-			APIKey:  cred.APIKey,
-			BaseURL: cred.BaseURL,
-		}
-	}
-	_, _ = ai.InitAI(facade.Redis, store.S, creds)
+	_, _ = ai.InitAI(facade.Redis, store.S, ai.CredentialsFromConfig(facade.Config.AI.Credentials))
 }
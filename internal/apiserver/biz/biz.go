@@ -36,6 +36,10 @@ type IBiz interface {
 	NotificationPreferences() notification.PreferenceBiz
 
 	Chat() chat.ChatBiz
+	Generations() chat.GenerationBiz
+	Shares() chat.ShareBiz
+	Feedback() chat.FeedbackBiz
+	Search() chat.SearchBiz
 	AiAgents() chat.AiAgentBiz
 }
 
@@ -109,6 +113,22 @@ func (b *biz) Chat() chat.ChatBiz {
 	return chat.New(b.ds, b.registry)
 }
 
+func (b *biz) Generations() chat.GenerationBiz {
+	return chat.NewGeneration()
+}
+
+func (b *biz) Shares() chat.ShareBiz {
+	return chat.NewShare(b.ds)
+}
+
+func (b *biz) Feedback() chat.FeedbackBiz {
+	return chat.NewFeedback(b.ds)
+}
+
+func (b *biz) Search() chat.SearchBiz {
+	return chat.NewSearch(b.ds)
+}
+
 func (b *biz) AiAgents() chat.AiAgentBiz {
 	return chat.NewAiAgent(b.ds)
 }
@@ -34,17 +34,19 @@ func NewAiAgent(ds store.IStore) *aiAgentBiz {
 // toAgentInfo converts model.AiAgentM to v1.AiAgentInfo.
 func toAgentInfo(m *model.AiAgentM) *v1.AiAgentInfo {
 	return &v1.AiAgentInfo{
-		AgentID:      m.AgentID,
-		Name:         m.Name,
-		Description:  m.Description,
-		Icon:         m.Icon,
-		Category:     string(m.Category),
-		SystemPrompt: m.SystemPrompt,
-		Model:        m.Model,
-		Temperature:  m.Temperature,
-		MaxTokens:    m.MaxTokens,
-		Sort:         m.Sort,
-		Status:       string(m.Status),
+		AgentID:        m.AgentID,
+		Name:           m.Name,
+		Description:    m.Description,
+		Icon:           m.Icon,
+		Category:       string(m.Category),
+		SystemPrompt:   m.SystemPrompt,
+		Model:          m.Model,
+		Temperature:    m.Temperature,
+		MaxTokens:      m.MaxTokens,
+		Tools:          m.Tools,
+		EmbeddingModel: m.EmbeddingModel,
+		Sort:           m.Sort,
+		Status:         string(m.Status),
 	}
 }
 
@@ -0,0 +1,174 @@
+// ABOUTME: Conversation branching for chat sessions.
+// ABOUTME: Resolves the branch a turn continues, forks edits and regenerations, and walks branch history.
+
+package chat
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"gorm.io/gorm"
+
+	"github.com/bingo-project/bingo/internal/pkg/errno"
+	"github.com/bingo-project/bingo/internal/pkg/model"
+	"github.com/bingo-project/bingo/internal/pkg/store"
+	aipkg "github.com/bingo-project/bingo/pkg/ai"
+	"github.com/bingo-project/bingo/pkg/store/where"
+)
+
+func (b *chatBiz) Edit(ctx context.Context, uid string, messageID uint64, req *aipkg.ChatRequest) error {
+	if len(req.Messages) != 1 || req.Messages[0].Role != aipkg.RoleUser {
+		return errno.ErrInvalidArgument.WithMessage("an edit replaces one user message")
+	}
+
+	msg, err := b.branchPoint(ctx, uid, req.SessionID, messageID)
+	if err != nil {
+		return err
+	}
+	if msg.Role != model.AiMessageRoleUser {
+		return errno.ErrInvalidArgument.WithMessage("only user messages can be edited")
+	}
+
+	// The edited message becomes a sibling of the original
+	req.ParentID = &msg.ParentID
+
+	return nil
+}
+
+func (b *chatBiz) Regenerate(ctx context.Context, uid string, messageID uint64, req *aipkg.ChatRequest) error {
+	if len(req.Messages) > 0 {
+		return errno.ErrInvalidArgument.WithMessage("regenerating a reply takes no messages")
+	}
+
+	msg, err := b.branchPoint(ctx, uid, req.SessionID, messageID)
+	if err != nil {
+		return err
+	}
+	if msg.Role != model.AiMessageRoleAssistant {
+		return errno.ErrInvalidArgument.WithMessage("only assistant replies can be regenerated")
+	}
+
+	// Answer the user message again, a reply may span several tool rounds
+	branch, err := b.ds.AiMessage().ListBranch(ctx, req.SessionID, msg.ID, 0)
+	if err != nil {
+		return errno.ErrDBRead.WithMessage("list branch: %v", err)
+	}
+	i := slices.IndexFunc(branch, func(m *model.AiMessageM) bool { return m.Role == model.AiMessageRoleUser })
+	if i < 0 {
+		return errno.ErrInvalidArgument.WithMessage("reply does not answer a user message")
+	}
+	req.ParentID = &branch[i].ID
+
+	return nil
+}
+
+// branchPoint validates the session and returns the stored message a new branch forks at.
+func (b *chatBiz) branchPoint(ctx context.Context, uid string, sessionID string, messageID uint64) (*model.AiMessageM, error) {
+	if err := b.validateSession(ctx, sessionID, uid); err != nil {
+		return nil, err
+	}
+
+	session, err := b.ds.AiSession().GetBySessionID(ctx, sessionID)
+	if err != nil {
+		return nil, errno.ErrDBRead.WithMessage("get session: %v", err)
+	}
+	if _, err := activeLeaf(ctx, b.ds, session); err != nil {
+		return nil, err
+	}
+
+	return getSessionMessage(ctx, b.ds, sessionID, messageID)
+}
+
+// resolveParent returns the stored message the turn continues: the branch point of an edit
+// or regeneration, otherwise the last message of the session's active branch.
+func (b *chatBiz) resolveParent(ctx context.Context, req *aipkg.ChatRequest) (uint64, error) {
+	if req.SessionID == "" {
+		return 0, nil
+	}
+	if req.ParentID != nil {
+		return *req.ParentID, nil
+	}
+
+	session, err := b.ds.AiSession().GetBySessionID(ctx, req.SessionID)
+	if err != nil {
+		return 0, errno.ErrDBRead.WithMessage("get session: %v", err)
+	}
+
+	return activeLeaf(ctx, b.ds, session)
+}
+
+// activeLeaf returns the last message of the session's active branch.
+// Sessions saved before branching existed are linked into a single branch on first use.
+func activeLeaf(ctx context.Context, ds store.IStore, session *model.AiSessionM) (uint64, error) {
+	if session.ActiveLeafID > 0 {
+		return session.ActiveLeafID, nil
+	}
+
+	leafID, err := ds.AiMessage().LinkSession(ctx, session.SessionID)
+	if err != nil {
+		return 0, errno.ErrDBWrite.WithMessage("link session messages: %v", err)
+	}
+	if leafID > 0 {
+		if err := ds.AiSession().UpdateActiveLeaf(ctx, session.SessionID, leafID); err != nil {
+			return 0, errno.ErrDBWrite.WithMessage("update active branch: %v", err)
+		}
+		session.ActiveLeafID = leafID
+	}
+
+	return leafID, nil
+}
+
+// getSessionMessage returns a conversation message of the session.
+func getSessionMessage(ctx context.Context, ds store.IStore, sessionID string, messageID uint64) (*model.AiMessageM, error) {
+	msg, err := ds.AiMessage().Get(ctx, where.F("id", messageID, "session_id", sessionID, "kind", model.AiMessageKindMessage))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errno.ErrAIMessageNotFound
+		}
+
+		return nil, errno.ErrDBRead.WithMessage("get message: %v", err)
+	}
+
+	return msg, nil
+}
+
+// branchHistory returns the messages of a branch, listed newest first, that follow the
+// summary cursor, oldest first, and whether the summary covers the rest of the branch.
+// A branch forked off before the cursor does not continue the summarized conversation,
+// so all of it is kept and the summary is not used. A branch cut off by the load limit
+// before reaching the cursor is assumed to continue it.
+func branchHistory(branch []*model.AiMessageM, cursor uint64) ([]*model.AiMessageM, bool) {
+	n := len(branch)
+	summarized := cursor > 0 && n > 0 && branch[n-1].ParentID > 0
+	for i, m := range branch {
+		if m.ID == cursor {
+			n, summarized = i, true
+
+			break
+		}
+		if m.ID < cursor {
+			summarized = false
+
+			break
+		}
+	}
+
+	history := slices.Clone(branch[:n])
+	slices.Reverse(history)
+
+	return history, summarized
+}
+
+// saveChain saves messages as a chain continuing parentID and returns the last saved message ID.
+func (b *chatBiz) saveChain(ctx context.Context, parentID uint64, messages []*model.AiMessageM) (uint64, error) {
+	for _, m := range messages {
+		m.ParentID = parentID
+		if err := b.ds.AiMessage().Create(ctx, m); err != nil {
+			return parentID, err
+		}
+		parentID = m.ID
+	}
+
+	return parentID, nil
+}
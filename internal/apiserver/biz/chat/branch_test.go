@@ -0,0 +1,59 @@
+// ABOUTME: Tests for conversation branch history.
+// ABOUTME: Checks how a branch is cut at the summary cursor and when the summary applies.
+
+package chat
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bingo-project/bingo/internal/pkg/model"
+)
+
+// branchOf builds a branch listed newest first from message IDs listed oldest first,
+// the oldest message continues parent.
+func branchOf(parent uint64, ids ...uint64) []*model.AiMessageM {
+	branch := make([]*model.AiMessageM, len(ids))
+	for i, id := range ids {
+		branch[len(ids)-1-i] = &model.AiMessageM{ID: id, ParentID: parent}
+		parent = id
+	}
+
+	return branch
+}
+
+func idsOf(messages []*model.AiMessageM) []uint64 {
+	ids := make([]uint64, len(messages))
+	for i, m := range messages {
+		ids[i] = m.ID
+	}
+
+	return ids
+}
+
+func TestBranchHistory(t *testing.T) {
+	tests := []struct {
+		name       string
+		branch     []*model.AiMessageM
+		cursor     uint64
+		want       []uint64
+		summarized bool
+	}{
+		{name: "no summary", branch: branchOf(0, 1, 2, 3), want: []uint64{1, 2, 3}},
+		{name: "empty branch", branch: nil, cursor: 2, want: []uint64{}},
+		{name: "cut at cursor", branch: branchOf(0, 1, 2, 3, 4), cursor: 2, want: []uint64{3, 4}, summarized: true},
+		{name: "cursor is the leaf", branch: branchOf(0, 1, 2), cursor: 2, want: []uint64{}, summarized: true},
+		{name: "cursor beyond the loaded window", branch: branchOf(4, 5, 6), cursor: 2, want: []uint64{5, 6}, summarized: true},
+		{name: "forked before cursor", branch: branchOf(0, 1, 7, 8), cursor: 4, want: []uint64{1, 7, 8}},
+		{name: "forked at root", branch: branchOf(0, 9), cursor: 4, want: []uint64{9}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			history, summarized := branchHistory(tt.branch, tt.cursor)
+			assert.Equal(t, tt.want, idsOf(history))
+			assert.Equal(t, tt.summarized, summarized)
+		})
+	}
+}
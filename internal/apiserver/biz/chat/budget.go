@@ -0,0 +1,143 @@
+// ABOUTME: Token-based context budgeting for chat requests.
+// ABOUTME: Trims history to the model context by estimated tokens and rejects prompts that cannot fit.
+
+package chat
+
+import (
+	"github.com/bingo-project/bingo/internal/pkg/errno"
+	"github.com/bingo-project/bingo/internal/pkg/facade"
+	aipkg "github.com/bingo-project/bingo/pkg/ai"
+)
+
+// contextSafetyRatio is the share of a model context left unused, token counts are
+// estimates and can fall short of the provider's tokenizer.
+const contextSafetyRatio = 0.1
+
+// completionBudget returns the tokens reserved for a completion requesting maxTokens.
+func completionBudget(maxTokens int) int {
+	if maxTokens > 0 {
+		return maxTokens
+	}
+	if facade.Config.AI.Session.MaxTokens > 0 {
+		return facade.Config.AI.Session.MaxTokens
+	}
+
+	return defaultEstimatedTokens
+}
+
+// fitContext drops the oldest history messages until the estimated prompt fits the model
+// context minus the completion budget and a safety margin, and returns the trimmed messages
+// with their token estimate.
+// Leading system messages and the latest turn are always kept; if they alone do not fit
+// the request is rejected.
+func (b *chatBiz) fitContext(req *aipkg.ChatRequest, messages []aipkg.Message) ([]aipkg.Message, int, error) {
+	tok := aipkg.TokenizerFor(req.Model)
+
+	sysEnd := leadingSystemCount(messages)
+	turnStart := latestTurnStart(messages, sysEnd)
+	system, history, turn := messages[:sysEnd], messages[sysEnd:turnStart], messages[turnStart:]
+
+	// Optional message count window on top of the token budget
+	if window := facade.Config.AI.Session.ContextWindow; window > 0 {
+		keep := max(window-len(system)-len(turn), 0)
+		if len(history) > keep {
+			history = history[len(history)-keep:]
+		}
+	}
+
+	fixed := tok.CountMessages(nil) + tok.CountTools(req.Tools)
+	for _, m := range system {
+		fixed += tok.CountMessage(m)
+	}
+	for _, m := range turn {
+		fixed += tok.CountMessage(m)
+	}
+
+	historyTokens := make([]int, len(history))
+	total := fixed
+	for i, m := range history {
+		historyTokens[i] = tok.CountMessage(m)
+		total += historyTokens[i]
+	}
+
+	if contextLimit := b.contextLimit(req.Model); contextLimit > 0 {
+		completion := completionBudget(req.MaxTokens)
+		limit := contextLimit - completion - int(float64(contextLimit)*contextSafetyRatio)
+		if fixed > limit {
+			return nil, 0, errno.ErrAIContextTooLong.WithMessage(
+				"prompt needs about %d tokens, model %s fits about %d after reserving %d for the completion",
+				fixed, req.Model, max(limit, 0), completion)
+		}
+
+		for total > limit && len(history) > 0 {
+			total -= historyTokens[0]
+			history, historyTokens = history[1:], historyTokens[1:]
+		}
+	}
+
+	// Drop tool results whose assistant tool call fell out of the window
+	for len(history) > 0 && history[0].Role == aipkg.RoleTool {
+		total -= historyTokens[0]
+		history, historyTokens = history[1:], historyTokens[1:]
+	}
+
+	result := make([]aipkg.Message, 0, len(system)+len(history)+len(turn))
+	result = append(result, system...)
+	result = append(result, history...)
+	result = append(result, turn...)
+
+	return result, total, nil
+}
+
+// fitFallback switches req to a fallback model and fits its prompt again, the fallback
+// can have a smaller context than the model the prompt was fitted to.
+func (b *chatBiz) fitFallback(req *aipkg.ChatRequest, fallbackModel string) error {
+	if fallbackModel == req.Model {
+		return nil
+	}
+
+	r := *req
+	r.Model = fallbackModel
+	messages, _, err := b.fitContext(&r, req.Messages)
+	if err != nil {
+		return err
+	}
+	req.Model, req.Messages = fallbackModel, messages
+
+	return nil
+}
+
+// contextLimit returns the context length of a model, or 0 if unknown.
+func (b *chatBiz) contextLimit(modelName string) int {
+	if b.registry == nil {
+		return 0
+	}
+	m, ok := b.registry.GetModel(modelName)
+	if !ok {
+		return 0
+	}
+
+	return m.MaxTokens
+}
+
+// leadingSystemCount returns the number of system messages at the start of messages.
+func leadingSystemCount(messages []aipkg.Message) int {
+	n := 0
+	for n < len(messages) && messages[n].Role == aipkg.RoleSystem {
+		n++
+	}
+
+	return n
+}
+
+// latestTurnStart returns the index of the last user message at or after from,
+// or from if there is none.
+func latestTurnStart(messages []aipkg.Message, from int) int {
+	for i := len(messages) - 1; i >= from; i-- {
+		if messages[i].Role == aipkg.RoleUser {
+			return i
+		}
+	}
+
+	return from
+}
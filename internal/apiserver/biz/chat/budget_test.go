@@ -0,0 +1,69 @@
+// ABOUTME: Tests for token-based context budgeting.
+// ABOUTME: Verifies the safety margin and refitting prompts to a fallback model's context.
+
+package chat
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bingo-project/bingo/internal/pkg/errno"
+	aipkg "github.com/bingo-project/bingo/pkg/ai"
+	"github.com/bingo-project/bingo/pkg/ai/providers/fake"
+)
+
+func testBudgetBiz() *chatBiz {
+	cfg := fake.DefaultConfig()
+	cfg.Models = []aipkg.ModelInfo{
+		{ID: "fake-large", MaxTokens: 100000},
+		{ID: "fake-small", MaxTokens: 1000},
+	}
+	registry := aipkg.NewRegistry()
+	registry.Register(fake.New(cfg))
+
+	return &chatBiz{registry: registry}
+}
+
+func TestFitContext_SafetyMargin(t *testing.T) {
+	b := testBudgetBiz()
+	tok := aipkg.TokenizerFor("fake-small")
+
+	// A turn estimated to fill the whole context minus the completion is rejected
+	text := strings.Repeat("word ", 2000)
+	for tok.CountMessages([]aipkg.Message{{Role: aipkg.RoleUser, Content: text}}) > 1000-100 {
+		text = text[5:]
+	}
+	require.Greater(t, tok.CountMessages([]aipkg.Message{{Role: aipkg.RoleUser, Content: text}}), 1000-100-100)
+	req := &aipkg.ChatRequest{Model: "fake-small", MaxTokens: 100}
+	_, _, err := b.fitContext(req, []aipkg.Message{{Role: aipkg.RoleUser, Content: text}})
+	assert.ErrorIs(t, err, errno.ErrAIContextTooLong)
+}
+
+func TestFitFallback(t *testing.T) {
+	b := testBudgetBiz()
+	history := []aipkg.Message{{Role: aipkg.RoleSystem, Content: "be brief"}}
+	for range 20 {
+		history = append(history,
+			aipkg.Message{Role: aipkg.RoleUser, Content: strings.Repeat("question ", 20)},
+			aipkg.Message{Role: aipkg.RoleAssistant, Content: strings.Repeat("answer ", 20)},
+		)
+	}
+	history = append(history, aipkg.Message{Role: aipkg.RoleUser, Content: "and now?"})
+
+	req := &aipkg.ChatRequest{Model: "fake-large", MaxTokens: 100}
+	messages, _, err := b.fitContext(req, history)
+	require.NoError(t, err)
+	require.Len(t, messages, len(history))
+	req.Messages = messages
+
+	// The smaller fallback context trims the history again
+	require.NoError(t, b.fitFallback(req, "fake-small"))
+	assert.Equal(t, "fake-small", req.Model)
+	assert.Less(t, len(req.Messages), len(history))
+	assert.Equal(t, "be brief", req.Messages[0].Content)
+	assert.Equal(t, "and now?", req.Messages[len(req.Messages)-1].Content)
+	assert.LessOrEqual(t, aipkg.TokenizerFor("fake-small").CountMessages(req.Messages), 1000-100)
+}
@@ -0,0 +1,380 @@
+// ABOUTME: Opt-in response cache for agent chats.
+// ABOUTME: Answers repeated questions from an exact Redis cache and a semantic embedding cache.
+
+package chat
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/bingo-project/bingo/internal/pkg/facade"
+	"github.com/bingo-project/bingo/internal/pkg/log"
+	"github.com/bingo-project/bingo/internal/pkg/model"
+	aipkg "github.com/bingo-project/bingo/pkg/ai"
+	"github.com/bingo-project/bingo/pkg/contextx"
+)
+
+// Response cache layers.
+const (
+	cacheLayerExact    = "exact"
+	cacheLayerSemantic = "semantic"
+)
+
+const (
+	// maxSemanticCacheEntries bounds the answers kept per semantic bucket.
+	maxSemanticCacheEntries = 256
+
+	// cacheTimeout bounds a cache lookup or store, including the question embedding.
+	cacheTimeout = 5 * time.Second
+)
+
+// cacheEntry is a cached answer.
+type cacheEntry struct {
+	Content   string    `json:"content"`
+	Model     string    `json:"model"`
+	Embedding []float32 `json:"embedding,omitempty"` // Question embedding, semantic entries only
+	ExpiresAt int64     `json:"expiresAt,omitempty"` // Unix seconds, semantic entries only
+}
+
+// cacheKeyInput is the part of a request that determines its answer.
+type cacheKeyInput struct {
+	Model          string                `json:"model"`
+	AgentVersion   int                   `json:"agentVersion"`
+	Temperature    float64               `json:"temperature"`
+	MaxTokens      int                   `json:"maxTokens"`
+	ResponseFormat *aipkg.ResponseFormat `json:"responseFormat,omitempty"`
+	Messages       []cacheKeyMessage     `json:"messages"`
+}
+
+type cacheKeyMessage struct {
+	Role    string              `json:"role"`
+	Content string              `json:"content"`
+	Parts   []aipkg.ContentPart `json:"parts,omitempty"`
+}
+
+// responseCache looks up and stores the answer of one request.
+type responseCache struct {
+	agentID        string
+	embeddingModel string
+	ttl            time.Duration
+	semanticTTL    time.Duration
+	threshold      float64
+	req            *aipkg.ChatRequest // Request the question embedding is charged to
+
+	key       string    // Exact cache key, empty when the exact layer is off
+	bucket    string    // Semantic bucket key, empty when the semantic layer is off or the request is not eligible
+	question  string    // Normalized question of the semantic layer
+	embedding []float32 // Question embedding, set by the semantic lookup
+}
+
+// newResponseCache returns the cache of a request to an agent, nil when the agent does not
+// cache answers or the request must reach the model: requests offering tools, carrying tool
+// results, or continuing a chosen message (edits and regenerations).
+//
+// The exact layer matches the whole context. The semantic layer only serves single questions,
+// matching the system messages exactly and the question by embedding similarity.
+func newResponseCache(agent *model.AiAgentM, req *aipkg.ChatRequest) *responseCache {
+	if facade.Redis == nil || agent == nil || (agent.CacheTTL <= 0 && agent.SemanticCacheTTL <= 0) {
+		return nil
+	}
+	if len(req.Tools) > 0 || req.ParentID != nil {
+		return nil
+	}
+	for _, m := range req.Messages {
+		if m.Role == aipkg.RoleTool || len(m.ToolCalls) > 0 {
+			return nil
+		}
+	}
+
+	c := &responseCache{
+		agentID:        agent.AgentID,
+		embeddingModel: agent.EmbeddingModel,
+		ttl:            time.Duration(agent.CacheTTL) * time.Second,
+		semanticTTL:    time.Duration(agent.SemanticCacheTTL) * time.Second,
+		threshold:      agent.SemanticCacheThreshold,
+		req:            req,
+	}
+	if c.threshold <= 0 {
+		c.threshold = model.DefaultSemanticCacheThreshold
+	}
+	if c.ttl > 0 {
+		c.key = responseCacheKey(cacheLayerExact, agent.AgentID, req, req.Messages)
+	}
+	if c.semanticTTL > 0 && c.embeddingModel != "" {
+		if system, question, ok := semanticQuestion(req.Messages); ok {
+			c.bucket = responseCacheKey(cacheLayerSemantic, agent.AgentID, req, system)
+			c.question = question
+		}
+	}
+	if c.key == "" && c.bucket == "" {
+		return nil
+	}
+
+	return c
+}
+
+// responseCacheKey builds the Redis key of a layer from the request settings and messages.
+func responseCacheKey(layer, agentID string, req *aipkg.ChatRequest, messages []aipkg.Message) string {
+	in := cacheKeyInput{
+		Model:          req.Model,
+		AgentVersion:   req.AgentVersion,
+		Temperature:    req.Temperature,
+		MaxTokens:      req.MaxTokens,
+		ResponseFormat: req.ResponseFormat,
+		Messages:       make([]cacheKeyMessage, len(messages)),
+	}
+	for i, m := range messages {
+		in.Messages[i] = cacheKeyMessage{Role: m.Role, Content: normalizeCacheText(m.Content), Parts: m.Parts}
+	}
+	data, _ := json.Marshal(in)
+
+	return fmt.Sprintf("%s:ai:cache:%s:%s:%x", facade.Config.App.Name, layer, agentID, sha256.Sum256(data))
+}
+
+// semanticQuestion splits messages into the leading system messages and a single text question.
+// Returns false when the messages hold a conversation or a multimodal question.
+func semanticQuestion(messages []aipkg.Message) ([]aipkg.Message, string, bool) {
+	if len(messages) == 0 {
+		return nil, "", false
+	}
+
+	last := messages[len(messages)-1]
+	question := normalizeCacheText(last.Content)
+	if last.Role != aipkg.RoleUser || len(last.Parts) > 0 || question == "" {
+		return nil, "", false
+	}
+
+	system := messages[:len(messages)-1]
+	for _, m := range system {
+		if m.Role != aipkg.RoleSystem {
+			return nil, "", false
+		}
+	}
+
+	return system, question, true
+}
+
+// normalizeCacheText trims s and collapses whitespace runs, so formatting differences still match.
+func normalizeCacheText(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// cacheGet returns the cached answer of a request and the layer it came from, nil on a miss.
+// Cache failures are logged and count as misses.
+func (b *chatBiz) cacheGet(ctx context.Context, c *responseCache) (*cacheEntry, string) {
+	if c == nil {
+		return nil, ""
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, cacheTimeout)
+	defer cancel()
+
+	if c.key != "" {
+		entry, err := getExactCacheEntry(ctx, c.key)
+		if err != nil {
+			log.C(ctx).Warnw("AI response cache lookup failed", "agent_id", c.agentID, "layer", cacheLayerExact, "err", err)
+		}
+		RecordCacheLookup(cacheLayerExact, entry != nil)
+		if entry != nil {
+			return entry, cacheLayerExact
+		}
+	}
+
+	if c.bucket != "" {
+		entry, err := b.getSemanticCacheEntry(ctx, c)
+		if err != nil {
+			log.C(ctx).Warnw("AI response cache lookup failed", "agent_id", c.agentID, "layer", cacheLayerSemantic, "err", err)
+		}
+		RecordCacheLookup(cacheLayerSemantic, entry != nil)
+		if entry != nil {
+			return entry, cacheLayerSemantic
+		}
+	}
+
+	return nil, ""
+}
+
+func getExactCacheEntry(ctx context.Context, key string) (*cacheEntry, error) {
+	data, err := facade.Redis.Get(ctx, key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, err
+	}
+
+	return &entry, nil
+}
+
+// getSemanticCacheEntry embeds the question and returns the most similar cached answer of its
+// bucket, pruning expired answers.
+func (b *chatBiz) getSemanticCacheEntry(ctx context.Context, c *responseCache) (*cacheEntry, error) {
+	embedding, call, err := b.knowledge.EmbedQuery(ctx, c.embeddingModel, c.question)
+	b.recordEmbedUsage(ctx, c.req, call, err)
+	if err != nil {
+		return nil, err
+	}
+	c.embedding = embedding
+
+	fields, err := facade.Redis.HGetAll(ctx, c.bucket).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make(map[string]*cacheEntry, len(fields))
+	for field, data := range fields {
+		var entry cacheEntry
+		if err := json.Unmarshal([]byte(data), &entry); err == nil {
+			entries[field] = &entry
+		}
+	}
+	match, expired := matchSemanticEntry(entries, embedding, c.threshold, time.Now())
+	if len(expired) > 0 {
+		if err := facade.Redis.HDel(ctx, c.bucket, expired...).Err(); err != nil {
+			log.C(ctx).Warnw("Failed to prune AI response cache", "agent_id", c.agentID, "err", err)
+		}
+	}
+
+	return match, nil
+}
+
+// matchSemanticEntry returns the live entry most similar to embedding with a similarity of at
+// least threshold, and the fields of the expired entries.
+func matchSemanticEntry(entries map[string]*cacheEntry, embedding []float32, threshold float64, now time.Time) (*cacheEntry, []string) {
+	var best *cacheEntry
+	bestScore := threshold
+	var expired []string
+	for field, entry := range entries {
+		if entry.ExpiresAt <= now.Unix() {
+			expired = append(expired, field)
+
+			continue
+		}
+		if score := aipkg.CosineSimilarity(embedding, entry.Embedding); score >= bestScore {
+			best = entry
+			bestScore = score
+		}
+	}
+
+	return best, expired
+}
+
+// cacheResponse caches a complete answer without tool calls.
+func (b *chatBiz) cacheResponse(c *responseCache, resp *aipkg.ChatResponse) {
+	if len(resp.Choices) == 0 {
+		return
+	}
+
+	choice := resp.Choices[0]
+	if choice.FinishReason != aipkg.FinishReasonStop || len(choice.Message.ToolCalls) > 0 {
+		return
+	}
+	b.cachePut(c, choice.Message.Content, resp.Model)
+}
+
+// cachePut stores an answer in every layer of the cache in the background.
+func (b *chatBiz) cachePut(c *responseCache, content string, modelName string) {
+	if c == nil || strings.TrimSpace(content) == "" {
+		return
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), cacheTimeout)
+		defer cancel()
+
+		if c.key != "" {
+			data, _ := json.Marshal(cacheEntry{Content: content, Model: modelName})
+			if err := facade.Redis.Set(ctx, c.key, data, c.ttl).Err(); err != nil {
+				log.C(ctx).Warnw("Failed to cache AI response", "agent_id", c.agentID, "layer", cacheLayerExact, "err", err)
+			}
+		}
+
+		// The lookup embedded the question, there is nothing to store without it
+		if c.bucket == "" || c.embedding == nil {
+			return
+		}
+		n, err := facade.Redis.HLen(ctx, c.bucket).Result()
+		if err != nil || n >= maxSemanticCacheEntries {
+			return
+		}
+		data, _ := json.Marshal(cacheEntry{
+			Content:   content,
+			Model:     modelName,
+			Embedding: c.embedding,
+			ExpiresAt: time.Now().Add(c.semanticTTL).Unix(),
+		})
+		pipe := facade.Redis.TxPipeline()
+		pipe.HSet(ctx, c.bucket, fmt.Sprintf("%x", sha256.Sum256([]byte(c.question))), data)
+		pipe.Expire(ctx, c.bucket, c.semanticTTL)
+		if _, err := pipe.Exec(ctx); err != nil {
+			log.C(ctx).Warnw("Failed to cache AI response", "agent_id", c.agentID, "layer", cacheLayerSemantic, "err", err)
+		}
+	}()
+}
+
+// cachedResponse builds the response of a cache hit, no tokens are used.
+func cachedResponse(entry *cacheEntry) *aipkg.ChatResponse {
+	return &aipkg.ChatResponse{
+		ID:      aipkg.GenerateID(),
+		Object:  "chat.completion",
+		Created: time.Now().Unix(),
+		Model:   entry.Model,
+		Choices: []aipkg.Choice{{
+			Message:      aipkg.Message{Role: aipkg.RoleAssistant, Content: entry.Content},
+			FinishReason: aipkg.FinishReasonStop,
+		}},
+		Cached: true,
+	}
+}
+
+// cachedStream streams a cache hit as one content chunk followed by the final chunk.
+func cachedStream(resp *aipkg.ChatResponse) *aipkg.ChatStream {
+	stream := aipkg.NewChatStream(aipkg.DefaultStreamBufferSize)
+	chunk := func(delta aipkg.Message, finishReason string, usage *aipkg.Usage) *aipkg.StreamChunk {
+		return &aipkg.StreamChunk{
+			ID:      resp.ID,
+			Object:  "chat.completion.chunk",
+			Created: resp.Created,
+			Model:   resp.Model,
+			Choices: []aipkg.Choice{{Delta: &delta, FinishReason: finishReason}},
+			Usage:   usage,
+			Cached:  true,
+		}
+	}
+
+	go func() {
+		defer stream.Close()
+
+		stream.Send(chunk(resp.Choices[0].Message, "", nil))
+		stream.Send(chunk(aipkg.Message{}, aipkg.FinishReasonStop, &aipkg.Usage{}))
+	}()
+
+	return stream
+}
+
+// saveCachedTurn saves a turn answered from cache to the session in the background.
+func (b *chatBiz) saveCachedTurn(ctx context.Context, uid string, req *aipkg.ChatRequest, parentID uint64, newMessages []aipkg.Message, resp *aipkg.ChatResponse) {
+	if req.SessionID == "" {
+		return
+	}
+
+	lang := contextx.Lang(ctx)
+	tags := tagsOf(req)
+	go func() {
+		ctx, cancel := context.WithTimeout(contextx.WithLang(context.Background(), lang), saveSessionTimeout)
+		defer cancel()
+		b.saveToSession(ctx, uid, req.SessionID, tags, parentID, newMessages, nil, resp)
+	}()
+}
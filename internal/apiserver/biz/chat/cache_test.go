@@ -0,0 +1,147 @@
+// ABOUTME: Tests for the agent response cache.
+// ABOUTME: Verifies cache keys, semantic eligibility, similarity matching and cached replies.
+
+package chat
+
+import (
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bingo-project/bingo/internal/pkg/config"
+	"github.com/bingo-project/bingo/internal/pkg/facade"
+	"github.com/bingo-project/bingo/internal/pkg/model"
+	aipkg "github.com/bingo-project/bingo/pkg/ai"
+)
+
+func withCacheRedis(t *testing.T) {
+	t.Helper()
+
+	prevRedis, prevApp := facade.Redis, facade.Config.App
+	facade.Redis = redis.NewClient(&redis.Options{Addr: "localhost:0"}) // Never dialled by the key helpers
+	if facade.Config.App == nil {
+		facade.Config.App = &config.App{Name: "bingo-test"}
+	}
+	t.Cleanup(func() {
+		_ = facade.Redis.Close()
+		facade.Redis, facade.Config.App = prevRedis, prevApp
+	})
+}
+
+func TestNewResponseCache(t *testing.T) {
+	withCacheRedis(t)
+
+	agent := &model.AiAgentM{AgentID: "faq", CacheTTL: 60, SemanticCacheTTL: 60, EmbeddingModel: "text-embedding-3-small"}
+	question := []aipkg.Message{
+		{Role: aipkg.RoleSystem, Content: "You answer FAQ."},
+		{Role: aipkg.RoleUser, Content: "How do I  reset my password?"},
+	}
+
+	t.Run("both layers", func(t *testing.T) {
+		c := newResponseCache(agent, &aipkg.ChatRequest{Model: "gpt-4o", Messages: question})
+		require.NotNil(t, c)
+		assert.NotEmpty(t, c.key)
+		assert.NotEmpty(t, c.bucket)
+		assert.Equal(t, "How do I reset my password?", c.question)
+		assert.Equal(t, model.DefaultSemanticCacheThreshold, c.threshold)
+	})
+
+	t.Run("conversation skips semantic layer", func(t *testing.T) {
+		messages := append([]aipkg.Message{
+			{Role: aipkg.RoleUser, Content: "Hi"},
+			{Role: aipkg.RoleAssistant, Content: "Hello!"},
+		}, question[1])
+		c := newResponseCache(agent, &aipkg.ChatRequest{Model: "gpt-4o", Messages: messages})
+		require.NotNil(t, c)
+		assert.NotEmpty(t, c.key)
+		assert.Empty(t, c.bucket)
+	})
+
+	tests := []struct {
+		name  string
+		agent *model.AiAgentM
+		req   *aipkg.ChatRequest
+	}{
+		{name: "no agent", req: &aipkg.ChatRequest{Messages: question}},
+		{name: "cache disabled", agent: &model.AiAgentM{AgentID: "faq"}, req: &aipkg.ChatRequest{Messages: question}},
+		{name: "tools", agent: agent, req: &aipkg.ChatRequest{Messages: question, Tools: []aipkg.Tool{{Type: "function"}}}},
+		{name: "regenerate", agent: agent, req: &aipkg.ChatRequest{Messages: question, ParentID: new(uint64)}},
+		{name: "tool result", agent: agent, req: &aipkg.ChatRequest{Messages: []aipkg.Message{{Role: aipkg.RoleTool, Content: "42"}}}},
+		{
+			name:  "semantic only without embedding model",
+			agent: &model.AiAgentM{AgentID: "faq", SemanticCacheTTL: 60},
+			req:   &aipkg.ChatRequest{Messages: question},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Nil(t, newResponseCache(tt.agent, tt.req))
+		})
+	}
+}
+
+func TestResponseCacheKey(t *testing.T) {
+	withCacheRedis(t)
+
+	messages := func(content string) []aipkg.Message {
+		return []aipkg.Message{{Role: aipkg.RoleUser, Content: content}}
+	}
+	req := &aipkg.ChatRequest{Model: "gpt-4o", AgentVersion: 2, Temperature: 0.7}
+	key := responseCacheKey(cacheLayerExact, "faq", req, messages("reset password"))
+
+	assert.Equal(t, key, responseCacheKey(cacheLayerExact, "faq", req, messages("  reset\n password ")))
+	assert.NotEqual(t, key, responseCacheKey(cacheLayerExact, "faq", req, messages("Reset password")))
+	assert.NotEqual(t, key, responseCacheKey(cacheLayerSemantic, "faq", req, messages("reset password")))
+	assert.NotEqual(t, key, responseCacheKey(cacheLayerExact, "other", req, messages("reset password")))
+
+	v3 := *req
+	v3.AgentVersion = 3
+	assert.NotEqual(t, key, responseCacheKey(cacheLayerExact, "faq", &v3, messages("reset password")))
+
+	other := *req
+	other.Model = "claude-sonnet-4"
+	assert.NotEqual(t, key, responseCacheKey(cacheLayerExact, "faq", &other, messages("reset password")))
+}
+
+func TestMatchSemanticEntry(t *testing.T) {
+	now := time.Now()
+	live := now.Add(time.Minute).Unix()
+	entries := map[string]*cacheEntry{
+		"close":   {Content: "close", Embedding: []float32{1, 0.1}, ExpiresAt: live},
+		"closer":  {Content: "closer", Embedding: []float32{1, 0.01}, ExpiresAt: live},
+		"far":     {Content: "far", Embedding: []float32{0, 1}, ExpiresAt: live},
+		"expired": {Content: "expired", Embedding: []float32{1, 0}, ExpiresAt: now.Add(-time.Minute).Unix()},
+	}
+
+	match, expired := matchSemanticEntry(entries, []float32{1, 0}, 0.95, now)
+	require.NotNil(t, match)
+	assert.Equal(t, "closer", match.Content)
+	assert.Equal(t, []string{"expired"}, expired)
+
+	match, _ = matchSemanticEntry(entries, []float32{0.5, 0.5}, 0.99, now)
+	assert.Nil(t, match)
+}
+
+func TestCachedStream(t *testing.T) {
+	resp := cachedResponse(&cacheEntry{Content: "Use the reset link.", Model: "gpt-4o"})
+	assert.True(t, resp.Cached)
+	assert.Zero(t, resp.Usage.TotalTokens)
+
+	stream := cachedStream(resp)
+	first, err := stream.Recv()
+	require.NoError(t, err)
+	assert.True(t, first.Cached)
+	assert.Equal(t, resp.ID, first.ID)
+	assert.Equal(t, "Use the reset link.", first.Choices[0].Delta.Content)
+
+	last, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, aipkg.FinishReasonStop, last.Choices[0].FinishReason)
+	require.NotNil(t, last.Usage)
+
+	_, err = stream.Recv()
+	assert.ErrorIs(t, err, aipkg.ErrStreamClosed)
+}
@@ -6,6 +6,7 @@ package chat
 import (
 	"context"
 	"errors"
+	"slices"
 	"strings"
 	"time"
 
@@ -19,6 +20,7 @@ import (
 	"github.com/bingo-project/bingo/internal/pkg/store"
 	aipkg "github.com/bingo-project/bingo/pkg/ai"
 	v1 "github.com/bingo-project/bingo/pkg/api/apiserver/v1"
+	"github.com/bingo-project/bingo/pkg/contextx"
 )
 
 const (
@@ -38,6 +40,15 @@ type ChatBiz interface {
 	// ChatStream performs a streaming chat completion
 	ChatStream(ctx context.Context, uid string, req *aipkg.ChatRequest) (*aipkg.ChatStream, error)
 
+	// Edit prepares req to answer an edited user message on a new branch, run it with Chat or ChatStream
+	Edit(ctx context.Context, uid string, messageID uint64, req *aipkg.ChatRequest) error
+
+	// Regenerate prepares req to answer again on a new branch, run it with Chat or ChatStream
+	Regenerate(ctx context.Context, uid string, messageID uint64, req *aipkg.ChatRequest) error
+
+	// Embeddings creates embedding vectors for the input texts
+	Embeddings(ctx context.Context, uid string, req *aipkg.EmbeddingRequest) (*aipkg.EmbeddingResponse, error)
+
 	// Sessions returns the session management interface
 	Sessions() SessionBiz
 
@@ -56,6 +67,8 @@ type chatBiz struct {
 	breakers      map[string]*CircuitBreaker
 	breakerConfig CircuitBreakerConfig
 	healthChecker *HealthChecker
+	tools         *aipkg.ToolRegistry
+	knowledge     *ai.KnowledgeBase
 }
 
 var _ ChatBiz = (*chatBiz)(nil)
@@ -70,6 +83,8 @@ func New(ds store.IStore, registry *aipkg.Registry) *chatBiz {
 		breakers:      make(map[string]*CircuitBreaker),
 		breakerConfig: DefaultCircuitBreakerConfig,
 		healthChecker: NewHealthChecker(registry),
+		tools:         newToolRegistry(ds),
+		knowledge:     ai.NewKnowledgeBase(ds, registry),
 	}
 
 	// Start health checker in background
@@ -80,7 +95,7 @@ func New(ds store.IStore, registry *aipkg.Registry) *chatBiz {
 
 func (b *chatBiz) Chat(ctx context.Context, uid string, req *aipkg.ChatRequest) (*aipkg.ChatResponse, error) {
 	start := time.Now()
-	if len(req.Messages) == 0 {
+	if len(req.Messages) == 0 && req.ParentID == nil {
 		return nil, errno.ErrAIEmptyMessages
 	}
 
@@ -88,6 +103,15 @@ func (b *chatBiz) Chat(ctx context.Context, uid string, req *aipkg.ChatRequest)
 	if err := b.validateMessageLength(req.Messages); err != nil {
 		return nil, err
 	}
+	if err := validateContentParts(req.Messages); err != nil {
+		return nil, err
+	}
+	if err := validateResponseFormat(req, false); err != nil {
+		return nil, err
+	}
+	if err := b.validateTools(req); err != nil {
+		return nil, err
+	}
 
 	// Validate session if provided and get role_id from session
 	if req.SessionID != "" {
@@ -102,8 +126,15 @@ func (b *chatBiz) Chat(ctx context.Context, uid string, req *aipkg.ChatRequest)
 		}
 	}
 
+	// Pin the stored message the turn continues, the reply is saved after it
+	parentID, err := b.resolveParent(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
 	// Apply agent preset if specified
-	if err := b.buildMessagesWithAgent(ctx, req); err != nil {
+	agent, err := b.buildMessagesWithAgent(ctx, req)
+	if err != nil {
 		return nil, err
 	}
 
@@ -113,23 +144,59 @@ func (b *chatBiz) Chat(ctx context.Context, uid string, req *aipkg.ChatRequest)
 		return nil, errno.ErrAIModelNotFound
 	}
 
+	// Check the new user messages against the agent's guardrails, the redacted text is saved
+	guard := newGuardrailPipeline(ctx, agent, uid, req)
+	if err := b.guardInput(ctx, guard, req); err != nil {
+		return nil, err
+	}
+
+	// Search the knowledge base with the guarded text only, raw input never reaches the embedder
+	b.injectKnowledge(ctx, agent, req)
+
 	// Capture new messages BEFORE loading history
 	newMessages := req.Messages
 
 	// Load and merge history messages
-	messages, err := b.loadAndMergeHistory(ctx, req.SessionID, req.Messages)
+	messages, historyEnd, err := b.loadAndMergeHistory(ctx, req.SessionID, parentID, req.Messages)
+	if err != nil {
+		return nil, err
+	}
+
+	// Stored history can hold text the guardrails never saw, such as imported transcripts
+	messages, err = b.guardHistory(ctx, guard, messages, historyEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	// Trim history to the model context and estimate prompt tokens
+	messages, promptTokens, err := b.fitContext(req, messages)
+	if err != nil {
+		return nil, err
+	}
+
+	// Inline uploaded images, history keeps the references
+	req.Messages, err = b.resolveImageRefs(messages)
 	if err != nil {
 		return nil, err
 	}
-	req.Messages = messages
 
 	// Check RPM limit before calling provider
 	if err := b.quota.CheckRPM(ctx, uid); err != nil {
 		return nil, err
 	}
 
+	// Answer repeated questions from the agent's response cache, without reserving quota
+	cache := newResponseCache(agent, req)
+	if entry, layer := b.cacheGet(ctx, cache); entry != nil {
+		resp := cachedResponse(entry)
+		b.saveCachedTurn(ctx, uid, req, parentID, newMessages, resp)
+		log.C(ctx).Infow("AI response served from cache", "uid", uid, "agent_id", req.AgentID, "layer", layer)
+
+		return resp, nil
+	}
+
 	// Reserve TPD quota atomically before calling provider
-	reservedTokens, err := b.quota.ReserveTPD(ctx, uid, req.MaxTokens)
+	reservedTokens, err := b.quota.ReserveTPD(ctx, uid, promptTokens+completionBudget(req.MaxTokens))
 	if err != nil {
 		return nil, err
 	}
@@ -155,37 +222,57 @@ func (b *chatBiz) Chat(ctx context.Context, uid string, req *aipkg.ChatRequest)
 	if err != nil {
 		return nil, err
 	}
-	req.Model = modelUsed
+	if err := b.fitFallback(req, modelUsed); err != nil {
+		return nil, err
+	}
 	breaker := b.getBreaker(providerName)
 
-	// Call provider
-	resp, err := provider.Chat(ctx, req)
+	// Call provider, executing server tool calls until a final answer matching the response format
+	resp, trace, spent, err := b.chatStructured(ctx, uid, provider, req)
+	if isUnanswered(err) {
+		quotaConsumed = true
+		b.settleUnanswered(uid, req, providerName, false, false, spent, reservedTokens, start, err)
+
+		return nil, err
+	}
 	if err != nil {
 		breaker.RecordFailure(ctx, err)
+		b.recordUsage(usageEntry{uid: uid, req: req, provider: providerName, latency: time.Since(start), err: err})
 
 		// Check if error is retriable and try fallback once
 		if b.isRetriableProviderError(err) {
 			fallback := b.fallback.SelectFallback(ctx, modelUsed)
 			if fallback != nil {
-				if provider2, ok := b.registry.Get(fallback.ProviderName); ok {
+				if provider2, ok := b.registry.Get(fallback.ProviderName); ok && b.fitFallback(req, fallback.Model) == nil {
 					log.C(ctx).Infow("AI provider error, using fallback",
 						"model", modelUsed, "fallback", fallback.Model, "err", err)
-					req.Model = fallback.Model
-					resp, err = provider2.Chat(ctx, req)
+					resp, trace, spent, err = b.chatStructured(ctx, uid, provider2, req)
+					if isUnanswered(err) {
+						quotaConsumed = true
+						b.settleUnanswered(uid, req, fallback.ProviderName, false, true, spent, reservedTokens, start, err)
+
+						return nil, err
+					}
 					if err == nil {
+						b.getBreaker(fallback.ProviderName).RecordSuccess(ctx)
+						b.guardOutput(ctx, guard, resp)
+
 						// Mark quota as consumed
 						quotaConsumed = true
-						b.handleChatSuccess(context.Background(), uid, req.SessionID, newMessages, resp, reservedTokens)
+						b.handleChatSuccess(context.Background(), uid, req.SessionID, tagsOf(req), parentID, newMessages, trace, resp, reservedTokens)
+						b.cacheResponse(cache, resp)
 
 						// Record fallback metrics
 						duration := time.Since(start).Seconds()
 						RecordRequest(fallback.ProviderName, req.Model, false, duration, "success")
 						RecordFallback(providerName, fallback.ProviderName)
+						b.recordUsage(usageEntry{uid: uid, req: req, provider: fallback.ProviderName, fallback: true, usage: resp.Usage, latency: time.Since(start)})
 
 						return resp, nil
 					}
 					// Record fallback failure too
 					b.getBreaker(fallback.ProviderName).RecordFailure(ctx, err)
+					b.recordUsage(usageEntry{uid: uid, req: req, provider: fallback.ProviderName, fallback: true, latency: time.Since(start), err: err})
 				}
 			}
 		}
@@ -195,21 +282,24 @@ func (b *chatBiz) Chat(ctx context.Context, uid string, req *aipkg.ChatRequest)
 	}
 
 	breaker.RecordSuccess(ctx)
+	b.guardOutput(ctx, guard, resp)
 
 	// Mark quota as consumed (will be adjusted with actual usage below)
 	quotaConsumed = true
-	b.handleChatSuccess(context.Background(), uid, req.SessionID, newMessages, resp, reservedTokens)
+	b.handleChatSuccess(context.Background(), uid, req.SessionID, tagsOf(req), parentID, newMessages, trace, resp, reservedTokens)
+	b.cacheResponse(cache, resp)
 
 	// Record metrics
 	duration := time.Since(start).Seconds()
 	RecordRequest(providerName, req.Model, false, duration, "success")
+	b.recordUsage(usageEntry{uid: uid, req: req, provider: providerName, usage: resp.Usage, latency: time.Since(start)})
 
 	return resp, nil
 }
 
 func (b *chatBiz) ChatStream(ctx context.Context, uid string, req *aipkg.ChatRequest) (*aipkg.ChatStream, error) {
 	start := time.Now()
-	if len(req.Messages) == 0 {
+	if len(req.Messages) == 0 && req.ParentID == nil {
 		return nil, errno.ErrAIEmptyMessages
 	}
 
@@ -217,6 +307,15 @@ func (b *chatBiz) ChatStream(ctx context.Context, uid string, req *aipkg.ChatReq
 	if err := b.validateMessageLength(req.Messages); err != nil {
 		return nil, err
 	}
+	if err := validateContentParts(req.Messages); err != nil {
+		return nil, err
+	}
+	if err := validateResponseFormat(req, true); err != nil {
+		return nil, err
+	}
+	if err := b.validateTools(req); err != nil {
+		return nil, err
+	}
 
 	// Validate session if provided and get role_id from session
 	if req.SessionID != "" {
@@ -231,8 +330,15 @@ func (b *chatBiz) ChatStream(ctx context.Context, uid string, req *aipkg.ChatReq
 		}
 	}
 
+	// Pin the stored message the turn continues, the reply is saved after it
+	parentID, err := b.resolveParent(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
 	// Apply agent preset if specified
-	if err := b.buildMessagesWithAgent(ctx, req); err != nil {
+	agent, err := b.buildMessagesWithAgent(ctx, req)
+	if err != nil {
 		return nil, err
 	}
 
@@ -242,23 +348,59 @@ func (b *chatBiz) ChatStream(ctx context.Context, uid string, req *aipkg.ChatReq
 		return nil, errno.ErrAIModelNotFound
 	}
 
+	// Check the new user messages against the agent's guardrails, the redacted text is saved
+	guard := newGuardrailPipeline(ctx, agent, uid, req)
+	if err := b.guardInput(ctx, guard, req); err != nil {
+		return nil, err
+	}
+
+	// Search the knowledge base with the guarded text only, raw input never reaches the embedder
+	b.injectKnowledge(ctx, agent, req)
+
 	// Capture new messages BEFORE loading history
 	newMessages := req.Messages
 
 	// Load and merge history messages
-	messages, err := b.loadAndMergeHistory(ctx, req.SessionID, req.Messages)
+	messages, historyEnd, err := b.loadAndMergeHistory(ctx, req.SessionID, parentID, req.Messages)
+	if err != nil {
+		return nil, err
+	}
+
+	// Stored history can hold text the guardrails never saw, such as imported transcripts
+	messages, err = b.guardHistory(ctx, guard, messages, historyEnd)
+	if err != nil {
+		return nil, err
+	}
+
+	// Trim history to the model context and estimate prompt tokens
+	messages, promptTokens, err := b.fitContext(req, messages)
+	if err != nil {
+		return nil, err
+	}
+
+	// Inline uploaded images, history keeps the references
+	req.Messages, err = b.resolveImageRefs(messages)
 	if err != nil {
 		return nil, err
 	}
-	req.Messages = messages
 
 	// Check RPM limit before calling provider
 	if err := b.quota.CheckRPM(ctx, uid); err != nil {
 		return nil, err
 	}
 
+	// Answer repeated questions from the agent's response cache, without reserving quota
+	cache := newResponseCache(agent, req)
+	if entry, layer := b.cacheGet(ctx, cache); entry != nil {
+		resp := cachedResponse(entry)
+		b.saveCachedTurn(ctx, uid, req, parentID, newMessages, resp)
+		log.C(ctx).Infow("AI response served from cache", "uid", uid, "agent_id", req.AgentID, "layer", layer, "stream", true)
+
+		return cachedStream(resp), nil
+	}
+
 	// Reserve TPD quota atomically before calling provider
-	reservedTokens, err := b.quota.ReserveTPD(ctx, uid, req.MaxTokens)
+	reservedTokens, err := b.quota.ReserveTPD(ctx, uid, promptTokens+completionBudget(req.MaxTokens))
 	if err != nil {
 		return nil, err
 	}
@@ -279,29 +421,39 @@ func (b *chatBiz) ChatStream(ctx context.Context, uid string, req *aipkg.ChatReq
 		}
 	}()
 
+	// Register the generation so the client can cancel it, the stream wrapper finishes it
+	ctx, gen := generations.start(ctx, uid, promptTokens)
+	defer func() {
+		if !quotaConsumed {
+			generations.finish(gen)
+		}
+	}()
+
 	// Get provider with fallback
 	provider, providerName, modelUsed, err := b.getProviderWithFallback(ctx, req.Model)
 	if err != nil {
 		return nil, err
 	}
-	req.Model = modelUsed
+	if err := b.fitFallback(req, modelUsed); err != nil {
+		return nil, err
+	}
 	breaker := b.getBreaker(providerName)
 
-	// Call provider
-	stream, err := provider.ChatStream(ctx, req)
+	// Call provider, executing server tool calls between streams
+	stream, trace, err := b.chatStreamWithTools(ctx, uid, provider, req)
 	if err != nil {
 		breaker.RecordFailure(ctx, err)
+		b.recordUsage(usageEntry{uid: uid, req: req, provider: providerName, stream: true, latency: time.Since(start), err: err})
 
 		// Check if error is retriable and try fallback once
 		// Only attempt fallback if initial call fails (no chunks sent yet)
 		if b.isRetriableProviderError(err) {
 			fallback := b.fallback.SelectFallback(ctx, modelUsed)
 			if fallback != nil {
-				if provider2, ok := b.registry.Get(fallback.ProviderName); ok {
+				if provider2, ok := b.registry.Get(fallback.ProviderName); ok && b.fitFallback(req, fallback.Model) == nil {
 					log.C(ctx).Infow("AI provider stream error, using fallback",
 						"model", modelUsed, "fallback", fallback.Model, "err", err)
-					req.Model = fallback.Model
-					stream, err = provider2.ChatStream(ctx, req)
+					stream, trace, err = b.chatStreamWithTools(ctx, uid, provider2, req)
 					if err == nil {
 						// Fallback succeeded, record success and proceed with stream
 						b.getBreaker(fallback.ProviderName).RecordSuccess(ctx)
@@ -312,10 +464,11 @@ func (b *chatBiz) ChatStream(ctx context.Context, uid string, req *aipkg.ChatReq
 						RecordRequest(fallback.ProviderName, req.Model, true, duration, "success")
 						RecordFallback(providerName, fallback.ProviderName)
 
-						return b.wrapStreamForSaving(stream, uid, req, newMessages, reservedTokens, fallback.ProviderName, start), nil
+						return b.wrapStreamForSaving(stream, gen, uid, req, cache, guard, parentID, newMessages, trace, reservedTokens, fallback.ProviderName, true, start), nil
 					}
 					// Record fallback failure too
 					b.getBreaker(fallback.ProviderName).RecordFailure(ctx, err)
+					b.recordUsage(usageEntry{uid: uid, req: req, provider: fallback.ProviderName, stream: true, fallback: true, latency: time.Since(start), err: err})
 				}
 			}
 		}
@@ -333,37 +486,124 @@ func (b *chatBiz) ChatStream(ctx context.Context, uid string, req *aipkg.ChatReq
 	RecordRequest(providerName, req.Model, true, duration, "success")
 
 	// Wrap stream to save messages and adjust quota after completion
-	return b.wrapStreamForSaving(stream, uid, req, newMessages, reservedTokens, providerName, start), nil
+	return b.wrapStreamForSaving(stream, gen, uid, req, cache, guard, parentID, newMessages, trace, reservedTokens, providerName, false, start), nil
 }
 
-// wrapStreamForSaving wraps a stream to save messages and adjust quota after completion.
-func (b *chatBiz) wrapStreamForSaving(stream *aipkg.ChatStream, uid string, req *aipkg.ChatRequest, newMessages []aipkg.Message, reservedTokens int, providerName string, startTime time.Time) *aipkg.ChatStream {
+// wrapStreamForSaving wraps a stream to save messages, adjust quota and record usage after completion.
+// Every chunk carries the generation ID and is buffered for resuming; a cancelled generation
+// ends with a "cancelled" finish reason. Output guardrails check the text sentence by sentence,
+// a blocked answer ends with "content_filter". A complete answer is stored in the response cache.
+func (b *chatBiz) wrapStreamForSaving(stream *aipkg.ChatStream, gen *generation, uid string, req *aipkg.ChatRequest, cache *responseCache, guard *guardrailPipeline, parentID uint64, newMessages []aipkg.Message, trace *toolTrace, reservedTokens int, providerName string, fallback bool, startTime time.Time) *aipkg.ChatStream {
 	wrapped := aipkg.NewChatStream(aipkg.DefaultStreamBufferSize)
 
 	go func() {
+		defer generations.finish(gen)
+		buffer := newStreamBuffer(gen.id, uid)
+		filter := newStreamGuard(guard)
+
 		var contentBuilder strings.Builder
+		toolCalls := aipkg.NewToolCallAccumulator()
 		var modelName string
-		var totalTokens int
+		var finishReason string
+		var usage aipkg.Usage
+		var filtered bool
 
 		for {
 			chunk, err := stream.Recv()
+
+			// Check the text against the output guardrails, a blocked answer stops the provider
+			held := false
+			if err == nil {
+				held, filtered = filter.filter(chunk)
+				if filtered {
+					gen.cancel()
+					go drainStream(stream)
+					err = aipkg.ErrStreamClosed
+				}
+			}
+
 			if err != nil {
+				calls := toolCalls.Calls()
+
+				// Release the text the guardrails still hold back
+				if !filtered {
+					text, blocked := filter.flush()
+					filtered = blocked
+					if text != "" {
+						contentBuilder.WriteString(text)
+						chunk := &aipkg.StreamChunk{
+							ID:      gen.id,
+							Object:  "chat.completion.chunk",
+							Created: time.Now().Unix(),
+							Model:   req.Model,
+							Choices: []aipkg.Choice{{Delta: &aipkg.Message{Role: aipkg.RoleAssistant, Content: text}}},
+						}
+						buffer.append(chunk)
+						wrapped.Send(chunk)
+					}
+				}
+
+				// Cancelled by the client or a dropped connection, or blocked by a guardrail: keep the partial answer
+				cancelled := !filtered && (gen.cancelled.Load() || errors.Is(err, context.Canceled))
+				if cancelled || filtered {
+					finishReason = aipkg.FinishReasonCancelled
+					if filtered {
+						finishReason = aipkg.FinishReasonContentFilter
+					}
+					calls = nil // Partial tool call arguments are not valid JSON
+					if usage.TotalTokens == 0 {
+						generated := contentBuilder.String()
+						if filter != nil {
+							generated = filter.generated.String()
+						}
+						usage = estimateUsage(req.Model, gen.promptTokens, generated)
+					}
+					final := &aipkg.StreamChunk{
+						ID:      gen.id,
+						Object:  "chat.completion.chunk",
+						Created: time.Now().Unix(),
+						Model:   req.Model,
+						Choices: []aipkg.Choice{{Delta: &aipkg.Message{}, FinishReason: finishReason}},
+						Usage:   &usage,
+					}
+					buffer.append(final)
+					wrapped.Send(final)
+					err = aipkg.ErrStreamClosed
+				}
+
 				// Stream ended, record final metrics
 				duration := time.Since(startTime).Seconds()
 				status := "success"
+				var streamErr error
 				if err != nil && err != aipkg.ErrStreamClosed {
 					status = "error"
+					streamErr = err
+				}
+				if cancelled {
+					status = model.AiUsageStatusCancelled
 				}
 				RecordRequest(providerName, req.Model, true, duration, status)
+				b.recordUsage(usageEntry{uid: uid, req: req, provider: providerName, stream: true, fallback: fallback, cancelled: cancelled, usage: usage, latency: time.Since(startTime), err: streamErr})
+				if filter != nil {
+					b.recordGuardrailEvents(contextx.WithLang(context.Background(), gen.lang), filter.events)
+				}
+
+				totalTokens := usage.TotalTokens
+				if streamErr == nil && !cancelled && !filtered {
+					if finishReason != aipkg.FinishReasonStop || len(calls) > 0 {
+						cache = nil
+					}
+					b.moderateStreamOutput(gen.lang, guard, contentBuilder.String(), cache, modelName)
+				}
 
 				// Stream ended, save accumulated content
-				if contentBuilder.Len() > 0 && req.SessionID != "" {
-					content := contentBuilder.String()
+				if (contentBuilder.Len() > 0 || len(calls) > 0 || filtered) && req.SessionID != "" {
+					reply := aipkg.Message{Role: aipkg.RoleAssistant, Content: contentBuilder.String(), ToolCalls: calls}
 					go func() {
-						ctx, cancel := context.WithTimeout(context.Background(), saveSessionTimeout)
+						ctx, cancel := context.WithTimeout(contextx.WithLang(context.Background(), gen.lang), saveSessionTimeout)
 						defer cancel()
 						// Pass newMessages explicitly
-						b.saveStreamToSession(ctx, uid, req.SessionID, newMessages, content, modelName, totalTokens)
+						b.saveStreamToSession(ctx, uid, req.SessionID, tagsOf(req), parentID, newMessages, trace.messages, reply, finishReason, modelName, totalTokens)
 					}()
 				}
 				// Adjust TPD quota with actual usage
@@ -374,6 +614,7 @@ func (b *chatBiz) wrapStreamForSaving(stream *aipkg.ChatStream, uid string, req
 						log.C(ctx).Errorw("Failed to adjust TPD quota", "uid", uid, "actual", totalTokens, "reserved", reservedTokens, "err", err)
 					}
 				}()
+				buffer.close(err)
 				wrapped.CloseWithError(err)
 
 				return
@@ -382,14 +623,24 @@ func (b *chatBiz) wrapStreamForSaving(stream *aipkg.ChatStream, uid string, req
 			// Accumulate content
 			if len(chunk.Choices) > 0 && chunk.Choices[0].Delta != nil {
 				contentBuilder.WriteString(chunk.Choices[0].Delta.Content)
+				toolCalls.Add(chunk.Choices[0].Delta.ToolCalls)
+			}
+			if len(chunk.Choices) > 0 && chunk.Choices[0].FinishReason != "" {
+				finishReason = chunk.Choices[0].FinishReason
 			}
 			if chunk.Model != "" {
 				modelName = chunk.Model
 			}
 			if chunk.Usage != nil {
-				totalTokens = chunk.Usage.TotalTokens
+				usage = *chunk.Usage
+			}
+			if held {
+				continue
 			}
 
+			// Clients cancel and resume the generation by this ID
+			chunk.ID = gen.id
+			buffer.append(chunk)
 			wrapped.Send(chunk)
 		}
 	}()
@@ -398,43 +649,42 @@ func (b *chatBiz) wrapStreamForSaving(stream *aipkg.ChatStream, uid string, req
 }
 
 // saveStreamToSession saves stream messages to session.
-func (b *chatBiz) saveStreamToSession(ctx context.Context, uid string, sessionID string, newMessages []aipkg.Message, content string, modelName string, tokens int) {
-	// Save user message (iterate over newMessages)
-	for _, msg := range newMessages {
-		if msg.Role == aipkg.RoleUser {
-			if err := b.ds.AiMessage().Create(ctx, &model.AiMessageM{
-				SessionID: sessionID,
-				Role:      msg.Role,
-				Content:   msg.Content,
-				Model:     "", // User messages don't need a model
-			}); err != nil {
-				log.C(ctx).Errorw("Failed to save user message", "session_id", sessionID, "uid", uid, "err", err)
-			}
-		}
+func (b *chatBiz) saveStreamToSession(ctx context.Context, uid string, sessionID string, tags messageTags, parentID uint64, newMessages []aipkg.Message, trace []aipkg.Message, reply aipkg.Message, finishReason string, modelName string, tokens int) {
+	usedModel := modelName
+	if usedModel == "" {
+		// Fallback if model name wasn't captured in stream
+		usedModel = "unknown"
 	}
 
-	// Save assistant response
-	if content != "" {
-		usedModel := modelName
-		if usedModel == "" {
-			// Fallback if model name wasn't captured in stream
-			usedModel = "unknown"
-		}
-		if err := b.ds.AiMessage().Create(ctx, &model.AiMessageM{
-			SessionID: sessionID,
-			Role:      aipkg.RoleAssistant,
-			Content:   content,
-			Tokens:    tokens,
-			Model:     usedModel,
-		}); err != nil {
-			log.C(ctx).Errorw("Failed to save assistant message", "session_id", sessionID, "uid", uid, "err", err)
-		}
+	// Save user and tool result messages (iterate over newMessages) and intermediate server tool rounds
+	messages := requestMessages(sessionID, newMessages)
+	messages = append(messages, traceMessages(sessionID, trace, usedModel)...)
+
+	// Save assistant response, a reply blocked by a guardrail is kept to show the turn was filtered
+	if reply.Content != "" || len(reply.ToolCalls) > 0 || finishReason == aipkg.FinishReasonContentFilter {
+		messages = append(messages, &model.AiMessageM{
+			SessionID:    sessionID,
+			Role:         aipkg.RoleAssistant,
+			Content:      reply.Content,
+			ToolCalls:    reply.ToolCalls,
+			Tokens:       tokens,
+			Model:        usedModel,
+			FinishReason: finishReason,
+		})
 	}
+	tags.apply(messages)
+	b.saveBranch(ctx, uid, sessionID, parentID, messages)
 
 	// Update session stats
 	if err := b.ds.AiSession().IncrementMessageCount(ctx, sessionID, tokens); err != nil {
 		log.C(ctx).Errorw("Failed to update session stats", "session_id", sessionID, "uid", uid, "err", err)
 	}
+
+	// Name a new session after its first exchange
+	b.requestTitle(ctx, sessionID)
+
+	// Fold old history into the rolling summary for later turns
+	go b.summarizeSession(sessionID, usedModel)
 }
 
 func (b *chatBiz) Sessions() SessionBiz {
@@ -478,7 +728,7 @@ func (b *chatBiz) validateMessageLength(messages []aipkg.Message) error {
 }
 
 // resolveModel resolves the model to use based on priority:
-// Request specified > Session preference > Database default > Config default > First available
+// Request specified (including the agent or experiment arm model) > Session preference > Database default > Config default > First available
 func (b *chatBiz) resolveModel(ctx context.Context, reqModel, sessionID string) string {
 	// 1. Request specified - validate against active models
 	if reqModel != "" {
@@ -510,8 +760,12 @@ func (b *chatBiz) resolveModel(ctx context.Context, reqModel, sessionID string)
 
 	// 5. First available model by sort order
 	models, err := b.ds.AiModel().ListActive(ctx)
-	if err == nil && len(models) > 0 {
-		return models[0].Model
+	if err == nil {
+		for _, m := range models {
+			if m.Type != model.AiModelTypeEmbedding {
+				return m.Model
+			}
+		}
 	}
 
 	return "" // Empty string - let caller handle error
@@ -581,45 +835,60 @@ func (b *chatBiz) isRetriableProviderError(err error) bool {
 	return false
 }
 
-// loadAndMergeHistory loads session history and merges with new messages.
-// Returns merged messages with sliding window applied.
-func (b *chatBiz) loadAndMergeHistory(ctx context.Context, sessionID string, newMessages []aipkg.Message) ([]aipkg.Message, error) {
+// loadAndMergeHistory loads the branch ending at parentID and merges it with new messages.
+// Leading system messages of the request are kept in front of the history. The returned
+// index is the end of the stored history in the merged messages.
+func (b *chatBiz) loadAndMergeHistory(ctx context.Context, sessionID string, parentID uint64, newMessages []aipkg.Message) ([]aipkg.Message, int, error) {
 	if sessionID == "" {
-		return newMessages, nil
+		return newMessages, 0, nil
 	}
 
-	// Get limit from config
-	limit := facade.Config.AI.Session.MaxMessages
-	if limit <= 0 {
-		limit = 50 // default
-	}
+	// Load the rolling summary, history continues after its cursor
+	cursor, summary := b.loadSummary(ctx, sessionID)
 
-	// Load history messages
-	history, err := b.ds.AiMessage().ListBySessionID(ctx, sessionID, limit)
+	// Load history messages of the branch
+	branch, err := b.ds.AiMessage().ListBranch(ctx, sessionID, parentID, historyLimit())
 	if err != nil {
 		log.C(ctx).Warnw("Failed to load message history", "session_id", sessionID, "err", err)
 
-		return newMessages, nil // Continue without history on error
+		return newMessages, 0, nil // Continue without history on error
+	}
+	history, summarized := branchHistory(branch, cursor)
+	if !summarized {
+		summary = ""
 	}
 
-	if len(history) == 0 {
-		return newMessages, nil
+	if len(history) == 0 && summary == "" {
+		return newMessages, 0, nil
 	}
 
+	// History never stores system prompts, keep the ones injected for this request
+	sysEnd := leadingSystemCount(newMessages)
+
 	// Convert DB messages to ai.Message
-	messages := make([]aipkg.Message, 0, len(history)+len(newMessages))
+	messages := make([]aipkg.Message, 0, len(history)+len(newMessages)+1)
+	messages = append(messages, newMessages[:sysEnd]...)
+	if summary != "" {
+		messages = append(messages, aipkg.Message{Role: aipkg.RoleSystem, Content: summaryPrefix + summary})
+	}
 	for _, m := range history {
-		messages = append(messages, aipkg.Message{
-			Role:    m.Role,
-			Content: m.Content,
-		})
+		messages = append(messages, toAIMessage(m))
+	}
+	historyEnd := len(messages)
+
+	// Client tool results continue the stored assistant tool call, append only them
+	toolStart := len(newMessages)
+	for toolStart > sysEnd && newMessages[toolStart-1].Role == aipkg.RoleTool {
+		toolStart--
 	}
 
 	// FIX: When we have history, only append the NEW user message(s)
 	// Find the last user message in newMessages
-	if len(newMessages) > 0 {
+	if toolStart < len(newMessages) {
+		messages = append(messages, newMessages[toolStart:]...)
+	} else if len(newMessages) > sysEnd {
 		lastUserMsgIdx := -1
-		for i := len(newMessages) - 1; i >= 0; i-- {
+		for i := len(newMessages) - 1; i >= sysEnd; i-- {
 			if newMessages[i].Role == aipkg.RoleUser {
 				lastUserMsgIdx = i
 
@@ -632,28 +901,11 @@ func (b *chatBiz) loadAndMergeHistory(ctx context.Context, sessionID string, new
 			messages = append(messages, newMessages[lastUserMsgIdx:]...)
 		} else {
 			// No user message in newMessages, append as-is (edge case)
-			messages = append(messages, newMessages...)
-		}
-	}
-
-	// Apply sliding window if configured
-	contextWindow := facade.Config.AI.Session.ContextWindow
-	if contextWindow > 0 && len(messages) > contextWindow {
-		// Keep system message if present, then last N-1 messages
-		var result []aipkg.Message
-		if len(messages) > 0 && messages[0].Role == aipkg.RoleSystem {
-			result = append(result, messages[0])
-			messages = messages[1:]
-			contextWindow--
-		}
-		if len(messages) > contextWindow {
-			messages = messages[len(messages)-contextWindow:]
+			messages = append(messages, newMessages[sysEnd:]...)
 		}
-		result = append(result, messages...)
-		messages = result
 	}
 
-	return messages, nil
+	return messages, historyEnd, nil
 }
 
 func (b *chatBiz) ListModels(ctx context.Context) (*v1.ListModelsResponse, error) {
@@ -666,6 +918,7 @@ func (b *chatBiz) ListModels(ctx context.Context) (*v1.ListModelsResponse, error
 			Object:      "model",
 			Created:     time.Now().Unix(),
 			OwnedBy:     m.Provider,
+			Type:        m.Type,
 			MaxTokens:   m.MaxTokens,
 			InputPrice:  m.InputPrice,
 			OutputPrice: m.OutputPrice,
@@ -679,43 +932,125 @@ func (b *chatBiz) ListModels(ctx context.Context) (*v1.ListModelsResponse, error
 }
 
 // saveToSession saves request and response to session (background goroutine)
-func (b *chatBiz) saveToSession(ctx context.Context, uid string, sessionID string, newMessages []aipkg.Message, resp *aipkg.ChatResponse) {
-	// Save user message (only the new ones passed in)
-	for _, msg := range newMessages {
-		if msg.Role == aipkg.RoleUser {
-			if err := b.ds.AiMessage().Create(ctx, &model.AiMessageM{
-				SessionID: sessionID,
-				Role:      msg.Role,
-				Content:   msg.Content,
-				Model:     "", // User messages don't need a model
-			}); err != nil {
-				log.C(ctx).Errorw("Failed to save user message", "session_id", sessionID, "uid", uid, "err", err)
-			}
-		}
-	}
+func (b *chatBiz) saveToSession(ctx context.Context, uid string, sessionID string, tags messageTags, parentID uint64, newMessages []aipkg.Message, trace []aipkg.Message, resp *aipkg.ChatResponse) {
+	// Save user and tool result messages (only the new ones passed in) and intermediate server tool rounds
+	messages := requestMessages(sessionID, newMessages)
+	messages = append(messages, traceMessages(sessionID, trace, resp.Model)...)
 
 	// Save assistant response
 	if len(resp.Choices) > 0 {
-		if err := b.ds.AiMessage().Create(ctx, &model.AiMessageM{
-			SessionID: sessionID,
-			Role:      aipkg.RoleAssistant,
-			Content:   resp.Choices[0].Message.Content,
-			Tokens:    resp.Usage.CompletionTokens,
-			Model:     resp.Model,
-		}); err != nil {
-			log.C(ctx).Errorw("Failed to save assistant message", "session_id", sessionID, "uid", uid, "err", err)
-		}
+		messages = append(messages, &model.AiMessageM{
+			SessionID:    sessionID,
+			Role:         aipkg.RoleAssistant,
+			Content:      resp.Choices[0].Message.Content,
+			ToolCalls:    resp.Choices[0].Message.ToolCalls,
+			Tokens:       resp.Usage.CompletionTokens,
+			Model:        resp.Model,
+			FinishReason: resp.Choices[0].FinishReason,
+		})
 	}
+	tags.apply(messages)
+	b.saveBranch(ctx, uid, sessionID, parentID, messages)
 
 	// Update session stats
 	if err := b.ds.AiSession().IncrementMessageCount(ctx, sessionID, resp.Usage.TotalTokens); err != nil {
 		log.C(ctx).Errorw("Failed to update session stats", "session_id", sessionID, "uid", uid, "err", err)
 	}
+
+	// Name a new session after its first exchange
+	b.requestTitle(ctx, sessionID)
+
+	// Fold old history into the rolling summary for later turns
+	go b.summarizeSession(sessionID, resp.Model)
+}
+
+// saveBranch saves the messages of a turn after parentID and makes them the active branch.
+func (b *chatBiz) saveBranch(ctx context.Context, uid string, sessionID string, parentID uint64, messages []*model.AiMessageM) {
+	leafID, err := b.saveChain(ctx, parentID, messages)
+	if err != nil {
+		log.C(ctx).Errorw("Failed to save session messages", "session_id", sessionID, "uid", uid, "err", err)
+	}
+	if leafID == parentID {
+		return
+	}
+
+	if err := b.ds.AiSession().UpdateActiveLeaf(ctx, sessionID, leafID); err != nil {
+		log.C(ctx).Errorw("Failed to update active branch", "session_id", sessionID, "uid", uid, "err", err)
+	}
+}
+
+// requestMessages builds the user and client tool result messages of a request.
+// A client tool result follows an assistant tool call message that is already stored.
+func requestMessages(sessionID string, newMessages []aipkg.Message) []*model.AiMessageM {
+	var messages []*model.AiMessageM
+	for _, msg := range newMessages {
+		if msg.Role != aipkg.RoleUser && msg.Role != aipkg.RoleTool {
+			continue
+		}
+		messages = append(messages, &model.AiMessageM{
+			SessionID:    sessionID,
+			Role:         msg.Role,
+			Content:      msg.Content,
+			ContentParts: msg.Parts,
+			Name:         msg.Name,
+			ToolCallID:   msg.ToolCallID,
+			Model:        "", // User messages don't need a model
+		})
+	}
+
+	return messages
+}
+
+// traceMessages builds intermediate assistant tool calls and server tool results.
+func traceMessages(sessionID string, trace []aipkg.Message, modelName string) []*model.AiMessageM {
+	messages := make([]*model.AiMessageM, 0, len(trace))
+	for _, msg := range trace {
+		m := &model.AiMessageM{
+			SessionID:  sessionID,
+			Role:       msg.Role,
+			Content:    msg.Content,
+			Name:       msg.Name,
+			ToolCalls:  msg.ToolCalls,
+			ToolCallID: msg.ToolCallID,
+		}
+		if msg.Role == aipkg.RoleAssistant {
+			m.Model = modelName
+		}
+		messages = append(messages, m)
+	}
+
+	return messages
+}
+
+// messageTags records the agent version and experiment arm that produced a turn.
+type messageTags struct {
+	agentVersion  int
+	experimentID  uint64
+	experimentArm string
+}
+
+func tagsOf(req *aipkg.ChatRequest) messageTags {
+	return messageTags{
+		agentVersion:  req.AgentVersion,
+		experimentID:  req.ExperimentID,
+		experimentArm: req.ExperimentArm,
+	}
+}
+
+// apply tags every message of a turn with the experiment arm and assistant messages with the agent version.
+func (t messageTags) apply(messages []*model.AiMessageM) {
+	for _, m := range messages {
+		m.ExperimentID = t.experimentID
+		m.ExperimentArm = t.experimentArm
+		if m.Role == aipkg.RoleAssistant {
+			m.AgentVersion = t.agentVersion
+		}
+	}
 }
 
 // handleChatSuccess handles post-success operations for Chat: quota adjustment and session save.
 // Called by both primary success path and fallback success path.
-func (b *chatBiz) handleChatSuccess(ctx context.Context, uid string, sessionID string, newMessages []aipkg.Message, resp *aipkg.ChatResponse, reservedTokens int) {
+func (b *chatBiz) handleChatSuccess(ctx context.Context, uid string, sessionID string, tags messageTags, parentID uint64, newMessages []aipkg.Message, trace []aipkg.Message, resp *aipkg.ChatResponse, reservedTokens int) {
 	// Adjust TPD quota with actual usage (background)
 	go func() {
 		ctx, cancel := context.WithTimeout(context.Background(), saveSessionTimeout)
@@ -729,33 +1064,61 @@ func (b *chatBiz) handleChatSuccess(ctx context.Context, uid string, sessionID s
 
 	// Save to session if session ID provided (background with timeout)
 	if sessionID != "" {
+		lang := contextx.Lang(ctx)
 		go func() {
-			ctx, cancel := context.WithTimeout(context.Background(), saveSessionTimeout)
+			ctx, cancel := context.WithTimeout(contextx.WithLang(context.Background(), lang), saveSessionTimeout)
 			defer cancel()
-			b.saveToSession(ctx, uid, sessionID, newMessages, resp)
+			b.saveToSession(ctx, uid, sessionID, tags, parentID, newMessages, trace, resp)
 		}()
 	}
 }
 
+// isUnanswered reports whether the model answered without a usable reply: it kept calling tools
+// past the round limit or its reply stayed invalid after the repair rounds. The provider worked,
+// so there is no fallback, but the tokens spent are charged.
+func isUnanswered(err error) bool {
+	return errors.Is(err, errno.ErrAIToolCallLimitExceeded) || errors.Is(err, errno.ErrAIInvalidStructuredOutput)
+}
+
+// settleUnanswered charges the tokens spent on a request the model answered without a usable
+// reply, such as tool rounds past the limit, and records it in the usage ledger.
+func (b *chatBiz) settleUnanswered(uid string, req *aipkg.ChatRequest, providerName string, stream, fallback bool, usage aipkg.Usage, reservedTokens int, start time.Time, err error) {
+	RecordRequest(providerName, req.Model, stream, time.Since(start).Seconds(), "error")
+	b.recordUsage(usageEntry{uid: uid, req: req, provider: providerName, stream: stream, fallback: fallback, usage: usage, latency: time.Since(start), err: err})
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), saveSessionTimeout)
+		defer cancel()
+		if err := b.quota.AdjustTPD(ctx, uid, usage.TotalTokens, reservedTokens); err != nil {
+			log.C(ctx).Errorw("Failed to adjust TPD quota",
+				"uid", uid, "actual", usage.TotalTokens,
+				"reserved", reservedTokens, "err", err)
+		}
+	}()
+}
+
 // buildMessagesWithAgent injects system prompt from agent preset if AgentID is provided.
-func (b *chatBiz) buildMessagesWithAgent(ctx context.Context, req *aipkg.ChatRequest) error {
+// Returns the agent with the settings applied to the request, nil without an agent.
+func (b *chatBiz) buildMessagesWithAgent(ctx context.Context, req *aipkg.ChatRequest) (*model.AiAgentM, error) {
 	if req.AgentID == "" {
-		return nil
+		return nil, nil
 	}
 
 	// Get agent details
 	agent, err := b.ds.AiAgents().GetByAgentID(ctx, req.AgentID)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return errno.ErrAIRoleNotFound
+			return nil, errno.ErrAIRoleNotFound
 		}
 
-		return errno.ErrDBRead.WithMessage("get ai agent: %v", err)
+		return nil, errno.ErrDBRead.WithMessage("get ai agent: %v", err)
 	}
 
 	if agent.Status == model.AiAgentStatusDisabled {
-		return errno.ErrAIRoleDisabled
+		return nil, errno.ErrAIRoleDisabled
 	}
+	agent = b.applyExperiment(ctx, agent, req)
+	req.AgentVersion = agent.PublishedVersion
 
 	// Use agent model if request model is not specified or default
 	if req.Model == "" || req.Model == facade.Config.AI.DefaultModel {
@@ -779,14 +1142,28 @@ func (b *chatBiz) buildMessagesWithAgent(ctx context.Context, req *aipkg.ChatReq
 		hasSystem = true
 	}
 
+	// Offer the agent's server tools to the model
+	for _, name := range agent.Tools {
+		tool, ok := b.tools.Get(name)
+		if !ok {
+			log.C(ctx).Warnw("Unknown AI agent tool", "agent_id", agent.AgentID, "tool", name)
+
+			continue
+		}
+		if !slices.Contains(req.ServerTools, name) {
+			req.Tools = append(req.Tools, tool.Definition())
+			req.ServerTools = append(req.ServerTools, name)
+		}
+	}
+
 	if !hasSystem && agent.SystemPrompt != "" {
 		systemMsg := aipkg.Message{
 			Role:    aipkg.RoleSystem,
-			Content: agent.SystemPrompt,
+			Content: b.renderAgentPrompt(ctx, req.UID, agent.SystemPrompt),
 		}
 		// Prepend system message
 		req.Messages = append([]aipkg.Message{systemMsg}, req.Messages...)
 	}
 
-	return nil
+	return agent, nil
 }
@@ -0,0 +1,107 @@
+// ABOUTME: Multimodal content validation and image reference resolution.
+// ABOUTME: Inlines images uploaded through the file biz as data URLs for providers.
+
+package chat
+
+import (
+	"encoding/base64"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/bingo-project/bingo/internal/apiserver/biz/file"
+	"github.com/bingo-project/bingo/internal/pkg/errno"
+	aipkg "github.com/bingo-project/bingo/pkg/ai"
+)
+
+const (
+	// maxContentParts is the maximum number of content parts per message.
+	maxContentParts = 16
+
+	// maxImageBytes is the maximum size of an uploaded image used as input.
+	maxImageBytes = 10 << 20
+)
+
+// validateContentParts checks multimodal parts of the request messages.
+func validateContentParts(messages []aipkg.Message) error {
+	for _, msg := range messages {
+		if len(msg.Parts) == 0 {
+			continue
+		}
+		if msg.Role != aipkg.RoleUser {
+			return errno.ErrAIInvalidContent.WithMessage("content parts are only allowed in user messages")
+		}
+		if len(msg.Parts) > maxContentParts {
+			return errno.ErrAIInvalidContent.WithMessage("message has more than %d content parts", maxContentParts)
+		}
+		for _, p := range msg.Parts {
+			if err := p.Validate(); err != nil {
+				return errno.ErrAIInvalidContent.WithMessage("%v", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// resolveImageRefs returns a copy of messages with uploaded image references inlined as data URLs.
+// Remote http(s) and data URLs are passed through unchanged.
+func (b *chatBiz) resolveImageRefs(messages []aipkg.Message) ([]aipkg.Message, error) {
+	result := make([]aipkg.Message, len(messages))
+	for i, msg := range messages {
+		result[i] = msg
+		if len(msg.Parts) == 0 {
+			continue
+		}
+
+		parts := make([]aipkg.ContentPart, len(msg.Parts))
+		for j, p := range msg.Parts {
+			parts[j] = p
+			if p.Type != aipkg.ContentPartImageURL || p.ImageURL == nil || isInlineOrRemoteURL(p.ImageURL.URL) {
+				continue
+			}
+
+			dataURL, err := readUploadedImage(p.ImageURL.URL)
+			if err != nil {
+				return nil, err
+			}
+			parts[j].ImageURL = &aipkg.ImageURL{URL: dataURL, Detail: p.ImageURL.Detail}
+		}
+		result[i].Parts = parts
+	}
+
+	return result, nil
+}
+
+// isInlineOrRemoteURL reports whether the URL can be passed to providers as-is.
+func isInlineOrRemoteURL(url string) bool {
+	return strings.HasPrefix(url, "data:") || strings.HasPrefix(url, "http://") || strings.HasPrefix(url, "https://")
+}
+
+// readUploadedImage reads an uploaded image and encodes it as a base64 data URL.
+func readUploadedImage(ref string) (string, error) {
+	path, err := file.LocalPath(ref)
+	if err != nil {
+		return "", errno.ErrAIInvalidContent.WithMessage("%v", err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil || info.IsDir() {
+		return "", errno.ErrAIInvalidContent.WithMessage("image not found: %s", ref)
+	}
+	if info.Size() > maxImageBytes {
+		return "", errno.ErrAIInvalidContent.WithMessage("image exceeds %d bytes: %s", maxImageBytes, ref)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", errno.ErrAIInvalidContent.WithMessage("read image: %v", err)
+	}
+
+	mimeType := http.DetectContentType(data)
+	if !strings.HasPrefix(mimeType, "image/") {
+		return "", errno.ErrAIInvalidContent.WithMessage("not an image: %s", ref)
+	}
+
+	return "data:" + mimeType + ";base64," + base64.StdEncoding.EncodeToString(data), nil
+}
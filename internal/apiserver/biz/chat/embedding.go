@@ -0,0 +1,109 @@
+// ABOUTME: Embedding business logic.
+// ABOUTME: Routes embedding requests to providers with RPM and TPD quota accounting and usage recording.
+
+package chat
+
+import (
+	"context"
+	"time"
+	"unicode/utf8"
+
+	"github.com/bingo-project/bingo/internal/pkg/errno"
+	"github.com/bingo-project/bingo/internal/pkg/log"
+	"github.com/bingo-project/bingo/internal/pkg/model"
+	aipkg "github.com/bingo-project/bingo/pkg/ai"
+)
+
+const (
+	// maxEmbeddingInputs is the maximum number of inputs per embedding request.
+	maxEmbeddingInputs = 256
+
+	// maxEmbeddingInputChars is the maximum characters of a single embedding input.
+	maxEmbeddingInputChars = 32768
+)
+
+func (b *chatBiz) Embeddings(ctx context.Context, uid string, req *aipkg.EmbeddingRequest) (*aipkg.EmbeddingResponse, error) {
+	start := time.Now()
+	if len(req.Input) == 0 {
+		return nil, errno.ErrAIEmptyMessages.WithMessage("input cannot be empty")
+	}
+	if len(req.Input) > maxEmbeddingInputs {
+		return nil, errno.ErrAIMessageTooLong.WithMessage("input exceeds %d items", maxEmbeddingInputs)
+	}
+
+	// Estimate tokens for quota reservation
+	tok := aipkg.TokenizerFor(req.Model)
+	estimated := 0
+	for _, text := range req.Input {
+		if utf8.RuneCountInString(text) > maxEmbeddingInputChars {
+			return nil, errno.ErrAIMessageTooLong.WithMessage("input exceeds %d characters", maxEmbeddingInputChars)
+		}
+		estimated += tok.Count(text)
+	}
+
+	// Resolve embedding model and provider
+	m, err := b.ds.AiModel().FindActiveByModel(ctx, req.Model)
+	if err != nil {
+		return nil, errno.ErrAIModelNotFound
+	}
+	if m.Type != model.AiModelTypeEmbedding {
+		return nil, errno.ErrAIEmbeddingNotSupported.WithMessage("model %s is not an embedding model", req.Model)
+	}
+	embedder, ok := b.registry.GetEmbedder(m.ProviderName)
+	if !ok {
+		return nil, errno.ErrAIEmbeddingNotSupported.WithMessage("provider %s does not support embeddings", m.ProviderName)
+	}
+	breaker := b.getBreaker(m.ProviderName)
+	if !breaker.Allow(ctx) {
+		return nil, errno.ErrAIAllModelsFailed.WithMessage("provider circuit open")
+	}
+
+	// Check RPM limit before calling provider
+	if err := b.quota.CheckRPM(ctx, uid); err != nil {
+		return nil, err
+	}
+
+	// Reserve TPD quota atomically before calling provider
+	reservedTokens, err := b.quota.ReserveTPD(ctx, uid, estimated)
+	if err != nil {
+		return nil, err
+	}
+
+	// Embeddings go to the ledger like chat completions, their quota is settled by the reservation
+	entry := usageEntry{uid: uid, req: &aipkg.ChatRequest{Model: req.Model}, provider: m.ProviderName}
+
+	resp, err := embedder.Embed(ctx, req)
+	if err != nil {
+		breaker.RecordFailure(ctx, err)
+		RecordRequest(m.ProviderName, req.Model, false, time.Since(start).Seconds(), "error")
+		entry.latency, entry.err = time.Since(start), err
+		b.recordUsage(entry)
+
+		releaseCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := b.quota.AdjustTPD(releaseCtx, uid, 0, reservedTokens); err != nil {
+			log.C(releaseCtx).Errorw("Failed to release reserved quota",
+				"uid", uid, "reserved", reservedTokens, "err", err)
+		}
+
+		return nil, errno.ErrAIProviderError.WithMessage("embeddings failed: %v", err)
+	}
+
+	breaker.RecordSuccess(ctx)
+	RecordRequest(m.ProviderName, req.Model, false, time.Since(start).Seconds(), "success")
+	entry.latency, entry.usage = time.Since(start), resp.Usage
+	b.recordUsage(entry)
+
+	// Adjust TPD quota with actual usage (background)
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), saveSessionTimeout)
+		defer cancel()
+		if err := b.quota.AdjustTPD(ctx, uid, resp.Usage.TotalTokens, reservedTokens); err != nil {
+			log.C(ctx).Errorw("Failed to adjust TPD quota",
+				"uid", uid, "actual", resp.Usage.TotalTokens,
+				"reserved", reservedTokens, "err", err)
+		}
+	}()
+
+	return resp, nil
+}
@@ -0,0 +1,89 @@
+// ABOUTME: A/B experiment assignment for agent chat requests.
+// ABOUTME: Sticks each user to a weighted arm of the agent's running experiment and applies its settings.
+
+package chat
+
+import (
+	"context"
+	"errors"
+	"hash/fnv"
+	"strconv"
+
+	"gorm.io/gorm"
+
+	"github.com/bingo-project/bingo/internal/pkg/facade"
+	"github.com/bingo-project/bingo/internal/pkg/log"
+	"github.com/bingo-project/bingo/internal/pkg/model"
+	aipkg "github.com/bingo-project/bingo/pkg/ai"
+)
+
+// applyExperiment assigns the request to an arm of the agent's running experiment and returns
+// the agent with the arm's settings applied. Anonymous requests and requests choosing a model
+// themselves are left out of experiments.
+func (b *chatBiz) applyExperiment(ctx context.Context, agent *model.AiAgentM, req *aipkg.ChatRequest) *model.AiAgentM {
+	if req.UID == "" || (req.Model != "" && req.Model != facade.Config.AI.DefaultModel) {
+		return agent
+	}
+
+	exp, err := b.ds.AiExperiment().GetRunning(ctx, agent.AgentID)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			log.C(ctx).Warnw("Failed to load AI experiment", "agent_id", agent.AgentID, "err", err)
+		}
+
+		return agent
+	}
+
+	arm := assignArm(exp.ID, req.UID, exp.Arms)
+	if arm == nil {
+		return agent
+	}
+
+	// Never modify the stored agent, the arm only applies to this request
+	assigned := *agent
+	if arm.AgentVersion > 0 {
+		v, err := b.ds.AiAgentVersion().GetVersion(ctx, agent.AgentID, arm.AgentVersion)
+		if err != nil {
+			log.C(ctx).Warnw("Failed to load AI experiment arm version", "experiment_id", exp.ID,
+				"arm", arm.Key, "version", arm.AgentVersion, "err", err)
+
+			return agent
+		}
+		v.ApplyTo(&assigned)
+	}
+	if arm.Model != "" {
+		assigned.Model = arm.Model
+	}
+	if arm.Temperature > 0 {
+		assigned.Temperature = arm.Temperature
+	}
+
+	req.ExperimentID = exp.ID
+	req.ExperimentArm = arm.Key
+
+	return &assigned
+}
+
+// assignArm picks the arm of a user by hashing the experiment and user IDs, so a user keeps
+// the same arm for the whole experiment while users spread across arms by weight.
+func assignArm(experimentID uint64, uid string, arms []model.AiExperimentArm) *model.AiExperimentArm {
+	total := 0
+	for _, arm := range arms {
+		total += max(arm.Weight, 0)
+	}
+	if total == 0 {
+		return nil
+	}
+
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(strconv.FormatUint(experimentID, 10) + ":" + uid))
+	n := int(h.Sum32() % uint32(total))
+	for i := range arms {
+		n -= max(arms[i].Weight, 0)
+		if n < 0 {
+			return &arms[i]
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,50 @@
+// ABOUTME: Tests for A/B experiment arm assignment.
+// ABOUTME: Verifies sticky assignment per user and weighted distribution across arms.
+
+package chat
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bingo-project/bingo/internal/pkg/model"
+)
+
+func TestAssignArm(t *testing.T) {
+	arms := []model.AiExperimentArm{
+		{Key: "control", Weight: 3},
+		{Key: "treatment", Weight: 1},
+	}
+
+	t.Run("sticky per user", func(t *testing.T) {
+		first := assignArm(1, "user-1", arms)
+		require.NotNil(t, first)
+		for range 10 {
+			assert.Equal(t, first.Key, assignArm(1, "user-1", arms).Key)
+		}
+	})
+
+	t.Run("distributed by weight", func(t *testing.T) {
+		counts := map[string]int{}
+		for i := range 4000 {
+			counts[assignArm(1, fmt.Sprintf("user-%d", i), arms).Key]++
+		}
+		assert.InDelta(t, 3000, counts["control"], 200)
+		assert.InDelta(t, 1000, counts["treatment"], 200)
+	})
+
+	t.Run("zero weight never assigned", func(t *testing.T) {
+		arms := []model.AiExperimentArm{{Key: "a", Weight: 1}, {Key: "b", Weight: 0}}
+		for i := range 100 {
+			assert.Equal(t, "a", assignArm(2, fmt.Sprintf("user-%d", i), arms).Key)
+		}
+	})
+
+	t.Run("no weight", func(t *testing.T) {
+		assert.Nil(t, assignArm(1, "user-1", nil))
+		assert.Nil(t, assignArm(1, "user-1", []model.AiExperimentArm{{Key: "a"}}))
+	})
+}
@@ -0,0 +1,124 @@
+// ABOUTME: User feedback on assistant replies.
+// ABOUTME: Rates messages with thumbs up or down, optional reasons and a comment.
+
+package chat
+
+import (
+	"context"
+
+	"github.com/bingo-project/bingo/internal/pkg/errno"
+	"github.com/bingo-project/bingo/internal/pkg/model"
+	"github.com/bingo-project/bingo/internal/pkg/store"
+	v1 "github.com/bingo-project/bingo/pkg/api/apiserver/v1"
+	"github.com/bingo-project/bingo/pkg/store/where"
+)
+
+// FeedbackBiz defines feedback on assistant replies.
+type FeedbackBiz interface {
+	// Rate creates or replaces the user's feedback on an assistant message
+	Rate(ctx context.Context, uid string, sessionID string, messageID uint64, req *v1.RateMessageRequest) (*v1.FeedbackInfo, error)
+
+	// Delete withdraws the user's feedback on a message
+	Delete(ctx context.Context, uid string, sessionID string, messageID uint64) error
+
+	// List returns the user's feedback on the messages of a session
+	List(ctx context.Context, uid string, sessionID string) ([]v1.FeedbackInfo, error)
+}
+
+type feedbackBiz struct {
+	ds       store.IStore
+	sessions *sessionBiz
+}
+
+var _ FeedbackBiz = (*feedbackBiz)(nil)
+
+// NewFeedback creates a new FeedbackBiz instance
+func NewFeedback(ds store.IStore) *feedbackBiz {
+	return &feedbackBiz{ds: ds, sessions: NewSession(ds)}
+}
+
+func (b *feedbackBiz) Rate(ctx context.Context, uid string, sessionID string, messageID uint64, req *v1.RateMessageRequest) (*v1.FeedbackInfo, error) {
+	session, err := b.sessions.getOwned(ctx, uid, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	msg, err := getSessionMessage(ctx, b.ds, sessionID, messageID)
+	if err != nil {
+		return nil, err
+	}
+	if msg.Role != model.AiMessageRoleAssistant {
+		return nil, errno.ErrInvalidArgument.WithMessage("only assistant replies can be rated")
+	}
+
+	feedback := &model.AiFeedbackM{
+		MessageID:     messageID,
+		UID:           uid,
+		SessionID:     sessionID,
+		Rating:        req.Rating,
+		Reasons:       req.Reasons,
+		Comment:       req.Comment,
+		Model:         msg.Model,
+		AgentID:       session.AgentID,
+		ExperimentID:  msg.ExperimentID,
+		ExperimentArm: msg.ExperimentArm,
+	}
+	if err := b.ds.AiFeedback().Rate(ctx, feedback); err != nil {
+		return nil, errno.ErrDBWrite.WithMessage("save feedback: %v", err)
+	}
+
+	// Re-read for the stored timestamps, the upsert does not return them
+	saved, err := b.ds.AiFeedback().Get(ctx, where.F("message_id", messageID, "uid", uid))
+	if err != nil {
+		return nil, errno.ErrDBRead.WithMessage("get feedback: %v", err)
+	}
+
+	return toFeedbackInfo(saved), nil
+}
+
+func (b *feedbackBiz) Delete(ctx context.Context, uid string, sessionID string, messageID uint64) error {
+	if _, err := b.sessions.getOwned(ctx, uid, sessionID); err != nil {
+		return err
+	}
+
+	if err := b.ds.AiFeedback().Delete(ctx, where.F("message_id", messageID, "uid", uid, "session_id", sessionID)); err != nil {
+		return errno.ErrDBWrite.WithMessage("delete feedback: %v", err)
+	}
+
+	return nil
+}
+
+func (b *feedbackBiz) List(ctx context.Context, uid string, sessionID string) ([]v1.FeedbackInfo, error) {
+	if _, err := b.sessions.getOwned(ctx, uid, sessionID); err != nil {
+		return nil, err
+	}
+
+	_, rows, err := b.ds.AiFeedback().List(ctx, where.F("session_id", sessionID, "uid", uid))
+	if err != nil {
+		return nil, errno.ErrDBRead.WithMessage("list feedback: %v", err)
+	}
+
+	result := make([]v1.FeedbackInfo, len(rows))
+	for i, row := range rows {
+		result[i] = *toFeedbackInfo(row)
+	}
+
+	return result, nil
+}
+
+// toFeedbackInfo converts model.AiFeedbackM to v1.FeedbackInfo
+func toFeedbackInfo(m *model.AiFeedbackM) *v1.FeedbackInfo {
+	reasons := []string(m.Reasons)
+	if reasons == nil {
+		reasons = []string{}
+	}
+
+	return &v1.FeedbackInfo{
+		MessageID: m.MessageID,
+		SessionID: m.SessionID,
+		Rating:    m.Rating,
+		Reasons:   reasons,
+		Comment:   m.Comment,
+		UpdatedAt: m.UpdatedAt,
+	}
+}
@@ -0,0 +1,172 @@
+// ABOUTME: In-flight generation tracking and cancellation.
+// ABOUTME: Cancels running streams locally or on the owning instance via Redis Pub/Sub.
+
+package chat
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/bingo-project/bingo/internal/pkg/errno"
+	"github.com/bingo-project/bingo/internal/pkg/facade"
+	"github.com/bingo-project/bingo/internal/pkg/log"
+	aipkg "github.com/bingo-project/bingo/pkg/ai"
+	"github.com/bingo-project/bingo/pkg/contextx"
+)
+
+const (
+	// GenerationCancelChannel is the Redis Pub/Sub channel for cancelling generations on other instances.
+	GenerationCancelChannel = "ai:cancel:generations"
+
+	// generationTTL bounds how long a generation's owner stays registered in Redis.
+	generationTTL = time.Hour
+)
+
+// generations tracks the streams running on this instance.
+var generations = &generationRegistry{running: make(map[string]*generation)}
+
+// GenerationBiz defines in-flight generation management.
+type GenerationBiz interface {
+	// Cancel stops a running generation of the user
+	Cancel(ctx context.Context, uid string, id string) error
+
+	// Resume replays a generation's stream after the given sequence number and follows it to the end
+	Resume(ctx context.Context, uid string, id string, after int) (*aipkg.ChatStream, error)
+}
+
+type generationBiz struct{}
+
+var _ GenerationBiz = (*generationBiz)(nil)
+
+// NewGeneration creates a new GenerationBiz instance
+func NewGeneration() *generationBiz {
+	return &generationBiz{}
+}
+
+func (b *generationBiz) Cancel(ctx context.Context, uid string, id string) error {
+	return generations.cancel(ctx, uid, id)
+}
+
+func (b *generationBiz) Resume(ctx context.Context, uid string, id string, after int) (*aipkg.ChatStream, error) {
+	return resumeStream(ctx, uid, id, after)
+}
+
+// generation is a running stream that can be cancelled by its owner.
+type generation struct {
+	id           string
+	uid          string
+	promptTokens int    // Estimated prompt tokens, settles quota when no usage was reported
+	lang         string // Language of the request, for work done after the stream ends
+	cancel       context.CancelFunc
+	cancelled    atomic.Bool
+}
+
+// stop cancels the provider context and marks the generation as cancelled by the client.
+func (g *generation) stop() {
+	g.cancelled.Store(true)
+	g.cancel()
+}
+
+type generationRegistry struct {
+	mu        sync.Mutex
+	running   map[string]*generation
+	subscribe sync.Once
+}
+
+// start registers a generation and returns the context its provider calls must use.
+// The context outlives the request so a briefly disconnected client can resume the stream.
+func (r *generationRegistry) start(ctx context.Context, uid string, promptTokens int) (context.Context, *generation) {
+	ctx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	g := &generation{id: aipkg.GenerateID(), uid: uid, promptTokens: promptTokens, lang: contextx.Lang(ctx), cancel: cancel}
+
+	r.mu.Lock()
+	r.running[g.id] = g
+	r.mu.Unlock()
+
+	if facade.Redis != nil {
+		r.subscribe.Do(func() { go r.listen() })
+		if err := facade.Redis.Set(ctx, generationKey(g.id), uid, generationTTL).Err(); err != nil {
+			log.C(ctx).Warnw("Failed to register AI generation", "id", g.id, "uid", uid, "err", err)
+		}
+	}
+
+	return ctx, g
+}
+
+// finish unregisters a generation and releases its context.
+func (r *generationRegistry) finish(g *generation) {
+	g.cancel()
+
+	r.mu.Lock()
+	delete(r.running, g.id)
+	r.mu.Unlock()
+
+	if facade.Redis != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := facade.Redis.Del(ctx, generationKey(g.id)).Err(); err != nil {
+			log.C(ctx).Warnw("Failed to unregister AI generation", "id", g.id, "err", err)
+		}
+	}
+}
+
+// cancel stops a generation owned by uid, forwarding to other instances when it runs elsewhere.
+func (r *generationRegistry) cancel(ctx context.Context, uid string, id string) error {
+	r.mu.Lock()
+	g, ok := r.running[id]
+	r.mu.Unlock()
+	if ok {
+		if g.uid != uid {
+			return errno.ErrAIGenerationNotFound // Don't reveal generations of other users
+		}
+		g.stop()
+
+		return nil
+	}
+
+	if facade.Redis == nil {
+		return errno.ErrAIGenerationNotFound
+	}
+
+	owner, err := facade.Redis.Get(ctx, generationKey(id)).Result()
+	if errors.Is(err, redis.Nil) || (err == nil && owner != uid) {
+		return errno.ErrAIGenerationNotFound
+	}
+	if err != nil {
+		return errno.ErrOperationFailed.WithMessage("redis error: %v", err)
+	}
+
+	if err := facade.Redis.Publish(ctx, GenerationCancelChannel, id).Err(); err != nil {
+		return errno.ErrOperationFailed.WithMessage("publish cancel: %v", err)
+	}
+
+	return nil
+}
+
+// listen cancels local generations requested through other instances.
+func (r *generationRegistry) listen() {
+	pubsub := facade.Redis.Subscribe(context.Background(), GenerationCancelChannel)
+	defer pubsub.Close()
+
+	for msg := range pubsub.Channel() {
+		r.mu.Lock()
+		g, ok := r.running[msg.Payload]
+		r.mu.Unlock()
+		if ok {
+			g.stop()
+		}
+	}
+
+	log.Warnw("AI generation cancel subscriber channel closed")
+}
+
+// generationKey builds the Redis key holding a generation's owner.
+func generationKey(id string) string {
+	return fmt.Sprintf("%s:ai:generation:%s", facade.Config.App.Name, id)
+}
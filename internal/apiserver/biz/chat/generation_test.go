@@ -0,0 +1,70 @@
+// ABOUTME: Tests for in-flight generation cancellation.
+// ABOUTME: Cancels a fake provider stream and checks the partial reply and settled usage.
+
+package chat
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bingo-project/bingo/internal/pkg/errno"
+	"github.com/bingo-project/bingo/internal/pkg/model"
+	mockstore "github.com/bingo-project/bingo/internal/pkg/testing/mock/store"
+	aipkg "github.com/bingo-project/bingo/pkg/ai"
+	"github.com/bingo-project/bingo/pkg/ai/providers/fake"
+)
+
+func TestWrapStreamForSaving_Cancel(t *testing.T) {
+	ds := mockstore.NewStore()
+	b := &chatBiz{ds: ds, quota: newQuotaChecker(ds)}
+
+	provider := newFakeProvider("fake", fake.Step{
+		Chunks:     strings.Split(strings.Repeat("word ", 100), " "),
+		ChunkDelay: 10 * time.Millisecond,
+	})
+	req := &aipkg.ChatRequest{
+		Model:    "fake-chat",
+		Messages: []aipkg.Message{{Role: aipkg.RoleUser, Content: "tell me a long story"}},
+	}
+
+	ctx, gen := generations.start(context.Background(), "u1", 12)
+	stream, err := provider.ChatStream(ctx, req)
+	require.NoError(t, err)
+	wrapped := b.wrapStreamForSaving(stream, gen, "u1", req, nil, nil, 0, req.Messages, &toolTrace{}, 0, "fake", false, time.Now())
+
+	first, err := wrapped.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, gen.id, first.ID)
+
+	assert.ErrorIs(t, generations.cancel(ctx, "u2", gen.id), errno.ErrAIGenerationNotFound)
+	require.NoError(t, generations.cancel(ctx, "u1", gen.id))
+
+	var last *aipkg.StreamChunk
+	for {
+		chunk, err := wrapped.Recv()
+		if err != nil {
+			assert.ErrorIs(t, err, aipkg.ErrStreamClosed)
+
+			break
+		}
+		last = chunk
+	}
+	require.NotNil(t, last)
+	assert.Equal(t, aipkg.FinishReasonCancelled, last.Choices[0].FinishReason)
+	require.NotNil(t, last.Usage)
+	assert.Equal(t, 12, last.Usage.PromptTokens)
+	assert.Positive(t, last.Usage.CompletionTokens)
+
+	// The ledger records the cancelled generation and it can no longer be cancelled
+	assert.Eventually(t, func() bool {
+		_, rows, _ := ds.AiUsage().List(ctx, nil)
+
+		return len(rows) == 1 && rows[0].Status == model.AiUsageStatusCancelled
+	}, time.Second, 10*time.Millisecond)
+	assert.ErrorIs(t, generations.cancel(context.Background(), "u1", gen.id), errno.ErrAIGenerationNotFound)
+}
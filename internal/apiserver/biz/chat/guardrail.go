@@ -0,0 +1,549 @@
+// ABOUTME: Guardrails pipeline for chat input and output.
+// ABOUTME: Applies an agent's blocklist, PII, prompt-injection and moderation rules and records matches for review.
+
+package chat
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/bingo-project/bingo/internal/pkg/ai"
+	"github.com/bingo-project/bingo/internal/pkg/errno"
+	"github.com/bingo-project/bingo/internal/pkg/log"
+	"github.com/bingo-project/bingo/internal/pkg/model"
+	aipkg "github.com/bingo-project/bingo/pkg/ai"
+	"github.com/bingo-project/bingo/pkg/contextx"
+)
+
+const (
+	// moderationTimeout is the timeout for moderating a streamed answer after it ended.
+	moderationTimeout = 30 * time.Second
+
+	// moderationMaxTokens is the maximum completion tokens of a moderation verdict.
+	moderationMaxTokens = 256
+
+	// streamGuardMaxRunes is the most streamed text held back waiting for a sentence end.
+	streamGuardMaxRunes = 256
+
+	// guardExcerptContext is the number of bytes around a match kept in an event excerpt.
+	guardExcerptContext = 200
+
+	// maxGuardExcerptChars is the maximum length of a stored event excerpt.
+	maxGuardExcerptChars = 500
+
+	// maxGuardRuleChars is the maximum length of a stored event rule.
+	maxGuardRuleChars = 255
+)
+
+// moderationPrompt instructs the moderation model.
+const moderationPrompt = "You are a content moderator. Decide whether the text below violates content policy: " +
+	"violence, self-harm, sexual content, hate, harassment, illegal activity or dangerous medical advice. " +
+	`Reply with JSON only, in the form {"flagged": true, "categories": ["self-harm"]}, with an empty list when not flagged.`
+
+// excerptPII masks personal data in event excerpts.
+var excerptPII, _ = aipkg.NewPIIGuard(nil)
+
+// guardrail is an agent rule with its guard, nil for moderation rules.
+type guardrail struct {
+	model.AiGuardrailRule
+	guard aipkg.Guard
+}
+
+// guardrailPipeline checks the texts of a request against the agent's rules.
+type guardrailPipeline struct {
+	uid       string
+	sessionID string
+	agentID   string
+	model     string
+	rules     []guardrail
+}
+
+// guardrailCheck is the outcome of checking a text.
+type guardrailCheck struct {
+	text    string // Text with redactions applied
+	blocked bool
+	events  []*model.AiGuardrailEventM
+}
+
+// moderationVerdict is the reply of a moderation model.
+type moderationVerdict struct {
+	Flagged    bool     `json:"flagged"`
+	Categories []string `json:"categories"`
+}
+
+// newGuardrailPipeline builds the pipeline of an agent, nil without rules.
+// Invalid rules are skipped, the admin API rejects them on save.
+func newGuardrailPipeline(ctx context.Context, agent *model.AiAgentM, uid string, req *aipkg.ChatRequest) *guardrailPipeline {
+	if agent == nil || len(agent.Guardrails) == 0 {
+		return nil
+	}
+
+	p := &guardrailPipeline{uid: uid, sessionID: req.SessionID, agentID: agent.AgentID, model: req.Model}
+	for _, rule := range agent.Guardrails {
+		guard, err := ai.NewGuardrail(rule)
+		if err != nil {
+			log.C(ctx).Warnw("Invalid AI agent guardrail", "agent_id", agent.AgentID, "type", rule.Type, "err", err)
+
+			continue
+		}
+		p.rules = append(p.rules, guardrail{AiGuardrailRule: rule, guard: guard})
+	}
+
+	return p
+}
+
+// checks reports whether local guards check the stage.
+func (p *guardrailPipeline) checks(stage string) bool {
+	return p != nil && slices.ContainsFunc(p.rules, func(r guardrail) bool { return r.guard != nil && r.AppliesTo(stage) })
+}
+
+// moderates reports whether moderation models check the stage.
+func (p *guardrailPipeline) moderates(stage string) bool {
+	return p != nil && slices.ContainsFunc(p.rules, func(r guardrail) bool { return r.guard == nil && r.AppliesTo(stage) })
+}
+
+// check runs the local guards of stage on text.
+func (p *guardrailPipeline) check(stage, text string) guardrailCheck {
+	ret := guardrailCheck{text: text}
+	if p == nil || strings.TrimSpace(text) == "" {
+		return ret
+	}
+
+	var redact []aipkg.GuardFinding
+	for _, r := range p.rules {
+		if r.guard == nil || !r.AppliesTo(stage) {
+			continue
+		}
+
+		findings := r.guard.Check(text)
+		for _, f := range findings {
+			ret.events = append(ret.events, p.event(stage, r.Action, f.Guard, f.Rule, guardExcerpt(text, f.Start, f.End)))
+		}
+		if len(findings) == 0 {
+			continue
+		}
+
+		switch r.Action {
+		case aipkg.GuardActionBlock:
+			ret.blocked = true
+		case aipkg.GuardActionRedact:
+			redact = append(redact, findings...)
+		}
+	}
+	ret.text = aipkg.Redact(text, redact)
+
+	return ret
+}
+
+// event creates a pending review event of a match.
+func (p *guardrailPipeline) event(stage, action, guard, rule, excerpt string) *model.AiGuardrailEventM {
+	return &model.AiGuardrailEventM{
+		UID:       p.uid,
+		SessionID: p.sessionID,
+		AgentID:   p.agentID,
+		Model:     p.model,
+		Stage:     stage,
+		Guard:     guard,
+		Rule:      truncateRunes(rule, maxGuardRuleChars),
+		Action:    action,
+		Excerpt:   excerpt,
+		Status:    model.AiGuardrailEventStatusPending,
+	}
+}
+
+// guardExcerpt returns the text around text[start:end] with personal data masked.
+func guardExcerpt(text string, start, end int) string {
+	start = max(start-guardExcerptContext, 0)
+	end = min(end+guardExcerptContext, len(text))
+	for start > 0 && !utf8.RuneStart(text[start]) {
+		start--
+	}
+	for end < len(text) && !utf8.RuneStart(text[end]) {
+		end++
+	}
+
+	s := text[start:end]
+
+	return truncateRunes(aipkg.Redact(s, excerptPII.Check(s)), maxGuardExcerptChars)
+}
+
+// checkGuardrails runs the local guards and moderation models of stage on text.
+func (b *chatBiz) checkGuardrails(ctx context.Context, p *guardrailPipeline, stage, text string) guardrailCheck {
+	ret := p.check(stage, text)
+	b.moderateGuardrails(ctx, p, stage, &ret)
+
+	return ret
+}
+
+// moderateGuardrails runs the moderation models of stage on the checked text. A failing
+// moderation model lets the text pass, so an outage does not stop the agent.
+func (b *chatBiz) moderateGuardrails(ctx context.Context, p *guardrailPipeline, stage string, c *guardrailCheck) {
+	if p == nil || strings.TrimSpace(c.text) == "" {
+		return
+	}
+
+	for _, r := range p.rules {
+		if r.guard != nil || !r.AppliesTo(stage) || c.blocked {
+			continue
+		}
+
+		verdict, err := b.moderate(ctx, p, r.Model, c.text)
+		if err != nil {
+			log.C(ctx).Warnw("AI moderation failed", "agent_id", p.agentID, "model", r.Model, "stage", stage, "err", err)
+
+			continue
+		}
+		if !verdict.Flagged {
+			continue
+		}
+
+		c.events = append(c.events, p.event(stage, r.Action, aipkg.GuardModeration, strings.Join(verdict.Categories, ","), guardExcerpt(c.text, 0, len(c.text))))
+		if r.Action == aipkg.GuardActionBlock {
+			c.blocked = true
+		}
+	}
+}
+
+// moderate asks a moderation model whether text violates content policy. The call is
+// recorded in the usage ledger and charged to the user of the checked request.
+func (b *chatBiz) moderate(ctx context.Context, p *guardrailPipeline, moderationModel, text string) (*moderationVerdict, error) {
+	m, err := b.ds.AiModel().FindActiveByModel(ctx, moderationModel)
+	if err != nil {
+		return nil, fmt.Errorf("find moderation model: %w", err)
+	}
+	provider, ok := b.registry.Get(m.ProviderName)
+	if !ok {
+		return nil, fmt.Errorf("provider %s not registered", m.ProviderName)
+	}
+	breaker := b.getBreaker(m.ProviderName)
+	if !breaker.Allow(ctx) {
+		return nil, fmt.Errorf("provider %s circuit open", m.ProviderName)
+	}
+
+	req := &aipkg.ChatRequest{
+		Model: moderationModel,
+		Messages: []aipkg.Message{
+			{Role: aipkg.RoleSystem, Content: moderationPrompt},
+			{Role: aipkg.RoleUser, Content: truncateRunes(text, maxMessageChars)},
+		},
+		MaxTokens: moderationMaxTokens,
+		SessionID: p.sessionID,
+		AgentID:   p.agentID,
+	}
+	start := time.Now()
+	resp, err := provider.Chat(ctx, req)
+	if err != nil {
+		breaker.RecordFailure(ctx, err)
+		b.recordSideUsage(ctx, p.uid, req, m.ProviderName, aipkg.Usage{}, time.Since(start), err)
+
+		return nil, err
+	}
+	breaker.RecordSuccess(ctx)
+	b.recordSideUsage(ctx, p.uid, req, m.ProviderName, resp.Usage, time.Since(start), nil)
+
+	if len(resp.Choices) == 0 {
+		return nil, fmt.Errorf("empty moderation verdict")
+	}
+	raw, err := aipkg.ParseStructured(resp.Choices[0].Message.Content, &aipkg.ResponseFormat{Type: aipkg.ResponseFormatJSONObject})
+	if err != nil {
+		return nil, fmt.Errorf("parse moderation verdict: %w", err)
+	}
+
+	var verdict moderationVerdict
+	if err := json.Unmarshal([]byte(raw), &verdict); err != nil {
+		return nil, fmt.Errorf("parse moderation verdict: %w", err)
+	}
+
+	return &verdict, nil
+}
+
+// guardInput checks the new user messages of req, redacting them before they reach the
+// model and the session. Returns ErrAIGuardrailBlocked when a block rule matches.
+func (b *chatBiz) guardInput(ctx context.Context, p *guardrailPipeline, req *aipkg.ChatRequest) error {
+	if p == nil {
+		return nil
+	}
+
+	stage := model.AiGuardrailStageInput
+	messages := slices.Clone(req.Messages)
+	var events []*model.AiGuardrailEventM
+	blocked := false
+	for i, m := range messages {
+		if m.Role != aipkg.RoleUser {
+			continue
+		}
+
+		c := b.checkGuardrails(ctx, p, stage, m.Content)
+		messages[i].Content = c.text
+		events = append(events, c.events...)
+		blocked = blocked || c.blocked
+
+		// Parts repeat the text of Content, only redact them
+		if len(m.Parts) > 0 {
+			parts := slices.Clone(m.Parts)
+			for j := range parts {
+				if parts[j].Type == aipkg.ContentPartText {
+					parts[j].Text = p.check(stage, parts[j].Text).text
+				}
+			}
+			messages[i].Parts = parts
+		}
+	}
+	b.recordGuardrailEvents(ctx, events)
+
+	if blocked {
+		log.C(ctx).Infow("AI request blocked by guardrail", "uid", p.uid, "agent_id", p.agentID)
+
+		return errno.ErrAIGuardrailBlocked
+	}
+	req.Messages = messages
+
+	return nil
+}
+
+// guardHistory checks the stored user messages before historyEnd, which may never have
+// passed the agent's input guardrails: imported transcripts and turns sent before the
+// session used the agent. Redactions apply to the prompt only, stored messages are kept.
+// Local guards run per message and moderation models once on the joined text. Only a
+// block is recorded as an event, so flagged history is not reported again every turn.
+func (b *chatBiz) guardHistory(ctx context.Context, p *guardrailPipeline, messages []aipkg.Message, historyEnd int) ([]aipkg.Message, error) {
+	if p == nil || historyEnd == 0 {
+		return messages, nil
+	}
+
+	stage := model.AiGuardrailStageInput
+	messages = slices.Clone(messages)
+	var texts []string
+	var events []*model.AiGuardrailEventM
+	blocked := false
+	for i, m := range messages[:historyEnd] {
+		if m.Role != aipkg.RoleUser {
+			continue
+		}
+
+		c := p.check(stage, m.Content)
+		messages[i].Content = c.text
+		texts = append(texts, c.text)
+		events = append(events, c.events...)
+		blocked = blocked || c.blocked
+		if len(m.Parts) > 0 {
+			parts := slices.Clone(m.Parts)
+			for j := range parts {
+				if parts[j].Type == aipkg.ContentPartText {
+					parts[j].Text = p.check(stage, parts[j].Text).text
+				}
+			}
+			messages[i].Parts = parts
+		}
+	}
+
+	c := guardrailCheck{text: strings.Join(texts, "\n\n"), blocked: blocked, events: events}
+	b.moderateGuardrails(ctx, p, stage, &c)
+	if !c.blocked {
+		return messages, nil
+	}
+
+	b.recordGuardrailEvents(ctx, slices.DeleteFunc(c.events, func(e *model.AiGuardrailEventM) bool { return e.Action != aipkg.GuardActionBlock }))
+	log.C(ctx).Infow("AI request blocked by guardrail on session history", "uid", p.uid, "agent_id", p.agentID, "session_id", p.sessionID)
+
+	return nil, errno.ErrAIGuardrailBlocked
+}
+
+// guardOutput checks a reply, redacting it in place. A blocked reply is emptied and
+// finishes with content_filter.
+func (b *chatBiz) guardOutput(ctx context.Context, p *guardrailPipeline, resp *aipkg.ChatResponse) {
+	if p == nil || len(resp.Choices) == 0 {
+		return
+	}
+
+	choice := &resp.Choices[0]
+	c := b.checkGuardrails(ctx, p, model.AiGuardrailStageOutput, choice.Message.Content)
+	b.recordGuardrailEvents(ctx, c.events)
+
+	if c.blocked {
+		log.C(ctx).Infow("AI reply blocked by guardrail", "uid", p.uid, "agent_id", p.agentID, "model", resp.Model)
+		choice.Message.Content = ""
+		choice.Message.ToolCalls = nil
+		choice.FinishReason = aipkg.FinishReasonContentFilter
+
+		return
+	}
+	choice.Message.Content = c.text
+}
+
+// moderateStreamOutput runs the output moderation models on a streamed answer once it ended.
+// The answer was already sent, so matches are recorded only; a blocked answer is not cached.
+func (b *chatBiz) moderateStreamOutput(lang string, p *guardrailPipeline, content string, cache *responseCache, modelName string) {
+	if !p.moderates(model.AiGuardrailStageOutput) {
+		b.cachePut(cache, content, modelName)
+
+		return
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(contextx.WithLang(context.Background(), lang), moderationTimeout)
+		defer cancel()
+
+		c := guardrailCheck{text: content}
+		b.moderateGuardrails(ctx, p, model.AiGuardrailStageOutput, &c)
+		b.recordGuardrailEvents(ctx, c.events)
+		if !c.blocked {
+			b.cachePut(cache, content, modelName)
+		}
+	}()
+}
+
+// recordGuardrailEvents saves guardrail matches for review in the background.
+func (b *chatBiz) recordGuardrailEvents(ctx context.Context, events []*model.AiGuardrailEventM) {
+	if len(events) == 0 {
+		return
+	}
+	for _, e := range events {
+		RecordGuardrailEvent(e.Stage, e.Guard, e.Action)
+	}
+
+	lang := contextx.Lang(ctx)
+	go func() {
+		ctx, cancel := context.WithTimeout(contextx.WithLang(context.Background(), lang), saveSessionTimeout)
+		defer cancel()
+
+		if err := b.ds.AiGuardrailEvent().CreateInBatch(ctx, events, len(events)); err != nil {
+			log.C(ctx).Errorw("Failed to record AI guardrail events", "agent_id", events[0].AgentID, "count", len(events), "err", err)
+		}
+	}()
+}
+
+// streamGuard checks streamed output incrementally. Text is held back until a sentence
+// ends, then the sentence is checked and released, redacted if needed.
+type streamGuard struct {
+	p         *guardrailPipeline
+	pending   string
+	generated strings.Builder // All streamed text, released or not
+	events    []*model.AiGuardrailEventM
+}
+
+// newStreamGuard creates a stream guard, nil if no local guard checks output.
+func newStreamGuard(p *guardrailPipeline) *streamGuard {
+	if !p.checks(model.AiGuardrailStageOutput) {
+		return nil
+	}
+
+	return &streamGuard{p: p}
+}
+
+// filter replaces the content of a chunk with the text released by the guards. held reports
+// a chunk left with nothing to send, blocked a match of a block rule.
+func (g *streamGuard) filter(chunk *aipkg.StreamChunk) (held bool, blocked bool) {
+	if g == nil || len(chunk.Choices) == 0 {
+		return false, false
+	}
+
+	choice := &chunk.Choices[0]
+	var text string
+	if choice.Delta != nil {
+		text = choice.Delta.Content
+	}
+	g.generated.WriteString(text)
+	g.pending += text
+
+	// The last chunk releases everything
+	var out string
+	if choice.FinishReason != "" {
+		out, blocked = g.flush()
+	} else {
+		out, blocked = g.release()
+	}
+	if blocked {
+		return false, true
+	}
+
+	if choice.Delta == nil {
+		if out == "" {
+			return false, false
+		}
+		choice.Delta = &aipkg.Message{Role: aipkg.RoleAssistant}
+	}
+	choice.Delta.Content = out
+	held = text != "" && out == "" && len(choice.Delta.ToolCalls) == 0 && choice.FinishReason == "" && chunk.Usage == nil
+
+	return held, false
+}
+
+// release checks and returns the pending text up to the last sentence end, or up to the
+// last space once streamGuardMaxRunes are pending.
+func (g *streamGuard) release() (string, bool) {
+	cut := sentenceCut(g.pending)
+	if cut == 0 {
+		if utf8.RuneCountInString(g.pending) < streamGuardMaxRunes {
+			return "", false
+		}
+		cut = len(g.pending)
+		if i := strings.LastIndexAny(g.pending, " \t"); i > 0 {
+			cut = i + 1
+		}
+	}
+
+	segment := g.pending[:cut]
+	g.pending = g.pending[cut:]
+
+	return g.check(segment)
+}
+
+// flush checks and returns all pending text.
+func (g *streamGuard) flush() (string, bool) {
+	if g == nil {
+		return "", false
+	}
+
+	segment := g.pending
+	g.pending = ""
+
+	return g.check(segment)
+}
+
+func (g *streamGuard) check(segment string) (string, bool) {
+	if segment == "" {
+		return "", false
+	}
+
+	c := g.p.check(model.AiGuardrailStageOutput, segment)
+	g.events = append(g.events, c.events...)
+	if c.blocked {
+		return "", true
+	}
+
+	return c.text, false
+}
+
+// sentenceCut returns the byte offset after the last sentence end in s, 0 if none.
+// Western punctuation only ends a sentence before a space, so e-mail addresses and
+// decimals are not cut.
+func sentenceCut(s string) int {
+	cut := 0
+	for i, r := range s {
+		switch r {
+		case '\n', '。', '！', '？', '；':
+			cut = i + utf8.RuneLen(r)
+		case '.', '!', '?', ';':
+			if i+1 < len(s) && (s[i+1] == ' ' || s[i+1] == '\n') {
+				cut = i + 1
+			}
+		}
+	}
+
+	return cut
+}
+
+// drainStream discards the rest of a stream, so its producer does not block on a full buffer.
+func drainStream(stream *aipkg.ChatStream) {
+	for {
+		if _, err := stream.Recv(); err != nil {
+			return
+		}
+	}
+}
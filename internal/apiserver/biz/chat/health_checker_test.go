@@ -0,0 +1,44 @@
+// ABOUTME: Tests for the provider health checker.
+// ABOUTME: Drives health checks against fake providers with injected failures.
+
+package chat
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	aipkg "github.com/bingo-project/bingo/pkg/ai"
+	"github.com/bingo-project/bingo/pkg/ai/providers/fake"
+)
+
+func newFakeProvider(name string, steps ...fake.Step) *fake.Provider {
+	cfg := fake.DefaultConfig()
+	cfg.Name = name
+
+	return fake.New(cfg).Enqueue(steps...)
+}
+
+func TestHealthChecker_CheckAll(t *testing.T) {
+	registry := aipkg.NewRegistry()
+	registry.Register(newFakeProvider("up"))
+	registry.Register(newFakeProvider("down", fake.Fail(fake.ErrUnavailable)))
+	registry.Register(newFakeProvider("unknown-model", fake.Fail(&fake.StatusError{StatusCode: http.StatusNotFound, Message: "model not found"})))
+
+	h := NewHealthChecker(registry)
+	h.checkAll(context.Background())
+
+	assert.Equal(t, HealthStatusHealthy, h.GetProviderHealth("up").Status)
+	assert.Equal(t, HealthStatusHealthy, h.GetProviderHealth("unknown-model").Status)
+
+	down := h.GetProviderHealth("down")
+	assert.Equal(t, HealthStatusUnhealthy, down.Status)
+	assert.ErrorIs(t, down.LastError, fake.ErrUnavailable)
+	assert.Equal(t, HealthStatusUnknown, h.GetProviderHealth("missing").Status)
+
+	// The provider recovers once the injected failure is consumed
+	h.checkAll(context.Background())
+	assert.Equal(t, HealthStatusHealthy, h.GetProviderHealth("down").Status)
+}
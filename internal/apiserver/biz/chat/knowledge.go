@@ -0,0 +1,98 @@
+// ABOUTME: Knowledge base retrieval for agent chats.
+// ABOUTME: Injects the agent's most relevant document chunks with citations.
+
+package chat
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/bingo-project/bingo/internal/pkg/ai"
+	"github.com/bingo-project/bingo/internal/pkg/log"
+	"github.com/bingo-project/bingo/internal/pkg/model"
+	aipkg "github.com/bingo-project/bingo/pkg/ai"
+)
+
+// knowledgePrompt introduces retrieved chunks to the model.
+const knowledgePrompt = "Use the following excerpts from the knowledge base when they are relevant to the question. " +
+	"Cite the excerpts you use as [n]. If they do not contain the answer, say so instead of guessing."
+
+// injectKnowledge adds the knowledge base chunks relevant to the last user message to the
+// leading system message of req. It runs after the input guardrails, so only redacted text
+// is sent to the embedding provider.
+func (b *chatBiz) injectKnowledge(ctx context.Context, agent *model.AiAgentM, req *aipkg.ChatRequest) {
+	if agent == nil {
+		return
+	}
+
+	knowledge := b.retrieveKnowledge(ctx, agent, req)
+	if knowledge == "" {
+		return
+	}
+
+	if len(req.Messages) > 0 && req.Messages[0].Role == aipkg.RoleSystem {
+		messages := slices.Clone(req.Messages)
+		messages[0].Content = strings.TrimSpace(messages[0].Content + "\n\n" + knowledge)
+		req.Messages = messages
+
+		return
+	}
+	req.Messages = append([]aipkg.Message{{Role: aipkg.RoleSystem, Content: knowledge}}, req.Messages...)
+}
+
+// retrieveKnowledge searches the agent's knowledge base with the last user message.
+// Returns an empty string if the agent has no knowledge base or nothing relevant is found.
+// Retrieval failures are logged and do not fail the chat.
+func (b *chatBiz) retrieveKnowledge(ctx context.Context, agent *model.AiAgentM, req *aipkg.ChatRequest) string {
+	if agent.EmbeddingModel == "" {
+		return ""
+	}
+
+	query := lastUserText(req.Messages)
+	if strings.TrimSpace(query) == "" {
+		return ""
+	}
+
+	hits, call, err := b.knowledge.Search(ctx, agent.AgentID, agent.EmbeddingModel, query, ai.DefaultKnowledgeTopK)
+	b.recordEmbedUsage(ctx, req, call, err)
+	if err != nil {
+		log.C(ctx).Warnw("AI knowledge retrieval failed", "agent_id", agent.AgentID, "err", err)
+
+		return ""
+	}
+
+	return formatKnowledge(hits)
+}
+
+// lastUserText returns the text of the last user message.
+func lastUserText(messages []aipkg.Message) string {
+	for i := len(messages) - 1; i >= 0; i-- {
+		if messages[i].Role != aipkg.RoleUser {
+			continue
+		}
+		if len(messages[i].Parts) > 0 {
+			return aipkg.TextOf(messages[i].Parts)
+		}
+
+		return messages[i].Content
+	}
+
+	return ""
+}
+
+// formatKnowledge renders retrieved chunks as numbered citations.
+func formatKnowledge(hits []ai.KnowledgeHit) string {
+	if len(hits) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString(knowledgePrompt)
+	for i, h := range hits {
+		fmt.Fprintf(&sb, "\n\n[%d] %s\n%s", i+1, h.Title, h.Content)
+	}
+
+	return sb.String()
+}
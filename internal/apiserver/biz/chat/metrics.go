@@ -1,11 +1,14 @@
 // ABOUTME: AI chat business metrics for monitoring and observability.
-// ABOUTME: Tracks request duration, success rates, fallback usage, and quota.
+// ABOUTME: Tracks request duration, success rates, fallback usage, quota and provider keys.
 
 package chat
 
 import (
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/bingo-project/bingo/internal/pkg/ai"
+	aipkg "github.com/bingo-project/bingo/pkg/ai"
 )
 
 var (
@@ -72,8 +75,48 @@ var (
 		},
 		[]string{}, // no labels for now
 	)
+
+	// aiCacheLookups tracks response cache lookups.
+	aiCacheLookups = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "ai_cache_lookups_total",
+			Help: "Total AI response cache lookups",
+		},
+		[]string{"layer", "result"}, // layer: exact, semantic; result: hit, miss
+	)
+
+	// aiGuardrailEvents tracks guardrail matches.
+	aiGuardrailEvents = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "ai_guardrail_events_total",
+			Help: "Total AI guardrail matches",
+		},
+		[]string{"stage", "guard", "action"}, // stage: input, output
+	)
+
+	// aiProviderKeyRequests tracks requests of providers with several API keys by key.
+	aiProviderKeyRequests = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "ai_provider_key_requests_total",
+			Help: "Total AI provider requests by API key",
+		},
+		[]string{"provider", "key", "result"}, // result: success, error, rate_limited
+	)
+
+	// aiProviderKeyHealthy tracks whether an API key is in use or cooling down.
+	aiProviderKeyHealthy = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "ai_provider_key_healthy",
+			Help: "AI provider API key health (0=cooling down, 1=healthy)",
+		},
+		[]string{"provider", "key"},
+	)
 )
 
+func init() {
+	ai.SetKeyObserver(RecordProviderKeyRequest)
+}
+
 // RecordRequest records an AI request with duration and result.
 func RecordRequest(provider, model string, isStream bool, duration float64, status string) {
 	aiRequestDuration.WithLabelValues(provider, model, boolToString(isStream)).Observe(duration)
@@ -114,6 +157,30 @@ func RecordRPMRejection() {
 	aiRPMRejections.WithLabelValues().Inc()
 }
 
+// RecordCacheLookup records a response cache lookup.
+func RecordCacheLookup(layer string, hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	aiCacheLookups.WithLabelValues(layer, result).Inc()
+}
+
+// RecordGuardrailEvent records a guardrail match.
+func RecordGuardrailEvent(stage, guard, action string) {
+	aiGuardrailEvents.WithLabelValues(stage, guard, action).Inc()
+}
+
+// RecordProviderKeyRequest records a request sent with a pooled provider API key.
+func RecordProviderKeyRequest(e aipkg.KeyEvent) {
+	aiProviderKeyRequests.WithLabelValues(e.Provider, e.Key, e.Result).Inc()
+	var healthy float64
+	if e.Healthy {
+		healthy = 1
+	}
+	aiProviderKeyHealthy.WithLabelValues(e.Provider, e.Key).Set(healthy)
+}
+
 func boolToString(b bool) string {
 	if b {
 		return "true"
@@ -0,0 +1,39 @@
+// ABOUTME: Agent system prompt templating for chat requests.
+// ABOUTME: Fills {{nickname}}, {{locale}} and {{date}} placeholders from the request.
+
+package chat
+
+import (
+	"context"
+	"time"
+
+	"github.com/bingo-project/bingo/internal/pkg/log"
+	aipkg "github.com/bingo-project/bingo/pkg/ai"
+	"github.com/bingo-project/bingo/pkg/contextx"
+)
+
+// renderAgentPrompt fills the placeholders of an agent system prompt. The user is only
+// looked up when the prompt has placeholders.
+func (b *chatBiz) renderAgentPrompt(ctx context.Context, uid string, prompt string) string {
+	if !aipkg.HasPromptVars(prompt) {
+		return prompt
+	}
+
+	vars := map[string]string{
+		"locale": contextx.Lang(ctx),
+		"date":   time.Now().Format(time.DateOnly),
+	}
+	if uid != "" {
+		user, err := b.ds.User().GetByUID(ctx, uid)
+		if err != nil {
+			log.C(ctx).Warnw("Failed to load user for agent prompt", "uid", uid, "err", err)
+		} else {
+			vars["nickname"] = user.Nickname
+			if vars["nickname"] == "" {
+				vars["nickname"] = user.Username
+			}
+		}
+	}
+
+	return aipkg.RenderPrompt(prompt, vars)
+}
@@ -11,6 +11,7 @@ import (
 
 	"gorm.io/gorm"
 
+	"github.com/bingo-project/bingo/internal/pkg/ai"
 	"github.com/bingo-project/bingo/internal/pkg/errno"
 	"github.com/bingo-project/bingo/internal/pkg/facade"
 	"github.com/bingo-project/bingo/internal/pkg/log"
@@ -19,8 +20,8 @@ import (
 )
 
 const (
-	// defaultEstimatedTokens is the default token estimate for quota reservation
-	// when MaxTokens is not specified in the request.
+	// defaultEstimatedTokens is the default completion token estimate
+	// when neither the request nor the config sets MaxTokens.
 	defaultEstimatedTokens = 4096
 
 	// quotaKeyTTL is the TTL for Redis quota keys (25 hours to cover full day + buffer)
@@ -32,11 +33,12 @@ const (
 
 // quotaChecker handles token quota validation and tracking.
 type quotaChecker struct {
-	ds store.IStore
+	ds  store.IStore
+	rpm *ai.RPMResolver
 }
 
 func newQuotaChecker(ds store.IStore) *quotaChecker {
-	return &quotaChecker{ds: ds}
+	return &quotaChecker{ds: ds, rpm: ai.NewRPMResolver(ds, facade.Redis)}
 }
 
 // ensureQuotaState ensures the user's daily quota is reset in DB if needed,
@@ -245,7 +247,11 @@ func (q *quotaChecker) CheckRPM(ctx context.Context, uid string) error {
 		return nil
 	}
 
-	rpm := facade.Config.AI.Quota.DefaultRPM
+	// Effective limit: user override > tier default > config default
+	rpm, err := q.rpm.RPM(ctx, uid)
+	if err != nil {
+		return errno.ErrOperationFailed.WithMessage("failed to resolve rpm: %v", err)
+	}
 	if rpm <= 0 {
 		return nil // RPM limit disabled
 	}
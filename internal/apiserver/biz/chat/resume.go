@@ -0,0 +1,206 @@
+// ABOUTME: Resumable chat streams buffered in Redis.
+// ABOUTME: Stores every chunk of a generation in order so reconnecting clients can replay the rest.
+
+package chat
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/bingo-project/bingo/internal/pkg/errno"
+	"github.com/bingo-project/bingo/internal/pkg/facade"
+	"github.com/bingo-project/bingo/internal/pkg/log"
+	aipkg "github.com/bingo-project/bingo/pkg/ai"
+)
+
+const (
+	// streamBufferTTL is how long a stream stays resumable after its last chunk.
+	streamBufferTTL = 10 * time.Minute
+
+	// streamResumePoll is the interval a resumed stream polls for new chunks.
+	streamResumePoll = 200 * time.Millisecond
+
+	// streamStallTimeout is how long a resumed stream waits for a chunk before it treats
+	// the generation as gone, for example because its instance died before finishing it.
+	streamStallTimeout = 2 * time.Minute
+)
+
+// streamEvent is a buffered stream chunk, the last event of a stream marks its end.
+type streamEvent struct {
+	Chunk *aipkg.StreamChunk `json:"chunk,omitempty"`
+	Done  bool               `json:"done,omitempty"`
+	Error string             `json:"error,omitempty"`
+}
+
+// streamBuffer appends the chunks of a generation to a Redis list, chunk N has sequence number N.
+// A nil buffer, used when Redis is not configured, discards everything.
+type streamBuffer struct {
+	id string
+}
+
+// newStreamBuffer creates the buffer of a generation owned by uid.
+func newStreamBuffer(id, uid string) *streamBuffer {
+	if facade.Redis == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := facade.Redis.Set(ctx, streamOwnerKey(id), uid, streamBufferTTL).Err(); err != nil {
+		log.C(ctx).Warnw("Failed to register resumable stream", "id", id, "uid", uid, "err", err)
+	}
+
+	return &streamBuffer{id: id}
+}
+
+// append buffers a chunk.
+func (s *streamBuffer) append(chunk *aipkg.StreamChunk) {
+	s.push(streamEvent{Chunk: chunk})
+}
+
+// close marks the end of the stream, err is the error it ended with, if any.
+func (s *streamBuffer) close(err error) {
+	ev := streamEvent{Done: true}
+	if err != nil && !errors.Is(err, aipkg.ErrStreamClosed) {
+		ev.Error = err.Error()
+	}
+	s.push(ev)
+}
+
+func (s *streamBuffer) push(ev streamEvent) {
+	if s == nil {
+		return
+	}
+
+	data, err := json.Marshal(ev)
+	if err != nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	key := streamBufferKey(s.id)
+	pipe := facade.Redis.TxPipeline()
+	pipe.RPush(ctx, key, data)
+	pipe.Expire(ctx, key, streamBufferTTL)
+	pipe.Expire(ctx, streamOwnerKey(s.id), streamBufferTTL)
+	if _, err := pipe.Exec(ctx); err != nil {
+		log.C(ctx).Warnw("Failed to buffer stream chunk", "id", s.id, "err", err)
+	}
+}
+
+// resumeStream replays the chunks of a generation after sequence number after,
+// then follows the buffer until the generation ends, ctx ends or the generation is gone.
+// ctx must be the request context, the stream stops following when the client leaves.
+func resumeStream(ctx context.Context, uid string, id string, after int) (*aipkg.ChatStream, error) {
+	if facade.Redis == nil {
+		return nil, errno.ErrAIGenerationNotFound
+	}
+
+	owner, err := facade.Redis.Get(ctx, streamOwnerKey(id)).Result()
+	if errors.Is(err, redis.Nil) || (err == nil && owner != uid) {
+		return nil, errno.ErrAIGenerationNotFound
+	}
+	if err != nil {
+		return nil, errno.ErrOperationFailed.WithMessage("redis error: %v", err)
+	}
+
+	out := aipkg.NewChatStream(aipkg.DefaultStreamBufferSize)
+	go func() {
+		ctx, cancel := context.WithTimeout(ctx, streamBufferTTL)
+		defer cancel()
+
+		next := int64(after)
+		key := streamBufferKey(id)
+		for {
+			items, err := facade.Redis.LRange(ctx, key, next, -1).Result()
+			if err != nil {
+				out.CloseWithError(err)
+
+				return
+			}
+
+			for _, item := range items {
+				next++
+
+				var ev streamEvent
+				if err := json.Unmarshal([]byte(item), &ev); err != nil {
+					out.CloseWithError(fmt.Errorf("decode buffered chunk: %w", err))
+
+					return
+				}
+				if ev.Done {
+					if ev.Error != "" {
+						out.CloseWithError(errors.New(ev.Error))
+					} else {
+						out.Close()
+					}
+
+					return
+				}
+				out.Send(ev.Chunk)
+			}
+
+			if len(items) == 0 {
+				if err := streamAlive(ctx, id); err != nil {
+					out.CloseWithError(err)
+
+					return
+				}
+			}
+			if err := wait(ctx, streamResumePoll); err != nil {
+				out.CloseWithError(err)
+
+				return
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+// streamAlive checks that a generation can still produce chunks. Every chunk refreshes the
+// TTL of the owner key, so the time since the last chunk is the TTL already spent.
+func streamAlive(ctx context.Context, id string) error {
+	ttl, err := facade.Redis.TTL(ctx, streamOwnerKey(id)).Result()
+	if err != nil {
+		return err
+	}
+	if ttl < 0 {
+		return errno.ErrAIGenerationNotFound.WithMessage("generation %s expired before it finished", id)
+	}
+	if streamBufferTTL-ttl > streamStallTimeout {
+		return errno.ErrAIGenerationNotFound.WithMessage("generation %s stopped sending chunks", id)
+	}
+
+	return nil
+}
+
+// wait sleeps for d unless the context ends first.
+func wait(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// streamBufferKey builds the Redis key of a generation's buffered chunks.
+func streamBufferKey(id string) string {
+	return fmt.Sprintf("%s:ai:stream:%s", facade.Config.App.Name, id)
+}
+
+// streamOwnerKey builds the Redis key holding the owner of a buffered stream.
+func streamOwnerKey(id string) string {
+	return fmt.Sprintf("%s:ai:stream:%s:owner", facade.Config.App.Name, id)
+}
@@ -0,0 +1,145 @@
+// ABOUTME: Tests for resumable chat streams.
+// ABOUTME: Resumes buffered fake provider streams from Redis and stops following generations that are gone.
+
+package chat
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bingo-project/bingo/internal/pkg/config"
+	"github.com/bingo-project/bingo/internal/pkg/errno"
+	"github.com/bingo-project/bingo/internal/pkg/facade"
+	mockstore "github.com/bingo-project/bingo/internal/pkg/testing/mock/store"
+	aipkg "github.com/bingo-project/bingo/pkg/ai"
+	"github.com/bingo-project/bingo/pkg/ai/providers/fake"
+)
+
+func TestResumeStream_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	redisClient := redis.NewClient(&redis.Options{
+		Addr: "localhost:6379",
+	})
+	defer redisClient.Close()
+
+	ctx := context.Background()
+	if err := redisClient.Ping(ctx).Err(); err != nil {
+		t.Skipf("Redis not available: %v", err)
+	}
+
+	if facade.Config.App == nil {
+		facade.Config.App = &config.App{Name: "bingo-test"}
+		defer func() { facade.Config.App = nil }()
+	}
+	facade.Redis = redisClient
+	defer func() { facade.Redis = nil }()
+
+	ds := mockstore.NewStore()
+	b := &chatBiz{ds: ds, quota: newQuotaChecker(ds)}
+
+	words := []string{"one ", "two ", "three ", "four ", "five ", "six"}
+	provider := newFakeProvider("fake", fake.Step{Chunks: words, ChunkDelay: 5 * time.Millisecond})
+	req := &aipkg.ChatRequest{
+		Model:    "fake-chat",
+		Messages: []aipkg.Message{{Role: aipkg.RoleUser, Content: "count to six"}},
+	}
+
+	streamCtx, gen := generations.start(ctx, "resume-user", 3)
+	stream, err := provider.ChatStream(streamCtx, req)
+	require.NoError(t, err)
+	wrapped := b.wrapStreamForSaving(stream, gen, "resume-user", req, nil, nil, 0, req.Messages, &toolTrace{}, 0, "fake", false, time.Now())
+	defer redisClient.Del(ctx, streamBufferKey(gen.id), streamOwnerKey(gen.id))
+
+	// The client reads two chunks, then the connection drops
+	for range 2 {
+		_, err := wrapped.Recv()
+		require.NoError(t, err)
+	}
+	go func() {
+		for {
+			if _, err := wrapped.Recv(); err != nil {
+				return
+			}
+		}
+	}()
+
+	_, err = resumeStream(ctx, "someone-else", gen.id, 2)
+	assert.ErrorIs(t, err, errno.ErrAIGenerationNotFound)
+
+	resumed, err := resumeStream(ctx, "resume-user", gen.id, 2)
+	require.NoError(t, err)
+
+	var content strings.Builder
+	var last *aipkg.StreamChunk
+	for {
+		chunk, err := resumed.Recv()
+		if err != nil {
+			assert.ErrorIs(t, err, aipkg.ErrStreamClosed)
+
+			break
+		}
+		assert.Equal(t, gen.id, chunk.ID)
+		if chunk.Choices[0].Delta != nil {
+			content.WriteString(chunk.Choices[0].Delta.Content)
+		}
+		last = chunk
+	}
+
+	assert.Equal(t, "three four five six", content.String())
+	require.NotNil(t, last)
+	assert.Equal(t, aipkg.FinishReasonStop, last.Choices[0].FinishReason)
+}
+
+func TestResumeStream_Gone_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	redisClient := redis.NewClient(&redis.Options{
+		Addr: "localhost:6379",
+	})
+	defer redisClient.Close()
+
+	ctx := context.Background()
+	if err := redisClient.Ping(ctx).Err(); err != nil {
+		t.Skipf("Redis not available: %v", err)
+	}
+
+	if facade.Config.App == nil {
+		facade.Config.App = &config.App{Name: "bingo-test"}
+		defer func() { facade.Config.App = nil }()
+	}
+	facade.Redis = redisClient
+	defer func() { facade.Redis = nil }()
+
+	// The owner instance died: no chunk refreshed the owner key for longer than the stall timeout
+	id := "resume-stalled"
+	require.NoError(t, redisClient.Set(ctx, streamOwnerKey(id), "resume-user", streamBufferTTL-streamStallTimeout-time.Second).Err())
+	defer redisClient.Del(ctx, streamOwnerKey(id))
+
+	resumed, err := resumeStream(ctx, "resume-user", id, 0)
+	require.NoError(t, err)
+	_, err = resumed.Recv()
+	assert.ErrorIs(t, err, errno.ErrAIGenerationNotFound)
+
+	// The resuming client left: following stops with the request
+	id = "resume-left"
+	require.NoError(t, redisClient.Set(ctx, streamOwnerKey(id), "resume-user", streamBufferTTL).Err())
+	defer redisClient.Del(ctx, streamOwnerKey(id))
+
+	reqCtx, cancel := context.WithCancel(ctx)
+	resumed, err = resumeStream(reqCtx, "resume-user", id, 0)
+	require.NoError(t, err)
+	cancel()
+	_, err = resumed.Recv()
+	assert.ErrorIs(t, err, context.Canceled)
+}
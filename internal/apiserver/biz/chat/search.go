@@ -0,0 +1,86 @@
+// ABOUTME: Conversation search for AI chat users.
+// ABOUTME: Searches the titles and messages of the user's own sessions.
+
+package chat
+
+import (
+	"context"
+
+	"github.com/bingo-project/bingo/internal/pkg/ai"
+	"github.com/bingo-project/bingo/internal/pkg/errno"
+	"github.com/bingo-project/bingo/internal/pkg/store"
+	v1 "github.com/bingo-project/bingo/pkg/api/apiserver/v1"
+)
+
+// SearchBiz defines conversation search for users.
+type SearchBiz interface {
+	// Search finds the user's sessions and messages matching every word of the query
+	Search(ctx context.Context, uid string, req *v1.AiSearchRequest) (*v1.AiSearchResponse, error)
+}
+
+type searchBiz struct {
+	searcher ai.Searcher
+}
+
+var _ SearchBiz = (*searchBiz)(nil)
+
+// NewSearch creates a new SearchBiz instance
+func NewSearch(ds store.IStore) *searchBiz {
+	return &searchBiz{searcher: ai.NewFulltextSearcher(ds)}
+}
+
+func (b *searchBiz) Search(ctx context.Context, uid string, req *v1.AiSearchRequest) (*v1.AiSearchResponse, error) {
+	// Default pagination
+	page := 1
+	pageSize := 20
+	if req.Page > 0 {
+		page = req.Page
+	}
+	if req.PageSize > 0 {
+		pageSize = req.PageSize
+	}
+
+	total, hits, err := b.searcher.Search(ctx, ai.SearchQuery{
+		UID:    uid,
+		Text:   req.Q,
+		Offset: (page - 1) * pageSize,
+		Limit:  pageSize,
+	})
+	if err != nil {
+		return nil, errno.ErrDBRead.WithMessage("%v", err)
+	}
+
+	return toSearchResponse(total, hits), nil
+}
+
+// toSearchResponse converts search hits to v1.AiSearchResponse
+func toSearchResponse(total int64, hits []ai.SearchHit) *v1.AiSearchResponse {
+	resp := &v1.AiSearchResponse{
+		Total: total,
+		Data:  make([]v1.AiSearchHit, len(hits)),
+	}
+	for i, h := range hits {
+		resp.Data[i] = v1.AiSearchHit{
+			SessionID:       h.SessionID,
+			UID:             h.UID,
+			Title:           h.Title,
+			TitleHighlights: toHighlights(h.TitleHighlights),
+			MessageID:       h.MessageID,
+			Role:            h.Role,
+			Snippet:         h.Snippet,
+			Highlights:      toHighlights(h.Highlights),
+			CreatedAt:       h.CreatedAt,
+		}
+	}
+
+	return resp
+}
+
+func toHighlights(hl []ai.Highlight) []v1.AiSearchHighlight {
+	out := make([]v1.AiSearchHighlight, len(hl))
+	for i, h := range hl {
+		out[i] = v1.AiSearchHighlight{Start: h.Start, End: h.End}
+	}
+
+	return out
+}
@@ -6,6 +6,7 @@ package chat
 import (
 	"context"
 	"errors"
+	"slices"
 
 	"github.com/google/uuid"
 	"github.com/jinzhu/copier"
@@ -25,7 +26,11 @@ type SessionBiz interface {
 	List(ctx context.Context, uid string) ([]v1.SessionInfo, error)
 	Update(ctx context.Context, uid string, sessionID string, title string, modelName string) (*v1.SessionInfo, error)
 	Delete(ctx context.Context, uid string, sessionID string) error
-	GetHistory(ctx context.Context, sessionID string, limit int) ([]ai.Message, error)
+	GetHistory(ctx context.Context, sessionID string, limit int) ([]*model.AiMessageM, error)
+	ListVariants(ctx context.Context, uid string, sessionID string, messageID uint64) ([]*model.AiMessageM, uint64, error)
+	SwitchBranch(ctx context.Context, uid string, sessionID string, messageID uint64) (*v1.SessionInfo, error)
+	Export(ctx context.Context, uid string, sessionID string) (*model.AiSessionM, []*model.AiMessageM, error)
+	Import(ctx context.Context, uid string, title string, modelName string, messages []ai.Message) (*v1.SessionInfo, error)
 }
 
 type sessionBiz struct {
@@ -92,6 +97,7 @@ func (b *sessionBiz) Create(ctx context.Context, uid string, title string, model
 		UID:       uid,
 		AgentID:   agentID, // 绑定角色
 		Title:     finalTitle,
+		AutoTitle: title == "", // 未指定标题时, 首轮对话后自动生成
 		Model:     selectedModel,
 		Status:    model.AiSessionStatusActive,
 	}
@@ -155,7 +161,8 @@ func (b *sessionBiz) Update(ctx context.Context, uid string, sessionID string, t
 	var fields []string
 	if title != "" {
 		session.Title = title
-		fields = append(fields, "title")
+		session.AutoTitle = false
+		fields = append(fields, "title", "auto_title")
 	}
 	if modelName != "" {
 		session.Model = modelName
@@ -195,19 +202,104 @@ func (b *sessionBiz) Delete(ctx context.Context, uid string, sessionID string) e
 	return nil
 }
 
-func (b *sessionBiz) GetHistory(ctx context.Context, sessionID string, limit int) ([]ai.Message, error) {
-	messages, err := b.ds.AiMessage().ListBySessionID(ctx, sessionID, limit)
+// GetHistory returns the last messages of the session's active branch, oldest first.
+func (b *sessionBiz) GetHistory(ctx context.Context, sessionID string, limit int) ([]*model.AiMessageM, error) {
+	session, err := b.ds.AiSession().GetBySessionID(ctx, sessionID)
+	if err != nil {
+		return nil, errno.ErrDBRead.WithMessage("get session: %v", err)
+	}
+
+	leafID, err := activeLeaf(ctx, b.ds, session)
+	if err != nil {
+		return nil, err
+	}
+
+	messages, err := b.ds.AiMessage().ListBranch(ctx, sessionID, leafID, limit)
 	if err != nil {
 		return nil, errno.ErrDBRead.WithMessage("get history: %v", err)
 	}
+	slices.Reverse(messages)
+
+	return messages, nil
+}
+
+// ListVariants returns the messages sharing the parent of a message, oldest first,
+// and the ID of the one on the active branch.
+func (b *sessionBiz) ListVariants(ctx context.Context, uid string, sessionID string, messageID uint64) ([]*model.AiMessageM, uint64, error) {
+	session, err := b.getOwned(ctx, uid, sessionID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	leafID, err := activeLeaf(ctx, b.ds, session)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	msg, err := getSessionMessage(ctx, b.ds, sessionID, messageID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	variants, err := b.ds.AiMessage().ListChildren(ctx, sessionID, msg.ParentID)
+	if err != nil {
+		return nil, 0, errno.ErrDBRead.WithMessage("list variants: %v", err)
+	}
+
+	branch, err := b.ds.AiMessage().ListBranch(ctx, sessionID, leafID, 0)
+	if err != nil {
+		return nil, 0, errno.ErrDBRead.WithMessage("list branch: %v", err)
+	}
+	var activeID uint64
+	if i := slices.IndexFunc(branch, func(m *model.AiMessageM) bool { return m.ParentID == msg.ParentID }); i >= 0 {
+		activeID = branch[i].ID
+	}
+
+	return variants, activeID, nil
+}
+
+// SwitchBranch continues the session on the most recent branch through a message.
+func (b *sessionBiz) SwitchBranch(ctx context.Context, uid string, sessionID string, messageID uint64) (*v1.SessionInfo, error) {
+	session, err := b.getOwned(ctx, uid, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := activeLeaf(ctx, b.ds, session); err != nil {
+		return nil, err
+	}
+
+	if _, err := getSessionMessage(ctx, b.ds, sessionID, messageID); err != nil {
+		return nil, err
+	}
 
-	result := make([]ai.Message, len(messages))
-	for i, m := range messages {
-		result[i] = ai.Message{
-			Role:    m.Role,
-			Content: m.Content,
+	leafID, err := b.ds.AiMessage().GetLatestLeaf(ctx, sessionID, messageID)
+	if err != nil {
+		return nil, errno.ErrDBRead.WithMessage("get branch leaf: %v", err)
+	}
+	if err := b.ds.AiSession().UpdateActiveLeaf(ctx, sessionID, leafID); err != nil {
+		return nil, errno.ErrDBWrite.WithMessage("update active branch: %v", err)
+	}
+	session.ActiveLeafID = leafID
+
+	return toSessionInfo(session), nil
+}
+
+// getOwned returns a session of the user.
+func (b *sessionBiz) getOwned(ctx context.Context, uid string, sessionID string) (*model.AiSessionM, error) {
+	session, err := b.ds.AiSession().GetBySessionID(ctx, sessionID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errno.ErrAISessionNotFound
 		}
+
+		return nil, errno.ErrDBRead.WithMessage("get session: %v", err)
 	}
 
-	return result, nil
+	// Check ownership
+	if session.UID != uid {
+		return nil, errno.ErrAISessionNotFound
+	}
+
+	return session, nil
 }
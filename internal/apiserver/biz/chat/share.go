@@ -0,0 +1,207 @@
+// ABOUTME: Public share links of chat sessions.
+// ABOUTME: Creates, lists and revokes share tokens and serves their read-only snapshots.
+
+package chat
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"slices"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/bingo-project/bingo/internal/pkg/errno"
+	"github.com/bingo-project/bingo/internal/pkg/log"
+	"github.com/bingo-project/bingo/internal/pkg/model"
+	"github.com/bingo-project/bingo/internal/pkg/store"
+	aipkg "github.com/bingo-project/bingo/pkg/ai"
+	v1 "github.com/bingo-project/bingo/pkg/api/apiserver/v1"
+	"github.com/bingo-project/bingo/pkg/store/where"
+)
+
+// ShareBiz defines session share link management.
+type ShareBiz interface {
+	// Create shares a snapshot of the session's active branch, expireDays of 0 never expires
+	Create(ctx context.Context, uid string, sessionID string, expireDays int) (*v1.ShareInfo, error)
+
+	// List returns the share links of a session
+	List(ctx context.Context, uid string, sessionID string) ([]v1.ShareInfo, error)
+
+	// Revoke deletes a share link of a session
+	Revoke(ctx context.Context, uid string, sessionID string, token string) error
+
+	// View counts a view of a share link and returns its snapshot, oldest message first. Tool
+	// calls and tool results are left out, they can hold private data of the owner.
+	View(ctx context.Context, token string) (*model.AiShareM, *model.AiSessionM, []*model.AiMessageM, error)
+}
+
+type shareBiz struct {
+	ds       store.IStore
+	sessions *sessionBiz
+}
+
+var _ ShareBiz = (*shareBiz)(nil)
+
+// NewShare creates a new ShareBiz instance
+func NewShare(ds store.IStore) *shareBiz {
+	return &shareBiz{ds: ds, sessions: NewSession(ds)}
+}
+
+func (b *shareBiz) Create(ctx context.Context, uid string, sessionID string, expireDays int) (*v1.ShareInfo, error) {
+	session, err := b.sessions.getOwned(ctx, uid, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	leafID, err := activeLeaf(ctx, b.ds, session)
+	if err != nil {
+		return nil, err
+	}
+	if leafID == 0 {
+		return nil, errno.ErrInvalidArgument.WithMessage("session has no messages to share")
+	}
+
+	token, err := newShareToken()
+	if err != nil {
+		return nil, errno.ErrOperationFailed.WithMessage("generate share token: %v", err)
+	}
+
+	share := &model.AiShareM{
+		Token:     token,
+		SessionID: sessionID,
+		UID:       uid,
+		LeafID:    leafID,
+		Title:     session.Title,
+	}
+	if expireDays > 0 {
+		expiresAt := time.Now().AddDate(0, 0, expireDays)
+		share.ExpiresAt = &expiresAt
+	}
+	if err := b.ds.AiShare().Create(ctx, share); err != nil {
+		return nil, errno.ErrDBWrite.WithMessage("create share: %v", err)
+	}
+
+	return toShareInfo(share), nil
+}
+
+func (b *shareBiz) List(ctx context.Context, uid string, sessionID string) ([]v1.ShareInfo, error) {
+	if _, err := b.sessions.getOwned(ctx, uid, sessionID); err != nil {
+		return nil, err
+	}
+
+	_, shares, err := b.ds.AiShare().List(ctx, where.F("session_id", sessionID))
+	if err != nil {
+		return nil, errno.ErrDBRead.WithMessage("list shares: %v", err)
+	}
+
+	result := make([]v1.ShareInfo, len(shares))
+	for i, s := range shares {
+		result[i] = *toShareInfo(s)
+	}
+
+	return result, nil
+}
+
+func (b *shareBiz) Revoke(ctx context.Context, uid string, sessionID string, token string) error {
+	if _, err := b.sessions.getOwned(ctx, uid, sessionID); err != nil {
+		return err
+	}
+
+	share, err := b.ds.AiShare().GetByToken(ctx, token)
+	if err != nil || share.SessionID != sessionID {
+		if err == nil || errors.Is(err, gorm.ErrRecordNotFound) {
+			return errno.ErrAIShareNotFound
+		}
+
+		return errno.ErrDBRead.WithMessage("get share: %v", err)
+	}
+
+	if err := b.ds.AiShare().Delete(ctx, where.F("id", share.ID)); err != nil {
+		return errno.ErrDBWrite.WithMessage("revoke share: %v", err)
+	}
+
+	return nil
+}
+
+func (b *shareBiz) View(ctx context.Context, token string) (*model.AiShareM, *model.AiSessionM, []*model.AiMessageM, error) {
+	share, err := b.ds.AiShare().GetByToken(ctx, token)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil, nil, errno.ErrAIShareNotFound
+		}
+
+		return nil, nil, nil, errno.ErrDBRead.WithMessage("get share: %v", err)
+	}
+	if share.Expired(time.Now()) {
+		return nil, nil, nil, errno.ErrAIShareNotFound
+	}
+
+	// Deleting the session takes its share links down
+	session, err := b.ds.AiSession().GetBySessionID(ctx, share.SessionID)
+	if err != nil || session.Status == model.AiSessionStatusDeleted {
+		return nil, nil, nil, errno.ErrAIShareNotFound
+	}
+
+	messages, err := b.ds.AiMessage().ListBranch(ctx, share.SessionID, share.LeafID, 0)
+	if err != nil {
+		return nil, nil, nil, errno.ErrDBRead.WithMessage("list messages: %v", err)
+	}
+	slices.Reverse(messages)
+	messages = sharedMessages(messages)
+
+	if err := b.ds.AiShare().IncrementViews(ctx, share.ID); err != nil {
+		log.C(ctx).Warnw("Failed to count share view", "token", token, "err", err)
+	} else {
+		share.Views++
+	}
+
+	return share, session, messages, nil
+}
+
+// sharedMessages returns the messages a share link shows. Tool results and the tool calls of
+// assistant turns are dropped, server tools such as get_user_profile return the owner's private
+// data; an assistant turn holding only tool calls is dropped as well.
+func sharedMessages(messages []*model.AiMessageM) []*model.AiMessageM {
+	shared := make([]*model.AiMessageM, 0, len(messages))
+	for _, m := range messages {
+		if m.Role == aipkg.RoleTool {
+			continue
+		}
+		if len(m.ToolCalls) > 0 {
+			if m.Content == "" && len(m.ContentParts) == 0 {
+				continue
+			}
+			c := *m
+			c.ToolCalls = nil
+			m = &c
+		}
+		shared = append(shared, m)
+	}
+
+	return shared
+}
+
+// newShareToken generates an unguessable URL-safe share token.
+func newShareToken() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// toShareInfo converts model.AiShareM to v1.ShareInfo
+func toShareInfo(m *model.AiShareM) *v1.ShareInfo {
+	return &v1.ShareInfo{
+		Token:     m.Token,
+		SessionID: m.SessionID,
+		Title:     m.Title,
+		Views:     m.Views,
+		ExpiresAt: m.ExpiresAt,
+		CreatedAt: m.CreatedAt,
+	}
+}
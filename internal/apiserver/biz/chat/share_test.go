@@ -0,0 +1,36 @@
+// ABOUTME: Tests for public share link snapshots.
+// ABOUTME: Checks that tool calls and tool results are left out of shared messages.
+
+package chat
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bingo-project/bingo/internal/pkg/model"
+	aipkg "github.com/bingo-project/bingo/pkg/ai"
+)
+
+func TestSharedMessages(t *testing.T) {
+	call := aipkg.ToolCall{ID: "call_1", Type: aipkg.ToolTypeFunction, Function: aipkg.FunctionCall{Name: "get_user_profile", Arguments: "{}"}}
+	withText := &model.AiMessageM{ID: 4, Role: aipkg.RoleAssistant, Content: "Let me check.", ToolCalls: []aipkg.ToolCall{call}}
+	messages := []*model.AiMessageM{
+		{ID: 1, Role: aipkg.RoleUser, Content: "How old am I?"},
+		{ID: 2, Role: aipkg.RoleAssistant, ToolCalls: []aipkg.ToolCall{call}},
+		{ID: 3, Role: aipkg.RoleTool, Name: "get_user_profile", ToolCallID: "call_1", Content: `{"uid":"u1","username":"jane","age":30}`},
+		withText,
+		{ID: 5, Role: aipkg.RoleAssistant, Content: "You are 30."},
+	}
+
+	shared := sharedMessages(messages)
+	require.Len(t, shared, 3)
+	assert.Equal(t, []uint64{1, 4, 5}, idsOf(shared))
+	for _, m := range shared {
+		assert.NotEqual(t, aipkg.RoleTool, m.Role)
+		assert.Empty(t, m.ToolCalls)
+	}
+	assert.Equal(t, "Let me check.", shared[1].Content)
+	assert.Len(t, withText.ToolCalls, 1, "the stored message is not modified")
+}
@@ -0,0 +1,81 @@
+// ABOUTME: Structured output validation for chat completions.
+// ABOUTME: Checks replies against the requested response format and asks the model to repair them.
+
+package chat
+
+import (
+	"context"
+
+	"github.com/bingo-project/bingo/internal/pkg/errno"
+	"github.com/bingo-project/bingo/internal/pkg/log"
+	aipkg "github.com/bingo-project/bingo/pkg/ai"
+)
+
+// maxRepairAttempts is the maximum number of repair rounds for a reply not matching the response format.
+const maxRepairAttempts = 2
+
+// validateResponseFormat checks the response format of a request. Structured replies are
+// validated once complete, so they cannot be streamed.
+func validateResponseFormat(req *aipkg.ChatRequest, stream bool) error {
+	if err := req.ResponseFormat.Validate(); err != nil {
+		return errno.ErrInvalidArgument.WithMessage("%v", err)
+	}
+	if stream && req.ResponseFormat.Structured() {
+		return errno.ErrInvalidArgument.WithMessage("response_format %s requires stream to be false", req.ResponseFormat.Type)
+	}
+
+	return nil
+}
+
+// chatStructured runs chatWithTools and validates the reply against the response format.
+// An invalid reply is sent back with a repair prompt up to maxRepairAttempts times; the
+// returned content is the compacted JSON and usage is summed over all rounds. Replies
+// handing tool calls to the client are returned unchecked. The returned usage covers the
+// rounds spent even when the repairs run out, so they are still charged.
+func (b *chatBiz) chatStructured(ctx context.Context, uid string, provider aipkg.Provider, req *aipkg.ChatRequest) (*aipkg.ChatResponse, []aipkg.Message, aipkg.Usage, error) {
+	if !req.ResponseFormat.Structured() {
+		return b.chatWithTools(ctx, uid, provider, req)
+	}
+
+	r := *req
+	r.Messages = append([]aipkg.Message(nil), req.Messages...)
+
+	var trace []aipkg.Message
+	var usage aipkg.Usage
+	for i := 0; ; i++ {
+		resp, round, spent, err := b.chatWithTools(ctx, uid, provider, &r)
+		usage.PromptTokens += spent.PromptTokens
+		usage.CompletionTokens += spent.CompletionTokens
+		usage.TotalTokens += spent.TotalTokens
+		if err != nil {
+			return nil, nil, usage, err
+		}
+		trace = append(trace, round...)
+
+		if len(resp.Choices) == 0 || len(resp.Choices[0].Message.ToolCalls) > 0 {
+			resp.Usage = usage
+
+			return resp, trace, usage, nil
+		}
+
+		reply := resp.Choices[0].Message
+		content, err := aipkg.ParseStructured(reply.Content, r.ResponseFormat)
+		if err == nil {
+			resp.Choices[0].Message.Content = content
+			resp.Usage = usage
+
+			return resp, trace, usage, nil
+		}
+		if i >= maxRepairAttempts {
+			return nil, nil, usage, errno.ErrAIInvalidStructuredOutput.WithMessage("AI reply does not match the requested response format: %v", err)
+		}
+
+		log.C(ctx).Infow("AI reply does not match response format, asking for repair",
+			"model", r.Model, "attempt", i+1, "err", err)
+		r.Messages = append(r.Messages, round...)
+		r.Messages = append(r.Messages,
+			reply,
+			aipkg.Message{Role: aipkg.RoleUser, Content: aipkg.RepairPrompt(err)},
+		)
+	}
+}
@@ -0,0 +1,92 @@
+// ABOUTME: Tests for structured output validation.
+// ABOUTME: Checks replies against a response format and the repair rounds of a fake provider.
+
+package chat
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bingo-project/bingo/internal/pkg/errno"
+	aipkg "github.com/bingo-project/bingo/pkg/ai"
+	"github.com/bingo-project/bingo/pkg/ai/providers/fake"
+)
+
+func TestChatStructured(t *testing.T) {
+	format := &aipkg.ResponseFormat{
+		Type: aipkg.ResponseFormatJSONSchema,
+		Schema: map[string]any{
+			"type":       "object",
+			"properties": map[string]any{"city": map[string]any{"type": "string"}},
+			"required":   []any{"city"},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		steps    []fake.Step
+		want     string
+		wantErr  error
+		requests int
+	}{
+		{
+			name:     "valid reply",
+			steps:    []fake.Step{{Content: "```json\n{\"city\": \"Paris\"}\n```"}},
+			want:     `{"city":"Paris"}`,
+			requests: 1,
+		},
+		{
+			name:     "repaired reply",
+			steps:    []fake.Step{{Content: "Paris"}, {Content: `{"town": "Paris"}`}, {Content: `{"city": "Paris"}`}},
+			want:     `{"city":"Paris"}`,
+			requests: 3,
+		},
+		{
+			name:     "repairs exhausted",
+			steps:    []fake.Step{{Content: "Paris"}, {Content: "Paris"}, {Content: "Paris"}},
+			wantErr:  errno.ErrAIInvalidStructuredOutput,
+			requests: maxRepairAttempts + 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := &chatBiz{tools: aipkg.NewToolRegistry()}
+			provider := newFakeProvider("fake", tt.steps...)
+			req := &aipkg.ChatRequest{
+				Model:          "fake-chat",
+				Messages:       []aipkg.Message{{Role: aipkg.RoleUser, Content: "Where is the Louvre?"}},
+				ResponseFormat: format,
+			}
+
+			resp, _, usage, err := b.chatStructured(context.Background(), "uid", provider, req)
+			requests := provider.Requests()
+			assert.Len(t, requests, tt.requests)
+			assert.Positive(t, usage.TotalTokens, "every round is charged")
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, resp.Choices[0].Message.Content)
+			assert.Len(t, req.Messages, 1)
+
+			// Each repair round resends the rejected reply and the repair prompt
+			last := requests[len(requests)-1].Messages
+			assert.Len(t, last, 1+2*(tt.requests-1))
+		})
+	}
+}
+
+func TestValidateResponseFormat(t *testing.T) {
+	jsonObject := &aipkg.ResponseFormat{Type: aipkg.ResponseFormatJSONObject}
+
+	assert.NoError(t, validateResponseFormat(&aipkg.ChatRequest{}, true))
+	assert.NoError(t, validateResponseFormat(&aipkg.ChatRequest{ResponseFormat: jsonObject}, false))
+	assert.Error(t, validateResponseFormat(&aipkg.ChatRequest{ResponseFormat: jsonObject}, true))
+	assert.Error(t, validateResponseFormat(&aipkg.ChatRequest{ResponseFormat: &aipkg.ResponseFormat{Type: aipkg.ResponseFormatJSONSchema}}, false))
+}
@@ -0,0 +1,309 @@
+// ABOUTME: Rolling conversation summaries for long chat sessions.
+// ABOUTME: Folds history beyond the context budget into a summary with a cheap model.
+
+package chat
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/bingo-project/bingo/internal/pkg/facade"
+	"github.com/bingo-project/bingo/internal/pkg/log"
+	"github.com/bingo-project/bingo/internal/pkg/model"
+	aipkg "github.com/bingo-project/bingo/pkg/ai"
+	"github.com/bingo-project/bingo/pkg/store/where"
+)
+
+const (
+	// summaryTimeout is the timeout for generating and saving a session summary.
+	summaryTimeout = 60 * time.Second
+
+	// summaryMaxTokens is the maximum completion tokens of a summary.
+	summaryMaxTokens = 1024
+
+	// summaryTriggerRatio is the share of the context budget unsummarized history may use.
+	summaryTriggerRatio = 0.75
+
+	// summaryKeepRatio is the share of the context budget kept verbatim after summarizing.
+	summaryKeepRatio = 0.25
+
+	// maxSummaryToolChars is the maximum characters of a tool result in the summary input.
+	maxSummaryToolChars = 500
+
+	// summaryPrefix introduces the summary to the chat model.
+	summaryPrefix = "Summary of the earlier conversation:\n"
+)
+
+// summaryPrompt instructs the summary model.
+const summaryPrompt = "You maintain a running summary of a conversation between a user and an AI assistant. " +
+	"Merge the previous summary and the new messages into one concise summary. " +
+	"Keep facts, decisions, user preferences, names, numbers and open questions, and drop small talk. " +
+	"Write in the language of the conversation and reply with the summary only."
+
+// historyLimit returns the maximum number of history messages loaded per request.
+func historyLimit() int {
+	if limit := facade.Config.AI.Session.MaxMessages; limit > 0 {
+		return limit
+	}
+
+	return 50 // default
+}
+
+// loadSummary returns the session summary cursor and the summary it points to.
+// Returns zero values if the session has no summary.
+func (b *chatBiz) loadSummary(ctx context.Context, sessionID string) (uint64, string) {
+	session, err := b.ds.AiSession().GetBySessionID(ctx, sessionID)
+	if err != nil || session.SummaryCursor == 0 {
+		return 0, ""
+	}
+
+	summary, err := b.ds.AiMessage().GetLatestSummary(ctx, sessionID)
+	if err != nil {
+		log.C(ctx).Warnw("Failed to load session summary", "session_id", sessionID, "err", err)
+
+		return 0, ""
+	}
+
+	return session.SummaryCursor, summary.Content
+}
+
+// summarizeSession rolls the session summary forward when the unsummarized history of the
+// active branch outgrows the context budget of the chat model. Does nothing without a
+// summary model, or on a branch forked off before the summarized part.
+func (b *chatBiz) summarizeSession(sessionID, chatModel string) {
+	summaryModel := facade.Config.AI.Session.SummaryModel
+	if summaryModel == "" {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), summaryTimeout)
+	defer cancel()
+
+	session, err := b.ds.AiSession().GetBySessionID(ctx, sessionID)
+	if err != nil {
+		log.C(ctx).Warnw("Failed to get session for summary", "session_id", sessionID, "err", err)
+
+		return
+	}
+
+	branch, err := b.ds.AiMessage().ListBranch(ctx, sessionID, session.ActiveLeafID, historyLimit())
+	if err != nil {
+		log.C(ctx).Warnw("Failed to load history for summary", "session_id", sessionID, "err", err)
+
+		return
+	}
+
+	// The summary only rolls forward along the branch it was made from
+	history, summarized := branchHistory(branch, session.SummaryCursor)
+	if session.SummaryCursor > 0 && !summarized {
+		return
+	}
+
+	var previous string
+	if session.SummaryCursor > 0 {
+		if m, err := b.ds.AiMessage().GetLatestSummary(ctx, sessionID); err == nil {
+			previous = m.Content
+		}
+	}
+
+	cut := b.summaryCut(history, chatModel)
+	cut = b.fitSummaryInput(history, cut, summaryModel, previous)
+	if cut == 0 {
+		return
+	}
+
+	content, usage, err := b.generateSummary(ctx, session, summaryModel, previous, history[:cut])
+	if err != nil {
+		log.C(ctx).Warnw("Failed to summarize session", "session_id", sessionID, "model", summaryModel, "err", err)
+
+		return
+	}
+
+	summary := &model.AiMessageM{
+		SessionID: sessionID,
+		Role:      model.AiMessageRoleSystem,
+		Kind:      model.AiMessageKindSummary,
+		Content:   content,
+		Tokens:    usage.CompletionTokens,
+		Model:     summaryModel,
+	}
+	if err := b.ds.AiMessage().Create(ctx, summary); err != nil {
+		log.C(ctx).Errorw("Failed to save session summary", "session_id", sessionID, "err", err)
+
+		return
+	}
+
+	// Another turn may have summarized concurrently, keep only the winner
+	ok, err := b.ds.AiSession().AdvanceSummaryCursor(ctx, sessionID, session.SummaryCursor, history[cut-1].ID)
+	if err != nil || !ok {
+		if err := b.ds.AiMessage().Delete(ctx, where.F("id", summary.ID)); err != nil {
+			log.C(ctx).Errorw("Failed to delete stale session summary", "session_id", sessionID, "err", err)
+		}
+
+		return
+	}
+
+	log.C(ctx).Infow("AI session summarized", "session_id", sessionID, "messages", cut, "cursor", history[cut-1].ID)
+}
+
+// summaryCut returns how many of the oldest history messages to fold into the summary,
+// or 0 if the history still fits the context budget and message window.
+func (b *chatBiz) summaryCut(history []*model.AiMessageM, chatModel string) int {
+	tok := aipkg.TokenizerFor(chatModel)
+	tokens := make([]int, len(history))
+	total := 0
+	for i, m := range history {
+		tokens[i] = tok.CountMessage(toAIMessage(m))
+		total += tokens[i]
+	}
+
+	budget := 0
+	if limit := b.contextLimit(chatModel); limit > 0 {
+		budget = max(limit-completionBudget(0), 0)
+	}
+	window := facade.Config.AI.Session.ContextWindow
+
+	overBudget := budget > 0 && float64(total) > float64(budget)*summaryTriggerRatio
+	overWindow := window > 0 && len(history) > window
+	if !overBudget && !overWindow {
+		return 0
+	}
+
+	// Keep the most recent messages verbatim
+	keepTokens := math.MaxInt
+	if budget > 0 {
+		keepTokens = int(float64(budget) * summaryKeepRatio)
+	}
+	keepCount := len(history)
+	if window > 0 {
+		keepCount = window / 2
+	}
+
+	cut, kept := len(history), 0
+	for cut > 0 && len(history)-cut < keepCount && kept+tokens[cut-1] <= keepTokens {
+		cut--
+		kept += tokens[cut]
+	}
+
+	// Start the kept part at a user message so tool calls stay with their results
+	for cut < len(history) && history[cut].Role != model.AiMessageRoleUser {
+		cut++
+	}
+
+	return cut
+}
+
+// fitSummaryInput shrinks cut so the summary input fits the summary model context.
+func (b *chatBiz) fitSummaryInput(history []*model.AiMessageM, cut int, summaryModel, previous string) int {
+	limit := b.contextLimit(summaryModel)
+	if limit <= 0 || cut == 0 {
+		return cut
+	}
+
+	tok := aipkg.TokenizerFor(summaryModel)
+	available := limit - summaryMaxTokens - tok.Count(summaryPrompt) - tok.Count(previous)
+	used := 0
+	for i := 0; i < cut; i++ {
+		used += tok.Count(transcriptLine(history[i]))
+		if used > available {
+			cut = i
+
+			break
+		}
+	}
+
+	// End on a turn boundary so the next summary starts at a user message
+	for cut > 0 && cut < len(history) && history[cut].Role != model.AiMessageRoleUser {
+		cut--
+	}
+
+	return cut
+}
+
+// generateSummary asks the summary model to merge messages into the previous summary. The
+// call is recorded in the usage ledger and charged to the session owner.
+func (b *chatBiz) generateSummary(ctx context.Context, session *model.AiSessionM, summaryModel, previous string, messages []*model.AiMessageM) (string, aipkg.Usage, error) {
+	m, err := b.ds.AiModel().FindActiveByModel(ctx, summaryModel)
+	if err != nil {
+		return "", aipkg.Usage{}, fmt.Errorf("find summary model: %w", err)
+	}
+	provider, ok := b.registry.Get(m.ProviderName)
+	if !ok {
+		return "", aipkg.Usage{}, fmt.Errorf("provider %s not registered", m.ProviderName)
+	}
+	breaker := b.getBreaker(m.ProviderName)
+	if !breaker.Allow(ctx) {
+		return "", aipkg.Usage{}, fmt.Errorf("provider %s circuit open", m.ProviderName)
+	}
+
+	var input strings.Builder
+	if previous != "" {
+		input.WriteString("Previous summary:\n" + previous + "\n\n")
+	}
+	input.WriteString("New messages:\n")
+	for _, msg := range messages {
+		input.WriteString(transcriptLine(msg))
+	}
+
+	req := &aipkg.ChatRequest{
+		Model: summaryModel,
+		Messages: []aipkg.Message{
+			{Role: aipkg.RoleSystem, Content: summaryPrompt},
+			{Role: aipkg.RoleUser, Content: input.String()},
+		},
+		MaxTokens: summaryMaxTokens,
+		SessionID: session.SessionID,
+		AgentID:   session.AgentID,
+	}
+	start := time.Now()
+	resp, err := provider.Chat(ctx, req)
+	if err != nil {
+		breaker.RecordFailure(ctx, err)
+		b.recordSideUsage(ctx, session.UID, req, m.ProviderName, aipkg.Usage{}, time.Since(start), err)
+
+		return "", aipkg.Usage{}, err
+	}
+	breaker.RecordSuccess(ctx)
+	b.recordSideUsage(ctx, session.UID, req, m.ProviderName, resp.Usage, time.Since(start), nil)
+
+	if len(resp.Choices) == 0 || strings.TrimSpace(resp.Choices[0].Message.Content) == "" {
+		return "", aipkg.Usage{}, fmt.Errorf("empty summary")
+	}
+
+	return strings.TrimSpace(resp.Choices[0].Message.Content), resp.Usage, nil
+}
+
+// transcriptLine renders a stored message as a line of the summary input.
+func transcriptLine(m *model.AiMessageM) string {
+	role, content := m.Role, m.Content
+	switch {
+	case m.Role == model.AiMessageRoleTool:
+		role = "tool " + m.Name
+		if runes := []rune(content); len(runes) > maxSummaryToolChars {
+			content = string(runes[:maxSummaryToolChars]) + "..."
+		}
+	case len(m.ToolCalls) > 0 && content == "":
+		names := make([]string, len(m.ToolCalls))
+		for i, c := range m.ToolCalls {
+			names[i] = c.Function.Name
+		}
+		content = "(called " + strings.Join(names, ", ") + ")"
+	}
+
+	return role + ": " + content + "\n\n"
+}
+
+// toAIMessage converts a stored message to ai.Message.
+func toAIMessage(m *model.AiMessageM) aipkg.Message {
+	return aipkg.Message{
+		Role:       m.Role,
+		Content:    m.Content,
+		Parts:      m.ContentParts,
+		Name:       m.Name,
+		ToolCalls:  m.ToolCalls,
+		ToolCallID: m.ToolCallID,
+	}
+}
@@ -0,0 +1,49 @@
+// ABOUTME: Automatic titles for new chat sessions.
+// ABOUTME: Queues a small model to replace a session's placeholder title after its first exchange.
+
+package chat
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/hibiken/asynq"
+
+	"github.com/bingo-project/bingo/internal/pkg/facade"
+	"github.com/bingo-project/bingo/internal/pkg/log"
+	"github.com/bingo-project/bingo/internal/pkg/task"
+	"github.com/bingo-project/bingo/pkg/contextx"
+)
+
+const (
+	// titleUniqueTTL keeps a session from being queued again while its title is pending.
+	titleUniqueTTL = 5 * time.Minute
+
+	// titleMaxRetry is the number of retries of a failed title generation.
+	titleMaxRetry = 3
+)
+
+// requestTitle queues a title for a session still showing its placeholder title, in the
+// language of the request. Does nothing without a title model.
+func (b *chatBiz) requestTitle(ctx context.Context, sessionID string) {
+	titleModel := facade.Config.AI.Session.TitleModel
+	if titleModel == "" || task.T == nil {
+		return
+	}
+
+	session, err := b.ds.AiSession().GetBySessionID(ctx, sessionID)
+	if err != nil || !session.AutoTitle {
+		return
+	}
+
+	payload := task.AiSessionTitlePayload{
+		SessionID: sessionID,
+		Model:     titleModel,
+		Lang:      contextx.Lang(ctx),
+	}
+	_, err = task.T.Queue(ctx, task.AiSessionTitle, payload).Dispatch(asynq.Unique(titleUniqueTTL), asynq.MaxRetry(titleMaxRetry))
+	if err != nil && !errors.Is(err, asynq.ErrDuplicateTask) && !errors.Is(err, asynq.ErrTaskIDConflict) {
+		log.C(ctx).Warnw("Failed to queue AI session title", "session_id", sessionID, "err", err)
+	}
+}
@@ -0,0 +1,278 @@
+// ABOUTME: Server-side tool execution for chat completions.
+// ABOUTME: Runs model tool calls in a loop until the model returns a final answer.
+
+package chat
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/bingo-project/bingo/internal/pkg/errno"
+	"github.com/bingo-project/bingo/internal/pkg/log"
+	"github.com/bingo-project/bingo/internal/pkg/store"
+	aipkg "github.com/bingo-project/bingo/pkg/ai"
+	"github.com/bingo-project/bingo/pkg/contextx"
+)
+
+// maxToolIterations is the maximum number of tool call rounds per request.
+const maxToolIterations = 5
+
+// toolTrace collects intermediate assistant tool-call and tool-result messages.
+type toolTrace struct {
+	messages []aipkg.Message
+}
+
+// newToolRegistry creates the registry of built-in server-side tools.
+func newToolRegistry(ds store.IStore) *aipkg.ToolRegistry {
+	r := aipkg.NewToolRegistry()
+
+	r.Register(aipkg.NewToolFunc(aipkg.FunctionDefinition{
+		Name:        "get_user_profile",
+		Description: "Get the profile of the current user, including nickname, username, gender, age and avatar.",
+		Parameters: map[string]any{
+			"type":       "object",
+			"properties": map[string]any{},
+		},
+	}, func(ctx context.Context, _ string) (string, error) {
+		user, err := ds.User().GetByUID(ctx, contextx.UserID(ctx))
+		if err != nil {
+			return "", err
+		}
+
+		data, err := json.Marshal(map[string]any{
+			"uid":      user.UID,
+			"nickname": user.Nickname,
+			"username": user.Username,
+			"gender":   user.Gender,
+			"age":      user.Age,
+			"avatar":   user.Avatar,
+		})
+
+		return string(data), err
+	}))
+
+	return r
+}
+
+// validateTools checks the tools declared by the client. Client tools are always handed back to
+// the client, so a name colliding with a server tool is rejected instead of running the server tool.
+func (b *chatBiz) validateTools(req *aipkg.ChatRequest) error {
+	for _, t := range req.Tools {
+		if _, ok := b.tools.Get(t.Function.Name); ok {
+			return errno.ErrInvalidArgument.WithMessage("tool name %s is reserved for a server tool", t.Function.Name)
+		}
+	}
+
+	return nil
+}
+
+// isServerToolCalls reports whether all tool calls target server tools the server offered for the request.
+func (b *chatBiz) isServerToolCalls(req *aipkg.ChatRequest, calls []aipkg.ToolCall) bool {
+	if !b.tools.HandlesAll(calls) {
+		return false
+	}
+	for _, c := range calls {
+		if !slices.Contains(req.ServerTools, c.Function.Name) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// hasServerTools reports whether the server offered any server tool to the model.
+func (b *chatBiz) hasServerTools(req *aipkg.ChatRequest) bool {
+	return len(req.ServerTools) > 0
+}
+
+// runTools executes tool calls and returns the tool result messages.
+// Tool errors are reported back to the model instead of failing the request.
+func (b *chatBiz) runTools(ctx context.Context, calls []aipkg.ToolCall) []aipkg.Message {
+	results := make([]aipkg.Message, 0, len(calls))
+	for _, c := range calls {
+		tool, _ := b.tools.Get(c.Function.Name)
+		content, err := tool.Call(ctx, c.Function.Arguments)
+		if err != nil {
+			log.C(ctx).Warnw("AI tool call failed", "tool", c.Function.Name, "err", err)
+			data, _ := json.Marshal(map[string]string{"error": err.Error()})
+			content = string(data)
+		}
+
+		results = append(results, aipkg.Message{
+			Role:       aipkg.RoleTool,
+			Name:       c.Function.Name,
+			ToolCallID: c.ID,
+			Content:    content,
+		})
+	}
+
+	return results
+}
+
+// chatWithTools calls the provider and executes server tool calls until a final answer is returned.
+// Returns the final response with usage summed over all rounds, plus the intermediate messages.
+// The returned usage also covers the rounds spent before an error, such as exceeding the tool
+// round limit, so they are still charged.
+func (b *chatBiz) chatWithTools(ctx context.Context, uid string, provider aipkg.Provider, req *aipkg.ChatRequest) (*aipkg.ChatResponse, []aipkg.Message, aipkg.Usage, error) {
+	ctx = contextx.WithUserID(ctx, uid)
+	r := *req
+	r.Messages = append([]aipkg.Message(nil), req.Messages...)
+
+	var trace []aipkg.Message
+	var usage aipkg.Usage
+	for i := 0; ; i++ {
+		resp, err := provider.Chat(ctx, &r)
+		if err != nil {
+			return nil, nil, usage, err
+		}
+		usage.PromptTokens += resp.Usage.PromptTokens
+		usage.CompletionTokens += resp.Usage.CompletionTokens
+		usage.TotalTokens += resp.Usage.TotalTokens
+
+		if len(resp.Choices) == 0 || !b.isServerToolCalls(&r, resp.Choices[0].Message.ToolCalls) {
+			resp.Usage = usage
+
+			return resp, trace, usage, nil
+		}
+		if i >= maxToolIterations {
+			return nil, nil, usage, errno.ErrAIToolCallLimitExceeded
+		}
+
+		msg := resp.Choices[0].Message
+		round := append([]aipkg.Message{msg}, b.runTools(ctx, msg.ToolCalls)...)
+		trace = append(trace, round...)
+		r.Messages = append(r.Messages, round...)
+	}
+}
+
+// chatStreamWithTools starts a stream and transparently executes server tool calls.
+// Tool call deltas are buffered; server tool rounds are hidden from the client,
+// while client tool calls are emitted as a single merged chunk.
+func (b *chatBiz) chatStreamWithTools(ctx context.Context, uid string, provider aipkg.Provider, req *aipkg.ChatRequest) (*aipkg.ChatStream, *toolTrace, error) {
+	trace := &toolTrace{}
+	stream, err := provider.ChatStream(ctx, req)
+	if err != nil || !b.hasServerTools(req) {
+		return stream, trace, err
+	}
+
+	out := aipkg.NewChatStream(aipkg.DefaultStreamBufferSize)
+	go b.pumpToolStream(contextx.WithUserID(ctx, uid), provider, req, stream, out, trace)
+
+	return out, trace, nil
+}
+
+// pumpToolStream forwards content chunks and runs server tool rounds between streams.
+func (b *chatBiz) pumpToolStream(ctx context.Context, provider aipkg.Provider, req *aipkg.ChatRequest, stream *aipkg.ChatStream, out *aipkg.ChatStream, trace *toolTrace) {
+	r := *req
+	r.Messages = append([]aipkg.Message(nil), req.Messages...)
+
+	var usage aipkg.Usage
+	for i := 0; ; i++ {
+		acc := aipkg.NewToolCallAccumulator()
+		var content strings.Builder
+		var roundUsage *aipkg.Usage
+		var final *aipkg.StreamChunk
+
+		for {
+			chunk, err := stream.Recv()
+			if err != nil {
+				if !errors.Is(err, aipkg.ErrStreamClosed) {
+					out.CloseWithError(err)
+
+					return
+				}
+
+				break
+			}
+
+			if chunk.Usage != nil {
+				roundUsage = chunk.Usage
+				chunk.Usage = nil
+			}
+			if len(chunk.Choices) == 0 {
+				continue
+			}
+			if delta := chunk.Choices[0].Delta; delta != nil && len(delta.ToolCalls) > 0 {
+				acc.Add(delta.ToolCalls)
+				delta.ToolCalls = nil
+			}
+			if chunk.Choices[0].FinishReason != "" {
+				// Text riding on the finish chunk is sent on its own, so it reaches
+				// the trace of a tool round as well as the client
+				if delta := chunk.Choices[0].Delta; delta != nil && delta.Content != "" {
+					content.WriteString(delta.Content)
+					text := *chunk
+					text.Choices = []aipkg.Choice{{Index: chunk.Choices[0].Index, Delta: &aipkg.Message{Role: delta.Role, Content: delta.Content}}}
+					out.Send(&text)
+					delta.Content = ""
+				}
+				final = chunk
+
+				continue
+			}
+			if delta := chunk.Choices[0].Delta; delta != nil && delta.Content != "" {
+				content.WriteString(delta.Content)
+				out.Send(chunk)
+			}
+		}
+
+		if roundUsage != nil {
+			usage.PromptTokens += roundUsage.PromptTokens
+			usage.CompletionTokens += roundUsage.CompletionTokens
+			usage.TotalTokens += roundUsage.TotalTokens
+		}
+
+		calls := acc.Calls()
+		if !b.isServerToolCalls(&r, calls) {
+			if final == nil {
+				out.Close()
+
+				return
+			}
+			if len(calls) > 0 {
+				for j := range calls {
+					idx := j
+					calls[j].Index = &idx
+				}
+				final.Choices[0].Delta = &aipkg.Message{Role: aipkg.RoleAssistant, ToolCalls: calls}
+				final.Choices[0].FinishReason = aipkg.FinishReasonToolCalls
+			}
+			final.Usage = &usage
+			out.Send(final)
+			out.Close()
+
+			return
+		}
+		if i >= maxToolIterations {
+			closeWithUsage(out, usage, errno.ErrAIToolCallLimitExceeded)
+
+			return
+		}
+
+		msg := aipkg.Message{Role: aipkg.RoleAssistant, Content: content.String(), ToolCalls: calls}
+		round := append([]aipkg.Message{msg}, b.runTools(ctx, calls)...)
+		trace.messages = append(trace.messages, round...)
+		r.Messages = append(r.Messages, round...)
+
+		var err error
+		stream, err = provider.ChatStream(ctx, &r)
+		if err != nil {
+			closeWithUsage(out, usage, errno.ErrAIProviderError.WithMessage("stream failed: %v", err))
+
+			return
+		}
+	}
+}
+
+// closeWithUsage ends a tool stream with an error, reporting the usage of the rounds already
+// spent first so the stream wrapper charges and records them.
+func closeWithUsage(out *aipkg.ChatStream, usage aipkg.Usage, err error) {
+	if usage.TotalTokens > 0 {
+		out.Send(&aipkg.StreamChunk{Object: "chat.completion.chunk", Created: time.Now().Unix(), Usage: &usage})
+	}
+	out.CloseWithError(err)
+}
@@ -0,0 +1,139 @@
+// ABOUTME: Tests for server-side tool execution.
+// ABOUTME: Verifies only server-offered tools run, tool rounds keep their text and rounds past the limit are charged.
+
+package chat
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bingo-project/bingo/internal/pkg/errno"
+	aipkg "github.com/bingo-project/bingo/pkg/ai"
+	"github.com/bingo-project/bingo/pkg/ai/providers/fake"
+)
+
+func testToolBiz() (*chatBiz, aipkg.Tool) {
+	tools := aipkg.NewToolRegistry()
+	echo := aipkg.NewToolFunc(aipkg.FunctionDefinition{Name: "echo"}, func(_ context.Context, arguments string) (string, error) {
+		return arguments, nil
+	})
+	tools.Register(echo)
+
+	return &chatBiz{tools: tools}, echo.Definition()
+}
+
+func echoCall() fake.Step {
+	return fake.ReplyToolCalls(aipkg.ToolCall{Function: aipkg.FunctionCall{Name: "echo", Arguments: "{}"}})
+}
+
+func TestChatWithTools_LimitCharged(t *testing.T) {
+	b, echo := testToolBiz()
+	provider := newFakeProvider("fake", fake.Repeat(echoCall(), maxToolIterations+1)...)
+	req := &aipkg.ChatRequest{
+		Model:       "fake-chat",
+		Messages:    []aipkg.Message{{Role: aipkg.RoleUser, Content: "loop"}},
+		Tools:       []aipkg.Tool{echo},
+		ServerTools: []string{"echo"},
+	}
+
+	_, _, usage, err := b.chatWithTools(context.Background(), "u1", provider, req)
+	require.ErrorIs(t, err, errno.ErrAIToolCallLimitExceeded)
+	assert.Len(t, provider.Requests(), maxToolIterations+1)
+	assert.Positive(t, usage.TotalTokens, "every tool round is charged")
+}
+
+func TestChatStreamWithTools_LimitCharged(t *testing.T) {
+	b, echo := testToolBiz()
+	provider := newFakeProvider("fake", fake.Repeat(echoCall(), maxToolIterations+1)...)
+	req := &aipkg.ChatRequest{
+		Model:       "fake-chat",
+		Messages:    []aipkg.Message{{Role: aipkg.RoleUser, Content: "loop"}},
+		Tools:       []aipkg.Tool{echo},
+		ServerTools: []string{"echo"},
+	}
+
+	stream, _, err := b.chatStreamWithTools(context.Background(), "u1", provider, req)
+	require.NoError(t, err)
+
+	var usage *aipkg.Usage
+	for {
+		chunk, err := stream.Recv()
+		if err != nil {
+			assert.ErrorIs(t, err, errno.ErrAIToolCallLimitExceeded)
+
+			break
+		}
+		if chunk.Usage != nil {
+			usage = chunk.Usage
+		}
+	}
+	require.NotNil(t, usage, "the usage of the spent rounds is reported before the error")
+	assert.Positive(t, usage.TotalTokens)
+}
+
+func TestChatWithTools_ClientToolsNotExecuted(t *testing.T) {
+	b, echo := testToolBiz()
+
+	// A tool declared by the client is rejected when it collides with a server tool
+	err := b.validateTools(&aipkg.ChatRequest{Tools: []aipkg.Tool{echo}})
+	require.ErrorIs(t, err, errno.ErrInvalidArgument)
+	require.NoError(t, b.validateTools(&aipkg.ChatRequest{Tools: []aipkg.Tool{{Function: aipkg.FunctionDefinition{Name: "lookup"}}}}))
+
+	// Only server tools the server offered are executed, other calls go back to the client
+	provider := newFakeProvider("fake", echoCall())
+	req := &aipkg.ChatRequest{
+		Model:    "fake-chat",
+		Messages: []aipkg.Message{{Role: aipkg.RoleUser, Content: "hi"}},
+		Tools:    []aipkg.Tool{echo},
+	}
+	resp, trace, _, err := b.chatWithTools(context.Background(), "u1", provider, req)
+	require.NoError(t, err)
+	assert.Empty(t, trace)
+	assert.Len(t, resp.Choices[0].Message.ToolCalls, 1)
+	assert.Len(t, provider.Requests(), 1)
+}
+
+func TestPumpToolStream_FinishChunkContent(t *testing.T) {
+	b, echo := testToolBiz()
+	provider := newFakeProvider("fake", fake.Reply("done"))
+	req := &aipkg.ChatRequest{
+		Model:       "fake-chat",
+		Messages:    []aipkg.Message{{Role: aipkg.RoleUser, Content: "echo it"}},
+		Tools:       []aipkg.Tool{echo},
+		ServerTools: []string{"echo"},
+	}
+
+	// The provider sends the text before the tool call on the finish chunk
+	idx := 0
+	stream := aipkg.NewChatStream(aipkg.DefaultStreamBufferSize)
+	stream.Send(&aipkg.StreamChunk{Choices: []aipkg.Choice{{Delta: &aipkg.Message{ToolCalls: []aipkg.ToolCall{
+		{Index: &idx, ID: "call_1", Function: aipkg.FunctionCall{Name: "echo", Arguments: "{}"}},
+	}}}}})
+	stream.Send(&aipkg.StreamChunk{Choices: []aipkg.Choice{{
+		Delta:        &aipkg.Message{Role: aipkg.RoleAssistant, Content: "Let me check."},
+		FinishReason: aipkg.FinishReasonToolCalls,
+	}}})
+	stream.Close()
+
+	out := aipkg.NewChatStream(aipkg.DefaultStreamBufferSize)
+	trace := &toolTrace{}
+	go b.pumpToolStream(context.Background(), provider, req, stream, out, trace)
+
+	var content strings.Builder
+	for {
+		chunk, err := out.Recv()
+		if err != nil {
+			break
+		}
+		if len(chunk.Choices) > 0 && chunk.Choices[0].Delta != nil {
+			content.WriteString(chunk.Choices[0].Delta.Content)
+		}
+	}
+	assert.Equal(t, "Let me check.done", content.String())
+	require.NotEmpty(t, trace.messages)
+	assert.Equal(t, "Let me check.", trace.messages[0].Content)
+}
@@ -0,0 +1,127 @@
+// ABOUTME: Session transcript export and import.
+// ABOUTME: Reads a session's active branch and rebuilds a new session from a transcript.
+
+package chat
+
+import (
+	"context"
+	"slices"
+
+	"github.com/google/uuid"
+
+	"github.com/bingo-project/bingo/internal/pkg/errno"
+	"github.com/bingo-project/bingo/internal/pkg/model"
+	aipkg "github.com/bingo-project/bingo/pkg/ai"
+	v1 "github.com/bingo-project/bingo/pkg/api/apiserver/v1"
+)
+
+// Export returns a session of the user and the messages of its active branch, oldest first.
+func (b *sessionBiz) Export(ctx context.Context, uid string, sessionID string) (*model.AiSessionM, []*model.AiMessageM, error) {
+	session, err := b.getOwned(ctx, uid, sessionID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	leafID, err := activeLeaf(ctx, b.ds, session)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	messages, err := b.ds.AiMessage().ListBranch(ctx, sessionID, leafID, 0)
+	if err != nil {
+		return nil, nil, errno.ErrDBRead.WithMessage("list messages: %v", err)
+	}
+	slices.Reverse(messages)
+
+	return session, messages, nil
+}
+
+// Import creates a session of the user holding the messages as a single branch.
+func (b *sessionBiz) Import(ctx context.Context, uid string, title string, modelName string, messages []aipkg.Message) (*v1.SessionInfo, error) {
+	if err := validateTranscript(messages); err != nil {
+		return nil, err
+	}
+
+	autoTitle := title == ""
+	if autoTitle {
+		title = "导入的对话"
+	}
+	session := &model.AiSessionM{
+		SessionID:    uuid.NewString(),
+		UID:          uid,
+		Title:        title,
+		AutoTitle:    autoTitle,
+		Model:        modelName,
+		MessageCount: len(messages),
+		Status:       model.AiSessionStatusActive,
+	}
+
+	err := b.ds.TX(ctx, func(ctx context.Context) error {
+		if err := b.ds.AiSession().Create(ctx, session); err != nil {
+			return errno.ErrDBWrite.WithMessage("create session: %v", err)
+		}
+
+		var parentID uint64
+		for _, msg := range messages {
+			m := &model.AiMessageM{
+				SessionID:    session.SessionID,
+				ParentID:     parentID,
+				Role:         msg.Role,
+				Content:      msg.Content,
+				ContentParts: msg.Parts,
+				Name:         msg.Name,
+				ToolCalls:    msg.ToolCalls,
+				ToolCallID:   msg.ToolCallID,
+			}
+			if msg.Role == aipkg.RoleAssistant {
+				m.Model = modelName
+			}
+			if err := b.ds.AiMessage().Create(ctx, m); err != nil {
+				return errno.ErrDBWrite.WithMessage("create message: %v", err)
+			}
+			parentID = m.ID
+		}
+
+		session.ActiveLeafID = parentID
+		if err := b.ds.AiSession().UpdateActiveLeaf(ctx, session.SessionID, parentID); err != nil {
+			return errno.ErrDBWrite.WithMessage("update active branch: %v", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return toSessionInfo(session), nil
+}
+
+// validateTranscript checks that imported messages form a conversation the chat can continue.
+// System prompts are never stored, and tool results must answer a preceding tool call.
+func validateTranscript(messages []aipkg.Message) error {
+	if err := validateContentParts(messages); err != nil {
+		return err
+	}
+
+	pending := map[string]bool{}
+	for i, msg := range messages {
+		switch msg.Role {
+		case aipkg.RoleUser:
+			clear(pending)
+		case aipkg.RoleAssistant:
+			clear(pending)
+			for _, c := range msg.ToolCalls {
+				pending[c.ID] = true
+			}
+		case aipkg.RoleTool:
+			if !pending[msg.ToolCallID] {
+				return errno.ErrInvalidArgument.WithMessage("message %d answers no preceding tool call", i)
+			}
+			delete(pending, msg.ToolCallID)
+		default:
+			return errno.ErrInvalidArgument.WithMessage("message %d has role %s, only user, assistant and tool messages can be imported", i, msg.Role)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,72 @@
+// ABOUTME: Tests for session transcript import.
+// ABOUTME: Checks which message sequences a transcript may hold.
+
+package chat
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	aipkg "github.com/bingo-project/bingo/pkg/ai"
+)
+
+func TestValidateTranscript(t *testing.T) {
+	call := aipkg.ToolCall{ID: "call_1", Type: "function", Function: aipkg.FunctionCall{Name: "search", Arguments: "{}"}}
+
+	tests := []struct {
+		name     string
+		messages []aipkg.Message
+		wantErr  bool
+	}{
+		{
+			name: "conversation",
+			messages: []aipkg.Message{
+				{Role: aipkg.RoleUser, Content: "hi"},
+				{Role: aipkg.RoleAssistant, Content: "hello"},
+			},
+		},
+		{
+			name: "tool round",
+			messages: []aipkg.Message{
+				{Role: aipkg.RoleUser, Content: "find it"},
+				{Role: aipkg.RoleAssistant, ToolCalls: []aipkg.ToolCall{call}},
+				{Role: aipkg.RoleTool, ToolCallID: "call_1", Content: "found"},
+				{Role: aipkg.RoleAssistant, Content: "here it is"},
+			},
+		},
+		{
+			name:     "system prompt",
+			messages: []aipkg.Message{{Role: aipkg.RoleSystem, Content: "be brief"}},
+			wantErr:  true,
+		},
+		{
+			name: "tool result without call",
+			messages: []aipkg.Message{
+				{Role: aipkg.RoleUser, Content: "find it"},
+				{Role: aipkg.RoleTool, ToolCallID: "call_1", Content: "found"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "tool call answered twice",
+			messages: []aipkg.Message{
+				{Role: aipkg.RoleAssistant, ToolCalls: []aipkg.ToolCall{call}},
+				{Role: aipkg.RoleTool, ToolCallID: "call_1", Content: "found"},
+				{Role: aipkg.RoleTool, ToolCallID: "call_1", Content: "found"},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateTranscript(tt.messages)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+// ABOUTME: AI usage ledger recording.
+// ABOUTME: Writes tokens, cost, latency and status of every chat completion and the model calls made for it.
+
+package chat
+
+import (
+	"context"
+	"time"
+	"unicode/utf8"
+
+	"github.com/bingo-project/bingo/internal/pkg/ai"
+	"github.com/bingo-project/bingo/internal/pkg/log"
+	"github.com/bingo-project/bingo/internal/pkg/model"
+	aipkg "github.com/bingo-project/bingo/pkg/ai"
+)
+
+// maxUsageErrorChars is the maximum characters of an error kept in the ledger.
+const maxUsageErrorChars = 255
+
+// usageEntry describes a finished chat completion for the usage ledger.
+type usageEntry struct {
+	uid       string
+	req       *aipkg.ChatRequest
+	provider  string
+	stream    bool
+	fallback  bool
+	cancelled bool
+	usage     aipkg.Usage
+	latency   time.Duration
+	err       error
+}
+
+// recordUsage writes a usage ledger entry in the background, pricing it with the model's prices.
+func (b *chatBiz) recordUsage(e usageEntry) {
+	row := &model.AiUsageM{
+		UID:              e.uid,
+		SessionID:        e.req.SessionID,
+		AgentID:          e.req.AgentID,
+		ExperimentID:     e.req.ExperimentID,
+		ExperimentArm:    e.req.ExperimentArm,
+		ProviderName:     e.provider,
+		Model:            e.req.Model,
+		Stream:           e.stream,
+		Fallback:         e.fallback,
+		PromptTokens:     e.usage.PromptTokens,
+		CompletionTokens: e.usage.CompletionTokens,
+		TotalTokens:      e.usage.TotalTokens,
+		LatencyMs:        e.latency.Milliseconds(),
+		Status:           model.AiUsageStatusSuccess,
+	}
+	if e.cancelled {
+		row.Status = model.AiUsageStatusCancelled
+	}
+	if e.err != nil {
+		row.Status = model.AiUsageStatusError
+		row.Error = truncateRunes(e.err.Error(), maxUsageErrorChars)
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), saveSessionTimeout)
+		defer cancel()
+
+		if m, err := b.ds.AiModel().GetByProviderAndModel(ctx, row.ProviderName, row.Model); err == nil {
+			row.Cost = ai.UsageCost(m, e.usage)
+		}
+		if err := b.ds.AiUsage().Create(ctx, row); err != nil {
+			log.C(ctx).Errorw("Failed to record AI usage",
+				"uid", row.UID, "provider", row.ProviderName, "model", row.Model, "err", err)
+		}
+	}()
+}
+
+// recordSideUsage records a model call made on the user's behalf besides the answer, such as a
+// session summary, and charges its tokens to the user's daily quota like the answer itself.
+func (b *chatBiz) recordSideUsage(ctx context.Context, uid string, req *aipkg.ChatRequest, providerName string, usage aipkg.Usage, latency time.Duration, err error) {
+	b.recordUsage(usageEntry{uid: uid, req: req, provider: providerName, usage: usage, latency: latency, err: err})
+
+	if b.quota == nil || usage.TotalTokens == 0 {
+		return
+	}
+	if err := b.quota.AdjustTPD(ctx, uid, usage.TotalTokens, 0); err != nil {
+		log.C(ctx).Errorw("Failed to charge AI usage to TPD quota", "uid", uid, "model", req.Model, "tokens", usage.TotalTokens, "err", err)
+	}
+}
+
+// recordEmbedUsage records an embedding made for a chat, such as a knowledge base search,
+// and charges it like other side requests. Nothing is recorded if no provider was called.
+func (b *chatBiz) recordEmbedUsage(ctx context.Context, req *aipkg.ChatRequest, call ai.EmbedCall, err error) {
+	if call.Provider == "" {
+		return
+	}
+
+	embedReq := &aipkg.ChatRequest{Model: call.Model, SessionID: req.SessionID, AgentID: req.AgentID}
+	b.recordSideUsage(ctx, req.UID, embedReq, call.Provider, call.Usage, call.Latency, err)
+}
+
+// estimateUsage estimates the usage of a generation stopped before the provider reported it.
+func estimateUsage(modelName string, promptTokens int, content string) aipkg.Usage {
+	completion := aipkg.TokenizerFor(modelName).CountMessage(aipkg.Message{Role: aipkg.RoleAssistant, Content: content})
+
+	return aipkg.Usage{
+		PromptTokens:     promptTokens,
+		CompletionTokens: completion,
+		TotalTokens:      promptTokens + completion,
+	}
+}
+
+// truncateRunes cuts s to at most n characters.
+func truncateRunes(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+
+	return string([]rune(s)[:n])
+}
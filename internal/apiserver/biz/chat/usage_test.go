@@ -0,0 +1,73 @@
+// ABOUTME: Tests for usage ledger helpers.
+// ABOUTME: Verifies error truncation and charging of unanswered, side and embedding requests.
+
+package chat
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bingo-project/bingo/internal/pkg/ai"
+	"github.com/bingo-project/bingo/internal/pkg/errno"
+	"github.com/bingo-project/bingo/internal/pkg/model"
+	mockstore "github.com/bingo-project/bingo/internal/pkg/testing/mock/store"
+	aipkg "github.com/bingo-project/bingo/pkg/ai"
+)
+
+func TestTruncateRunes(t *testing.T) {
+	assert.Equal(t, "abc", truncateRunes("abc", 5))
+	assert.Equal(t, "你好", truncateRunes("你好世界", 2))
+	assert.Len(t, []rune(truncateRunes(strings.Repeat("x", 300), maxUsageErrorChars)), maxUsageErrorChars)
+}
+
+func TestSettleUnanswered(t *testing.T) {
+	ds := mockstore.NewStore()
+	b := &chatBiz{ds: ds, quota: newQuotaChecker(ds)}
+	req := &aipkg.ChatRequest{Model: "fake-chat", SessionID: "s1"}
+
+	spent := aipkg.Usage{PromptTokens: 300, CompletionTokens: 60, TotalTokens: 360}
+	b.settleUnanswered("u1", req, "fake", false, false, spent, 1000, time.Now(), errno.ErrAIInvalidStructuredOutput)
+
+	assert.Eventually(t, func() bool {
+		_, rows, _ := ds.AiUsage().List(context.Background(), nil)
+
+		return len(rows) == 1 && rows[0].TotalTokens == 360 && rows[0].Status == model.AiUsageStatusError && rows[0].SessionID == "s1"
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestRecordSideUsage(t *testing.T) {
+	ds := mockstore.NewStore()
+	b := &chatBiz{ds: ds, quota: newQuotaChecker(ds)}
+	req := &aipkg.ChatRequest{Model: "fake-summary", SessionID: "s1"}
+
+	b.recordSideUsage(context.Background(), "u1", req, "fake", aipkg.Usage{PromptTokens: 80, CompletionTokens: 20, TotalTokens: 100}, time.Millisecond, nil)
+
+	assert.Eventually(t, func() bool {
+		_, rows, _ := ds.AiUsage().List(context.Background(), nil)
+
+		return len(rows) == 1 && rows[0].UID == "u1" && rows[0].TotalTokens == 100 && rows[0].SessionID == "s1"
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestRecordEmbedUsage(t *testing.T) {
+	ds := mockstore.NewStore()
+	b := &chatBiz{ds: ds, quota: newQuotaChecker(ds)}
+	req := &aipkg.ChatRequest{UID: "u1", Model: "fake-chat", SessionID: "s1", AgentID: "a1"}
+
+	// No provider was called, nothing is recorded
+	b.recordEmbedUsage(context.Background(), req, ai.EmbedCall{Model: "fake-embed"}, errno.ErrAIModelNotFound)
+
+	call := ai.EmbedCall{Provider: "fake", Model: "fake-embed", Usage: aipkg.Usage{PromptTokens: 12, TotalTokens: 12}, Latency: time.Millisecond}
+	b.recordEmbedUsage(context.Background(), req, call, nil)
+
+	assert.Eventually(t, func() bool {
+		_, rows, _ := ds.AiUsage().List(context.Background(), nil)
+
+		return len(rows) == 1 && rows[0].UID == "u1" && rows[0].Model == "fake-embed" &&
+			rows[0].TotalTokens == 12 && rows[0].SessionID == "s1" && rows[0].AgentID == "a1"
+	}, time.Second, 10*time.Millisecond)
+}
@@ -1,6 +1,7 @@
 package file
 
 import (
+	"fmt"
 	"path/filepath"
 	"strings"
 	"time"
@@ -77,6 +78,22 @@ func (b *fileBiz) Upload(ctx *gin.Context, req *v1.UploadFileRequest) (path stri
 	return
 }
 
+// LocalPath maps a path returned by Upload (e.g. storage/upload/2006/01/02/x.png)
+// to the file on disk, rejecting anything outside the upload directory.
+func LocalPath(ref string) (string, error) {
+	rel, ok := strings.CutPrefix(strings.TrimPrefix(ref, "/"), "storage/upload/")
+	if !ok {
+		return "", fmt.Errorf("not an uploaded file: %s", ref)
+	}
+
+	rel = filepath.Clean("/" + rel)[1:]
+	if rel == "" {
+		return "", fmt.Errorf("not an uploaded file: %s", ref)
+	}
+
+	return filepath.Join("storage/public/upload", rel), nil
+}
+
 func getResizeRatio(size int64) float64 {
 	// <= 300k
 	if size < 1024*100 {
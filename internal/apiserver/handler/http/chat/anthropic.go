@@ -0,0 +1,264 @@
+// ABOUTME: Anthropic Messages-compatible HTTP handler for AI chat.
+// ABOUTME: Converts Anthropic requests and responses around the shared chat pipeline.
+
+package chat
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/bingo-project/bingo/internal/pkg/core"
+	"github.com/bingo-project/bingo/internal/pkg/errno"
+	"github.com/bingo-project/bingo/pkg/ai"
+	v1 "github.com/bingo-project/bingo/pkg/api/apiserver/v1"
+	"github.com/bingo-project/bingo/pkg/contextx"
+)
+
+// Messages
+// @Summary    Create a message (Anthropic-compatible)
+// @Security   Bearer
+// @Tags       AI
+// @Accept     application/json
+// @Produce    json
+// @Param      request  body      v1.AnthropicMessagesRequest  true  "Messages request"
+// @Success    200      {object}  v1.AnthropicMessageResponse
+// @Failure    400      {object}  core.ErrResponse
+// @Failure    404      {object}  core.ErrResponse
+// @Failure    429      {object}  core.ErrResponse
+// @Failure    500      {object}  core.ErrResponse
+// @Router     /v1/messages [POST].
+func (h *ChatHandler) Messages(c *gin.Context) {
+	var req v1.AnthropicMessagesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		core.Response(c, nil, errno.ErrInvalidArgument.WithMessage("%s", err.Error()))
+
+		return
+	}
+
+	uid := contextx.UserID(c)
+	aiReq, err := convertAnthropicRequest(&req)
+	if err != nil {
+		core.Response(c, nil, err)
+
+		return
+	}
+	aiReq.UID = uid
+
+	chat := h.b.Chat()
+	if aiReq.Stream {
+		stream, err := chat.ChatStream(c, uid, aiReq)
+		if err != nil {
+			core.Response(c, nil, err)
+
+			return
+		}
+
+		writeAnthropicStream(c, stream, req.Model)
+
+		return
+	}
+
+	resp, err := chat.Chat(c, uid, aiReq)
+	if err != nil {
+		core.Response(c, nil, err)
+
+		return
+	}
+
+	core.Response(c, convertToAnthropic(resp), nil)
+}
+
+// convertAnthropicRequest converts v1.AnthropicMessagesRequest to ai.ChatRequest
+func convertAnthropicRequest(req *v1.AnthropicMessagesRequest) (*ai.ChatRequest, error) {
+	aiReq := &ai.ChatRequest{
+		Model:       req.Model,
+		MaxTokens:   req.MaxTokens,
+		Temperature: req.Temperature,
+		Stream:      req.Stream,
+		SessionID:   req.SessionID,
+	}
+
+	if system := anthropicText(req.System); system != "" {
+		aiReq.Messages = append(aiReq.Messages, ai.Message{Role: ai.RoleSystem, Content: system})
+	}
+	for _, msg := range req.Messages {
+		messages, err := convertAnthropicMessage(msg)
+		if err != nil {
+			return nil, err
+		}
+		aiReq.Messages = append(aiReq.Messages, messages...)
+	}
+
+	for _, t := range req.Tools {
+		aiReq.Tools = append(aiReq.Tools, ai.Tool{
+			Type: ai.ToolTypeFunction,
+			Function: ai.FunctionDefinition{
+				Name:        t.Name,
+				Description: t.Description,
+				Parameters:  t.InputSchema,
+			},
+		})
+	}
+	if tc := req.ToolChoice; tc != nil {
+		switch tc.Type {
+		case "auto":
+			aiReq.ToolChoice = &ai.ToolChoice{Mode: ai.ToolChoiceAuto}
+		case "any":
+			aiReq.ToolChoice = &ai.ToolChoice{Mode: ai.ToolChoiceRequired}
+		case "none":
+			aiReq.ToolChoice = &ai.ToolChoice{Mode: ai.ToolChoiceNone}
+		case "tool":
+			aiReq.ToolChoice = &ai.ToolChoice{Mode: ai.ToolChoiceRequired, Name: tc.Name}
+		}
+	}
+
+	return aiReq, nil
+}
+
+// convertAnthropicMessage converts an Anthropic turn to ai messages. Tool results of a
+// user turn become tool messages ahead of the rest of its content.
+func convertAnthropicMessage(msg v1.AnthropicMessage) ([]ai.Message, error) {
+	if msg.Content.IsEmpty() {
+		return nil, errno.ErrInvalidArgument.WithMessage("message content is required")
+	}
+	if len(msg.Content.Blocks) == 0 {
+		return []ai.Message{{Role: msg.Role, Content: msg.Content.Text}}, nil
+	}
+
+	if msg.Role == ai.RoleAssistant {
+		m := ai.Message{Role: ai.RoleAssistant}
+		var texts []string
+		for _, b := range msg.Content.Blocks {
+			switch b.Type {
+			case v1.AnthropicBlockText:
+				texts = append(texts, b.Text)
+			case v1.AnthropicBlockToolUse:
+				args := "{}"
+				if len(b.Input) > 0 {
+					args = string(b.Input)
+				}
+				m.ToolCalls = append(m.ToolCalls, ai.ToolCall{
+					ID:       b.ID,
+					Type:     ai.ToolTypeFunction,
+					Function: ai.FunctionCall{Name: b.Name, Arguments: args},
+				})
+			default:
+				return nil, errno.ErrInvalidArgument.WithMessage("%s blocks are not allowed in assistant messages", b.Type)
+			}
+		}
+		m.Content = strings.Join(texts, "\n")
+
+		return []ai.Message{m}, nil
+	}
+
+	var messages []ai.Message
+	var parts []ai.ContentPart
+	hasImage := false
+	for _, b := range msg.Content.Blocks {
+		switch b.Type {
+		case v1.AnthropicBlockText:
+			parts = append(parts, ai.ContentPart{Type: ai.ContentPartText, Text: b.Text})
+		case v1.AnthropicBlockImage:
+			url := b.Source.URL
+			if b.Source.Type == "base64" {
+				url = "data:" + b.Source.MediaType + ";base64," + b.Source.Data
+			}
+			parts = append(parts, ai.ContentPart{Type: ai.ContentPartImageURL, ImageURL: &ai.ImageURL{URL: url}})
+			hasImage = true
+		case v1.AnthropicBlockToolResult:
+			var content string
+			if b.Content != nil {
+				content = anthropicText(*b.Content)
+			}
+			messages = append(messages, ai.Message{Role: ai.RoleTool, Content: content, ToolCallID: b.ToolUseID})
+		default:
+			return nil, errno.ErrInvalidArgument.WithMessage("%s blocks are not allowed in user messages", b.Type)
+		}
+	}
+
+	if len(parts) > 0 {
+		m := ai.Message{Role: ai.RoleUser, Content: ai.TextOf(parts)}
+		if hasImage {
+			m.Parts = parts
+		}
+		messages = append(messages, m)
+	}
+
+	return messages, nil
+}
+
+// anthropicText joins the text of plain or block content
+func anthropicText(c v1.AnthropicContent) string {
+	if len(c.Blocks) == 0 {
+		return c.Text
+	}
+
+	var texts []string
+	for _, b := range c.Blocks {
+		if b.Type == v1.AnthropicBlockText {
+			texts = append(texts, b.Text)
+		}
+	}
+
+	return strings.Join(texts, "\n")
+}
+
+// convertToAnthropic converts ai.ChatResponse to v1.AnthropicMessageResponse
+func convertToAnthropic(resp *ai.ChatResponse) *v1.AnthropicMessageResponse {
+	msg := &v1.AnthropicMessageResponse{
+		ID:      resp.ID,
+		Type:    "message",
+		Role:    ai.RoleAssistant,
+		Model:   resp.Model,
+		Content: []v1.AnthropicContentBlock{},
+		Usage: v1.AnthropicUsage{
+			InputTokens:  resp.Usage.PromptTokens,
+			OutputTokens: resp.Usage.CompletionTokens,
+		},
+	}
+	if len(resp.Choices) == 0 {
+		return msg
+	}
+
+	choice := resp.Choices[0]
+	if choice.Message.Content != "" {
+		msg.Content = append(msg.Content, v1.AnthropicContentBlock{Type: v1.AnthropicBlockText, Text: choice.Message.Content})
+	}
+	for _, tc := range choice.Message.ToolCalls {
+		msg.Content = append(msg.Content, v1.AnthropicContentBlock{
+			Type:  v1.AnthropicBlockToolUse,
+			ID:    tc.ID,
+			Name:  tc.Function.Name,
+			Input: toolInput(tc.Function.Arguments),
+		})
+	}
+	stopReason := anthropicStopReason(choice.FinishReason)
+	msg.StopReason = &stopReason
+
+	return msg
+}
+
+// toolInput returns tool call arguments as a JSON object, an empty one if they are not valid JSON
+func toolInput(arguments string) json.RawMessage {
+	if arguments == "" || !json.Valid([]byte(arguments)) {
+		return json.RawMessage("{}")
+	}
+
+	return json.RawMessage(arguments)
+}
+
+// anthropicStopReason maps a finish reason to an Anthropic stop reason
+func anthropicStopReason(finishReason string) string {
+	switch finishReason {
+	case ai.FinishReasonLength:
+		return v1.AnthropicStopMaxTokens
+	case ai.FinishReasonToolCalls:
+		return v1.AnthropicStopToolUse
+	case ai.FinishReasonContentFilter:
+		return v1.AnthropicStopRefusal
+	default:
+		return v1.AnthropicStopEndTurn
+	}
+}
@@ -0,0 +1,203 @@
+// ABOUTME: Anthropic Messages stream events for AI chat.
+// ABOUTME: Translates OpenAI-style stream chunks into message and content block events.
+
+package chat
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/bingo-project/bingo/pkg/ai"
+	v1 "github.com/bingo-project/bingo/pkg/api/apiserver/v1"
+)
+
+// Anthropic stream event types.
+const (
+	anthropicEventMessageStart      = "message_start"
+	anthropicEventMessageDelta      = "message_delta"
+	anthropicEventMessageStop       = "message_stop"
+	anthropicEventContentBlockStart = "content_block_start"
+	anthropicEventContentBlockDelta = "content_block_delta"
+	anthropicEventContentBlockStop  = "content_block_stop"
+	anthropicEventError             = "error"
+)
+
+// anthropicStream tracks the open content block while chunks are translated to events.
+// Text and each tool call get a block of their own, opening one closes the previous.
+type anthropicStream struct {
+	model        string
+	started      bool
+	index        int    // Index of the open block, -1 if none
+	blockType    string // Type of the open block
+	toolIndex    int    // Stream index of the tool call in the open tool_use block
+	toolID       string // ID of the tool call in the open tool_use block
+	stopReason   string
+	outputTokens int
+}
+
+func newAnthropicStream(model string) *anthropicStream {
+	return &anthropicStream{model: model, index: -1, stopReason: v1.AnthropicStopEndTurn}
+}
+
+// chunk returns the events of a stream chunk.
+func (s *anthropicStream) chunk(chunk *ai.StreamChunk) []v1.AnthropicStreamEvent {
+	var events []v1.AnthropicStreamEvent
+	if !s.started {
+		if chunk.Model != "" {
+			s.model = chunk.Model
+		}
+		events = append(events, s.start(chunk.ID))
+	}
+
+	for _, ch := range chunk.Choices {
+		if ch.Delta != nil {
+			if ch.Delta.Content != "" {
+				if s.blockType != v1.AnthropicBlockText {
+					events = append(events, s.open(v1.AnthropicBlockText, v1.AnthropicContentBlock{Type: v1.AnthropicBlockText})...)
+				}
+				events = append(events, s.delta(&v1.AnthropicStreamDelta{Type: "text_delta", Text: ch.Delta.Content}))
+			}
+			for _, tc := range ch.Delta.ToolCalls {
+				toolIndex := s.toolIndex
+				if tc.Index != nil {
+					toolIndex = *tc.Index
+				}
+				if s.blockType != v1.AnthropicBlockToolUse || toolIndex != s.toolIndex || (tc.ID != "" && tc.ID != s.toolID) {
+					events = append(events, s.open(v1.AnthropicBlockToolUse, v1.AnthropicContentBlock{
+						Type: v1.AnthropicBlockToolUse,
+						ID:   tc.ID,
+						Name: tc.Function.Name,
+					})...)
+					s.toolIndex = toolIndex
+					s.toolID = tc.ID
+				}
+				if tc.Function.Arguments != "" {
+					events = append(events, s.delta(&v1.AnthropicStreamDelta{Type: "input_json_delta", PartialJSON: tc.Function.Arguments}))
+				}
+			}
+		}
+		if ch.FinishReason != "" {
+			s.stopReason = anthropicStopReason(ch.FinishReason)
+		}
+	}
+	if chunk.Usage != nil {
+		s.outputTokens = chunk.Usage.CompletionTokens
+	}
+
+	return events
+}
+
+// finish returns the closing events of a stream that ended with err, nil or
+// ai.ErrStreamClosed for a normal end.
+func (s *anthropicStream) finish(id string, err error) []v1.AnthropicStreamEvent {
+	var events []v1.AnthropicStreamEvent
+	if !s.started {
+		events = append(events, s.start(id))
+	}
+	if err != nil && !errors.Is(err, ai.ErrStreamClosed) {
+		return append(events, v1.AnthropicStreamEvent{
+			Type:  anthropicEventError,
+			Error: &v1.AnthropicError{Type: "api_error", Message: "stream error occurred"},
+		})
+	}
+
+	events = append(events, s.close()...)
+	stopReason := s.stopReason
+
+	return append(events,
+		v1.AnthropicStreamEvent{
+			Type:  anthropicEventMessageDelta,
+			Delta: &v1.AnthropicStreamDelta{StopReason: &stopReason},
+			Usage: &v1.AnthropicUsage{OutputTokens: s.outputTokens},
+		},
+		v1.AnthropicStreamEvent{Type: anthropicEventMessageStop},
+	)
+}
+
+// start returns the message_start event.
+func (s *anthropicStream) start(id string) v1.AnthropicStreamEvent {
+	s.started = true
+
+	return v1.AnthropicStreamEvent{
+		Type: anthropicEventMessageStart,
+		Message: &v1.AnthropicMessageResponse{
+			ID:      id,
+			Type:    "message",
+			Role:    ai.RoleAssistant,
+			Model:   s.model,
+			Content: []v1.AnthropicContentBlock{},
+		},
+	}
+}
+
+// open closes the open block and starts a new one.
+func (s *anthropicStream) open(blockType string, block v1.AnthropicContentBlock) []v1.AnthropicStreamEvent {
+	events := s.close()
+	s.index++
+	s.blockType = blockType
+	index := s.index
+
+	return append(events, v1.AnthropicStreamEvent{Type: anthropicEventContentBlockStart, Index: &index, ContentBlock: &block})
+}
+
+// close returns the content_block_stop event of the open block, if any.
+func (s *anthropicStream) close() []v1.AnthropicStreamEvent {
+	if s.blockType == "" {
+		return nil
+	}
+	s.blockType = ""
+	index := s.index
+
+	return []v1.AnthropicStreamEvent{{Type: anthropicEventContentBlockStop, Index: &index}}
+}
+
+// delta returns a content_block_delta event of the open block.
+func (s *anthropicStream) delta(delta *v1.AnthropicStreamDelta) v1.AnthropicStreamEvent {
+	index := s.index
+
+	return v1.AnthropicStreamEvent{Type: anthropicEventContentBlockDelta, Index: &index, Delta: delta}
+}
+
+// writeAnthropicStream writes chunks as Anthropic server-sent events. Like writeStream,
+// the rest of the stream is drained in the background if the client goes away.
+func writeAnthropicStream(c *gin.Context, stream *ai.ChatStream, model string) {
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+
+	s := newAnthropicStream(model)
+	var id string
+	if clientGone := c.Stream(func(w io.Writer) bool {
+		chunk, err := stream.Recv()
+		if err != nil {
+			writeAnthropicEvents(w, s.finish(id, err))
+
+			return false
+		}
+
+		id = chunk.ID
+		writeAnthropicEvents(w, s.chunk(chunk))
+
+		return true
+	}); clientGone {
+		go func() {
+			for {
+				if _, err := stream.Recv(); err != nil {
+					return
+				}
+			}
+		}()
+	}
+}
+
+// writeAnthropicEvents writes events named after their type.
+func writeAnthropicEvents(w io.Writer, events []v1.AnthropicStreamEvent) {
+	for _, e := range events {
+		data, _ := json.Marshal(e)
+		fmt.Fprintf(w, "event: %s\ndata: %s\n\n", e.Type, data)
+	}
+}
@@ -0,0 +1,183 @@
+package chat
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bingo-project/bingo/pkg/ai"
+	v1 "github.com/bingo-project/bingo/pkg/api/apiserver/v1"
+)
+
+func TestConvertAnthropicRequest(t *testing.T) {
+	var req v1.AnthropicMessagesRequest
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"model": "glm-4-flash",
+		"max_tokens": 256,
+		"system": [{"type": "text", "text": "Be brief."}],
+		"messages": [
+			{"role": "user", "content": [
+				{"type": "text", "text": "What is this?"},
+				{"type": "image", "source": {"type": "base64", "media_type": "image/png", "data": "AAAA"}}
+			]},
+			{"role": "assistant", "content": [
+				{"type": "text", "text": "Checking."},
+				{"type": "tool_use", "id": "toolu_1", "name": "lookup", "input": {"q": "cat"}}
+			]},
+			{"role": "user", "content": [
+				{"type": "tool_result", "tool_use_id": "toolu_1", "content": "a cat"},
+				{"type": "text", "text": "Thanks"}
+			]}
+		],
+		"tools": [{"name": "lookup", "input_schema": {"type": "object"}}],
+		"tool_choice": {"type": "any"}
+	}`), &req))
+
+	got, err := convertAnthropicRequest(&req)
+	require.NoError(t, err)
+
+	assert.Equal(t, 256, got.MaxTokens)
+	require.Len(t, got.Messages, 5)
+	assert.Equal(t, ai.Message{Role: ai.RoleSystem, Content: "Be brief."}, got.Messages[0])
+
+	assert.Equal(t, "What is this?", got.Messages[1].Content)
+	require.Len(t, got.Messages[1].Parts, 2)
+	assert.Equal(t, "data:image/png;base64,AAAA", got.Messages[1].Parts[1].ImageURL.URL)
+
+	assert.Equal(t, "Checking.", got.Messages[2].Content)
+	require.Len(t, got.Messages[2].ToolCalls, 1)
+	assert.Equal(t, "toolu_1", got.Messages[2].ToolCalls[0].ID)
+	assert.JSONEq(t, `{"q": "cat"}`, got.Messages[2].ToolCalls[0].Function.Arguments)
+
+	// Tool results come first, text-only content stays plain
+	assert.Equal(t, ai.Message{Role: ai.RoleTool, Content: "a cat", ToolCallID: "toolu_1"}, got.Messages[3])
+	assert.Equal(t, ai.Message{Role: ai.RoleUser, Content: "Thanks"}, got.Messages[4])
+
+	require.Len(t, got.Tools, 1)
+	assert.Equal(t, "lookup", got.Tools[0].Function.Name)
+	assert.Equal(t, &ai.ToolChoice{Mode: ai.ToolChoiceRequired}, got.ToolChoice)
+}
+
+func TestConvertAnthropicRequest_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		msg  v1.AnthropicMessage
+	}{
+		{
+			name: "empty content",
+			msg:  v1.AnthropicMessage{Role: ai.RoleUser},
+		},
+		{
+			name: "tool use from user",
+			msg: v1.AnthropicMessage{Role: ai.RoleUser, Content: v1.AnthropicContent{Blocks: []v1.AnthropicContentBlock{
+				{Type: v1.AnthropicBlockToolUse, ID: "toolu_1", Name: "lookup"},
+			}}},
+		},
+		{
+			name: "image from assistant",
+			msg: v1.AnthropicMessage{Role: ai.RoleAssistant, Content: v1.AnthropicContent{Blocks: []v1.AnthropicContentBlock{
+				{Type: v1.AnthropicBlockImage, Source: &v1.AnthropicImageSource{Type: "url", URL: "https://example.com/a.png"}},
+			}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := convertAnthropicRequest(&v1.AnthropicMessagesRequest{MaxTokens: 1, Messages: []v1.AnthropicMessage{tt.msg}})
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestConvertToAnthropic(t *testing.T) {
+	got := convertToAnthropic(&ai.ChatResponse{
+		ID:    "chatcmpl-1",
+		Model: "glm-4-flash",
+		Choices: []ai.Choice{{
+			Message: ai.Message{
+				Role:    ai.RoleAssistant,
+				Content: "Let me check.",
+				ToolCalls: []ai.ToolCall{
+					{ID: "call_1", Type: ai.ToolTypeFunction, Function: ai.FunctionCall{Name: "lookup", Arguments: `{"q":"cat"}`}},
+				},
+			},
+			FinishReason: ai.FinishReasonToolCalls,
+		}},
+		Usage: ai.Usage{PromptTokens: 10, CompletionTokens: 5, TotalTokens: 15},
+	})
+
+	data, err := json.Marshal(got)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"id": "chatcmpl-1",
+		"type": "message",
+		"role": "assistant",
+		"model": "glm-4-flash",
+		"content": [
+			{"type": "text", "text": "Let me check."},
+			{"type": "tool_use", "id": "call_1", "name": "lookup", "input": {"q": "cat"}}
+		],
+		"stop_reason": "tool_use",
+		"stop_sequence": null,
+		"usage": {"input_tokens": 10, "output_tokens": 5}
+	}`, string(data))
+}
+
+func TestAnthropicStream(t *testing.T) {
+	idx := func(i int) *int { return &i }
+	chunks := []*ai.StreamChunk{
+		{ID: "chatcmpl-1", Model: "glm-4-flash", Choices: []ai.Choice{{Delta: &ai.Message{Content: "Hel"}}}},
+		{ID: "chatcmpl-1", Choices: []ai.Choice{{Delta: &ai.Message{Content: "lo"}}}},
+		{ID: "chatcmpl-1", Choices: []ai.Choice{{Delta: &ai.Message{ToolCalls: []ai.ToolCall{
+			{Index: idx(0), ID: "call_1", Function: ai.FunctionCall{Name: "lookup"}},
+		}}}}},
+		{ID: "chatcmpl-1", Choices: []ai.Choice{{Delta: &ai.Message{ToolCalls: []ai.ToolCall{
+			{Index: idx(0), Function: ai.FunctionCall{Arguments: `{"q":`}},
+		}}}}},
+		{ID: "chatcmpl-1", Choices: []ai.Choice{{Delta: &ai.Message{ToolCalls: []ai.ToolCall{
+			{Index: idx(0), Function: ai.FunctionCall{Arguments: `"cat"}`}},
+		}}}}},
+		{ID: "chatcmpl-1", Choices: []ai.Choice{{Delta: &ai.Message{}, FinishReason: ai.FinishReasonToolCalls}}, Usage: &ai.Usage{CompletionTokens: 7}},
+	}
+
+	s := newAnthropicStream("")
+	var events []v1.AnthropicStreamEvent
+	for _, chunk := range chunks {
+		events = append(events, s.chunk(chunk)...)
+	}
+	events = append(events, s.finish("chatcmpl-1", ai.ErrStreamClosed)...)
+
+	var got []string
+	for _, e := range events {
+		data, err := json.Marshal(e)
+		require.NoError(t, err)
+		got = append(got, string(data))
+	}
+
+	want := []string{
+		`{"type":"message_start","message":{"id":"chatcmpl-1","type":"message","role":"assistant","model":"glm-4-flash","content":[],"stop_reason":null,"stop_sequence":null,"usage":{"input_tokens":0,"output_tokens":0}}}`,
+		`{"type":"content_block_start","index":0,"content_block":{"type":"text","text":""}}`,
+		`{"type":"content_block_delta","index":0,"delta":{"type":"text_delta","text":"Hel"}}`,
+		`{"type":"content_block_delta","index":0,"delta":{"type":"text_delta","text":"lo"}}`,
+		`{"type":"content_block_stop","index":0}`,
+		`{"type":"content_block_start","index":1,"content_block":{"type":"tool_use","id":"call_1","name":"lookup","input":{}}}`,
+		`{"type":"content_block_delta","index":1,"delta":{"type":"input_json_delta","partial_json":"{\"q\":"}}`,
+		`{"type":"content_block_delta","index":1,"delta":{"type":"input_json_delta","partial_json":"\"cat\"}"}}`,
+		`{"type":"content_block_stop","index":1}`,
+		`{"type":"message_delta","delta":{"stop_reason":"tool_use"},"usage":{"input_tokens":0,"output_tokens":7}}`,
+		`{"type":"message_stop"}`,
+	}
+	assert.Equal(t, want, got)
+}
+
+func TestAnthropicStream_Error(t *testing.T) {
+	s := newAnthropicStream("glm-4-flash")
+	events := s.finish("chatcmpl-1", assert.AnError)
+
+	require.Len(t, events, 2)
+	assert.Equal(t, "message_start", events[0].Type)
+	assert.Equal(t, "error", events[1].Type)
+	assert.Equal(t, "api_error", events[1].Error.Type)
+}
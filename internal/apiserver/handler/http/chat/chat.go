@@ -8,10 +8,13 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 
 	"github.com/bingo-project/bingo/internal/apiserver/biz"
+	bizchat "github.com/bingo-project/bingo/internal/apiserver/biz/chat"
 	"github.com/bingo-project/bingo/internal/pkg/core"
 	"github.com/bingo-project/bingo/internal/pkg/errno"
 	"github.com/bingo-project/bingo/internal/pkg/store"
@@ -36,13 +39,22 @@ func NewChatHandler(ds store.IStore, registry *ai.Registry) *ChatHandler {
 // @Tags       AI
 // @Accept     application/json
 // @Produce    json
-// @Param      request  body      v1.ChatCompletionRequest  true  "Chat request"
-// @Success    200      {object}  v1.ChatCompletionResponse
-// @Failure    400      {object}  core.ErrResponse
-// @Failure    429      {object}  core.ErrResponse
-// @Failure    500      {object}  core.ErrResponse
+// @Param      request        body      v1.ChatCompletionRequest  true   "Chat request"
+// @Param      Last-Event-ID  header    string                    false  "Resume a stream after this event ID"
+// @Success    200            {object}  v1.ChatCompletionResponse
+// @Failure    400            {object}  core.ErrResponse
+// @Failure    404            {object}  core.ErrResponse
+// @Failure    429            {object}  core.ErrResponse
+// @Failure    500            {object}  core.ErrResponse
 // @Router     /v1/chat/completions [POST].
 func (h *ChatHandler) ChatCompletions(c *gin.Context) {
+	// Reconnecting clients resume the interrupted stream instead of starting a new one
+	if lastEventID := c.GetHeader("Last-Event-ID"); lastEventID != "" {
+		h.resumeStream(c, lastEventID)
+
+		return
+	}
+
 	var req v1.ChatCompletionRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		core.Response(c, nil, errno.ErrInvalidArgument.WithMessage("%s", err.Error()))
@@ -60,21 +72,54 @@ func (h *ChatHandler) ChatCompletions(c *gin.Context) {
 		Stream:      req.Stream,
 		SessionID:   req.SessionID,
 		UID:         uid,
+		ToolChoice:  ai.ParseToolChoice(req.ToolChoice),
+	}
+	if f := req.ResponseFormat; f != nil {
+		aiReq.ResponseFormat = &ai.ResponseFormat{Type: f.Type}
+		if f.JSONSchema != nil {
+			aiReq.ResponseFormat.Name = f.JSONSchema.Name
+			aiReq.ResponseFormat.Schema = f.JSONSchema.Schema
+			aiReq.ResponseFormat.Strict = f.JSONSchema.Strict
+		}
 	}
 	for _, msg := range req.Messages {
-		aiReq.Messages = append(aiReq.Messages, ai.Message{
-			Role:    msg.Role,
-			Content: msg.Content,
+		if msg.Content.IsEmpty() && len(msg.ToolCalls) == 0 {
+			core.Response(c, nil, errno.ErrInvalidArgument.WithMessage("message content is required"))
+
+			return
+		}
+		aiReq.Messages = append(aiReq.Messages, convertMessageFromDTO(msg))
+	}
+	for _, t := range req.Tools {
+		aiReq.Tools = append(aiReq.Tools, ai.Tool{
+			Type: t.Type,
+			Function: ai.FunctionDefinition{
+				Name:        t.Function.Name,
+				Description: t.Function.Description,
+				Parameters:  t.Function.Parameters,
+			},
 		})
 	}
 
+	complete(c, h.b.Chat(), uid, aiReq)
+}
+
+// complete runs a chat request and responds with the completion or its event stream
+func complete(c *gin.Context, b bizchat.ChatBiz, uid string, req *ai.ChatRequest) {
 	if req.Stream {
-		h.handleStream(c, uid, aiReq)
+		stream, err := b.ChatStream(c, uid, req)
+		if err != nil {
+			core.Response(c, nil, err)
+
+			return
+		}
+
+		writeStream(c, stream, 0)
 
 		return
 	}
 
-	resp, err := h.b.Chat().Chat(c, uid, aiReq)
+	resp, err := b.Chat(c, uid, req)
 	if err != nil {
 		core.Response(c, nil, err)
 
@@ -84,20 +129,35 @@ func (h *ChatHandler) ChatCompletions(c *gin.Context) {
 	core.Response(c, convertToDTO(resp), nil)
 }
 
-// handleStream handles streaming response
-func (h *ChatHandler) handleStream(c *gin.Context, uid string, req *ai.ChatRequest) {
-	stream, err := h.b.Chat().ChatStream(c, uid, req)
+// resumeStream replays a generation after the event ID the client saw last
+func (h *ChatHandler) resumeStream(c *gin.Context, lastEventID string) {
+	id, seq, err := parseEventID(lastEventID)
+	if err != nil {
+		core.Response(c, nil, errno.ErrInvalidArgument.WithMessage("invalid Last-Event-ID: %s", lastEventID))
+
+		return
+	}
+
+	// The request context ends the resumed stream once the client leaves
+	stream, err := h.b.Generations().Resume(c.Request.Context(), contextx.UserID(c), id, seq)
 	if err != nil {
 		core.Response(c, nil, err)
 
 		return
 	}
 
+	writeStream(c, stream, seq)
+}
+
+// writeStream writes chunks as server-sent events with "<generation id>:<seq>" event IDs,
+// seq continues from the given number. If the client goes away the rest of the stream
+// is drained in the background so the generation still completes and can be resumed.
+func writeStream(c *gin.Context, stream *ai.ChatStream, seq int) {
 	c.Header("Content-Type", "text/event-stream")
 	c.Header("Cache-Control", "no-cache")
 	c.Header("Connection", "keep-alive")
 
-	c.Stream(func(w io.Writer) bool {
+	if clientGone := c.Stream(func(w io.Writer) bool {
 		chunk, err := stream.Recv()
 		if err != nil {
 			if !errors.Is(err, ai.ErrStreamClosed) {
@@ -116,11 +176,52 @@ func (h *ChatHandler) handleStream(c *gin.Context, uid string, req *ai.ChatReque
 			return false
 		}
 
+		seq++
 		data, _ := json.Marshal(convertChunkToDTO(chunk))
-		fmt.Fprintf(w, "data: %s\n\n", data)
+		fmt.Fprintf(w, "id: %s:%d\ndata: %s\n\n", chunk.ID, seq, data)
 
 		return true
-	})
+	}); clientGone {
+		go func() {
+			for {
+				if _, err := stream.Recv(); err != nil {
+					return
+				}
+			}
+		}()
+	}
+}
+
+// parseEventID splits an event ID into the generation ID and the chunk sequence number
+func parseEventID(eventID string) (string, int, error) {
+	i := strings.LastIndex(eventID, ":")
+	if i <= 0 {
+		return "", 0, fmt.Errorf("missing sequence number")
+	}
+
+	seq, err := strconv.Atoi(eventID[i+1:])
+	if err != nil || seq < 0 {
+		return "", 0, fmt.Errorf("invalid sequence number")
+	}
+
+	return eventID[:i], seq, nil
+}
+
+// CancelCompletion
+// @Summary    Cancel a running streamed chat completion
+// @Security   Bearer
+// @Tags       AI
+// @Accept     application/json
+// @Produce    json
+// @Param      id   path      string  true  "Completion ID from the stream chunks"
+// @Success    200  {object}  nil
+// @Failure    404  {object}  core.ErrResponse
+// @Failure    500  {object}  core.ErrResponse
+// @Router     /v1/chat/completions/{id}/cancel [POST].
+func (h *ChatHandler) CancelCompletion(c *gin.Context) {
+	uid := contextx.UserID(c)
+	err := h.b.Generations().Cancel(c, uid, c.Param("id"))
+	core.Response(c, nil, err)
 }
 
 // ListModels
@@ -142,11 +243,8 @@ func convertToDTO(resp *ai.ChatResponse) *v1.ChatCompletionResponse {
 	choices := make([]v1.ChatChoice, len(resp.Choices))
 	for i, ch := range resp.Choices {
 		choices[i] = v1.ChatChoice{
-			Index: ch.Index,
-			Message: v1.ChatMessage{
-				Role:    ch.Message.Role,
-				Content: ch.Message.Content,
-			},
+			Index:        ch.Index,
+			Message:      convertMessageToDTO(ch.Message),
 			FinishReason: ch.FinishReason,
 		}
 	}
@@ -170,13 +268,12 @@ func convertChunkToDTO(chunk *ai.StreamChunk) *v1.ChatCompletionResponse {
 	choices := make([]v1.ChatChoice, len(chunk.Choices))
 	for i, ch := range chunk.Choices {
 		choice := v1.ChatChoice{
-			Index: ch.Index,
+			Index:        ch.Index,
+			FinishReason: ch.FinishReason,
 		}
 		if ch.Delta != nil {
-			choice.Delta = &v1.ChatMessage{
-				Role:    ch.Delta.Role,
-				Content: ch.Delta.Content,
-			}
+			delta := convertMessageToDTO(*ch.Delta)
+			choice.Delta = &delta
 		}
 		choices[i] = choice
 	}
@@ -189,3 +286,65 @@ func convertChunkToDTO(chunk *ai.StreamChunk) *v1.ChatCompletionResponse {
 		Choices: choices,
 	}
 }
+
+// convertMessageFromDTO converts v1.ChatMessage to ai.Message
+func convertMessageFromDTO(msg v1.ChatMessage) ai.Message {
+	m := ai.Message{
+		Role:       msg.Role,
+		Content:    msg.Content.Text,
+		Name:       msg.Name,
+		ToolCallID: msg.ToolCallID,
+	}
+	for _, p := range msg.Content.Parts {
+		part := ai.ContentPart{Type: p.Type, Text: p.Text}
+		if p.ImageURL != nil {
+			part.ImageURL = &ai.ImageURL{URL: p.ImageURL.URL, Detail: p.ImageURL.Detail}
+		}
+		m.Parts = append(m.Parts, part)
+	}
+	if len(m.Parts) > 0 {
+		m.Content = ai.TextOf(m.Parts)
+	}
+	for _, tc := range msg.ToolCalls {
+		m.ToolCalls = append(m.ToolCalls, ai.ToolCall{
+			ID:   tc.ID,
+			Type: tc.Type,
+			Function: ai.FunctionCall{
+				Name:      tc.Function.Name,
+				Arguments: tc.Function.Arguments,
+			},
+		})
+	}
+
+	return m
+}
+
+// convertMessageToDTO converts ai.Message to v1.ChatMessage
+func convertMessageToDTO(msg ai.Message) v1.ChatMessage {
+	m := v1.ChatMessage{
+		Role:       msg.Role,
+		Content:    v1.NewChatContent(msg.Content),
+		Name:       msg.Name,
+		ToolCallID: msg.ToolCallID,
+	}
+	for _, p := range msg.Parts {
+		part := v1.ChatContentPart{Type: p.Type, Text: p.Text}
+		if p.ImageURL != nil {
+			part.ImageURL = &v1.ChatImageURL{URL: p.ImageURL.URL, Detail: p.ImageURL.Detail}
+		}
+		m.Content.Parts = append(m.Content.Parts, part)
+	}
+	for _, tc := range msg.ToolCalls {
+		m.ToolCalls = append(m.ToolCalls, v1.ChatToolCall{
+			Index: tc.Index,
+			ID:    tc.ID,
+			Type:  tc.Type,
+			Function: v1.ChatFunctionCall{
+				Name:      tc.Function.Name,
+				Arguments: tc.Function.Arguments,
+			},
+		})
+	}
+
+	return m
+}
@@ -0,0 +1,91 @@
+// ABOUTME: Embedding HTTP handler.
+// ABOUTME: Provides the OpenAI-compatible embeddings endpoint.
+
+package chat
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/bingo-project/bingo/internal/pkg/core"
+	"github.com/bingo-project/bingo/internal/pkg/errno"
+	"github.com/bingo-project/bingo/pkg/ai"
+	v1 "github.com/bingo-project/bingo/pkg/api/apiserver/v1"
+	"github.com/bingo-project/bingo/pkg/contextx"
+)
+
+// Embeddings
+// @Summary    Create embeddings
+// @Security   Bearer
+// @Tags       AI
+// @Accept     application/json
+// @Produce    json
+// @Param      request  body      v1.EmbeddingRequest  true  "Embedding request"
+// @Success    200      {object}  v1.EmbeddingResponse
+// @Failure    400      {object}  core.ErrResponse
+// @Failure    429      {object}  core.ErrResponse
+// @Failure    500      {object}  core.ErrResponse
+// @Router     /v1/embeddings [POST].
+func (h *ChatHandler) Embeddings(c *gin.Context) {
+	var req v1.EmbeddingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		core.Response(c, nil, errno.ErrInvalidArgument.WithMessage("%s", err.Error()))
+
+		return
+	}
+
+	input, ok := parseEmbeddingInput(req.Input)
+	if !ok {
+		core.Response(c, nil, errno.ErrInvalidArgument.WithMessage("input must be a string or an array of strings"))
+
+		return
+	}
+
+	uid := contextx.UserID(c)
+	resp, err := h.b.Chat().Embeddings(c, uid, &ai.EmbeddingRequest{
+		Model:      req.Model,
+		Input:      input,
+		Dimensions: req.Dimensions,
+		UID:        uid,
+	})
+	if err != nil {
+		core.Response(c, nil, err)
+
+		return
+	}
+
+	data := make([]v1.EmbeddingData, len(resp.Data))
+	for i, d := range resp.Data {
+		data[i] = v1.EmbeddingData{Object: "embedding", Index: d.Index, Embedding: d.Embedding}
+	}
+
+	core.Response(c, &v1.EmbeddingResponse{
+		Object: "list",
+		Data:   data,
+		Model:  resp.Model,
+		Usage: v1.EmbeddingUsage{
+			PromptTokens: resp.Usage.PromptTokens,
+			TotalTokens:  resp.Usage.TotalTokens,
+		},
+	}, nil)
+}
+
+// parseEmbeddingInput accepts a string or an array of strings
+func parseEmbeddingInput(v any) ([]string, bool) {
+	switch in := v.(type) {
+	case string:
+		return []string{in}, in != ""
+	case []any:
+		result := make([]string, len(in))
+		for i, item := range in {
+			s, ok := item.(string)
+			if !ok || s == "" {
+				return nil, false
+			}
+			result[i] = s
+		}
+
+		return result, len(result) > 0
+	}
+
+	return nil, false
+}
@@ -0,0 +1,101 @@
+// ABOUTME: Feedback HTTP handlers for AI assistant replies.
+// ABOUTME: Rates, un-rates and lists the user's ratings of a session's messages.
+
+package chat
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/bingo-project/bingo/internal/apiserver/biz"
+	"github.com/bingo-project/bingo/internal/pkg/core"
+	"github.com/bingo-project/bingo/internal/pkg/errno"
+	"github.com/bingo-project/bingo/internal/pkg/store"
+	v1 "github.com/bingo-project/bingo/pkg/api/apiserver/v1"
+	"github.com/bingo-project/bingo/pkg/contextx"
+)
+
+type FeedbackHandler struct {
+	b biz.IBiz
+}
+
+func NewFeedbackHandler(ds store.IStore) *FeedbackHandler {
+	return &FeedbackHandler{b: biz.NewBiz(ds)}
+}
+
+// RateMessage
+// @Summary    Rate an assistant reply with thumbs up or down
+// @Security   Bearer
+// @Tags       AI
+// @Accept     application/json
+// @Produce    json
+// @Param      session_id  path      string                 true  "Session ID"
+// @Param      message_id  path      int                    true  "Assistant message ID"
+// @Param      request     body      v1.RateMessageRequest  true  "Feedback"
+// @Success    200         {object}  v1.FeedbackInfo
+// @Failure    400         {object}  core.ErrResponse
+// @Failure    404         {object}  core.ErrResponse
+// @Failure    500         {object}  core.ErrResponse
+// @Router     /v1/ai/sessions/{session_id}/messages/{message_id}/feedback [PUT].
+func (h *FeedbackHandler) RateMessage(c *gin.Context) {
+	var req v1.RateMessageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		core.Response(c, nil, errno.ErrInvalidArgument.WithMessage("%s", err.Error()))
+
+		return
+	}
+	messageID, err := strconv.ParseUint(c.Param("message_id"), 10, 64)
+	if err != nil {
+		core.Response(c, nil, errno.ErrInvalidArgument.WithMessage("invalid message id"))
+
+		return
+	}
+
+	uid := contextx.UserID(c)
+	feedback, err := h.b.Feedback().Rate(c, uid, c.Param("session_id"), messageID, &req)
+	core.Response(c, feedback, err)
+}
+
+// DeleteFeedback
+// @Summary    Withdraw the rating of a reply
+// @Security   Bearer
+// @Tags       AI
+// @Accept     application/json
+// @Produce    json
+// @Param      session_id  path      string  true  "Session ID"
+// @Param      message_id  path      int     true  "Message ID"
+// @Success    200         {object}  nil
+// @Failure    400         {object}  core.ErrResponse
+// @Failure    404         {object}  core.ErrResponse
+// @Failure    500         {object}  core.ErrResponse
+// @Router     /v1/ai/sessions/{session_id}/messages/{message_id}/feedback [DELETE].
+func (h *FeedbackHandler) DeleteFeedback(c *gin.Context) {
+	messageID, err := strconv.ParseUint(c.Param("message_id"), 10, 64)
+	if err != nil {
+		core.Response(c, nil, errno.ErrInvalidArgument.WithMessage("invalid message id"))
+
+		return
+	}
+
+	uid := contextx.UserID(c)
+	err = h.b.Feedback().Delete(c, uid, c.Param("session_id"), messageID)
+	core.Response(c, nil, err)
+}
+
+// ListFeedback
+// @Summary    List the user's ratings of a session's replies
+// @Security   Bearer
+// @Tags       AI
+// @Accept     application/json
+// @Produce    json
+// @Param      session_id  path      string  true  "Session ID"
+// @Success    200         {object}  []v1.FeedbackInfo
+// @Failure    404         {object}  core.ErrResponse
+// @Failure    500         {object}  core.ErrResponse
+// @Router     /v1/ai/sessions/{session_id}/feedback [GET].
+func (h *FeedbackHandler) ListFeedback(c *gin.Context) {
+	uid := contextx.UserID(c)
+	feedback, err := h.b.Feedback().List(c, uid, c.Param("session_id"))
+	core.Response(c, feedback, err)
+}
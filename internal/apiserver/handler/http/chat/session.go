@@ -1,9 +1,11 @@
 // ABOUTME: Session HTTP handlers for AI chat sessions.
-// ABOUTME: Provides endpoints for session CRUD and history.
+// ABOUTME: Provides endpoints for session CRUD, search, history, message branching, export and import.
 
 package chat
 
 import (
+	"fmt"
+	"net/http"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
@@ -11,6 +13,7 @@ import (
 	"github.com/bingo-project/bingo/internal/apiserver/biz"
 	"github.com/bingo-project/bingo/internal/pkg/core"
 	"github.com/bingo-project/bingo/internal/pkg/errno"
+	"github.com/bingo-project/bingo/internal/pkg/model"
 	"github.com/bingo-project/bingo/internal/pkg/store"
 	"github.com/bingo-project/bingo/pkg/ai"
 	v1 "github.com/bingo-project/bingo/pkg/api/apiserver/v1"
@@ -66,6 +69,32 @@ func (h *SessionHandler) ListSessions(c *gin.Context) {
 	core.Response(c, sessions, err)
 }
 
+// SearchSessions
+// @Summary    Search the titles and messages of the user's sessions
+// @Security   Bearer
+// @Tags       AI
+// @Accept     application/json
+// @Produce    json
+// @Param      q         query     string  true   "Search words, all must match"
+// @Param      page      query     int     false  "Page number" minimum(1)
+// @Param      pageSize  query     int     false  "Page size" minimum(1) maximum(100)
+// @Success    200       {object}  v1.AiSearchResponse
+// @Failure    400       {object}  core.ErrResponse
+// @Failure    500       {object}  core.ErrResponse
+// @Router     /v1/ai/search [GET].
+func (h *SessionHandler) SearchSessions(c *gin.Context) {
+	var req v1.AiSearchRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		core.Response(c, nil, errno.ErrInvalidArgument.WithMessage("%s", err.Error()))
+
+		return
+	}
+
+	uid := contextx.UserID(c)
+	resp, err := h.b.Search().Search(c, uid, &req)
+	core.Response(c, resp, err)
+}
+
 // GetSession
 // @Summary    Get chat session
 // @Security   Bearer
@@ -170,12 +199,9 @@ func (h *SessionHandler) GetSessionHistory(c *gin.Context) {
 		return
 	}
 
-	data := make([]v1.ChatMessage, len(messages))
+	data := make([]v1.SessionMessage, len(messages))
 	for i, m := range messages {
-		data[i] = v1.ChatMessage{
-			Role:    m.Role,
-			Content: m.Content,
-		}
+		data[i] = convertSessionMessageToDTO(m)
 	}
 
 	core.Response(c, v1.SessionHistoryResponse{
@@ -183,3 +209,262 @@ func (h *SessionHandler) GetSessionHistory(c *gin.Context) {
 		Messages:  data,
 	}, nil)
 }
+
+// ExportSession
+// @Summary    Export the active branch of a session as JSON or Markdown
+// @Security   Bearer
+// @Tags       AI
+// @Produce    json
+// @Produce    text/markdown
+// @Param      session_id  path      string  true   "Session ID"
+// @Param      format      query     string  false  "json (default) or markdown"
+// @Success    200         {object}  v1.SessionTranscript
+// @Failure    400         {object}  core.ErrResponse
+// @Failure    404         {object}  core.ErrResponse
+// @Failure    500         {object}  core.ErrResponse
+// @Router     /v1/ai/sessions/{session_id}/export [GET].
+func (h *SessionHandler) ExportSession(c *gin.Context) {
+	var req v1.ExportSessionRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		core.Response(c, nil, errno.ErrInvalidArgument.WithMessage("%s", err.Error()))
+
+		return
+	}
+
+	uid := contextx.UserID(c)
+	sessionID := c.Param("session_id")
+	session, messages, err := h.b.Chat().Sessions().Export(c, uid, sessionID)
+	if err != nil {
+		core.Response(c, nil, err)
+
+		return
+	}
+
+	transcript := toTranscript(session, messages)
+	if req.Format == "markdown" {
+		c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.md"`, sessionID))
+		c.Data(http.StatusOK, "text/markdown; charset=utf-8", []byte(renderMarkdown(transcript)))
+
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.json"`, sessionID))
+	c.JSON(http.StatusOK, transcript)
+}
+
+// ImportSession
+// @Summary    Import a JSON transcript into a new session
+// @Security   Bearer
+// @Tags       AI
+// @Accept     application/json
+// @Produce    json
+// @Param      request  body      v1.SessionTranscript  true  "Transcript"
+// @Success    200      {object}  v1.SessionInfo
+// @Failure    400      {object}  core.ErrResponse
+// @Failure    500      {object}  core.ErrResponse
+// @Router     /v1/ai/sessions/import [POST].
+func (h *SessionHandler) ImportSession(c *gin.Context) {
+	var req v1.SessionTranscript
+	if err := c.ShouldBindJSON(&req); err != nil {
+		core.Response(c, nil, errno.ErrInvalidArgument.WithMessage("%s", err.Error()))
+
+		return
+	}
+
+	messages := make([]ai.Message, len(req.Messages))
+	for i, m := range req.Messages {
+		messages[i] = convertMessageFromDTO(m.ChatMessage)
+	}
+
+	uid := contextx.UserID(c)
+	session, err := h.b.Chat().Sessions().Import(c, uid, req.Title, req.Model, messages)
+	core.Response(c, session, err)
+}
+
+// EditMessage
+// @Summary    Edit a user message into a new branch and answer it
+// @Security   Bearer
+// @Tags       AI
+// @Accept     application/json
+// @Produce    json
+// @Param      session_id  path      string                 true  "Session ID"
+// @Param      message_id  path      int                    true  "Message ID"
+// @Param      request     body      v1.EditMessageRequest  true  "Edit request"
+// @Success    200         {object}  v1.ChatCompletionResponse
+// @Failure    400         {object}  core.ErrResponse
+// @Failure    404         {object}  core.ErrResponse
+// @Failure    429         {object}  core.ErrResponse
+// @Failure    500         {object}  core.ErrResponse
+// @Router     /v1/ai/sessions/{session_id}/messages/{message_id}/edit [POST].
+func (h *SessionHandler) EditMessage(c *gin.Context) {
+	var req v1.EditMessageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		core.Response(c, nil, errno.ErrInvalidArgument.WithMessage("%s", err.Error()))
+
+		return
+	}
+	if req.Content.IsEmpty() {
+		core.Response(c, nil, errno.ErrInvalidArgument.WithMessage("message content is required"))
+
+		return
+	}
+	messageID, err := strconv.ParseUint(c.Param("message_id"), 10, 64)
+	if err != nil {
+		core.Response(c, nil, errno.ErrInvalidArgument.WithMessage("invalid message id"))
+
+		return
+	}
+
+	uid := contextx.UserID(c)
+	aiReq := &ai.ChatRequest{
+		Model:       req.Model,
+		Messages:    []ai.Message{convertMessageFromDTO(v1.ChatMessage{Role: ai.RoleUser, Content: req.Content})},
+		MaxTokens:   req.MaxTokens,
+		Temperature: req.Temperature,
+		Stream:      req.Stream,
+		SessionID:   c.Param("session_id"),
+		UID:         uid,
+	}
+
+	chat := h.b.Chat()
+	if err := chat.Edit(c, uid, messageID, aiReq); err != nil {
+		core.Response(c, nil, err)
+
+		return
+	}
+
+	complete(c, chat, uid, aiReq)
+}
+
+// RegenerateMessage
+// @Summary    Answer again on a new branch next to an assistant reply
+// @Security   Bearer
+// @Tags       AI
+// @Accept     application/json
+// @Produce    json
+// @Param      session_id  path      string                       true  "Session ID"
+// @Param      message_id  path      int                          true  "Assistant message ID"
+// @Param      request     body      v1.RegenerateMessageRequest  true  "Regenerate request"
+// @Success    200         {object}  v1.ChatCompletionResponse
+// @Failure    400         {object}  core.ErrResponse
+// @Failure    404         {object}  core.ErrResponse
+// @Failure    429         {object}  core.ErrResponse
+// @Failure    500         {object}  core.ErrResponse
+// @Router     /v1/ai/sessions/{session_id}/messages/{message_id}/regenerate [POST].
+func (h *SessionHandler) RegenerateMessage(c *gin.Context) {
+	var req v1.RegenerateMessageRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		core.Response(c, nil, errno.ErrInvalidArgument.WithMessage("%s", err.Error()))
+
+		return
+	}
+	messageID, err := strconv.ParseUint(c.Param("message_id"), 10, 64)
+	if err != nil {
+		core.Response(c, nil, errno.ErrInvalidArgument.WithMessage("invalid message id"))
+
+		return
+	}
+
+	uid := contextx.UserID(c)
+	aiReq := &ai.ChatRequest{
+		Model:       req.Model,
+		MaxTokens:   req.MaxTokens,
+		Temperature: req.Temperature,
+		Stream:      req.Stream,
+		SessionID:   c.Param("session_id"),
+		UID:         uid,
+	}
+
+	chat := h.b.Chat()
+	if err := chat.Regenerate(c, uid, messageID, aiReq); err != nil {
+		core.Response(c, nil, err)
+
+		return
+	}
+
+	complete(c, chat, uid, aiReq)
+}
+
+// ListMessageVariants
+// @Summary    List the variants of a message
+// @Security   Bearer
+// @Tags       AI
+// @Accept     application/json
+// @Produce    json
+// @Param      session_id  path      string  true  "Session ID"
+// @Param      message_id  path      int     true  "Message ID"
+// @Success    200         {object}  v1.MessageVariantsResponse
+// @Failure    404         {object}  core.ErrResponse
+// @Failure    500         {object}  core.ErrResponse
+// @Router     /v1/ai/sessions/{session_id}/messages/{message_id}/variants [GET].
+func (h *SessionHandler) ListMessageVariants(c *gin.Context) {
+	messageID, err := strconv.ParseUint(c.Param("message_id"), 10, 64)
+	if err != nil {
+		core.Response(c, nil, errno.ErrInvalidArgument.WithMessage("invalid message id"))
+
+		return
+	}
+
+	uid := contextx.UserID(c)
+	variants, activeID, err := h.b.Chat().Sessions().ListVariants(c, uid, c.Param("session_id"), messageID)
+	if err != nil {
+		core.Response(c, nil, err)
+
+		return
+	}
+
+	resp := v1.MessageVariantsResponse{
+		ActiveID: activeID,
+		Messages: make([]v1.SessionMessage, len(variants)),
+	}
+	for i, m := range variants {
+		resp.ParentID = m.ParentID
+		resp.Messages[i] = convertSessionMessageToDTO(m)
+	}
+
+	core.Response(c, resp, nil)
+}
+
+// SwitchBranch
+// @Summary    Continue a session on the branch of a message
+// @Security   Bearer
+// @Tags       AI
+// @Accept     application/json
+// @Produce    json
+// @Param      session_id  path      string                  true  "Session ID"
+// @Param      request     body      v1.SwitchBranchRequest  true  "Branch request"
+// @Success    200         {object}  v1.SessionInfo
+// @Failure    400         {object}  core.ErrResponse
+// @Failure    404         {object}  core.ErrResponse
+// @Failure    500         {object}  core.ErrResponse
+// @Router     /v1/ai/sessions/{session_id}/branch [PUT].
+func (h *SessionHandler) SwitchBranch(c *gin.Context) {
+	var req v1.SwitchBranchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		core.Response(c, nil, errno.ErrInvalidArgument.WithMessage("%s", err.Error()))
+
+		return
+	}
+
+	uid := contextx.UserID(c)
+	session, err := h.b.Chat().Sessions().SwitchBranch(c, uid, c.Param("session_id"), req.MessageID)
+	core.Response(c, session, err)
+}
+
+// convertSessionMessageToDTO converts a stored message to v1.SessionMessage
+func convertSessionMessageToDTO(m *model.AiMessageM) v1.SessionMessage {
+	return v1.SessionMessage{
+		ID:       m.ID,
+		ParentID: m.ParentID,
+		ChatMessage: convertMessageToDTO(ai.Message{
+			Role:       m.Role,
+			Content:    m.Content,
+			Parts:      m.ContentParts,
+			Name:       m.Name,
+			ToolCalls:  m.ToolCalls,
+			ToolCallID: m.ToolCallID,
+		}),
+		FinishReason: m.FinishReason,
+		CreatedAt:    m.CreatedAt,
+	}
+}
@@ -0,0 +1,111 @@
+// ABOUTME: Share link HTTP handlers for AI chat sessions.
+// ABOUTME: Manages a session's share links and serves their public read-only snapshots.
+
+package chat
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/bingo-project/bingo/internal/apiserver/biz"
+	"github.com/bingo-project/bingo/internal/pkg/core"
+	"github.com/bingo-project/bingo/internal/pkg/errno"
+	"github.com/bingo-project/bingo/internal/pkg/store"
+	v1 "github.com/bingo-project/bingo/pkg/api/apiserver/v1"
+	"github.com/bingo-project/bingo/pkg/contextx"
+)
+
+type ShareHandler struct {
+	b biz.IBiz
+}
+
+func NewShareHandler(ds store.IStore) *ShareHandler {
+	return &ShareHandler{b: biz.NewBiz(ds)}
+}
+
+// CreateShare
+// @Summary    Create a public share link of a session
+// @Security   Bearer
+// @Tags       AI
+// @Accept     application/json
+// @Produce    json
+// @Param      session_id  path      string                 true  "Session ID"
+// @Param      request     body      v1.CreateShareRequest  true  "Share request"
+// @Success    200         {object}  v1.ShareInfo
+// @Failure    400         {object}  core.ErrResponse
+// @Failure    404         {object}  core.ErrResponse
+// @Failure    500         {object}  core.ErrResponse
+// @Router     /v1/ai/sessions/{session_id}/shares [POST].
+func (h *ShareHandler) CreateShare(c *gin.Context) {
+	var req v1.CreateShareRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		core.Response(c, nil, errno.ErrInvalidArgument.WithMessage("%s", err.Error()))
+
+		return
+	}
+
+	uid := contextx.UserID(c)
+	share, err := h.b.Shares().Create(c, uid, c.Param("session_id"), req.ExpireDays)
+	core.Response(c, share, err)
+}
+
+// ListShares
+// @Summary    List the share links of a session
+// @Security   Bearer
+// @Tags       AI
+// @Accept     application/json
+// @Produce    json
+// @Param      session_id  path      string  true  "Session ID"
+// @Success    200         {object}  []v1.ShareInfo
+// @Failure    404         {object}  core.ErrResponse
+// @Failure    500         {object}  core.ErrResponse
+// @Router     /v1/ai/sessions/{session_id}/shares [GET].
+func (h *ShareHandler) ListShares(c *gin.Context) {
+	uid := contextx.UserID(c)
+	shares, err := h.b.Shares().List(c, uid, c.Param("session_id"))
+	core.Response(c, shares, err)
+}
+
+// RevokeShare
+// @Summary    Revoke a share link of a session
+// @Security   Bearer
+// @Tags       AI
+// @Accept     application/json
+// @Produce    json
+// @Param      session_id  path      string  true  "Session ID"
+// @Param      token       path      string  true  "Share token"
+// @Success    200         {object}  nil
+// @Failure    404         {object}  core.ErrResponse
+// @Failure    500         {object}  core.ErrResponse
+// @Router     /v1/ai/sessions/{session_id}/shares/{token} [DELETE].
+func (h *ShareHandler) RevokeShare(c *gin.Context) {
+	uid := contextx.UserID(c)
+	err := h.b.Shares().Revoke(c, uid, c.Param("session_id"), c.Param("token"))
+	core.Response(c, nil, err)
+}
+
+// ViewShare
+// @Summary    View the read-only snapshot behind a share link
+// @Tags       AI
+// @Accept     application/json
+// @Produce    json
+// @Param      token  path      string  true  "Share token"
+// @Success    200    {object}  v1.SharedSessionResponse
+// @Failure    404    {object}  core.ErrResponse
+// @Failure    500    {object}  core.ErrResponse
+// @Router     /v1/ai/shares/{token} [GET].
+func (h *ShareHandler) ViewShare(c *gin.Context) {
+	share, session, messages, err := h.b.Shares().View(c, c.Param("token"))
+	if err != nil {
+		core.Response(c, nil, err)
+
+		return
+	}
+
+	transcript := toTranscript(session, messages)
+	transcript.Title = share.Title
+	core.Response(c, v1.SharedSessionResponse{
+		SessionTranscript: transcript,
+		Views:             share.Views,
+		SharedAt:          share.CreatedAt,
+	}, nil)
+}
@@ -0,0 +1,92 @@
+// ABOUTME: Session transcript rendering for export and share links.
+// ABOUTME: Converts stored messages into JSON transcripts and readable Markdown.
+
+package chat
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/bingo-project/bingo/internal/pkg/model"
+	"github.com/bingo-project/bingo/pkg/ai"
+	v1 "github.com/bingo-project/bingo/pkg/api/apiserver/v1"
+)
+
+// toTranscript converts a session and the messages of its active branch to v1.SessionTranscript
+func toTranscript(session *model.AiSessionM, messages []*model.AiMessageM) v1.SessionTranscript {
+	t := v1.SessionTranscript{
+		Title:     session.Title,
+		Model:     session.Model,
+		CreatedAt: &session.CreatedAt,
+		Messages:  make([]v1.TranscriptMessage, len(messages)),
+	}
+	for i, m := range messages {
+		t.Messages[i] = v1.TranscriptMessage{
+			ChatMessage: convertSessionMessageToDTO(m).ChatMessage,
+			Model:       m.Model,
+			CreatedAt:   &m.CreatedAt,
+		}
+	}
+
+	return t
+}
+
+// renderMarkdown renders a transcript as a Markdown document
+func renderMarkdown(t v1.SessionTranscript) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# %s\n\n", t.Title)
+	if t.Model != "" {
+		fmt.Fprintf(&sb, "- Model: %s\n", t.Model)
+	}
+	if t.CreatedAt != nil {
+		fmt.Fprintf(&sb, "- Created: %s\n", t.CreatedAt.Format("2006-01-02 15:04:05"))
+	}
+
+	for _, m := range t.Messages {
+		sb.WriteString("\n---\n\n")
+		switch m.Role {
+		case ai.RoleUser:
+			sb.WriteString("## User\n\n")
+		case ai.RoleAssistant:
+			sb.WriteString("## Assistant\n\n")
+		default:
+			fmt.Fprintf(&sb, "## Tool result `%s`\n\n", m.Name)
+		}
+
+		if len(m.Content.Parts) == 0 {
+			writeMarkdownText(&sb, m.Role, m.Content.Text)
+		}
+		for _, p := range m.Content.Parts {
+			switch {
+			case p.Type == ai.ContentPartText:
+				writeMarkdownText(&sb, m.Role, p.Text)
+			case p.ImageURL != nil && strings.HasPrefix(p.ImageURL.URL, "data:"):
+				// Inline images would bloat the document
+				sb.WriteString("*[image]*\n\n")
+			case p.ImageURL != nil:
+				fmt.Fprintf(&sb, "![image](%s)\n\n", p.ImageURL.URL)
+			}
+		}
+
+		for _, tc := range m.ToolCalls {
+			fmt.Fprintf(&sb, "Tool call `%s`:\n\n```json\n%s\n```\n\n", tc.Function.Name, tc.Function.Arguments)
+		}
+	}
+
+	return sb.String()
+}
+
+// writeMarkdownText writes message text, tool results are kept verbatim in a code block
+func writeMarkdownText(sb *strings.Builder, role string, text string) {
+	if text == "" {
+		return
+	}
+	if role == ai.RoleTool {
+		fmt.Fprintf(sb, "```\n%s\n```\n\n", text)
+
+		return
+	}
+
+	sb.WriteString(text)
+	sb.WriteString("\n\n")
+}
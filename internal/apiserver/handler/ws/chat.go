@@ -0,0 +1,26 @@
+// ABOUTME: WebSocket AI chat method handlers.
+// ABOUTME: Provides cancellation of running streamed completions for WS clients.
+
+package ws
+
+import (
+	"github.com/bingo-project/websocket"
+	"github.com/bingo-project/websocket/jsonrpc"
+
+	"github.com/bingo-project/bingo/internal/pkg/errno"
+	v1 "github.com/bingo-project/bingo/pkg/api/apiserver/v1"
+)
+
+// CancelChatCompletion cancels a running streamed completion of the current user.
+func (h *Handler) CancelChatCompletion(c *websocket.Context) *jsonrpc.Response {
+	var req v1.CancelChatCompletionRequest
+	if err := c.BindValidate(&req); err != nil {
+		return c.Error(errno.ErrInvalidArgument.WithMessage("%s", err.Error()))
+	}
+
+	if err := h.b.Generations().Cancel(c, c.UserID(), req.ID); err != nil {
+		return c.Error(err)
+	}
+
+	return c.JSON(map[string]string{"id": req.ID})
+}
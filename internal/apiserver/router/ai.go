@@ -1,5 +1,5 @@
 // ABOUTME: AI router registration for chat, session, and agent endpoints.
-// ABOUTME: Registers chat completions, models, sessions, and agent preset routes.
+// ABOUTME: Registers chat completions, models, sessions, share links, feedback, and agent preset routes.
 
 package router
 
@@ -24,6 +24,14 @@ func MapAiRouters(g *gin.Engine) {
 		return
 	}
 
+	shareHandler := chathandler.NewShareHandler(store.S)
+
+	// Public share links, readable without authentication
+	shares := g.Group("/v1/ai/shares")
+	shares.Use(middleware.Lang())
+	shares.Use(middleware.Maintenance())
+	shares.GET("/:token", shareHandler.ViewShare)
+
 	// v1 group
 	v1 := g.Group("/v1")
 	v1.Use(middleware.Lang())
@@ -38,29 +46,53 @@ func MapAiRouters(g *gin.Engine) {
 	chatHandler := chathandler.NewChatHandler(store.S, registry)
 	sessionHandler := chathandler.NewSessionHandler(store.S, registry)
 	agentHandler := chathandler.NewAgentHandler(store.S)
+	feedbackHandler := chathandler.NewFeedbackHandler(store.S)
 
-	// Get AI quota limit
-	rpm := facade.Config.AI.Quota.DefaultRPM
-	if rpm <= 0 {
-		rpm = 20 // fallback default
-	}
+	// Per-user AI rate limit, resolved from the user's quota and tier
+	rpm := ai.NewRPMResolver(store.S, facade.Redis)
 
 	// OpenAI-compatible endpoints
-	// Apply rate limiter only to chat completions (consumes quota)
+	// Apply rate limiter only to chat completions and embeddings (consume quota)
 	v1.POST("/chat/completions", httpmw.AILimiter(rpm), chatHandler.ChatCompletions)
+	v1.POST("/chat/completions/:id/cancel", chatHandler.CancelCompletion)
+	v1.POST("/embeddings", httpmw.AILimiter(rpm), chatHandler.Embeddings)
 	v1.GET("/models", chatHandler.ListModels)
 
+	// Anthropic-compatible endpoint, served by the same chat pipeline.
+	// Anthropic SDKs send the token as x-api-key, which is accepted on this route only.
+	messages := g.Group("/v1")
+	messages.Use(middleware.Lang())
+	messages.Use(middleware.Maintenance())
+	messages.Use(httpmw.APIKeyAuthorization)
+	messages.Use(auth.Middleware(authn))
+	messages.POST("/messages", httpmw.AILimiter(rpm), chatHandler.Messages)
+
 	// Session management
 	sessions := v1.Group("/ai/sessions")
 	{
 		sessions.POST("", sessionHandler.CreateSession)
 		sessions.GET("", sessionHandler.ListSessions)
+		sessions.POST("/import", sessionHandler.ImportSession)
 		sessions.GET("/:session_id", sessionHandler.GetSession)
 		sessions.PUT("/:session_id", sessionHandler.UpdateSession)
 		sessions.DELETE("/:session_id", sessionHandler.DeleteSession)
 		sessions.GET("/:session_id/history", sessionHandler.GetSessionHistory)
+		sessions.PUT("/:session_id/branch", sessionHandler.SwitchBranch)
+		sessions.GET("/:session_id/export", sessionHandler.ExportSession)
+		sessions.POST("/:session_id/shares", shareHandler.CreateShare)
+		sessions.GET("/:session_id/shares", shareHandler.ListShares)
+		sessions.DELETE("/:session_id/shares/:token", shareHandler.RevokeShare)
+		sessions.GET("/:session_id/feedback", feedbackHandler.ListFeedback)
+		sessions.GET("/:session_id/messages/:message_id/variants", sessionHandler.ListMessageVariants)
+		sessions.POST("/:session_id/messages/:message_id/edit", httpmw.AILimiter(rpm), sessionHandler.EditMessage)
+		sessions.POST("/:session_id/messages/:message_id/regenerate", httpmw.AILimiter(rpm), sessionHandler.RegenerateMessage)
+		sessions.PUT("/:session_id/messages/:message_id/feedback", feedbackHandler.RateMessage)
+		sessions.DELETE("/:session_id/messages/:message_id/feedback", feedbackHandler.DeleteFeedback)
 	}
 
+	// Search across the user's sessions
+	v1.GET("/ai/search", sessionHandler.SearchSessions)
+
 	// Agent presets (read-only for users)
 	agents := v1.Group("/ai/agents")
 	{
@@ -42,4 +42,5 @@ func RegisterWSHandlers(router *websocket.Router, rateLimitStore *middleware.Rat
 	private.Handle("subscribe", websocket.SubscribeHandler)
 	private.Handle("unsubscribe", websocket.UnsubscribeHandler)
 	private.Handle("auth.user-info", h.UserInfo)
+	private.Handle("ai.chat.cancel", h.CancelChatCompletion)
 }
@@ -1,11 +1,12 @@
 // ABOUTME: Redis Pub/Sub subscriber for notification push.
-// ABOUTME: Subscribes to notification channels and broadcasts to WebSocket clients.
+// ABOUTME: Subscribes to notification channels and pushes to all or one user's WebSocket clients.
 
 package subscriber
 
 import (
 	"context"
 	"encoding/json"
+	"strings"
 
 	"github.com/bingo-project/websocket"
 	"github.com/bingo-project/websocket/jsonrpc"
@@ -36,6 +37,7 @@ func NewNotificationSubscriber(hub *websocket.Hub) *NotificationSubscriber {
 
 func (s *NotificationSubscriber) Start() {
 	go s.subscribeBroadcast()
+	go s.subscribeUsers()
 }
 
 func (s *NotificationSubscriber) Stop() {
@@ -60,6 +62,39 @@ func (s *NotificationSubscriber) subscribeBroadcast() {
 	}
 }
 
+func (s *NotificationSubscriber) subscribeUsers() {
+	pubsub := s.redis.PSubscribe(s.ctx, notification.RedisUserChannelPrefix+"*")
+	defer pubsub.Close()
+
+	ch := pubsub.Channel()
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case msg := <-ch:
+			if msg == nil {
+				continue
+			}
+			s.handleUserPush(strings.TrimPrefix(msg.Channel, notification.RedisUserChannelPrefix), msg.Payload)
+		}
+	}
+}
+
+func (s *NotificationSubscriber) handleUserPush(userID string, payload string) {
+	var msg struct {
+		Method string          `json:"method"`
+		Data   json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(payload), &msg); err != nil {
+		log.Errorw("failed to unmarshal user push message", "err", err)
+
+		return
+	}
+
+	// Push to every connection of the user on this instance
+	s.hub.PushToUserAllPlatforms(userID, msg.Method, msg.Data)
+}
+
 func (s *NotificationSubscriber) handleBroadcast(payload string) {
 	var msg struct {
 		Method string         `json:"method"`
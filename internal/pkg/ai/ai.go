@@ -9,6 +9,7 @@ import (
 
 	"github.com/redis/go-redis/v9"
 
+	"github.com/bingo-project/bingo/internal/pkg/config"
 	"github.com/bingo-project/bingo/internal/pkg/log"
 	"github.com/bingo-project/bingo/internal/pkg/store"
 	aipkg "github.com/bingo-project/bingo/pkg/ai"
@@ -17,16 +18,40 @@ import (
 var (
 	globalRegistry *aipkg.Registry
 	globalLoader   *Loader
+	keyObserver    aipkg.KeyObserver
 )
 
+// CredentialsFromConfig converts the configured provider credentials.
+func CredentialsFromConfig(cfg map[string]config.AICredential) map[string]Credential {
+	creds := make(map[string]Credential, len(cfg))
+	for name, cred := range cfg {
+		keys := make([]Key, len(cred.Keys))
+		for i, k := range cred.Keys {
+			keys[i] = Key{Name: k.Name, APIKey: k.APIKey, Weight: k.Weight}
+		}
+		creds[name] = Credential{
+			APIKey:  cred.APIKey,
+			BaseURL: cred.BaseURL,
+			Keys:    keys,
+		}
+	}
+
+	return creds
+}
+
+// SetKeyObserver sets the observer notified of the requests of providers with several keys.
+// Call it before InitAI, it applies to the providers loaded afterwards.
+func SetKeyObserver(o aipkg.KeyObserver) {
+	keyObserver = o
+}
+
 // InitAI initializes AI registry and starts reload mechanisms.
 // Called from initConfig() in each server.
-// Returns the registry (or nil if no credentials configured) and any error.
+// Providers are loaded with the credentials stored in the database, the configured
+// credentials bootstrap providers and override the stored ones.
 func InitAI(redisClient *redis.Client, st store.IStore, creds map[string]Credential) (*aipkg.Registry, error) {
 	if len(creds) == 0 {
-		log.Info("No AI credentials configured, skipping AI initialization")
-
-		return nil, nil
+		log.Info("No AI credentials configured, loading AI providers from the database only")
 	}
 
 	globalRegistry = aipkg.NewRegistry()
@@ -53,7 +78,12 @@ func GetRegistry() *aipkg.Registry {
 }
 
 // TriggerReload sends a Redis pub/sub message to trigger reload across all services.
+// Without Redis it does nothing, services then pick changes up by periodic reload.
 func TriggerReload(ctx context.Context, redis *redis.Client) error {
+	if redis == nil {
+		return nil
+	}
+
 	return redis.Publish(ctx, AIReloadChannel, "trigger").Err()
 }
 
@@ -45,6 +45,10 @@ func (s *FallbackSelector) SelectFallback(ctx context.Context, originalModel str
 		if !m.AllowFallback {
 			continue
 		}
+		// Embedding models cannot serve chat requests
+		if m.Type == model.AiModelTypeEmbedding {
+			continue
+		}
 		// Check if provider is registered in Registry
 		if _, ok := s.registry.Get(m.ProviderName); ok {
 			log.C(ctx).Infow("AI model fallback selected",
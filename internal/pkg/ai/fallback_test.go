@@ -11,6 +11,7 @@ import (
 
 	"github.com/bingo-project/bingo/internal/pkg/model"
 	aipkg "github.com/bingo-project/bingo/pkg/ai"
+	"github.com/bingo-project/bingo/pkg/ai/providers/fake"
 	"github.com/bingo-project/bingo/pkg/store/where"
 )
 
@@ -58,10 +59,10 @@ func TestFallbackSelector_SelectFallback_Success(t *testing.T) {
 	registry := aipkg.NewRegistry()
 
 	// Register test providers
-	registry.Register(&mockProvider{name: "openai", models: []aipkg.ModelInfo{
+	registry.Register(fake.New(&fake.Config{Name: "openai", Models: []aipkg.ModelInfo{
 		{ID: "gpt-4o", Provider: "openai"},
 		{ID: "gpt-3.5-turbo", Provider: "openai"},
-	}})
+	}}))
 
 	store := &mockModelStore{
 		models: []*model.AiModelM{
@@ -80,9 +81,9 @@ func TestFallbackSelector_SelectFallback_Success(t *testing.T) {
 
 func TestFallbackSelector_SelectFallback_NoFallbackAllowed(t *testing.T) {
 	registry := aipkg.NewRegistry()
-	registry.Register(&mockProvider{name: "openai", models: []aipkg.ModelInfo{
+	registry.Register(fake.New(&fake.Config{Name: "openai", Models: []aipkg.ModelInfo{
 		{ID: "gpt-4o", Provider: "openai"},
-	}})
+	}}))
 
 	store := &mockModelStore{
 		models: []*model.AiModelM{
@@ -126,18 +127,3 @@ func TestFallbackSelector_SelectFallback_StoreError(t *testing.T) {
 
 	assert.Equal(t, "", fallback, "should return empty on store error")
 }
-
-// mockProvider is a minimal mock Provider
-type mockProvider struct {
-	name   string
-	models []aipkg.ModelInfo
-}
-
-func (m *mockProvider) Name() string              { return m.name }
-func (m *mockProvider) Models() []aipkg.ModelInfo { return m.models }
-func (m *mockProvider) Chat(ctx context.Context, req *aipkg.ChatRequest) (*aipkg.ChatResponse, error) {
-	return nil, nil
-}
-func (m *mockProvider) ChatStream(ctx context.Context, req *aipkg.ChatRequest) (*aipkg.ChatStream, error) {
-	return nil, nil
-}
@@ -0,0 +1,58 @@
+// ABOUTME: Agent guardrail rule building and validation.
+// ABOUTME: Turns stored guardrail rules into text guards, shared by the chat pipeline and the admin API.
+
+package ai
+
+import (
+	"fmt"
+
+	"github.com/bingo-project/bingo/internal/pkg/model"
+	aipkg "github.com/bingo-project/bingo/pkg/ai"
+)
+
+// NewGuardrail builds the guard of a rule. Moderation rules are checked by a model and
+// have no guard, nil is returned for them.
+func NewGuardrail(rule model.AiGuardrailRule) (aipkg.Guard, error) {
+	switch rule.Type {
+	case aipkg.GuardBlocklist:
+		return aipkg.NewBlocklistGuard(rule.Keywords, rule.Patterns)
+	case aipkg.GuardPII:
+		return aipkg.NewPIIGuard(rule.PIITypes)
+	case aipkg.GuardInjection:
+		return aipkg.NewInjectionGuard(), nil
+	case aipkg.GuardModeration:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unsupported guardrail type %q", rule.Type)
+	}
+}
+
+// ValidateGuardrail checks the type, action, stages and settings of a rule.
+func ValidateGuardrail(rule model.AiGuardrailRule) error {
+	switch rule.Action {
+	case aipkg.GuardActionBlock, aipkg.GuardActionFlag:
+	case aipkg.GuardActionRedact:
+		if rule.Type != aipkg.GuardBlocklist && rule.Type != aipkg.GuardPII {
+			return fmt.Errorf("%s guardrail cannot redact", rule.Type)
+		}
+	default:
+		return fmt.Errorf("unsupported guardrail action %q", rule.Action)
+	}
+
+	for _, stage := range rule.Stages {
+		if stage != model.AiGuardrailStageInput && stage != model.AiGuardrailStageOutput {
+			return fmt.Errorf("unsupported guardrail stage %q", stage)
+		}
+	}
+
+	switch {
+	case rule.Type == aipkg.GuardBlocklist && len(rule.Keywords) == 0 && len(rule.Patterns) == 0:
+		return fmt.Errorf("blocklist guardrail needs keywords or patterns")
+	case rule.Type == aipkg.GuardModeration && rule.Model == "":
+		return fmt.Errorf("moderation guardrail needs a model")
+	}
+
+	_, err := NewGuardrail(rule)
+
+	return err
+}
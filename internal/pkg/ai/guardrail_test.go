@@ -0,0 +1,39 @@
+// ABOUTME: Tests for agent guardrail rule validation.
+// ABOUTME: Checks accepted rules and the errors of invalid types, actions, stages and settings.
+
+package ai
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bingo-project/bingo/internal/pkg/model"
+	aipkg "github.com/bingo-project/bingo/pkg/ai"
+)
+
+func TestValidateGuardrail(t *testing.T) {
+	valid := []model.AiGuardrailRule{
+		{Type: aipkg.GuardBlocklist, Action: aipkg.GuardActionRedact, Keywords: []string{"secret"}},
+		{Type: aipkg.GuardPII, Action: aipkg.GuardActionRedact, Stages: []string{model.AiGuardrailStageInput}},
+		{Type: aipkg.GuardInjection, Action: aipkg.GuardActionBlock},
+		{Type: aipkg.GuardModeration, Action: aipkg.GuardActionFlag, Model: "gpt-4o-mini"},
+	}
+	for _, rule := range valid {
+		assert.NoError(t, ValidateGuardrail(rule), rule.Type)
+	}
+
+	invalid := map[string]model.AiGuardrailRule{
+		"type":            {Type: "toxicity", Action: aipkg.GuardActionBlock},
+		"action":          {Type: aipkg.GuardInjection, Action: "warn"},
+		"redact":          {Type: aipkg.GuardInjection, Action: aipkg.GuardActionRedact},
+		"stage":           {Type: aipkg.GuardInjection, Action: aipkg.GuardActionBlock, Stages: []string{"history"}},
+		"empty blocklist": {Type: aipkg.GuardBlocklist, Action: aipkg.GuardActionBlock},
+		"pattern":         {Type: aipkg.GuardBlocklist, Action: aipkg.GuardActionBlock, Patterns: []string{"(unclosed"}},
+		"pii type":        {Type: aipkg.GuardPII, Action: aipkg.GuardActionRedact, PIITypes: []string{"passport"}},
+		"no model":        {Type: aipkg.GuardModeration, Action: aipkg.GuardActionBlock},
+	}
+	for name, rule := range invalid {
+		assert.Error(t, ValidateGuardrail(rule), name)
+	}
+}
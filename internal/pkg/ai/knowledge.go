@@ -0,0 +1,238 @@
+// ABOUTME: Agent knowledge base indexing and retrieval.
+// ABOUTME: Embeds document chunks into MySQL and searches them with a cosine index.
+
+package ai
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/bingo-project/bingo/internal/pkg/log"
+	"github.com/bingo-project/bingo/internal/pkg/model"
+	"github.com/bingo-project/bingo/internal/pkg/store"
+	aipkg "github.com/bingo-project/bingo/pkg/ai"
+)
+
+const (
+	// DefaultKnowledgeTopK is the default number of chunks retrieved per query.
+	DefaultKnowledgeTopK = 4
+
+	// DefaultKnowledgeMinScore is the minimum cosine similarity of a retrieved chunk.
+	DefaultKnowledgeMinScore = 0.3
+
+	// knowledgeEmbedBatch is the number of chunks embedded per provider call.
+	knowledgeEmbedBatch = 64
+
+	// maxKnowledgeErrorLen is the maximum length of a stored indexing error.
+	maxKnowledgeErrorLen = 255
+)
+
+// KnowledgeHit is a chunk retrieved from an agent's knowledge base.
+type KnowledgeHit struct {
+	DocID   uint64
+	Title   string
+	Seq     int
+	Content string
+	Score   float64
+}
+
+// EmbedCall describes the provider calls made for one embedding, for the usage ledger.
+// Provider is empty when no provider was called.
+type EmbedCall struct {
+	Provider string
+	Model    string
+	Usage    aipkg.Usage
+	Latency  time.Duration
+}
+
+// KnowledgeBase indexes and searches agent knowledge documents.
+// Vectors are stored as JSON in MySQL and ranked in memory, which suits small knowledge bases.
+type KnowledgeBase struct {
+	store    store.IStore
+	registry *aipkg.Registry
+}
+
+// NewKnowledgeBase creates a new KnowledgeBase.
+func NewKnowledgeBase(st store.IStore, registry *aipkg.Registry) *KnowledgeBase {
+	return &KnowledgeBase{
+		store:    st,
+		registry: registry,
+	}
+}
+
+// Index splits content into chunks, embeds and stores them, and marks the document ready.
+// On failure the document is marked failed with the error message.
+func (k *KnowledgeBase) Index(ctx context.Context, doc *model.AiKnowledgeDocM, content string) error {
+	chunks := aipkg.SplitText(content, aipkg.DefaultChunkSize, aipkg.DefaultChunkOverlap)
+	err := k.index(ctx, doc, chunks)
+	if err != nil {
+		doc.Status = model.AiKnowledgeDocStatusFailed
+		doc.Error = truncate(err.Error(), maxKnowledgeErrorLen)
+		if uerr := k.store.AiKnowledgeDoc().Update(ctx, doc, "status", "error"); uerr != nil {
+			log.C(ctx).Errorw("Failed to mark knowledge doc failed", "doc_id", doc.ID, "err", uerr)
+		}
+
+		return err
+	}
+
+	doc.Status = model.AiKnowledgeDocStatusReady
+	doc.ChunkCount = len(chunks)
+	doc.Error = ""
+
+	return k.store.AiKnowledgeDoc().Update(ctx, doc, "status", "chunk_count", "error")
+}
+
+func (k *KnowledgeBase) index(ctx context.Context, doc *model.AiKnowledgeDocM, chunks []string) error {
+	if len(chunks) == 0 {
+		return fmt.Errorf("document has no content")
+	}
+
+	vectors, _, err := k.embed(ctx, doc.EmbeddingModel, chunks)
+	if err != nil {
+		return err
+	}
+
+	objs := make([]*model.AiKnowledgeChunkM, len(chunks))
+	for i, c := range chunks {
+		objs[i] = &model.AiKnowledgeChunkM{
+			DocID:     doc.ID,
+			AgentID:   doc.AgentID,
+			Seq:       i,
+			Content:   c,
+			Embedding: vectors[i],
+		}
+	}
+
+	if err := k.store.AiKnowledgeChunk().DeleteByDocID(ctx, doc.ID); err != nil {
+		return fmt.Errorf("delete chunks: %w", err)
+	}
+	if err := k.store.AiKnowledgeChunk().CreateInBatch(ctx, objs, knowledgeEmbedBatch); err != nil {
+		return fmt.Errorf("save chunks: %w", err)
+	}
+
+	return nil
+}
+
+// Search returns the chunks of the agent's knowledge base most similar to query,
+// along with the embedding call made for the query.
+func (k *KnowledgeBase) Search(ctx context.Context, agentID, embeddingModel, query string, topK int) ([]KnowledgeHit, EmbedCall, error) {
+	chunks, err := k.store.AiKnowledgeChunk().ListReadyByAgent(ctx, agentID, embeddingModel)
+	if err != nil {
+		return nil, EmbedCall{}, fmt.Errorf("list chunks: %w", err)
+	}
+	if len(chunks) == 0 {
+		return nil, EmbedCall{}, nil
+	}
+
+	vectors, call, err := k.embed(ctx, embeddingModel, []string{query})
+	if err != nil {
+		return nil, call, err
+	}
+
+	index := make([][]float32, len(chunks))
+	for i, c := range chunks {
+		index[i] = c.Embedding
+	}
+	scored := aipkg.TopK(vectors[0], index, topK, DefaultKnowledgeMinScore)
+	if len(scored) == 0 {
+		return nil, call, nil
+	}
+
+	docs, err := k.store.AiKnowledgeDoc().ListByAgentID(ctx, agentID)
+	if err != nil {
+		return nil, call, fmt.Errorf("list docs: %w", err)
+	}
+	titles := make(map[uint64]string, len(docs))
+	for _, d := range docs {
+		titles[d.ID] = d.Title
+	}
+
+	hits := make([]KnowledgeHit, len(scored))
+	for i, s := range scored {
+		c := chunks[s.Index]
+		hits[i] = KnowledgeHit{
+			DocID:   c.DocID,
+			Title:   titles[c.DocID],
+			Seq:     c.Seq,
+			Content: c.Content,
+			Score:   s.Score,
+		}
+	}
+
+	return hits, call, nil
+}
+
+// EmbedQuery embeds a single text with the given embedding model,
+// returning the embedding call made for it.
+func (k *KnowledgeBase) EmbedQuery(ctx context.Context, embeddingModel, query string) ([]float32, EmbedCall, error) {
+	vectors, call, err := k.embed(ctx, embeddingModel, []string{query})
+	if err != nil {
+		return nil, call, err
+	}
+
+	return vectors[0], call, nil
+}
+
+// embed embeds input texts in batches with the given embedding model.
+// The returned call sums usage across batches and names the provider once one was called.
+func (k *KnowledgeBase) embed(ctx context.Context, modelName string, input []string) ([][]float32, EmbedCall, error) {
+	call := EmbedCall{Model: modelName}
+	if k.registry == nil {
+		return nil, call, fmt.Errorf("AI registry not initialized")
+	}
+
+	m, err := k.store.AiModel().FindActiveByModel(ctx, modelName)
+	if err != nil {
+		return nil, call, fmt.Errorf("find embedding model %s: %w", modelName, err)
+	}
+	if m.Type != model.AiModelTypeEmbedding {
+		return nil, call, fmt.Errorf("model %s is not an embedding model", modelName)
+	}
+	embedder, ok := k.registry.GetEmbedder(m.ProviderName)
+	if !ok {
+		return nil, call, fmt.Errorf("provider %s does not support embeddings", m.ProviderName)
+	}
+
+	call.Provider = m.ProviderName
+	start := time.Now()
+
+	vectors := make([][]float32, 0, len(input))
+	for offset := 0; offset < len(input); offset += knowledgeEmbedBatch {
+		batch := input[offset:min(offset+knowledgeEmbedBatch, len(input))]
+		resp, err := embedder.Embed(ctx, &aipkg.EmbeddingRequest{Model: modelName, Input: batch})
+		if err != nil {
+			call.Latency = time.Since(start)
+			return nil, call, fmt.Errorf("embed chunks: %w", err)
+		}
+		call.Usage.PromptTokens += resp.Usage.PromptTokens
+		call.Usage.TotalTokens += resp.Usage.TotalTokens
+		if len(resp.Data) != len(batch) {
+			call.Latency = time.Since(start)
+			return nil, call, fmt.Errorf("embed chunks: got %d embeddings for %d inputs", len(resp.Data), len(batch))
+		}
+
+		ordered := make([][]float32, len(batch))
+		for i, d := range resp.Data {
+			idx := d.Index
+			if idx < 0 || idx >= len(batch) {
+				idx = i
+			}
+			ordered[idx] = d.Embedding
+		}
+		vectors = append(vectors, ordered...)
+	}
+	call.Latency = time.Since(start)
+
+	return vectors, call, nil
+}
+
+// truncate shortens s to at most n runes.
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+
+	return string(runes[:n])
+}
@@ -6,13 +6,17 @@ package ai
 import (
 	"context"
 	"fmt"
+	"slices"
 
+	"github.com/bingo-project/bingo/internal/pkg/facade"
 	"github.com/bingo-project/bingo/internal/pkg/log"
 	"github.com/bingo-project/bingo/internal/pkg/model"
 	"github.com/bingo-project/bingo/internal/pkg/store"
 	aipkg "github.com/bingo-project/bingo/pkg/ai"
 	"github.com/bingo-project/bingo/pkg/ai/providers/claude"
 	"github.com/bingo-project/bingo/pkg/ai/providers/gemini"
+	"github.com/bingo-project/bingo/pkg/ai/providers/llamacpp"
+	"github.com/bingo-project/bingo/pkg/ai/providers/ollama"
 	"github.com/bingo-project/bingo/pkg/ai/providers/openai"
 	"github.com/bingo-project/bingo/pkg/ai/providers/qwen"
 )
@@ -21,6 +25,14 @@ import (
 type Credential struct {
 	APIKey  string
 	BaseURL string
+	Keys    []Key // Additional keys balanced with APIKey
+}
+
+// Key is an API key of a provider.
+type Key struct {
+	Name   string // Identifies the key in logs and metrics
+	APIKey string
+	Weight int
 }
 
 // Loader loads AI providers from database into Registry.
@@ -41,6 +53,11 @@ func NewLoader(registry *aipkg.Registry, st store.IStore, creds map[string]Crede
 
 // Load loads active providers from database into registry.
 func (l *Loader) Load(ctx context.Context) error {
+	return l.load(ctx, nil)
+}
+
+// load loads active providers, key pools carry over the key state of the previous providers.
+func (l *Loader) load(ctx context.Context, previous map[string]aipkg.Provider) error {
 	providers, err := l.store.AiProvider().ListActive(ctx)
 	if err != nil {
 		return fmt.Errorf("list active providers: %w", err)
@@ -51,10 +68,19 @@ func (l *Loader) Load(ctx context.Context) error {
 		return fmt.Errorf("list active models: %w", err)
 	}
 
+	keys, err := l.store.AiProviderKey().ListActive(ctx)
+	if err != nil {
+		return fmt.Errorf("list active provider keys: %w", err)
+	}
+
 	modelsByProvider := l.groupModelsByProvider(models)
+	keysByProvider := make(map[string][]*model.AiProviderKeyM)
+	for _, k := range keys {
+		keysByProvider[k.ProviderName] = append(keysByProvider[k.ProviderName], k)
+	}
 
 	for _, p := range providers {
-		if err := l.loadProvider(ctx, p, modelsByProvider[p.Name]); err != nil {
+		if err := l.loadProvider(ctx, p, modelsByProvider[p.Name], keysByProvider[p.Name], previous[p.Name]); err != nil {
 			log.Errorw("Failed to load AI provider", "provider", p.Name, "err", err)
 		}
 	}
@@ -62,11 +88,17 @@ func (l *Loader) Load(ctx context.Context) error {
 	return nil
 }
 
-// Reload clears and reloads all providers.
+// Reload clears and reloads all providers. Key cooldowns and counters survive the reload.
 func (l *Loader) Reload(ctx context.Context) error {
+	previous := make(map[string]aipkg.Provider)
+	for _, name := range l.registry.ListProviders() {
+		if p, ok := l.registry.Get(name); ok {
+			previous[name] = p
+		}
+	}
 	l.registry.Clear()
 
-	return l.Load(ctx)
+	return l.load(ctx, previous)
 }
 
 // groupModelsByProvider groups models by provider name.
@@ -79,26 +111,155 @@ func (l *Loader) groupModelsByProvider(models []*model.AiModelM) map[string][]*m
 	return result
 }
 
-// loadProvider creates and registers a single provider.
-func (l *Loader) loadProvider(ctx context.Context, p *model.AiProviderM, models []*model.AiModelM) error {
-	cred, hasCred := l.credentials[p.Name]
-	if !hasCred {
+// loadProvider creates and registers a single provider. A provider with several keys is
+// registered as a key pool balancing them, carrying over the key state of previous.
+func (l *Loader) loadProvider(ctx context.Context, p *model.AiProviderM, models []*model.AiModelM, dbKeys []*model.AiProviderKeyM, previous aipkg.Provider) error {
+	cred, hasCred := l.credential(ctx, p)
+	if !hasCred && len(dbKeys) == 0 {
 		log.Warnw("AI provider has no credential, skipping", "provider", p.Name)
 
 		return nil
 	}
 
-	provider, err := l.createProvider(p.Name, cred, models)
+	keys := l.providerKeys(ctx, p.Name, cred, dbKeys)
+	if len(keys) <= 1 {
+		if len(keys) == 1 {
+			cred.APIKey = keys[0].APIKey
+		}
+		provider, err := l.createProvider(p.Name, cred, models)
+		if err != nil {
+			return err
+		}
+
+		l.registry.Register(provider)
+		log.Infow("AI provider loaded", "provider", p.Name)
+
+		return nil
+	}
+
+	members := make([]aipkg.PoolKey, len(keys))
+	for i, k := range keys {
+		keyCred := cred
+		keyCred.APIKey = k.APIKey
+		provider, err := l.createProvider(p.Name, keyCred, models)
+		if err != nil {
+			return fmt.Errorf("create client of key %s: %w", k.Name, err)
+		}
+		members[i] = aipkg.PoolKey{Name: k.Name, Weight: k.Weight, Provider: provider}
+	}
+
+	pool, err := aipkg.NewKeyPool(p.Name, members, aipkg.KeyPoolConfig{
+		Strategy: p.KeyStrategy,
+		Observer: keyObserver,
+		Previous: previous,
+	})
 	if err != nil {
 		return err
 	}
 
-	l.registry.Register(provider)
-	log.Infow("AI provider loaded", "provider", p.Name)
+	l.registry.Register(pool)
+	log.Infow("AI provider loaded", "provider", p.Name, "keys", len(keys), "strategy", p.KeyStrategy)
 
 	return nil
 }
 
+// credential merges the credential stored with a provider and the configured one. Configured
+// values take precedence, so the config file can bootstrap providers and override the database.
+func (l *Loader) credential(ctx context.Context, p *model.AiProviderM) (Credential, bool) {
+	cred := Credential{BaseURL: p.BaseURL}
+	if p.APIKey != "" {
+		cred.APIKey, _ = decryptKey(ctx, p.Name, "default", p.APIKey)
+	}
+
+	configured, ok := l.credentials[p.Name]
+	if ok {
+		if configured.APIKey != "" {
+			cred.APIKey = configured.APIKey
+		}
+		if configured.BaseURL != "" {
+			cred.BaseURL = configured.BaseURL
+		}
+		cred.Keys = configured.Keys
+	}
+
+	return cred, ok || cred.APIKey != "" || cred.BaseURL != ""
+}
+
+// providerKeys collects the keys of a provider: the configured key and extra keys first,
+// then the keys added in the database. Database keys are stored encrypted with the app key.
+func (l *Loader) providerKeys(ctx context.Context, name string, cred Credential, dbKeys []*model.AiProviderKeyM) []Key {
+	var keys []Key
+	if cred.APIKey != "" {
+		keys = append(keys, Key{Name: "default", APIKey: cred.APIKey, Weight: 1})
+	}
+	for i, k := range cred.Keys {
+		if k.APIKey == "" {
+			continue
+		}
+		if k.Name == "" {
+			k.Name = fmt.Sprintf("config-%d", i+1)
+		}
+		keys = append(keys, k)
+	}
+
+	for _, k := range dbKeys {
+		apiKey, ok := decryptKey(ctx, name, k.Name, k.APIKey)
+		if !ok {
+			continue
+		}
+		keys = append(keys, Key{Name: k.Name, APIKey: apiKey, Weight: k.Weight})
+	}
+
+	return keys
+}
+
+// decryptKey decrypts an API key stored in the database, failures are logged and the key skipped.
+func decryptKey(ctx context.Context, provider, name, encrypted string) (string, bool) {
+	if facade.AES == nil {
+		log.C(ctx).Warnw("AI provider key cannot be decrypted without an app key, skipping", "provider", provider, "key", name)
+
+		return "", false
+	}
+	apiKey, err := facade.AES.DecryptString(encrypted)
+	if err != nil {
+		log.C(ctx).Errorw("Failed to decrypt AI provider key, skipping", "provider", provider, "key", name, "err", err)
+
+		return "", false
+	}
+
+	return apiKey, true
+}
+
+// builtinProviders have a dedicated client or a known endpoint, other providers are
+// OpenAI-compatible and need a base URL.
+var builtinProviders = []string{"openai", "deepseek", "moonshot", "glm", "claude", "gemini", "qwen", "ollama", "llamacpp"}
+
+// reservedProviders are names kept for development providers, they cannot be configured
+// from the admin API.
+var reservedProviders = []string{"fake"}
+
+// DevProviderFactory creates a development provider from its credential and models.
+type DevProviderFactory func(cred Credential, models []aipkg.ModelInfo) (aipkg.Provider, error)
+
+// devProviders are the development providers registered by tests and dev builds.
+var devProviders = map[string]DevProviderFactory{}
+
+// RegisterDevProvider makes a development provider loadable under a reserved name. It is
+// called from tests and from files built with the dev tag only.
+func RegisterDevProvider(name string, factory DevProviderFactory) {
+	devProviders[name] = factory
+}
+
+// NeedsBaseURL reports whether a provider has no default endpoint and must be given a base URL.
+func NeedsBaseURL(name string) bool {
+	return !slices.Contains(builtinProviders, name)
+}
+
+// IsReservedProvider reports whether name is kept for development providers.
+func IsReservedProvider(name string) bool {
+	return slices.Contains(reservedProviders, name)
+}
+
 // createProvider instantiates a provider by name.
 func (l *Loader) createProvider(name string, cred Credential, models []*model.AiModelM) (aipkg.Provider, error) {
 	modelInfos := make([]aipkg.ModelInfo, len(models))
@@ -107,10 +268,20 @@ func (l *Loader) createProvider(name string, cred Credential, models []*model.Ai
 			ID:        m.Model,
 			Name:      m.DisplayName,
 			Provider:  m.ProviderName,
+			Type:      string(m.Type),
 			MaxTokens: m.MaxTokens,
 		}
 	}
 
+	if IsReservedProvider(name) {
+		factory, ok := devProviders[name]
+		if !ok {
+			return nil, fmt.Errorf("provider %s is only available in development builds", name)
+		}
+
+		return factory(cred, modelInfos)
+	}
+
 	switch name {
 	case "openai":
 		cfg := openai.DefaultConfig()
@@ -166,6 +337,26 @@ func (l *Loader) createProvider(name string, cred Credential, models []*model.Ai
 
 		return qwen.New(cfg)
 
+	case "ollama":
+		cfg := ollama.DefaultConfig()
+		cfg.APIKey = cred.APIKey
+		if cred.BaseURL != "" {
+			cfg.BaseURL = cred.BaseURL
+		}
+		cfg.Models = modelInfos
+
+		return ollama.New(cfg)
+
+	case "llamacpp":
+		cfg := llamacpp.DefaultConfig()
+		cfg.APIKey = cred.APIKey
+		if cred.BaseURL != "" {
+			cfg.BaseURL = cred.BaseURL
+		}
+		cfg.Models = modelInfos
+
+		return llamacpp.New(cfg)
+
 	default:
 		cfg := openai.DefaultConfig()
 		cfg.Name = name
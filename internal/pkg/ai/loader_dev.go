@@ -0,0 +1,33 @@
+//go:build dev
+
+// ABOUTME: Development providers of the AI loader, only built with the dev tag.
+// ABOUTME: Registers the keyless fake provider, its base URL points to a cassette to replay.
+
+package ai
+
+import (
+	aipkg "github.com/bingo-project/bingo/pkg/ai"
+	"github.com/bingo-project/bingo/pkg/ai/providers/fake"
+)
+
+func init() {
+	RegisterDevProvider("fake", newFakeProvider)
+}
+
+// newFakeProvider creates the fake provider, replaying the cassette at the base URL if set.
+func newFakeProvider(cred Credential, models []aipkg.ModelInfo) (aipkg.Provider, error) {
+	cfg := fake.DefaultConfig()
+	if len(models) > 0 {
+		cfg.Models = models
+	}
+	p := fake.New(cfg)
+	if cred.BaseURL != "" {
+		cassette, err := fake.LoadCassette(cred.BaseURL)
+		if err != nil {
+			return nil, err
+		}
+		p.Replay(cassette)
+	}
+
+	return p, nil
+}
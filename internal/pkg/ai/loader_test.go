@@ -7,12 +7,15 @@ import (
 	"context"
 	"testing"
 
+	"github.com/bingo-project/component-base/crypt"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/bingo-project/bingo/internal/pkg/facade"
 	"github.com/bingo-project/bingo/internal/pkg/model"
 	mockstore "github.com/bingo-project/bingo/internal/pkg/testing/mock/store"
 	aipkg "github.com/bingo-project/bingo/pkg/ai"
+	"github.com/bingo-project/bingo/pkg/ai/providers/fake"
 )
 
 func TestLoader_Load_Success(t *testing.T) {
@@ -41,6 +44,72 @@ func TestLoader_Load_Success(t *testing.T) {
 	assert.True(t, store.AiProvider().(*mockstore.AiProviderStore).ListActiveCalled, "ListActive should be called")
 }
 
+func TestLoader_Load_LocalProviders(t *testing.T) {
+	store := mockstore.NewStore()
+	registry := aipkg.NewRegistry()
+
+	store.AiProvider().(*mockstore.AiProviderStore).ListActiveResult = []*model.AiProviderM{
+		{Name: "ollama", Status: model.AiProviderStatusActive},
+		{Name: "llamacpp", Status: model.AiProviderStatusActive},
+	}
+	store.AiModel().(*mockstore.AiModelStore).ListActiveResult = []*model.AiModelM{
+		{ProviderName: "ollama", Model: "llama3.1:8b", Status: model.AiModelStatusActive, MaxTokens: 8192},
+	}
+
+	// Local providers only need a base URL
+	creds := map[string]Credential{
+		"ollama":   {BaseURL: "http://ollama.internal:11434"},
+		"llamacpp": {BaseURL: "http://llama.internal:8080/v1"},
+	}
+
+	loader := NewLoader(registry, store, creds)
+	require.NoError(t, loader.Load(context.Background()))
+
+	for _, name := range []string{"ollama", "llamacpp"} {
+		_, ok := registry.GetModelLister(name)
+		assert.True(t, ok, "%s should support model discovery", name)
+		_, ok = registry.GetEmbedder(name)
+		assert.True(t, ok, "%s should support embeddings", name)
+	}
+	_, ok := registry.GetModel("llama3.1:8b")
+	assert.True(t, ok)
+}
+
+func TestLoader_Load_ReservedProvider(t *testing.T) {
+	store := mockstore.NewStore()
+	store.AiProvider().(*mockstore.AiProviderStore).ListActiveResult = []*model.AiProviderM{
+		{Name: "fake", Status: model.AiProviderStatusActive},
+	}
+	creds := map[string]Credential{
+		"fake": {BaseURL: "/etc/passwd"},
+	}
+
+	registered, ok := devProviders["fake"]
+	delete(devProviders, "fake")
+	t.Cleanup(func() {
+		delete(devProviders, "fake")
+		if ok {
+			devProviders["fake"] = registered
+		}
+	})
+
+	// Without a registered development provider the reserved name is not loaded
+	registry := aipkg.NewRegistry()
+	require.NoError(t, NewLoader(registry, store, creds).Load(context.Background()))
+	_, ok = registry.Get("fake")
+	assert.False(t, ok, "fake provider is only available in development builds")
+
+	RegisterDevProvider("fake", func(cred Credential, models []aipkg.ModelInfo) (aipkg.Provider, error) {
+		cfg := fake.DefaultConfig()
+		cfg.Models = models
+
+		return fake.New(cfg), nil
+	})
+	require.NoError(t, NewLoader(registry, store, creds).Load(context.Background()))
+	_, ok = registry.Get("fake")
+	assert.True(t, ok)
+}
+
 func TestLoader_Load_NoCredential_Skips(t *testing.T) {
 	store := mockstore.NewStore()
 	registry := aipkg.NewRegistry()
@@ -60,6 +129,106 @@ func TestLoader_Load_NoCredential_Skips(t *testing.T) {
 	require.False(t, ok, "provider without credential should not be registered")
 }
 
+func TestLoader_Load_KeyPool(t *testing.T) {
+	original := facade.AES
+	facade.AES = crypt.NewAES("0123456789abcdef0123456789abcdef")
+	defer func() { facade.AES = original }()
+
+	store := mockstore.NewStore()
+	registry := aipkg.NewRegistry()
+
+	store.AiProvider().(*mockstore.AiProviderStore).ListActiveResult = []*model.AiProviderM{
+		{Name: "openai", Status: model.AiProviderStatusActive, KeyStrategy: aipkg.KeyStrategyLeastUsed},
+		{Name: "deepseek", Status: model.AiProviderStatusActive},
+	}
+	store.AiModel().(*mockstore.AiModelStore).ListActiveResult = []*model.AiModelM{
+		{ProviderName: "openai", Model: "gpt-4o", Status: model.AiModelStatusActive},
+	}
+	encrypted, err := facade.AES.EncryptString("sk-db")
+	require.NoError(t, err)
+	keys := store.AiProviderKey()
+	require.NoError(t, keys.Create(context.Background(), &model.AiProviderKeyM{
+		ProviderName: "openai", Name: "team-b", APIKey: encrypted, Weight: 2, Status: model.AiProviderKeyStatusActive,
+	}))
+	require.NoError(t, keys.Create(context.Background(), &model.AiProviderKeyM{
+		ProviderName: "openai", Name: "broken", APIKey: "not-encrypted", Status: model.AiProviderKeyStatusActive,
+	}))
+	require.NoError(t, keys.Create(context.Background(), &model.AiProviderKeyM{
+		ProviderName: "openai", Name: "off", APIKey: encrypted, Status: model.AiProviderKeyStatusDisabled,
+	}))
+	require.NoError(t, keys.Create(context.Background(), &model.AiProviderKeyM{
+		ProviderName: "deepseek", Name: "only", APIKey: encrypted, Status: model.AiProviderKeyStatusActive,
+	}))
+
+	creds := map[string]Credential{
+		"openai": {APIKey: "sk-yaml", Keys: []Key{{APIKey: "sk-extra", Weight: 3}}},
+	}
+
+	loader := NewLoader(registry, store, creds)
+	require.NoError(t, loader.Load(context.Background()))
+
+	provider, ok := registry.Get("openai")
+	require.True(t, ok)
+	reporter, ok := provider.(aipkg.KeyReporter)
+	require.True(t, ok, "a provider with several keys is a key pool")
+	var names []string
+	var weights []int
+	for _, k := range reporter.Keys() {
+		names = append(names, k.Name)
+		weights = append(weights, k.Weight)
+	}
+	assert.Equal(t, []string{"default", "config-1", "team-b"}, names, "undecryptable and disabled keys are skipped")
+	assert.Equal(t, []int{1, 3, 2}, weights)
+	_, ok = registry.GetModel("gpt-4o")
+	assert.True(t, ok)
+
+	// A single database key loads the provider without a configured credential
+	provider, ok = registry.Get("deepseek")
+	require.True(t, ok)
+	_, ok = provider.(aipkg.KeyReporter)
+	assert.False(t, ok)
+}
+
+func TestLoader_Load_StoredCredential(t *testing.T) {
+	original := facade.AES
+	facade.AES = crypt.NewAES("0123456789abcdef0123456789abcdef")
+	defer func() { facade.AES = original }()
+
+	encrypted, err := facade.AES.EncryptString("sk-db")
+	require.NoError(t, err)
+
+	store := mockstore.NewStore()
+	registry := aipkg.NewRegistry()
+	store.AiProvider().(*mockstore.AiProviderStore).ListActiveResult = []*model.AiProviderM{
+		{Name: "mistral", BaseURL: "https://api.mistral.ai/v1", APIKey: encrypted, Status: model.AiProviderStatusActive},
+		{Name: "openai", BaseURL: "https://proxy.internal/v1", APIKey: encrypted, Status: model.AiProviderStatusActive},
+	}
+	store.AiModel().(*mockstore.AiModelStore).ListActiveResult = []*model.AiModelM{}
+
+	loader := NewLoader(registry, store, map[string]Credential{
+		"openai": {APIKey: "sk-yaml"},
+	})
+	require.NoError(t, loader.Load(context.Background()))
+
+	// Providers onboarded in the database load without a configured credential
+	_, ok := registry.Get("mistral")
+	assert.True(t, ok)
+
+	cred, ok := loader.credential(context.Background(), &model.AiProviderM{Name: "openai", BaseURL: "https://proxy.internal/v1", APIKey: encrypted})
+	require.True(t, ok)
+	assert.Equal(t, "sk-yaml", cred.APIKey, "the configured key overrides the stored one")
+	assert.Equal(t, "https://proxy.internal/v1", cred.BaseURL)
+
+	cred, ok = loader.credential(context.Background(), &model.AiProviderM{Name: "mistral", APIKey: encrypted})
+	require.True(t, ok)
+	assert.Equal(t, "sk-db", cred.APIKey)
+
+	_, ok = loader.credential(context.Background(), &model.AiProviderM{Name: "gemini"})
+	assert.False(t, ok)
+	assert.True(t, NeedsBaseURL("mistral"))
+	assert.False(t, NeedsBaseURL("ollama"))
+}
+
 func TestLoader_Load_DBError_ReturnsError(t *testing.T) {
 	store := mockstore.NewStore()
 	registry := aipkg.NewRegistry()
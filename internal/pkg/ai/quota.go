@@ -0,0 +1,116 @@
+// ABOUTME: Effective per-user AI rate limits.
+// ABOUTME: Resolves RPM from user override, tier and config, cached in Redis.
+
+package ai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"gorm.io/gorm"
+
+	"github.com/bingo-project/bingo/internal/pkg/facade"
+	"github.com/bingo-project/bingo/internal/pkg/log"
+	"github.com/bingo-project/bingo/internal/pkg/model"
+	"github.com/bingo-project/bingo/internal/pkg/store"
+)
+
+// rpmCacheTTL bounds how long a cached limit survives tier changes made outside the admin API.
+const rpmCacheTTL = 5 * time.Minute
+
+// RPMResolver resolves each user's effective requests-per-minute limit.
+// Priority: user override > tier default > config default. A config default-rpm of 0 or less
+// turns RPM limiting off, the resolved limit is then 0 for every user.
+type RPMResolver struct {
+	ds    store.IStore
+	redis *redis.Client
+}
+
+// NewRPMResolver creates a new RPMResolver, a nil redis client disables caching.
+func NewRPMResolver(ds store.IStore, redis *redis.Client) *RPMResolver {
+	return &RPMResolver{
+		ds:    ds,
+		redis: redis,
+	}
+}
+
+// RPM returns the user's effective limit, served from Redis when cached. 0 means unlimited.
+func (r *RPMResolver) RPM(ctx context.Context, uid string) (int, error) {
+	if r.redis != nil {
+		rpm, err := r.redis.Get(ctx, rpmCacheKey(uid)).Int()
+		if err == nil {
+			return rpm, nil
+		}
+		if !errors.Is(err, redis.Nil) {
+			log.C(ctx).Warnw("Failed to read cached RPM limit", "uid", uid, "err", err)
+		}
+	}
+
+	rpm, err := r.resolve(ctx, uid)
+	if err != nil {
+		return 0, err
+	}
+
+	if r.redis != nil {
+		if err := r.redis.Set(ctx, rpmCacheKey(uid), rpm, rpmCacheTTL).Err(); err != nil {
+			log.C(ctx).Warnw("Failed to cache RPM limit", "uid", uid, "err", err)
+		}
+	}
+
+	return rpm, nil
+}
+
+// Invalidate drops the cached limit so the next request resolves it again.
+func (r *RPMResolver) Invalidate(ctx context.Context, uid string) error {
+	if r.redis == nil {
+		return nil
+	}
+
+	return r.redis.Del(ctx, rpmCacheKey(uid)).Err()
+}
+
+// InvalidateAll drops the cached limits of all users, used when a tier changes.
+func (r *RPMResolver) InvalidateAll(ctx context.Context) error {
+	if r.redis == nil {
+		return nil
+	}
+
+	iter := r.redis.Scan(ctx, 0, rpmCacheKey("*"), 100).Iterator()
+	for iter.Next(ctx) {
+		if err := r.redis.Del(ctx, iter.Val()).Err(); err != nil {
+			return err
+		}
+	}
+
+	return iter.Err()
+}
+
+// resolve reads the limit from the user's quota and tier, users without a quota record are on the free tier.
+// The configured default applies only when neither sets a limit, and a default of 0 or less means unlimited.
+func (r *RPMResolver) resolve(ctx context.Context, uid string) (int, error) {
+	tier := model.AiQuotaTierFree
+	quota, err := r.ds.AiUserQuota().GetByUID(ctx, uid)
+	switch {
+	case err == nil:
+		if quota.RPM > 0 {
+			return quota.RPM, nil
+		}
+		tier = quota.Tier
+	case !errors.Is(err, gorm.ErrRecordNotFound):
+		return 0, fmt.Errorf("get ai user quota: %w", err)
+	}
+
+	if t, err := r.ds.AiQuotaTier().GetByTier(ctx, tier); err == nil && t.RPM > 0 {
+		return t.RPM, nil
+	}
+
+	return max(facade.Config.AI.Quota.DefaultRPM, 0), nil
+}
+
+// rpmCacheKey builds the Redis key caching a user's effective limit.
+func rpmCacheKey(uid string) string {
+	return fmt.Sprintf("%s:ai:rpm-limit:%s", facade.Config.App.Name, uid)
+}
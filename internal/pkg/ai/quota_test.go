@@ -0,0 +1,135 @@
+// ABOUTME: Tests for per-user RPM resolution.
+// ABOUTME: Covers override and tier priority, and Redis caching with invalidation.
+
+package ai
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bingo-project/bingo/internal/pkg/config"
+	"github.com/bingo-project/bingo/internal/pkg/facade"
+	"github.com/bingo-project/bingo/internal/pkg/model"
+	mockstore "github.com/bingo-project/bingo/internal/pkg/testing/mock/store"
+)
+
+func newQuotaStore(t *testing.T) *mockstore.Store {
+	t.Helper()
+
+	ctx := context.Background()
+	store := mockstore.NewStore()
+	require.NoError(t, store.AiQuotaTier().Create(ctx, &model.AiQuotaTierM{Tier: model.AiQuotaTierFree, RPM: 10}))
+	require.NoError(t, store.AiQuotaTier().Create(ctx, &model.AiQuotaTierM{Tier: model.AiQuotaTierPro, RPM: 60}))
+	require.NoError(t, store.AiUserQuota().Create(ctx, &model.AiUserQuotaM{UID: "pro-user", Tier: model.AiQuotaTierPro}))
+	require.NoError(t, store.AiUserQuota().Create(ctx, &model.AiUserQuotaM{UID: "override-user", Tier: model.AiQuotaTierPro, RPM: 200}))
+	require.NoError(t, store.AiUserQuota().Create(ctx, &model.AiUserQuotaM{UID: "custom-tier-user", Tier: "custom"}))
+
+	return store
+}
+
+func TestRPMResolver_RPM(t *testing.T) {
+	original := facade.Config.AI.Quota.DefaultRPM
+	facade.Config.AI.Quota.DefaultRPM = 30
+	defer func() { facade.Config.AI.Quota.DefaultRPM = original }()
+
+	r := NewRPMResolver(newQuotaStore(t), nil)
+	ctx := context.Background()
+
+	tests := []struct {
+		uid  string
+		want int
+	}{
+		{uid: "pro-user", want: 60},
+		{uid: "override-user", want: 200},
+		{uid: "new-user", want: 10},         // No quota record, free tier
+		{uid: "custom-tier-user", want: 30}, // Unknown tier, config default
+	}
+	for _, tt := range tests {
+		t.Run(tt.uid, func(t *testing.T) {
+			rpm, err := r.RPM(ctx, tt.uid)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, rpm)
+		})
+	}
+
+	// A default of 0 only lifts the limit of users neither an override nor a tier limits
+	facade.Config.AI.Quota.DefaultRPM = 0
+	for uid, want := range map[string]int{"override-user": 200, "pro-user": 60, "new-user": 10, "custom-tier-user": 0} {
+		rpm, err := r.RPM(ctx, uid)
+		require.NoError(t, err)
+		assert.Equal(t, want, rpm, uid)
+	}
+}
+
+func TestRPMResolver_RPM_StoreError(t *testing.T) {
+	original := facade.Config.AI.Quota.DefaultRPM
+	facade.Config.AI.Quota.DefaultRPM = 30
+	defer func() { facade.Config.AI.Quota.DefaultRPM = original }()
+
+	store := newQuotaStore(t)
+	store.AiUserQuota().(*mockstore.AiUserQuotaStore).GetByUIDErr = errors.New("db down")
+
+	_, err := NewRPMResolver(store, nil).RPM(context.Background(), "pro-user")
+	assert.Error(t, err)
+}
+
+func TestRPMResolver_Cache_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	redisClient := redis.NewClient(&redis.Options{
+		Addr: "localhost:6379",
+	})
+	defer redisClient.Close()
+
+	ctx := context.Background()
+	if err := redisClient.Ping(ctx).Err(); err != nil {
+		t.Skipf("Redis not available: %v", err)
+	}
+
+	if facade.Config.App == nil {
+		facade.Config.App = &config.App{Name: "bingo-test"}
+		defer func() { facade.Config.App = nil }()
+	}
+
+	original := facade.Config.AI.Quota.DefaultRPM
+	facade.Config.AI.Quota.DefaultRPM = 30
+	defer func() { facade.Config.AI.Quota.DefaultRPM = original }()
+
+	store := newQuotaStore(t)
+	quotas := store.AiUserQuota().(*mockstore.AiUserQuotaStore)
+	r := NewRPMResolver(store, redisClient)
+	require.NoError(t, r.Invalidate(ctx, "pro-user"))
+	defer r.Invalidate(ctx, "pro-user")
+
+	rpm, err := r.RPM(ctx, "pro-user")
+	require.NoError(t, err)
+	assert.Equal(t, 60, rpm)
+
+	// Upgrade is not visible until the cached limit is invalidated
+	quota, _ := quotas.GetByUID(ctx, "pro-user")
+	quota.RPM = 120
+	calls := quotas.GetByUIDCalled
+	rpm, err = r.RPM(ctx, "pro-user")
+	require.NoError(t, err)
+	assert.Equal(t, 60, rpm)
+	assert.Equal(t, calls, quotas.GetByUIDCalled)
+
+	require.NoError(t, r.Invalidate(ctx, "pro-user"))
+	rpm, err = r.RPM(ctx, "pro-user")
+	require.NoError(t, err)
+	assert.Equal(t, 120, rpm)
+
+	// A tier change drops every cached limit
+	quota.RPM = 0
+	require.NoError(t, r.InvalidateAll(ctx))
+	rpm, err = r.RPM(ctx, "pro-user")
+	require.NoError(t, err)
+	assert.Equal(t, 60, rpm)
+}
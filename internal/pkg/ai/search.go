@@ -0,0 +1,196 @@
+// ABOUTME: Conversation search across AI sessions and messages.
+// ABOUTME: Defines the search engine interface, the MySQL FULLTEXT engine, and match highlighting.
+
+package ai
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/bingo-project/bingo/internal/pkg/store"
+)
+
+const (
+	// DefaultSnippetRunes is the length of the message excerpt shown for a search hit.
+	DefaultSnippetRunes = 160
+
+	// maxSearchTerms is the maximum number of terms a query is split into.
+	maxSearchTerms = 8
+)
+
+// Searcher searches conversations by session title and message content.
+// Another engine can be swapped in by implementing it.
+type Searcher interface {
+	Search(ctx context.Context, q SearchQuery) (int64, []SearchHit, error)
+}
+
+// SearchQuery is a conversation search. Every term of Text must match.
+type SearchQuery struct {
+	UID    string // Empty searches all users
+	Text   string
+	Offset int
+	Limit  int
+}
+
+// SearchHit is a session whose title matches, or a message whose content matches.
+type SearchHit struct {
+	SessionID       string
+	UID             string
+	Title           string
+	TitleHighlights []Highlight
+	MessageID       uint64 // 0 when the session title matched
+	Role            string
+	Snippet         string
+	Highlights      []Highlight // Matches in Snippet
+	CreatedAt       time.Time
+}
+
+// Highlight is a match in a text as rune offsets, End is exclusive.
+type Highlight struct {
+	Start int
+	End   int
+}
+
+// FulltextSearcher searches conversations with the MySQL FULLTEXT index on message content.
+type FulltextSearcher struct {
+	store store.IStore
+}
+
+var _ Searcher = (*FulltextSearcher)(nil)
+
+// NewFulltextSearcher creates a new FulltextSearcher.
+func NewFulltextSearcher(st store.IStore) *FulltextSearcher {
+	return &FulltextSearcher{store: st}
+}
+
+// Search returns the hits of a query, newest first.
+func (s *FulltextSearcher) Search(ctx context.Context, q SearchQuery) (int64, []SearchHit, error) {
+	terms := SearchTerms(q.Text)
+	total, rows, err := s.store.AiMessage().Search(ctx, q.UID, terms, q.Offset, q.Limit)
+	if err != nil {
+		return 0, nil, fmt.Errorf("search messages: %w", err)
+	}
+
+	hits := make([]SearchHit, len(rows))
+	for i, r := range rows {
+		hits[i] = SearchHit{
+			SessionID:       r.SessionID,
+			UID:             r.UID,
+			Title:           r.Title,
+			TitleHighlights: Highlights(r.Title, terms),
+			MessageID:       r.MessageID,
+			Role:            r.Role,
+			CreatedAt:       r.CreatedAt,
+		}
+		if r.MessageID > 0 {
+			hits[i].Snippet, hits[i].Highlights = Snippet(r.Content, terms, DefaultSnippetRunes)
+		}
+	}
+
+	return total, hits, nil
+}
+
+// SearchTerms splits a query into distinct terms on whitespace, dropping double quotes.
+func SearchTerms(text string) []string {
+	var terms []string
+	for _, f := range strings.Fields(strings.ReplaceAll(text, `"`, " ")) {
+		if slices.ContainsFunc(terms, func(t string) bool { return strings.EqualFold(t, f) }) {
+			continue
+		}
+		terms = append(terms, f)
+		if len(terms) == maxSearchTerms {
+			break
+		}
+	}
+
+	return terms
+}
+
+// Highlights returns the case-insensitive matches of terms in text, ordered and merged.
+func Highlights(text string, terms []string) []Highlight {
+	return highlights(foldRunes(text), terms)
+}
+
+// Snippet returns an excerpt of at most width runes around the first match of terms in text,
+// marked with an ellipsis where cut, and the matches in it.
+func Snippet(text string, terms []string, width int) (string, []Highlight) {
+	runes := []rune(text)
+	hl := highlights(foldRunes(text), terms)
+	if len(runes) <= width {
+		return text, hl
+	}
+
+	// Show some context before the first match
+	start := 0
+	if len(hl) > 0 {
+		start = max(0, min(hl[0].Start-width/4, len(runes)-width))
+	}
+	end := start + width
+
+	snippet := string(runes[start:end])
+	shift := -start
+	if start > 0 {
+		snippet = "…" + snippet
+		shift++
+	}
+	if end < len(runes) {
+		snippet += "…"
+	}
+
+	var out []Highlight
+	for _, h := range hl {
+		if h.End <= start || h.Start >= end {
+			continue
+		}
+		out = append(out, Highlight{Start: max(h.Start, start) + shift, End: min(h.End, end) + shift})
+	}
+
+	return snippet, out
+}
+
+// highlights finds terms in lower-cased runes.
+func highlights(folded []rune, terms []string) []Highlight {
+	var hl []Highlight
+	for _, t := range terms {
+		term := foldRunes(t)
+		if len(term) == 0 {
+			continue
+		}
+		for i := 0; i+len(term) <= len(folded); i++ {
+			if slices.Equal(folded[i:i+len(term)], term) {
+				hl = append(hl, Highlight{Start: i, End: i + len(term)})
+			}
+		}
+	}
+	if len(hl) == 0 {
+		return nil
+	}
+
+	slices.SortFunc(hl, func(a, b Highlight) int { return a.Start - b.Start })
+	merged := hl[:1]
+	for _, h := range hl[1:] {
+		last := &merged[len(merged)-1]
+		if h.Start <= last.End {
+			last.End = max(last.End, h.End)
+
+			continue
+		}
+		merged = append(merged, h)
+	}
+
+	return merged
+}
+
+// foldRunes lower-cases text rune by rune, keeping rune offsets aligned with the original.
+func foldRunes(text string) []rune {
+	runes := []rune(text)
+	for i, r := range runes {
+		runes[i] = unicode.ToLower(r)
+	}
+
+	return runes
+}
@@ -0,0 +1,59 @@
+// ABOUTME: Tests for conversation search helpers.
+// ABOUTME: Covers query splitting, match highlighting and snippet windows.
+
+package ai
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchTerms(t *testing.T) {
+	assert.Equal(t, []string{"refund", "policy"}, SearchTerms(`  "refund"  policy Refund `))
+	assert.Empty(t, SearchTerms(` "" `))
+	assert.Len(t, SearchTerms("a b c d e f g h i j"), maxSearchTerms)
+}
+
+func TestHighlights(t *testing.T) {
+	tests := []struct {
+		name  string
+		text  string
+		terms []string
+		want  []Highlight
+	}{
+		{name: "case insensitive", text: "Refund policy", terms: []string{"refund"}, want: []Highlight{{0, 6}}},
+		{name: "every occurrence", text: "go go", terms: []string{"go"}, want: []Highlight{{0, 2}, {3, 5}}},
+		{name: "overlapping terms merge", text: "refunds", terms: []string{"refund", "funds"}, want: []Highlight{{0, 7}}},
+		{name: "rune offsets", text: "申请退款流程", terms: []string{"退款"}, want: []Highlight{{2, 4}}},
+		{name: "no match", text: "hello", terms: []string{"bye"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Highlights(tt.text, tt.terms))
+		})
+	}
+}
+
+func TestSnippet(t *testing.T) {
+	short, hl := Snippet("short refund text", []string{"refund"}, 40)
+	assert.Equal(t, "short refund text", short)
+	assert.Equal(t, []Highlight{{6, 12}}, hl)
+
+	text := strings.Repeat("a", 100) + "refund" + strings.Repeat("b", 100)
+	snippet, hl := Snippet(text, []string{"refund"}, 40)
+	runes := []rune(snippet)
+	assert.True(t, strings.HasPrefix(snippet, "…"))
+	assert.True(t, strings.HasSuffix(snippet, "…"))
+	assert.Len(t, runes, 42)
+	assert.Len(t, hl, 1)
+	assert.Equal(t, "refund", string(runes[hl[0].Start:hl[0].End]))
+
+	// A match near the end keeps the window inside the text
+	text = strings.Repeat("a", 100) + "refund"
+	snippet, hl = Snippet(text, []string{"refund"}, 40)
+	assert.False(t, strings.HasSuffix(snippet, "…"))
+	assert.Equal(t, "refund", string([]rune(snippet)[hl[0].Start:hl[0].End]))
+}
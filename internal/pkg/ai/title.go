@@ -0,0 +1,149 @@
+// ABOUTME: Session title generation with a small model.
+// ABOUTME: Turns the opening of a conversation into a short title in the user's language.
+
+package ai
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bingo-project/bingo/internal/pkg/log"
+	"github.com/bingo-project/bingo/internal/pkg/model"
+	"github.com/bingo-project/bingo/internal/pkg/store"
+	aipkg "github.com/bingo-project/bingo/pkg/ai"
+)
+
+const (
+	// titleMaxTokens is the maximum completion tokens of a title.
+	titleMaxTokens = 32
+
+	// maxTitleRunes is the maximum length of a stored title.
+	maxTitleRunes = 50
+
+	// maxTitleInputRunes is the maximum length of a message in the title input.
+	maxTitleInputRunes = 1000
+
+	// maxUsageErrorRunes is the maximum length of an error kept in the usage ledger.
+	maxUsageErrorRunes = 255
+)
+
+// titlePrompt instructs the title model.
+const titlePrompt = "Write a short title of at most 8 words for the conversation below. " +
+	"Reply with the title only, without quotes or trailing punctuation."
+
+// titleTrimmer lists the quotes and punctuation models wrap titles in.
+const titleTrimmer = " \t\"'`*#“”‘’「」『』《》.。!！?？:："
+
+// TitleGenerator titles chat sessions with a small model.
+type TitleGenerator struct {
+	store    store.IStore
+	registry *aipkg.Registry
+}
+
+// NewTitleGenerator creates a new TitleGenerator.
+func NewTitleGenerator(st store.IStore, registry *aipkg.Registry) *TitleGenerator {
+	return &TitleGenerator{
+		store:    st,
+		registry: registry,
+	}
+}
+
+// Generate returns a title for the opening messages of a session.
+// lang is the user's language code, the title follows the conversation if it is empty.
+// The title call is recorded in the usage ledger against the session owner.
+func (g *TitleGenerator) Generate(ctx context.Context, session *model.AiSessionM, modelName, lang string, messages []*model.AiMessageM) (string, error) {
+	if g.registry == nil {
+		return "", fmt.Errorf("AI is not configured")
+	}
+
+	m, err := g.store.AiModel().FindActiveByModel(ctx, modelName)
+	if err != nil {
+		return "", fmt.Errorf("find title model: %w", err)
+	}
+	provider, ok := g.registry.Get(m.ProviderName)
+	if !ok {
+		return "", fmt.Errorf("provider %s not registered", m.ProviderName)
+	}
+
+	var input strings.Builder
+	for _, msg := range messages {
+		if (msg.Role != model.AiMessageRoleUser && msg.Role != model.AiMessageRoleAssistant) || msg.Content == "" {
+			continue
+		}
+		fmt.Fprintf(&input, "%s: %s\n\n", msg.Role, truncate(msg.Content, maxTitleInputRunes))
+	}
+	if input.Len() == 0 {
+		return "", fmt.Errorf("conversation has no text")
+	}
+
+	prompt := titlePrompt
+	if lang != "" {
+		prompt += fmt.Sprintf(" Write the title in the language with code %q.", lang)
+	} else {
+		prompt += " Write the title in the language of the conversation."
+	}
+
+	start := time.Now()
+	resp, err := provider.Chat(ctx, &aipkg.ChatRequest{
+		Model: modelName,
+		Messages: []aipkg.Message{
+			{Role: aipkg.RoleSystem, Content: prompt},
+			{Role: aipkg.RoleUser, Content: input.String()},
+		},
+		MaxTokens: titleMaxTokens,
+	})
+	if err != nil {
+		g.recordUsage(ctx, session, m, aipkg.Usage{}, time.Since(start), err)
+
+		return "", err
+	}
+	g.recordUsage(ctx, session, m, resp.Usage, time.Since(start), nil)
+	if len(resp.Choices) == 0 {
+		return "", fmt.Errorf("empty title")
+	}
+
+	title := cleanTitle(resp.Choices[0].Message.Content)
+	if title == "" {
+		return "", fmt.Errorf("empty title")
+	}
+
+	return title, nil
+}
+
+// recordUsage writes the usage ledger entry of a title call.
+func (g *TitleGenerator) recordUsage(ctx context.Context, session *model.AiSessionM, m *model.AiModelM, usage aipkg.Usage, latency time.Duration, err error) {
+	row := &model.AiUsageM{
+		UID:              session.UID,
+		SessionID:        session.SessionID,
+		AgentID:          session.AgentID,
+		ProviderName:     m.ProviderName,
+		Model:            m.Model,
+		PromptTokens:     usage.PromptTokens,
+		CompletionTokens: usage.CompletionTokens,
+		TotalTokens:      usage.TotalTokens,
+		Cost:             UsageCost(m, usage),
+		LatencyMs:        latency.Milliseconds(),
+		Status:           model.AiUsageStatusSuccess,
+	}
+	if err != nil {
+		row.Status = model.AiUsageStatusError
+		row.Error = truncate(err.Error(), maxUsageErrorRunes)
+	}
+
+	if err := g.store.AiUsage().Create(ctx, row); err != nil {
+		log.C(ctx).Errorw("Failed to record AI title usage", "uid", row.UID, "session_id", row.SessionID, "model", row.Model, "err", err)
+	}
+}
+
+// cleanTitle keeps the first line of a model reply, without wrapping quotes and punctuation.
+func cleanTitle(reply string) string {
+	title := strings.TrimSpace(reply)
+	if i := strings.IndexByte(title, '\n'); i >= 0 {
+		title = title[:i]
+	}
+	title = strings.TrimPrefix(strings.TrimSpace(title), "Title:")
+
+	return strings.Trim(truncate(strings.Trim(title, titleTrimmer), maxTitleRunes), titleTrimmer)
+}
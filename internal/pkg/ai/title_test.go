@@ -0,0 +1,73 @@
+// ABOUTME: Tests for session title generation.
+// ABOUTME: Runs the title model on a fake provider and checks how replies are cleaned up.
+
+package ai
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bingo-project/bingo/internal/pkg/model"
+	mockstore "github.com/bingo-project/bingo/internal/pkg/testing/mock/store"
+	aipkg "github.com/bingo-project/bingo/pkg/ai"
+	"github.com/bingo-project/bingo/pkg/ai/providers/fake"
+)
+
+func TestTitleGenerator_Generate(t *testing.T) {
+	ctx := context.Background()
+	st := mockstore.NewStore()
+	require.NoError(t, st.AiModel().Create(ctx, &model.AiModelM{ProviderName: "fake", Model: "fake-mini", Status: model.AiModelStatusActive}))
+
+	provider := fake.New(fake.DefaultConfig()).Enqueue(fake.Reply("\"退款流程说明。\"\n"))
+	registry := aipkg.NewRegistry()
+	registry.Register(provider)
+
+	messages := []*model.AiMessageM{
+		{Role: model.AiMessageRoleUser, Content: "怎么申请退款？"},
+		{Role: model.AiMessageRoleAssistant, ToolCalls: []aipkg.ToolCall{{ID: "call_1"}}},
+		{Role: model.AiMessageRoleTool, Content: "order 42"},
+		{Role: model.AiMessageRoleAssistant, Content: "在订单页点击申请退款即可。"},
+	}
+	session := &model.AiSessionM{UID: "u1", SessionID: "s1"}
+	title, err := NewTitleGenerator(st, registry).Generate(ctx, session, "fake-mini", "zh", messages)
+	require.NoError(t, err)
+	assert.Equal(t, "退款流程说明", title)
+
+	_, rows, err := st.AiUsage().List(ctx, nil)
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Equal(t, "u1", rows[0].UID)
+	assert.Equal(t, "s1", rows[0].SessionID)
+	assert.Equal(t, "fake-mini", rows[0].Model)
+	assert.Positive(t, rows[0].TotalTokens)
+
+	reqs := provider.Requests()
+	require.Len(t, reqs, 1)
+	assert.Contains(t, reqs[0].Messages[0].Content, `"zh"`)
+	assert.NotContains(t, reqs[0].Messages[1].Content, "order 42")
+
+	_, err = NewTitleGenerator(st, registry).Generate(ctx, session, "missing-model", "", messages)
+	assert.Error(t, err)
+}
+
+func TestCleanTitle(t *testing.T) {
+	tests := []struct {
+		reply string
+		want  string
+	}{
+		{reply: "Refund policy", want: "Refund policy"},
+		{reply: "  \"Refund policy.\"  ", want: "Refund policy"},
+		{reply: "Title: **Refund policy**", want: "Refund policy"},
+		{reply: "《退款政策》\n解释：……", want: "退款政策"},
+		{reply: "...", want: ""},
+		{reply: strings.Repeat("长", 80), want: strings.Repeat("长", maxTitleRunes)},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, cleanTitle(tt.reply), tt.reply)
+	}
+}
@@ -0,0 +1,14 @@
+// ABOUTME: AI usage ledger pricing.
+// ABOUTME: Prices token usage with the per-1K token prices of a model.
+
+package ai
+
+import (
+	"github.com/bingo-project/bingo/internal/pkg/model"
+	aipkg "github.com/bingo-project/bingo/pkg/ai"
+)
+
+// UsageCost computes the cost of a completion, model prices are per 1K tokens.
+func UsageCost(m *model.AiModelM, usage aipkg.Usage) float64 {
+	return (float64(usage.PromptTokens)*m.InputPrice + float64(usage.CompletionTokens)*m.OutputPrice) / 1000
+}
@@ -0,0 +1,22 @@
+// ABOUTME: Tests for AI usage pricing.
+// ABOUTME: Checks costs are computed from per-1K token prices.
+
+package ai
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/bingo-project/bingo/internal/pkg/model"
+	aipkg "github.com/bingo-project/bingo/pkg/ai"
+)
+
+func TestUsageCost(t *testing.T) {
+	m := &model.AiModelM{InputPrice: 0.005, OutputPrice: 0.015}
+
+	cost := UsageCost(m, aipkg.Usage{PromptTokens: 2000, CompletionTokens: 500, TotalTokens: 2500})
+	assert.InDelta(t, 0.0175, cost, 1e-9)
+
+	assert.Zero(t, UsageCost(&model.AiModelM{}, aipkg.Usage{PromptTokens: 100, CompletionTokens: 100}))
+}
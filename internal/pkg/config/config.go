@@ -37,20 +37,30 @@ type AIConfig struct {
 
 // AICredential Provider 凭证
 type AICredential struct {
-	APIKey  string `mapstructure:"api-key" json:"apiKey" yaml:"api-key"`
-	BaseURL string `mapstructure:"base-url" json:"baseURL" yaml:"base-url"`
+	APIKey  string  `mapstructure:"api-key" json:"apiKey" yaml:"api-key"`
+	BaseURL string  `mapstructure:"base-url" json:"baseURL" yaml:"base-url"`
+	Keys    []AIKey `mapstructure:"keys" json:"keys" yaml:"keys"` // 额外的 API Key，与 api-key 一起按权重分摊请求
+}
+
+// AIKey Provider 的一个 API Key
+type AIKey struct {
+	Name   string `mapstructure:"name" json:"name" yaml:"name"` // 日志与监控中的标识，不含 Key 本身
+	APIKey string `mapstructure:"api-key" json:"apiKey" yaml:"api-key"`
+	Weight int    `mapstructure:"weight" json:"weight" yaml:"weight"` // 权重，默认 1
 }
 
 // AISessionConfig 会话配置
 type AISessionConfig struct {
-	MaxMessages   int `mapstructure:"max-messages" json:"maxMessages" yaml:"max-messages"`       // 单会话最大消息数
-	MaxTokens     int `mapstructure:"max-tokens" json:"maxTokens" yaml:"max-tokens"`             // 单次请求最大 token
-	ContextWindow int `mapstructure:"context-window" json:"contextWindow" yaml:"context-window"` // 上下文窗口大小
+	MaxMessages   int    `mapstructure:"max-messages" json:"maxMessages" yaml:"max-messages"`       // 单会话最大消息数
+	MaxTokens     int    `mapstructure:"max-tokens" json:"maxTokens" yaml:"max-tokens"`             // 单次请求默认预留的回复 token
+	ContextWindow int    `mapstructure:"context-window" json:"contextWindow" yaml:"context-window"` // 上下文最大消息数，0 表示仅按估算的 token 预算裁剪
+	SummaryModel  string `mapstructure:"summary-model" json:"summaryModel" yaml:"summary-model"`    // 会话摘要模型，为空时超出预算的历史直接丢弃
+	TitleModel    string `mapstructure:"title-model" json:"titleModel" yaml:"title-model"`          // 会话标题模型，为空时不自动生成标题
 }
 
 // AIQuotaConfig 配额配置
 type AIQuotaConfig struct {
 	Enabled    bool `mapstructure:"enabled" json:"enabled" yaml:"enabled"`
-	DefaultRPM int  `mapstructure:"default-rpm" json:"defaultRpm" yaml:"default-rpm"` // 默认 RPM
+	DefaultRPM int  `mapstructure:"default-rpm" json:"defaultRpm" yaml:"default-rpm"` // 默认 RPM，用户覆盖与层级均未设置时生效，0 表示不限制
 	DefaultTPD int  `mapstructure:"default-tpd" json:"defaultTpd" yaml:"default-tpd"` // 默认 TPD
 }
@@ -0,0 +1,34 @@
+// ABOUTME: Database migration adding tool calling columns to ai_message.
+// ABOUTME: Stores assistant tool calls and tool result references.
+
+package migration
+
+import (
+	"github.com/bingo-project/bingoctl/pkg/migrate"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+type AddToolColumnsToAIMessageTable struct {
+	Name       string         `gorm:"type:varchar(64);not null;default:''"`
+	ToolCalls  datatypes.JSON `gorm:"type:json"`
+	ToolCallID string         `gorm:"type:varchar(64);not null;default:''"`
+}
+
+func (AddToolColumnsToAIMessageTable) TableName() string {
+	return "ai_message"
+}
+
+func (AddToolColumnsToAIMessageTable) Up(migrator gorm.Migrator) {
+	_ = migrator.AutoMigrate(&AddToolColumnsToAIMessageTable{})
+}
+
+func (AddToolColumnsToAIMessageTable) Down(migrator gorm.Migrator) {
+	_ = migrator.DropColumn(&AddToolColumnsToAIMessageTable{}, "name")
+	_ = migrator.DropColumn(&AddToolColumnsToAIMessageTable{}, "tool_calls")
+	_ = migrator.DropColumn(&AddToolColumnsToAIMessageTable{}, "tool_call_id")
+}
+
+func init() {
+	migrate.Add("2026_10_17_100000_add_tool_columns_to_ai_message_table", AddToolColumnsToAIMessageTable{}.Up, AddToolColumnsToAIMessageTable{}.Down)
+}
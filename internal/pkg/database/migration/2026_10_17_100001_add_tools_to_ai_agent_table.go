@@ -0,0 +1,30 @@
+// ABOUTME: Database migration adding tools column to ai_agent.
+// ABOUTME: Lists the server-side tools an agent is allowed to call.
+
+package migration
+
+import (
+	"github.com/bingo-project/bingoctl/pkg/migrate"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+type AddToolsToAIAgentTable struct {
+	Tools datatypes.JSON `gorm:"type:json"`
+}
+
+func (AddToolsToAIAgentTable) TableName() string {
+	return "ai_agent"
+}
+
+func (AddToolsToAIAgentTable) Up(migrator gorm.Migrator) {
+	_ = migrator.AutoMigrate(&AddToolsToAIAgentTable{})
+}
+
+func (AddToolsToAIAgentTable) Down(migrator gorm.Migrator) {
+	_ = migrator.DropColumn(&AddToolsToAIAgentTable{}, "tools")
+}
+
+func init() {
+	migrate.Add("2026_10_17_100001_add_tools_to_ai_agent_table", AddToolsToAIAgentTable{}.Up, AddToolsToAIAgentTable{}.Down)
+}
@@ -0,0 +1,30 @@
+// ABOUTME: Database migration adding content_parts column to ai_message.
+// ABOUTME: Stores multimodal (text + image) user message content.
+
+package migration
+
+import (
+	"github.com/bingo-project/bingoctl/pkg/migrate"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+type AddContentPartsToAIMessageTable struct {
+	ContentParts datatypes.JSON `gorm:"type:json"`
+}
+
+func (AddContentPartsToAIMessageTable) TableName() string {
+	return "ai_message"
+}
+
+func (AddContentPartsToAIMessageTable) Up(migrator gorm.Migrator) {
+	_ = migrator.AutoMigrate(&AddContentPartsToAIMessageTable{})
+}
+
+func (AddContentPartsToAIMessageTable) Down(migrator gorm.Migrator) {
+	_ = migrator.DropColumn(&AddContentPartsToAIMessageTable{}, "content_parts")
+}
+
+func init() {
+	migrate.Add("2026_10_17_100002_add_content_parts_to_ai_message_table", AddContentPartsToAIMessageTable{}.Up, AddContentPartsToAIMessageTable{}.Down)
+}
@@ -0,0 +1,29 @@
+// ABOUTME: Database migration adding type column to ai_model.
+// ABOUTME: Distinguishes chat models from embedding models.
+
+package migration
+
+import (
+	"github.com/bingo-project/bingoctl/pkg/migrate"
+	"gorm.io/gorm"
+)
+
+type AddTypeToAIModelTable struct {
+	Type string `gorm:"type:varchar(16);not null;default:chat"`
+}
+
+func (AddTypeToAIModelTable) TableName() string {
+	return "ai_model"
+}
+
+func (AddTypeToAIModelTable) Up(migrator gorm.Migrator) {
+	_ = migrator.AutoMigrate(&AddTypeToAIModelTable{})
+}
+
+func (AddTypeToAIModelTable) Down(migrator gorm.Migrator) {
+	_ = migrator.DropColumn(&AddTypeToAIModelTable{}, "type")
+}
+
+func init() {
+	migrate.Add("2026_10_17_100003_add_type_to_ai_model_table", AddTypeToAIModelTable{}.Up, AddTypeToAIModelTable{}.Down)
+}
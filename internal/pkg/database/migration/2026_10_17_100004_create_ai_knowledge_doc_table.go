@@ -0,0 +1,40 @@
+// ABOUTME: Database migration for ai_knowledge_doc table.
+// ABOUTME: Creates table for documents attached to agent knowledge bases.
+
+package migration
+
+import (
+	"time"
+
+	"github.com/bingo-project/bingoctl/pkg/migrate"
+	"gorm.io/gorm"
+)
+
+type CreateAIKnowledgeDocTable struct {
+	ID             uint64    `gorm:"primaryKey"`
+	AgentID        string    `gorm:"type:varchar(32);index:idx_agent_id;not null"`
+	Title          string    `gorm:"type:varchar(255);not null"`
+	EmbeddingModel string    `gorm:"type:varchar(64);not null;default:''"`
+	ChunkCount     int       `gorm:"type:int;not null;default:0"`
+	Chars          int       `gorm:"type:int;not null;default:0"`
+	Status         string    `gorm:"type:varchar(16);not null;default:'indexing'"`
+	Error          string    `gorm:"type:varchar(255);not null;default:''"`
+	CreatedAt      time.Time `gorm:"type:DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3)"`
+	UpdatedAt      time.Time `gorm:"type:DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3)"`
+}
+
+func (CreateAIKnowledgeDocTable) TableName() string {
+	return "ai_knowledge_doc"
+}
+
+func (CreateAIKnowledgeDocTable) Up(migrator gorm.Migrator) {
+	_ = migrator.AutoMigrate(&CreateAIKnowledgeDocTable{})
+}
+
+func (CreateAIKnowledgeDocTable) Down(migrator gorm.Migrator) {
+	_ = migrator.DropTable(&CreateAIKnowledgeDocTable{})
+}
+
+func init() {
+	migrate.Add("2026_10_17_100004_create_ai_knowledge_doc_table", CreateAIKnowledgeDocTable{}.Up, CreateAIKnowledgeDocTable{}.Down)
+}
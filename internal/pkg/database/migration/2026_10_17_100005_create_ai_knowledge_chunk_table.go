@@ -0,0 +1,38 @@
+// ABOUTME: Database migration for ai_knowledge_chunk table.
+// ABOUTME: Creates table for embedded knowledge document chunks.
+
+package migration
+
+import (
+	"time"
+
+	"github.com/bingo-project/bingoctl/pkg/migrate"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+type CreateAIKnowledgeChunkTable struct {
+	ID        uint64         `gorm:"primaryKey"`
+	DocID     uint64         `gorm:"type:bigint unsigned;index:idx_doc_id;not null"`
+	AgentID   string         `gorm:"type:varchar(32);index:idx_agent_id;not null"`
+	Seq       int            `gorm:"type:int;not null;default:0"`
+	Content   string         `gorm:"type:text;not null"`
+	Embedding datatypes.JSON `gorm:"type:json"`
+	CreatedAt time.Time      `gorm:"type:DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3)"`
+}
+
+func (CreateAIKnowledgeChunkTable) TableName() string {
+	return "ai_knowledge_chunk"
+}
+
+func (CreateAIKnowledgeChunkTable) Up(migrator gorm.Migrator) {
+	_ = migrator.AutoMigrate(&CreateAIKnowledgeChunkTable{})
+}
+
+func (CreateAIKnowledgeChunkTable) Down(migrator gorm.Migrator) {
+	_ = migrator.DropTable(&CreateAIKnowledgeChunkTable{})
+}
+
+func init() {
+	migrate.Add("2026_10_17_100005_create_ai_knowledge_chunk_table", CreateAIKnowledgeChunkTable{}.Up, CreateAIKnowledgeChunkTable{}.Down)
+}
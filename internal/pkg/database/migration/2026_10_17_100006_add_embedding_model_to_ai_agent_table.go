@@ -0,0 +1,29 @@
+// ABOUTME: Database migration adding embedding_model column to ai_agent.
+// ABOUTME: Selects the embedding model used for the agent's knowledge base.
+
+package migration
+
+import (
+	"github.com/bingo-project/bingoctl/pkg/migrate"
+	"gorm.io/gorm"
+)
+
+type AddEmbeddingModelToAIAgentTable struct {
+	EmbeddingModel string `gorm:"type:varchar(64);not null;default:''"`
+}
+
+func (AddEmbeddingModelToAIAgentTable) TableName() string {
+	return "ai_agent"
+}
+
+func (AddEmbeddingModelToAIAgentTable) Up(migrator gorm.Migrator) {
+	_ = migrator.AutoMigrate(&AddEmbeddingModelToAIAgentTable{})
+}
+
+func (AddEmbeddingModelToAIAgentTable) Down(migrator gorm.Migrator) {
+	_ = migrator.DropColumn(&AddEmbeddingModelToAIAgentTable{}, "embedding_model")
+}
+
+func init() {
+	migrate.Add("2026_10_17_100006_add_embedding_model_to_ai_agent_table", AddEmbeddingModelToAIAgentTable{}.Up, AddEmbeddingModelToAIAgentTable{}.Down)
+}
@@ -0,0 +1,29 @@
+// ABOUTME: Database migration adding kind column to ai_message.
+// ABOUTME: Distinguishes conversation messages from rolling session summaries.
+
+package migration
+
+import (
+	"github.com/bingo-project/bingoctl/pkg/migrate"
+	"gorm.io/gorm"
+)
+
+type AddKindToAIMessageTable struct {
+	Kind string `gorm:"type:varchar(16);not null;default:'message'"`
+}
+
+func (AddKindToAIMessageTable) TableName() string {
+	return "ai_message"
+}
+
+func (AddKindToAIMessageTable) Up(migrator gorm.Migrator) {
+	_ = migrator.AutoMigrate(&AddKindToAIMessageTable{})
+}
+
+func (AddKindToAIMessageTable) Down(migrator gorm.Migrator) {
+	_ = migrator.DropColumn(&AddKindToAIMessageTable{}, "kind")
+}
+
+func init() {
+	migrate.Add("2026_10_17_100007_add_kind_to_ai_message_table", AddKindToAIMessageTable{}.Up, AddKindToAIMessageTable{}.Down)
+}
@@ -0,0 +1,29 @@
+// ABOUTME: Database migration adding summary_cursor column to ai_session.
+// ABOUTME: Tracks the last message covered by the session's rolling summary.
+
+package migration
+
+import (
+	"github.com/bingo-project/bingoctl/pkg/migrate"
+	"gorm.io/gorm"
+)
+
+type AddSummaryCursorToAISessionTable struct {
+	SummaryCursor uint64 `gorm:"type:bigint unsigned;not null;default:0"`
+}
+
+func (AddSummaryCursorToAISessionTable) TableName() string {
+	return "ai_session"
+}
+
+func (AddSummaryCursorToAISessionTable) Up(migrator gorm.Migrator) {
+	_ = migrator.AutoMigrate(&AddSummaryCursorToAISessionTable{})
+}
+
+func (AddSummaryCursorToAISessionTable) Down(migrator gorm.Migrator) {
+	_ = migrator.DropColumn(&AddSummaryCursorToAISessionTable{}, "summary_cursor")
+}
+
+func init() {
+	migrate.Add("2026_10_17_100008_add_summary_cursor_to_ai_session_table", AddSummaryCursorToAISessionTable{}.Up, AddSummaryCursorToAISessionTable{}.Down)
+}
@@ -0,0 +1,46 @@
+// ABOUTME: Database migration for ai_usage table.
+// ABOUTME: Creates the per-request ledger of AI tokens, cost and latency.
+
+package migration
+
+import (
+	"time"
+
+	"github.com/bingo-project/bingoctl/pkg/migrate"
+	"gorm.io/gorm"
+)
+
+type CreateAIUsageTable struct {
+	ID               uint64    `gorm:"primaryKey"`
+	UID              string    `gorm:"type:varchar(64);index:idx_uid_created_at,priority:1;not null"`
+	SessionID        string    `gorm:"type:varchar(64);not null;default:''"`
+	AgentID          string    `gorm:"type:varchar(32);not null;default:''"`
+	ProviderName     string    `gorm:"type:varchar(32);not null"`
+	Model            string    `gorm:"type:varchar(64);index:idx_model_created_at,priority:1;not null"`
+	Stream           bool      `gorm:"type:tinyint(1);not null;default:0"`
+	Fallback         bool      `gorm:"type:tinyint(1);not null;default:0"`
+	PromptTokens     int       `gorm:"type:int;not null;default:0"`
+	CompletionTokens int       `gorm:"type:int;not null;default:0"`
+	TotalTokens      int       `gorm:"type:int;not null;default:0"`
+	Cost             float64   `gorm:"type:decimal(16,8);not null;default:0"`
+	LatencyMs        int64     `gorm:"type:bigint;not null;default:0"`
+	Status           string    `gorm:"type:varchar(16);not null;default:'success'"`
+	Error            string    `gorm:"type:varchar(255);not null;default:''"`
+	CreatedAt        time.Time `gorm:"type:DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3);index:idx_uid_created_at,priority:2;index:idx_model_created_at,priority:2;index:idx_created_at"`
+}
+
+func (CreateAIUsageTable) TableName() string {
+	return "ai_usage"
+}
+
+func (CreateAIUsageTable) Up(migrator gorm.Migrator) {
+	_ = migrator.AutoMigrate(&CreateAIUsageTable{})
+}
+
+func (CreateAIUsageTable) Down(migrator gorm.Migrator) {
+	_ = migrator.DropTable(&CreateAIUsageTable{})
+}
+
+func init() {
+	migrate.Add("2026_10_17_100009_create_ai_usage_table", CreateAIUsageTable{}.Up, CreateAIUsageTable{}.Down)
+}
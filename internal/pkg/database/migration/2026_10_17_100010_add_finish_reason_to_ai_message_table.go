@@ -0,0 +1,29 @@
+// ABOUTME: Database migration adding finish_reason column to ai_message.
+// ABOUTME: Records why an assistant reply ended, including client cancellation.
+
+package migration
+
+import (
+	"github.com/bingo-project/bingoctl/pkg/migrate"
+	"gorm.io/gorm"
+)
+
+type AddFinishReasonToAIMessageTable struct {
+	FinishReason string `gorm:"type:varchar(32);not null;default:''"`
+}
+
+func (AddFinishReasonToAIMessageTable) TableName() string {
+	return "ai_message"
+}
+
+func (AddFinishReasonToAIMessageTable) Up(migrator gorm.Migrator) {
+	_ = migrator.AutoMigrate(&AddFinishReasonToAIMessageTable{})
+}
+
+func (AddFinishReasonToAIMessageTable) Down(migrator gorm.Migrator) {
+	_ = migrator.DropColumn(&AddFinishReasonToAIMessageTable{}, "finish_reason")
+}
+
+func init() {
+	migrate.Add("2026_10_17_100010_add_finish_reason_to_ai_message_table", AddFinishReasonToAIMessageTable{}.Up, AddFinishReasonToAIMessageTable{}.Down)
+}
@@ -0,0 +1,29 @@
+// ABOUTME: Database migration adding parent_id column to ai_message.
+// ABOUTME: Links each message to the previous one so a session forms a tree of branches.
+
+package migration
+
+import (
+	"github.com/bingo-project/bingoctl/pkg/migrate"
+	"gorm.io/gorm"
+)
+
+type AddParentIDToAIMessageTable struct {
+	ParentID uint64 `gorm:"type:bigint unsigned;index:idx_parent_id;not null;default:0"`
+}
+
+func (AddParentIDToAIMessageTable) TableName() string {
+	return "ai_message"
+}
+
+func (AddParentIDToAIMessageTable) Up(migrator gorm.Migrator) {
+	_ = migrator.AutoMigrate(&AddParentIDToAIMessageTable{})
+}
+
+func (AddParentIDToAIMessageTable) Down(migrator gorm.Migrator) {
+	_ = migrator.DropColumn(&AddParentIDToAIMessageTable{}, "parent_id")
+}
+
+func init() {
+	migrate.Add("2026_10_17_100011_add_parent_id_to_ai_message_table", AddParentIDToAIMessageTable{}.Up, AddParentIDToAIMessageTable{}.Down)
+}
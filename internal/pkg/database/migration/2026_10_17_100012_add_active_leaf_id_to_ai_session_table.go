@@ -0,0 +1,29 @@
+// ABOUTME: Database migration adding active_leaf_id column to ai_session.
+// ABOUTME: Points at the last message of the branch the session continues.
+
+package migration
+
+import (
+	"github.com/bingo-project/bingoctl/pkg/migrate"
+	"gorm.io/gorm"
+)
+
+type AddActiveLeafIDToAISessionTable struct {
+	ActiveLeafID uint64 `gorm:"type:bigint unsigned;not null;default:0"`
+}
+
+func (AddActiveLeafIDToAISessionTable) TableName() string {
+	return "ai_session"
+}
+
+func (AddActiveLeafIDToAISessionTable) Up(migrator gorm.Migrator) {
+	_ = migrator.AutoMigrate(&AddActiveLeafIDToAISessionTable{})
+}
+
+func (AddActiveLeafIDToAISessionTable) Down(migrator gorm.Migrator) {
+	_ = migrator.DropColumn(&AddActiveLeafIDToAISessionTable{}, "active_leaf_id")
+}
+
+func init() {
+	migrate.Add("2026_10_17_100012_add_active_leaf_id_to_ai_session_table", AddActiveLeafIDToAISessionTable{}.Up, AddActiveLeafIDToAISessionTable{}.Down)
+}
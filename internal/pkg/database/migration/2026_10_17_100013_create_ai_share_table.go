@@ -0,0 +1,39 @@
+// ABOUTME: Database migration for ai_share table.
+// ABOUTME: Creates public share links of session snapshots with expiry and view counts.
+
+package migration
+
+import (
+	"time"
+
+	"github.com/bingo-project/bingoctl/pkg/migrate"
+	"gorm.io/gorm"
+)
+
+type CreateAIShareTable struct {
+	ID        uint64     `gorm:"primaryKey"`
+	Token     string     `gorm:"type:varchar(64);uniqueIndex:uk_token;not null"`
+	SessionID string     `gorm:"type:varchar(64);index:idx_session_id;not null"`
+	UID       string     `gorm:"type:varchar(64);not null"`
+	LeafID    uint64     `gorm:"type:bigint unsigned;not null;default:0"`
+	Title     string     `gorm:"type:varchar(255);not null;default:''"`
+	Views     int        `gorm:"type:int;not null;default:0"`
+	ExpiresAt *time.Time `gorm:"type:DATETIME(3)"`
+	CreatedAt time.Time  `gorm:"type:DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3)"`
+}
+
+func (CreateAIShareTable) TableName() string {
+	return "ai_share"
+}
+
+func (CreateAIShareTable) Up(migrator gorm.Migrator) {
+	_ = migrator.AutoMigrate(&CreateAIShareTable{})
+}
+
+func (CreateAIShareTable) Down(migrator gorm.Migrator) {
+	_ = migrator.DropTable(&CreateAIShareTable{})
+}
+
+func init() {
+	migrate.Add("2026_10_17_100013_create_ai_share_table", CreateAIShareTable{}.Up, CreateAIShareTable{}.Down)
+}
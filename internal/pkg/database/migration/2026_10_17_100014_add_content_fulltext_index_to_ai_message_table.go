@@ -0,0 +1,31 @@
+// ABOUTME: Database migration adding a FULLTEXT index on ai_message content.
+// ABOUTME: Uses the ngram parser so conversation search also matches CJK text.
+
+package migration
+
+import (
+	"github.com/bingo-project/bingoctl/pkg/migrate"
+	"gorm.io/gorm"
+)
+
+type AddContentFulltextIndexToAIMessageTable struct {
+	Content string `gorm:"type:text;not null;index:idx_content,class:FULLTEXT,option:WITH PARSER ngram"`
+}
+
+func (AddContentFulltextIndexToAIMessageTable) TableName() string {
+	return "ai_message"
+}
+
+func (AddContentFulltextIndexToAIMessageTable) Up(migrator gorm.Migrator) {
+	if !migrator.HasIndex(&AddContentFulltextIndexToAIMessageTable{}, "idx_content") {
+		_ = migrator.CreateIndex(&AddContentFulltextIndexToAIMessageTable{}, "idx_content")
+	}
+}
+
+func (AddContentFulltextIndexToAIMessageTable) Down(migrator gorm.Migrator) {
+	_ = migrator.DropIndex(&AddContentFulltextIndexToAIMessageTable{}, "idx_content")
+}
+
+func init() {
+	migrate.Add("2026_10_17_100014_add_content_fulltext_index_to_ai_message_table", AddContentFulltextIndexToAIMessageTable{}.Up, AddContentFulltextIndexToAIMessageTable{}.Down)
+}
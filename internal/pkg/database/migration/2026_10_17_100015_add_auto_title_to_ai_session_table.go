@@ -0,0 +1,29 @@
+// ABOUTME: Database migration adding auto_title column to ai_session.
+// ABOUTME: Marks sessions whose placeholder title is replaced by a generated one.
+
+package migration
+
+import (
+	"github.com/bingo-project/bingoctl/pkg/migrate"
+	"gorm.io/gorm"
+)
+
+type AddAutoTitleToAISessionTable struct {
+	AutoTitle bool `gorm:"type:tinyint(1);not null;default:0"`
+}
+
+func (AddAutoTitleToAISessionTable) TableName() string {
+	return "ai_session"
+}
+
+func (AddAutoTitleToAISessionTable) Up(migrator gorm.Migrator) {
+	_ = migrator.AutoMigrate(&AddAutoTitleToAISessionTable{})
+}
+
+func (AddAutoTitleToAISessionTable) Down(migrator gorm.Migrator) {
+	_ = migrator.DropColumn(&AddAutoTitleToAISessionTable{}, "auto_title")
+}
+
+func init() {
+	migrate.Add("2026_10_17_100015_add_auto_title_to_ai_session_table", AddAutoTitleToAISessionTable{}.Up, AddAutoTitleToAISessionTable{}.Down)
+}
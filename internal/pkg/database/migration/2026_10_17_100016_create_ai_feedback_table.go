@@ -0,0 +1,42 @@
+// ABOUTME: Database migration for ai_feedback table.
+// ABOUTME: Creates per-user ratings of assistant messages with reasons and comments.
+
+package migration
+
+import (
+	"time"
+
+	"github.com/bingo-project/bingoctl/pkg/migrate"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+type CreateAIFeedbackTable struct {
+	ID        uint64                      `gorm:"primaryKey"`
+	MessageID uint64                      `gorm:"type:bigint unsigned;uniqueIndex:uk_message_uid;not null"`
+	UID       string                      `gorm:"type:varchar(64);uniqueIndex:uk_message_uid;not null"`
+	SessionID string                      `gorm:"type:varchar(64);index:idx_session_id;not null"`
+	Rating    int                         `gorm:"type:tinyint;not null"`
+	Reasons   datatypes.JSONSlice[string] `gorm:"type:json"`
+	Comment   string                      `gorm:"type:varchar(1000);not null;default:''"`
+	Model     string                      `gorm:"type:varchar(64);not null;default:''"`
+	AgentID   string                      `gorm:"type:varchar(64);not null;default:''"`
+	CreatedAt time.Time                   `gorm:"type:DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3);index:idx_created_at"`
+	UpdatedAt time.Time                   `gorm:"type:DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3)"`
+}
+
+func (CreateAIFeedbackTable) TableName() string {
+	return "ai_feedback"
+}
+
+func (CreateAIFeedbackTable) Up(migrator gorm.Migrator) {
+	_ = migrator.AutoMigrate(&CreateAIFeedbackTable{})
+}
+
+func (CreateAIFeedbackTable) Down(migrator gorm.Migrator) {
+	_ = migrator.DropTable(&CreateAIFeedbackTable{})
+}
+
+func init() {
+	migrate.Add("2026_10_17_100016_create_ai_feedback_table", CreateAIFeedbackTable{}.Up, CreateAIFeedbackTable{}.Down)
+}
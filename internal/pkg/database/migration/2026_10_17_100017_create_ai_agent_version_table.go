@@ -0,0 +1,42 @@
+// ABOUTME: Database migration for ai_agent_version table.
+// ABOUTME: Creates immutable snapshots of agent prompts and generation settings.
+
+package migration
+
+import (
+	"time"
+
+	"github.com/bingo-project/bingoctl/pkg/migrate"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+type CreateAIAgentVersionTable struct {
+	ID           uint64                      `gorm:"primaryKey"`
+	AgentID      string                      `gorm:"type:varchar(32);uniqueIndex:uk_agent_version;not null"`
+	Version      int                         `gorm:"type:int;uniqueIndex:uk_agent_version;not null"`
+	SystemPrompt string                      `gorm:"type:text;not null"`
+	Model        string                      `gorm:"type:varchar(64);not null;default:''"`
+	Temperature  float64                     `gorm:"type:decimal(3,2);not null;default:0.70"`
+	MaxTokens    int                         `gorm:"type:int;not null;default:2000"`
+	Tools        datatypes.JSONSlice[string] `gorm:"type:json"`
+	Author       string                      `gorm:"type:varchar(64);not null;default:''"`
+	Note         string                      `gorm:"type:varchar(255);not null;default:''"`
+	CreatedAt    time.Time                   `gorm:"type:DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3)"`
+}
+
+func (CreateAIAgentVersionTable) TableName() string {
+	return "ai_agent_version"
+}
+
+func (CreateAIAgentVersionTable) Up(migrator gorm.Migrator) {
+	_ = migrator.AutoMigrate(&CreateAIAgentVersionTable{})
+}
+
+func (CreateAIAgentVersionTable) Down(migrator gorm.Migrator) {
+	_ = migrator.DropTable(&CreateAIAgentVersionTable{})
+}
+
+func init() {
+	migrate.Add("2026_10_17_100017_create_ai_agent_version_table", CreateAIAgentVersionTable{}.Up, CreateAIAgentVersionTable{}.Down)
+}
@@ -0,0 +1,29 @@
+// ABOUTME: Database migration adding published_version column to ai_agent.
+// ABOUTME: Points each agent at the version whose settings it serves.
+
+package migration
+
+import (
+	"github.com/bingo-project/bingoctl/pkg/migrate"
+	"gorm.io/gorm"
+)
+
+type AddPublishedVersionToAIAgentTable struct {
+	PublishedVersion int `gorm:"type:int;not null;default:0"`
+}
+
+func (AddPublishedVersionToAIAgentTable) TableName() string {
+	return "ai_agent"
+}
+
+func (AddPublishedVersionToAIAgentTable) Up(migrator gorm.Migrator) {
+	_ = migrator.AutoMigrate(&AddPublishedVersionToAIAgentTable{})
+}
+
+func (AddPublishedVersionToAIAgentTable) Down(migrator gorm.Migrator) {
+	_ = migrator.DropColumn(&AddPublishedVersionToAIAgentTable{}, "published_version")
+}
+
+func init() {
+	migrate.Add("2026_10_17_100018_add_published_version_to_ai_agent_table", AddPublishedVersionToAIAgentTable{}.Up, AddPublishedVersionToAIAgentTable{}.Down)
+}
@@ -0,0 +1,29 @@
+// ABOUTME: Database migration adding agent_version column to ai_message.
+// ABOUTME: Records the agent version that produced each assistant message.
+
+package migration
+
+import (
+	"github.com/bingo-project/bingoctl/pkg/migrate"
+	"gorm.io/gorm"
+)
+
+type AddAgentVersionToAIMessageTable struct {
+	AgentVersion int `gorm:"type:int;not null;default:0"`
+}
+
+func (AddAgentVersionToAIMessageTable) TableName() string {
+	return "ai_message"
+}
+
+func (AddAgentVersionToAIMessageTable) Up(migrator gorm.Migrator) {
+	_ = migrator.AutoMigrate(&AddAgentVersionToAIMessageTable{})
+}
+
+func (AddAgentVersionToAIMessageTable) Down(migrator gorm.Migrator) {
+	_ = migrator.DropColumn(&AddAgentVersionToAIMessageTable{}, "agent_version")
+}
+
+func init() {
+	migrate.Add("2026_10_17_100019_add_agent_version_to_ai_message_table", AddAgentVersionToAIMessageTable{}.Up, AddAgentVersionToAIMessageTable{}.Down)
+}
@@ -0,0 +1,40 @@
+// ABOUTME: Database migration for ai_experiment table.
+// ABOUTME: Creates A/B experiments splitting an agent's users across weighted arms.
+
+package migration
+
+import (
+	"time"
+
+	"github.com/bingo-project/bingoctl/pkg/migrate"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+type CreateAIExperimentTable struct {
+	ID        uint64         `gorm:"primaryKey"`
+	Name      string         `gorm:"type:varchar(64);not null"`
+	AgentID   string         `gorm:"type:varchar(32);index:idx_agent_id;not null"`
+	Status    string         `gorm:"type:varchar(16);not null;default:'draft'"`
+	Arms      datatypes.JSON `gorm:"type:json"`
+	StartedAt *time.Time     `gorm:"type:DATETIME(3)"`
+	StoppedAt *time.Time     `gorm:"type:DATETIME(3)"`
+	CreatedAt time.Time      `gorm:"type:DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3)"`
+	UpdatedAt time.Time      `gorm:"type:DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3)"`
+}
+
+func (CreateAIExperimentTable) TableName() string {
+	return "ai_experiment"
+}
+
+func (CreateAIExperimentTable) Up(migrator gorm.Migrator) {
+	_ = migrator.AutoMigrate(&CreateAIExperimentTable{})
+}
+
+func (CreateAIExperimentTable) Down(migrator gorm.Migrator) {
+	_ = migrator.DropTable(&CreateAIExperimentTable{})
+}
+
+func init() {
+	migrate.Add("2026_10_17_100020_create_ai_experiment_table", CreateAIExperimentTable{}.Up, CreateAIExperimentTable{}.Down)
+}
@@ -0,0 +1,31 @@
+// ABOUTME: Database migration adding experiment columns to ai_message.
+// ABOUTME: Records the A/B experiment and arm of the turn that produced each message.
+
+package migration
+
+import (
+	"github.com/bingo-project/bingoctl/pkg/migrate"
+	"gorm.io/gorm"
+)
+
+type AddExperimentToAIMessageTable struct {
+	ExperimentID  uint64 `gorm:"type:bigint unsigned;not null;default:0"`
+	ExperimentArm string `gorm:"type:varchar(32);not null;default:''"`
+}
+
+func (AddExperimentToAIMessageTable) TableName() string {
+	return "ai_message"
+}
+
+func (AddExperimentToAIMessageTable) Up(migrator gorm.Migrator) {
+	_ = migrator.AutoMigrate(&AddExperimentToAIMessageTable{})
+}
+
+func (AddExperimentToAIMessageTable) Down(migrator gorm.Migrator) {
+	_ = migrator.DropColumn(&AddExperimentToAIMessageTable{}, "experiment_id")
+	_ = migrator.DropColumn(&AddExperimentToAIMessageTable{}, "experiment_arm")
+}
+
+func init() {
+	migrate.Add("2026_10_17_100021_add_experiment_to_ai_message_table", AddExperimentToAIMessageTable{}.Up, AddExperimentToAIMessageTable{}.Down)
+}
@@ -0,0 +1,31 @@
+// ABOUTME: Database migration adding experiment columns to ai_usage.
+// ABOUTME: Records the A/B experiment and arm of each ledger entry for per-arm reporting.
+
+package migration
+
+import (
+	"github.com/bingo-project/bingoctl/pkg/migrate"
+	"gorm.io/gorm"
+)
+
+type AddExperimentToAIUsageTable struct {
+	ExperimentID  uint64 `gorm:"type:bigint unsigned;index:idx_experiment_id;not null;default:0"`
+	ExperimentArm string `gorm:"type:varchar(32);not null;default:''"`
+}
+
+func (AddExperimentToAIUsageTable) TableName() string {
+	return "ai_usage"
+}
+
+func (AddExperimentToAIUsageTable) Up(migrator gorm.Migrator) {
+	_ = migrator.AutoMigrate(&AddExperimentToAIUsageTable{})
+}
+
+func (AddExperimentToAIUsageTable) Down(migrator gorm.Migrator) {
+	_ = migrator.DropColumn(&AddExperimentToAIUsageTable{}, "experiment_id")
+	_ = migrator.DropColumn(&AddExperimentToAIUsageTable{}, "experiment_arm")
+}
+
+func init() {
+	migrate.Add("2026_10_17_100022_add_experiment_to_ai_usage_table", AddExperimentToAIUsageTable{}.Up, AddExperimentToAIUsageTable{}.Down)
+}
@@ -0,0 +1,31 @@
+// ABOUTME: Database migration adding experiment columns to ai_feedback.
+// ABOUTME: Records the A/B experiment and arm of the rated message for per-arm reporting.
+
+package migration
+
+import (
+	"github.com/bingo-project/bingoctl/pkg/migrate"
+	"gorm.io/gorm"
+)
+
+type AddExperimentToAIFeedbackTable struct {
+	ExperimentID  uint64 `gorm:"type:bigint unsigned;index:idx_experiment_id;not null;default:0"`
+	ExperimentArm string `gorm:"type:varchar(32);not null;default:''"`
+}
+
+func (AddExperimentToAIFeedbackTable) TableName() string {
+	return "ai_feedback"
+}
+
+func (AddExperimentToAIFeedbackTable) Up(migrator gorm.Migrator) {
+	_ = migrator.AutoMigrate(&AddExperimentToAIFeedbackTable{})
+}
+
+func (AddExperimentToAIFeedbackTable) Down(migrator gorm.Migrator) {
+	_ = migrator.DropColumn(&AddExperimentToAIFeedbackTable{}, "experiment_id")
+	_ = migrator.DropColumn(&AddExperimentToAIFeedbackTable{}, "experiment_arm")
+}
+
+func init() {
+	migrate.Add("2026_10_17_100023_add_experiment_to_ai_feedback_table", AddExperimentToAIFeedbackTable{}.Up, AddExperimentToAIFeedbackTable{}.Down)
+}
@@ -0,0 +1,33 @@
+// ABOUTME: Database migration adding response cache settings to ai_agent.
+// ABOUTME: Per-agent TTLs of the exact and semantic caches and the similarity threshold.
+
+package migration
+
+import (
+	"github.com/bingo-project/bingoctl/pkg/migrate"
+	"gorm.io/gorm"
+)
+
+type AddCacheToAIAgentTable struct {
+	CacheTTL               int     `gorm:"type:int;not null;default:0"`
+	SemanticCacheTTL       int     `gorm:"type:int;not null;default:0"`
+	SemanticCacheThreshold float64 `gorm:"type:decimal(4,3);not null;default:0.950"`
+}
+
+func (AddCacheToAIAgentTable) TableName() string {
+	return "ai_agent"
+}
+
+func (AddCacheToAIAgentTable) Up(migrator gorm.Migrator) {
+	_ = migrator.AutoMigrate(&AddCacheToAIAgentTable{})
+}
+
+func (AddCacheToAIAgentTable) Down(migrator gorm.Migrator) {
+	_ = migrator.DropColumn(&AddCacheToAIAgentTable{}, "cache_ttl")
+	_ = migrator.DropColumn(&AddCacheToAIAgentTable{}, "semantic_cache_ttl")
+	_ = migrator.DropColumn(&AddCacheToAIAgentTable{}, "semantic_cache_threshold")
+}
+
+func init() {
+	migrate.Add("2026_10_17_100024_add_cache_to_ai_agent_table", AddCacheToAIAgentTable{}.Up, AddCacheToAIAgentTable{}.Down)
+}
@@ -0,0 +1,30 @@
+// ABOUTME: Database migration adding guardrail rules to ai_agent.
+// ABOUTME: Per-agent blocklist, PII, prompt-injection and moderation guards.
+
+package migration
+
+import (
+	"github.com/bingo-project/bingoctl/pkg/migrate"
+	"gorm.io/datatypes"
+	"gorm.io/gorm"
+)
+
+type AddGuardrailsToAIAgentTable struct {
+	Guardrails datatypes.JSON `gorm:"type:json"`
+}
+
+func (AddGuardrailsToAIAgentTable) TableName() string {
+	return "ai_agent"
+}
+
+func (AddGuardrailsToAIAgentTable) Up(migrator gorm.Migrator) {
+	_ = migrator.AutoMigrate(&AddGuardrailsToAIAgentTable{})
+}
+
+func (AddGuardrailsToAIAgentTable) Down(migrator gorm.Migrator) {
+	_ = migrator.DropColumn(&AddGuardrailsToAIAgentTable{}, "guardrails")
+}
+
+func init() {
+	migrate.Add("2026_10_17_100025_add_guardrails_to_ai_agent_table", AddGuardrailsToAIAgentTable{}.Up, AddGuardrailsToAIAgentTable{}.Down)
+}
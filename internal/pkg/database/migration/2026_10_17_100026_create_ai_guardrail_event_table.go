@@ -0,0 +1,46 @@
+// ABOUTME: Database migration for ai_guardrail_event table.
+// ABOUTME: Creates the guardrail matches kept for admin review.
+
+package migration
+
+import (
+	"time"
+
+	"github.com/bingo-project/bingoctl/pkg/migrate"
+	"gorm.io/gorm"
+)
+
+type CreateAIGuardrailEventTable struct {
+	ID         uint64     `gorm:"primaryKey"`
+	UID        string     `gorm:"type:varchar(64);not null;default:''"`
+	SessionID  string     `gorm:"type:varchar(64);not null;default:''"`
+	AgentID    string     `gorm:"type:varchar(32);index:idx_agent_id;not null;default:''"`
+	Model      string     `gorm:"type:varchar(64);not null;default:''"`
+	Stage      string     `gorm:"type:varchar(16);not null"`
+	Guard      string     `gorm:"type:varchar(16);not null"`
+	Rule       string     `gorm:"type:varchar(255);not null;default:''"`
+	Action     string     `gorm:"type:varchar(16);not null"`
+	Excerpt    string     `gorm:"type:text"`
+	Status     string     `gorm:"type:varchar(16);index:idx_status;not null;default:'pending'"`
+	ReviewedBy string     `gorm:"type:varchar(64);not null;default:''"`
+	ReviewNote string     `gorm:"type:varchar(1000);not null;default:''"`
+	ReviewedAt *time.Time `gorm:"type:DATETIME(3)"`
+	CreatedAt  time.Time  `gorm:"type:DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3);index:idx_created_at"`
+	UpdatedAt  time.Time  `gorm:"type:DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3)"`
+}
+
+func (CreateAIGuardrailEventTable) TableName() string {
+	return "ai_guardrail_event"
+}
+
+func (CreateAIGuardrailEventTable) Up(migrator gorm.Migrator) {
+	_ = migrator.AutoMigrate(&CreateAIGuardrailEventTable{})
+}
+
+func (CreateAIGuardrailEventTable) Down(migrator gorm.Migrator) {
+	_ = migrator.DropTable(&CreateAIGuardrailEventTable{})
+}
+
+func init() {
+	migrate.Add("2026_10_17_100026_create_ai_guardrail_event_table", CreateAIGuardrailEventTable{}.Up, CreateAIGuardrailEventTable{}.Down)
+}
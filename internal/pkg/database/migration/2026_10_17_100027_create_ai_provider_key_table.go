@@ -0,0 +1,38 @@
+// ABOUTME: Database migration for ai_provider_key table.
+// ABOUTME: Creates the API keys a provider's requests are balanced across.
+
+package migration
+
+import (
+	"time"
+
+	"github.com/bingo-project/bingoctl/pkg/migrate"
+	"gorm.io/gorm"
+)
+
+type CreateAIProviderKeyTable struct {
+	ID           uint      `gorm:"primaryKey"`
+	ProviderName string    `gorm:"type:varchar(32);uniqueIndex:uk_provider_key_name;not null"`
+	Name         string    `gorm:"type:varchar(64);uniqueIndex:uk_provider_key_name;not null"`
+	APIKey       string    `gorm:"column:api_key;type:varchar(1024);not null"`
+	Weight       int       `gorm:"type:int;not null;default:1"`
+	Status       string    `gorm:"type:varchar(16);not null;default:active"`
+	CreatedAt    time.Time `gorm:"type:DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3)"`
+	UpdatedAt    time.Time `gorm:"type:DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3)"`
+}
+
+func (CreateAIProviderKeyTable) TableName() string {
+	return "ai_provider_key"
+}
+
+func (CreateAIProviderKeyTable) Up(migrator gorm.Migrator) {
+	_ = migrator.AutoMigrate(&CreateAIProviderKeyTable{})
+}
+
+func (CreateAIProviderKeyTable) Down(migrator gorm.Migrator) {
+	_ = migrator.DropTable(&CreateAIProviderKeyTable{})
+}
+
+func init() {
+	migrate.Add("2026_10_17_100027_create_ai_provider_key_table", CreateAIProviderKeyTable{}.Up, CreateAIProviderKeyTable{}.Down)
+}
@@ -0,0 +1,29 @@
+// ABOUTME: Database migration adding the key selection strategy to ai_provider.
+// ABOUTME: Chooses how requests are spread over a provider's API keys.
+
+package migration
+
+import (
+	"github.com/bingo-project/bingoctl/pkg/migrate"
+	"gorm.io/gorm"
+)
+
+type AddKeyStrategyToAIProviderTable struct {
+	KeyStrategy string `gorm:"type:varchar(16);not null;default:''"`
+}
+
+func (AddKeyStrategyToAIProviderTable) TableName() string {
+	return "ai_provider"
+}
+
+func (AddKeyStrategyToAIProviderTable) Up(migrator gorm.Migrator) {
+	_ = migrator.AutoMigrate(&AddKeyStrategyToAIProviderTable{})
+}
+
+func (AddKeyStrategyToAIProviderTable) Down(migrator gorm.Migrator) {
+	_ = migrator.DropColumn(&AddKeyStrategyToAIProviderTable{}, "key_strategy")
+}
+
+func init() {
+	migrate.Add("2026_10_17_100028_add_key_strategy_to_ai_provider_table", AddKeyStrategyToAIProviderTable{}.Up, AddKeyStrategyToAIProviderTable{}.Down)
+}
@@ -0,0 +1,31 @@
+// ABOUTME: Database migration adding the credential to ai_provider.
+// ABOUTME: Base URL and encrypted API key of providers onboarded from the admin panel.
+
+package migration
+
+import (
+	"github.com/bingo-project/bingoctl/pkg/migrate"
+	"gorm.io/gorm"
+)
+
+type AddCredentialToAIProviderTable struct {
+	BaseURL string `gorm:"column:base_url;type:varchar(255);not null;default:''"`
+	APIKey  string `gorm:"column:api_key;type:varchar(1024);not null;default:''"`
+}
+
+func (AddCredentialToAIProviderTable) TableName() string {
+	return "ai_provider"
+}
+
+func (AddCredentialToAIProviderTable) Up(migrator gorm.Migrator) {
+	_ = migrator.AutoMigrate(&AddCredentialToAIProviderTable{})
+}
+
+func (AddCredentialToAIProviderTable) Down(migrator gorm.Migrator) {
+	_ = migrator.DropColumn(&AddCredentialToAIProviderTable{}, "base_url")
+	_ = migrator.DropColumn(&AddCredentialToAIProviderTable{}, "api_key")
+}
+
+func init() {
+	migrate.Add("2026_10_17_100029_add_credential_to_ai_provider_table", AddCredentialToAIProviderTable{}.Up, AddCredentialToAIProviderTable{}.Down)
+}
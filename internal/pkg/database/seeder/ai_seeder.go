@@ -26,6 +26,9 @@ var defaultProviders = []model.AiProviderM{
 	{Name: "claude", DisplayName: "Claude", Status: model.AiProviderStatusDisabled, Sort: 5},
 	{Name: "gemini", DisplayName: "Gemini", Status: model.AiProviderStatusDisabled, Sort: 6},
 	{Name: "qwen", DisplayName: "通义千问", Status: model.AiProviderStatusDisabled, Sort: 7},
+	// Self-hosted, models are imported from the server
+	{Name: "ollama", DisplayName: "Ollama", Status: model.AiProviderStatusDisabled, Sort: 8},
+	{Name: "llamacpp", DisplayName: "llama.cpp", Status: model.AiProviderStatusDisabled, Sort: 9},
 }
 
 var defaultModels = []model.AiModelM{
@@ -100,4 +100,123 @@ var (
 		Reason:  "ServiceUnavailable.AllModelsFailed",
 		Message: "AI service is temporarily unavailable, please try again later.",
 	}
+
+	// ErrAIToolCallLimitExceeded 工具调用轮次超限
+	ErrAIToolCallLimitExceeded = &errorsx.ErrorX{
+		Code:    http.StatusUnprocessableEntity,
+		Reason:  "UnprocessableEntity.AIToolCallLimitExceeded",
+		Message: "Too many tool call iterations.",
+	}
+
+	// ErrAIInvalidContent 消息内容无效
+	ErrAIInvalidContent = &errorsx.ErrorX{
+		Code:    http.StatusBadRequest,
+		Reason:  "InvalidArgument.AIInvalidContent",
+		Message: "Message content is invalid.",
+	}
+
+	// ErrAIEmbeddingNotSupported 模型不支持向量化
+	ErrAIEmbeddingNotSupported = &errorsx.ErrorX{
+		Code:    http.StatusBadRequest,
+		Reason:  "InvalidArgument.AIEmbeddingNotSupported",
+		Message: "Model does not support embeddings.",
+	}
+
+	// ErrAIKnowledgeDocNotFound 知识库文档不存在
+	ErrAIKnowledgeDocNotFound = &errorsx.ErrorX{
+		Code:    http.StatusNotFound,
+		Reason:  "NotFound.AIKnowledgeDocNotFound",
+		Message: "Knowledge document not found.",
+	}
+
+	// ErrAIModelDiscoveryNotSupported Provider 不支持模型发现
+	ErrAIModelDiscoveryNotSupported = &errorsx.ErrorX{
+		Code:    http.StatusBadRequest,
+		Reason:  "InvalidArgument.AIModelDiscoveryNotSupported",
+		Message: "Provider does not support model discovery.",
+	}
+
+	// ErrAIGenerationNotFound 生成任务不存在或已结束
+	ErrAIGenerationNotFound = &errorsx.ErrorX{
+		Code:    http.StatusNotFound,
+		Reason:  "NotFound.AIGenerationNotFound",
+		Message: "AI generation not found or already finished.",
+	}
+
+	// ErrAIMessageNotFound 会话消息不存在
+	ErrAIMessageNotFound = &errorsx.ErrorX{
+		Code:    http.StatusNotFound,
+		Reason:  "NotFound.AIMessageNotFound",
+		Message: "AI message not found.",
+	}
+
+	// ErrAIShareNotFound 分享链接不存在、已撤销或已过期
+	ErrAIShareNotFound = &errorsx.ErrorX{
+		Code:    http.StatusNotFound,
+		Reason:  "NotFound.AIShareNotFound",
+		Message: "Share link not found or expired.",
+	}
+
+	// ErrAIFeedbackNotFound 反馈不存在
+	ErrAIFeedbackNotFound = &errorsx.ErrorX{
+		Code:    http.StatusNotFound,
+		Reason:  "NotFound.AIFeedbackNotFound",
+		Message: "AI feedback not found.",
+	}
+
+	// ErrAIInvalidStructuredOutput 结构化输出多次修复后仍不符合格式
+	ErrAIInvalidStructuredOutput = &errorsx.ErrorX{
+		Code:    http.StatusUnprocessableEntity,
+		Reason:  "UnprocessableEntity.AIInvalidStructuredOutput",
+		Message: "AI reply does not match the requested response format.",
+	}
+
+	// ErrAIAgentVersionNotFound 智能体版本不存在
+	ErrAIAgentVersionNotFound = &errorsx.ErrorX{
+		Code:    http.StatusNotFound,
+		Reason:  "NotFound.AIAgentVersionNotFound",
+		Message: "AI agent version not found.",
+	}
+
+	// ErrAIExperimentNotFound 实验不存在
+	ErrAIExperimentNotFound = &errorsx.ErrorX{
+		Code:    http.StatusNotFound,
+		Reason:  "NotFound.AIExperimentNotFound",
+		Message: "AI experiment not found.",
+	}
+
+	// ErrAIExperimentState 实验当前状态不允许该操作
+	ErrAIExperimentState = &errorsx.ErrorX{
+		Code:    http.StatusBadRequest,
+		Reason:  "InvalidArgument.AIExperimentState",
+		Message: "The operation is not allowed in the current experiment status.",
+	}
+
+	// ErrAIGuardrailBlocked 消息被内容安全规则拦截
+	ErrAIGuardrailBlocked = &errorsx.ErrorX{
+		Code:    http.StatusBadRequest,
+		Reason:  "InvalidArgument.AIGuardrailBlocked",
+		Message: "The message was blocked by content policy.",
+	}
+
+	// ErrAIGuardrailEventNotFound 内容安全事件不存在
+	ErrAIGuardrailEventNotFound = &errorsx.ErrorX{
+		Code:    http.StatusNotFound,
+		Reason:  "NotFound.AIGuardrailEventNotFound",
+		Message: "AI guardrail event not found.",
+	}
+
+	// ErrAIProviderKeyNotFound Provider API Key 不存在
+	ErrAIProviderKeyNotFound = &errorsx.ErrorX{
+		Code:    http.StatusNotFound,
+		Reason:  "NotFound.AIProviderKeyNotFound",
+		Message: "AI provider key not found.",
+	}
+
+	// ErrAIProviderInUse Provider 仍有模型，不能删除
+	ErrAIProviderInUse = &errorsx.ErrorX{
+		Code:    http.StatusBadRequest,
+		Reason:  "InvalidArgument.AIProviderInUse",
+		Message: "AI provider still has models.",
+	}
 )
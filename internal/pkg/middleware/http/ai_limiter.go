@@ -8,14 +8,15 @@ import (
 
 	"github.com/gin-gonic/gin"
 
+	"github.com/bingo-project/bingo/internal/pkg/ai"
 	"github.com/bingo-project/bingo/internal/pkg/core"
 	"github.com/bingo-project/bingo/internal/pkg/errno"
 	"github.com/bingo-project/bingo/pkg/contextx"
 )
 
 // AILimiter creates a rate limiter middleware for AI endpoints.
-// Uses Redis for distributed rate limiting.
-func AILimiter(defaultRPM int) gin.HandlerFunc {
+// Uses Redis for distributed rate limiting with each user's effective RPM.
+func AILimiter(resolver *ai.RPMResolver) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		uid := contextx.UserID(c)
 		if uid == "" {
@@ -24,10 +25,18 @@ func AILimiter(defaultRPM int) gin.HandlerFunc {
 			return
 		}
 
-		// Get user's RPM limit (default for now, can be from DB)
-		rpm := defaultRPM
+		// Get user's RPM limit from override or tier
+		rpm, err := resolver.RPM(c, uid)
+		if err != nil {
+			core.Response(c, nil, errno.ErrOperationFailed.WithMessage("rate limiter error: %v", err))
+			c.Abort()
+
+			return
+		}
 		if rpm <= 0 {
-			rpm = 10 // fallback default
+			c.Next() // RPM limit disabled
+
+			return
 		}
 
 		// Use Redis-based limiter via shared GetLimiterContext
@@ -74,3 +74,16 @@ func ForceUserAgent(c *gin.Context) {
 
 	_ = c.AbortWithError(http.StatusBadRequest, errors.New("User-Agent not found"))
 }
+
+// APIKeyAuthorization maps the x-api-key header sent by Anthropic SDKs to a bearer
+// Authorization header, so the shared authentication accepts it. It only applies
+// to routes registered with it and must run before authentication.
+func APIKeyAuthorization(c *gin.Context) {
+	if c.GetHeader("Authorization") == "" {
+		if key := c.GetHeader("X-Api-Key"); key != "" {
+			c.Request.Header.Set("Authorization", "Bearer "+key)
+		}
+	}
+
+	c.Next()
+}
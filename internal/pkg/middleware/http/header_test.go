@@ -0,0 +1,43 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestAPIKeyAuthorization(t *testing.T) {
+	Convey("TestAPIKeyAuthorization", t, func() {
+		authorization := func(headers map[string]string) string {
+			var got string
+			r := gin.New()
+			r.POST("/v1/messages", APIKeyAuthorization, func(c *gin.Context) {
+				got = c.GetHeader("Authorization")
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/v1/messages", nil)
+			for k, v := range headers {
+				req.Header.Set(k, v)
+			}
+			r.ServeHTTP(httptest.NewRecorder(), req)
+
+			return got
+		}
+
+		Convey("maps x-api-key to a bearer token", func() {
+			So(authorization(map[string]string{"X-Api-Key": "k1"}), ShouldEqual, "Bearer k1")
+		})
+
+		Convey("keeps an existing Authorization header", func() {
+			So(authorization(map[string]string{"Authorization": "Bearer t1", "X-Api-Key": "k1"}), ShouldEqual, "Bearer t1")
+		})
+
+		Convey("leaves requests without a key alone", func() {
+			So(authorization(nil), ShouldBeEmpty)
+		})
+	})
+}
@@ -3,7 +3,11 @@
 
 package model
 
-import "time"
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
 
 // AiAgentStatus represents the status of an AI agent.
 type AiAgentStatus string
@@ -13,6 +17,9 @@ const (
 	AiAgentStatusDisabled AiAgentStatus = "disabled"
 )
 
+// DefaultSemanticCacheThreshold is the default minimum similarity to reuse a cached answer.
+const DefaultSemanticCacheThreshold = 0.95
+
 // AiAgentCategory represents the category of an AI agent.
 type AiAgentCategory string
 
@@ -26,18 +33,25 @@ const (
 
 // AiAgentM represents an AI agent preset.
 type AiAgentM struct {
-	ID           uint            `gorm:"primaryKey" json:"id"`
-	AgentID      string          `gorm:"column:agent_id;type:varchar(32);uniqueIndex:uk_agent_id;not null" json:"agentId"`
-	Name         string          `gorm:"column:name;type:varchar(64);not null" json:"name"`
-	Description  string          `gorm:"column:description;type:varchar(255)" json:"description"`
-	Icon         string          `gorm:"column:icon;type:varchar(255)" json:"icon"`
-	Category     AiAgentCategory `gorm:"column:category;type:varchar(32);not null;default:'general'" json:"category"`
-	SystemPrompt string          `gorm:"column:system_prompt;type:text;not null" json:"systemPrompt"`
-	Model        string          `gorm:"column:model;type:varchar(64)" json:"model"`
-	Temperature  float64         `gorm:"column:temperature;type:decimal(3,2);not null;default:0.70" json:"temperature"`
-	MaxTokens    int             `gorm:"column:max_tokens;type:int;not null;default:2000" json:"maxTokens"`
-	Sort         int             `gorm:"column:sort;type:int;not null;default:0" json:"sort"`
-	Status       AiAgentStatus   `gorm:"column:status;type:varchar(16);not null;default:'active'" json:"status"`
+	ID                     uint                                 `gorm:"primaryKey" json:"id"`
+	AgentID                string                               `gorm:"column:agent_id;type:varchar(32);uniqueIndex:uk_agent_id;not null" json:"agentId"`
+	Name                   string                               `gorm:"column:name;type:varchar(64);not null" json:"name"`
+	Description            string                               `gorm:"column:description;type:varchar(255)" json:"description"`
+	Icon                   string                               `gorm:"column:icon;type:varchar(255)" json:"icon"`
+	Category               AiAgentCategory                      `gorm:"column:category;type:varchar(32);not null;default:'general'" json:"category"`
+	SystemPrompt           string                               `gorm:"column:system_prompt;type:text;not null" json:"systemPrompt"`
+	Model                  string                               `gorm:"column:model;type:varchar(64)" json:"model"`
+	Temperature            float64                              `gorm:"column:temperature;type:decimal(3,2);not null;default:0.70" json:"temperature"`
+	MaxTokens              int                                  `gorm:"column:max_tokens;type:int;not null;default:2000" json:"maxTokens"`
+	Tools                  datatypes.JSONSlice[string]          `gorm:"column:tools;type:json" json:"tools"`                                               // Server-side tool names
+	EmbeddingModel         string                               `gorm:"column:embedding_model;type:varchar(64);not null;default:''" json:"embeddingModel"` // Knowledge base embedding model
+	Sort                   int                                  `gorm:"column:sort;type:int;not null;default:0" json:"sort"`
+	Status                 AiAgentStatus                        `gorm:"column:status;type:varchar(16);not null;default:'active'" json:"status"`
+	PublishedVersion       int                                  `gorm:"column:published_version;type:int;not null;default:0" json:"publishedVersion"`                           // Version whose settings the agent serves, 0 until first versioned
+	CacheTTL               int                                  `gorm:"column:cache_ttl;type:int;not null;default:0" json:"cacheTtl"`                                           // Exact response cache TTL in seconds, 0 disables
+	SemanticCacheTTL       int                                  `gorm:"column:semantic_cache_ttl;type:int;not null;default:0" json:"semanticCacheTtl"`                          // Semantic response cache TTL in seconds, 0 disables, needs EmbeddingModel
+	SemanticCacheThreshold float64                              `gorm:"column:semantic_cache_threshold;type:decimal(4,3);not null;default:0.950" json:"semanticCacheThreshold"` // Minimum similarity to reuse a cached answer
+	Guardrails             datatypes.JSONSlice[AiGuardrailRule] `gorm:"column:guardrails;type:json" json:"guardrails"`                                                          // Input and output guards, not versioned
 
 	CreatedAt time.Time `gorm:"type:DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3)" json:"createdAt"`
 	UpdatedAt time.Time `gorm:"type:DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3)" json:"updatedAt"`
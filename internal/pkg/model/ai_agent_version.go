@@ -0,0 +1,63 @@
+// ABOUTME: AI agent version model definition.
+// ABOUTME: Immutable snapshots of an agent's prompt and generation settings with author and change note.
+
+package model
+
+import (
+	"slices"
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+// AiAgentVersionM is an immutable snapshot of the versioned settings of an agent.
+// The agent row holds a copy of its published version.
+type AiAgentVersionM struct {
+	ID           uint64                      `gorm:"primaryKey" json:"id"`
+	AgentID      string                      `gorm:"column:agent_id;type:varchar(32);uniqueIndex:uk_agent_version;not null" json:"agentId"`
+	Version      int                         `gorm:"column:version;type:int;uniqueIndex:uk_agent_version;not null" json:"version"`
+	SystemPrompt string                      `gorm:"column:system_prompt;type:text;not null" json:"systemPrompt"` // May contain {{variable}} placeholders
+	Model        string                      `gorm:"column:model;type:varchar(64);not null;default:''" json:"model"`
+	Temperature  float64                     `gorm:"column:temperature;type:decimal(3,2);not null;default:0.70" json:"temperature"`
+	MaxTokens    int                         `gorm:"column:max_tokens;type:int;not null;default:2000" json:"maxTokens"`
+	Tools        datatypes.JSONSlice[string] `gorm:"column:tools;type:json" json:"tools"`
+	Author       string                      `gorm:"column:author;type:varchar(64);not null;default:''" json:"author"` // Admin who created the version
+	Note         string                      `gorm:"column:note;type:varchar(255);not null;default:''" json:"note"`    // Change note
+	CreatedAt    time.Time                   `gorm:"type:DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3)" json:"createdAt"`
+}
+
+func (AiAgentVersionM) TableName() string {
+	return "ai_agent_version"
+}
+
+// NewAiAgentVersion snapshots the versioned settings of an agent.
+func NewAiAgentVersion(agent *AiAgentM, version int) *AiAgentVersionM {
+	return &AiAgentVersionM{
+		AgentID:      agent.AgentID,
+		Version:      version,
+		SystemPrompt: agent.SystemPrompt,
+		Model:        agent.Model,
+		Temperature:  agent.Temperature,
+		MaxTokens:    agent.MaxTokens,
+		Tools:        agent.Tools,
+	}
+}
+
+// ApplyTo copies the versioned settings onto an agent and marks the version published.
+func (v *AiAgentVersionM) ApplyTo(agent *AiAgentM) {
+	agent.SystemPrompt = v.SystemPrompt
+	agent.Model = v.Model
+	agent.Temperature = v.Temperature
+	agent.MaxTokens = v.MaxTokens
+	agent.Tools = v.Tools
+	agent.PublishedVersion = v.Version
+}
+
+// SameSettings reports whether two versions have the same settings.
+func (v *AiAgentVersionM) SameSettings(o *AiAgentVersionM) bool {
+	return v.SystemPrompt == o.SystemPrompt &&
+		v.Model == o.Model &&
+		v.Temperature == o.Temperature &&
+		v.MaxTokens == o.MaxTokens &&
+		slices.Equal(v.Tools, o.Tools)
+}
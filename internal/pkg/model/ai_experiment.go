@@ -0,0 +1,46 @@
+// ABOUTME: AI experiment model definition.
+// ABOUTME: A/B experiments splitting an agent's users across weighted arms of model, temperature and prompt.
+
+package model
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+// AiExperimentStatus represents the lifecycle of an experiment.
+type AiExperimentStatus string
+
+const (
+	AiExperimentStatusDraft   AiExperimentStatus = "draft"
+	AiExperimentStatusRunning AiExperimentStatus = "running"
+	AiExperimentStatusStopped AiExperimentStatus = "stopped"
+)
+
+// AiExperimentArm is a variant of an agent users can be assigned to.
+type AiExperimentArm struct {
+	Key          string  `json:"key"`                    // Unique within the experiment, recorded on messages
+	Weight       int     `json:"weight"`                 // Relative share of users
+	Model        string  `json:"model,omitempty"`        // Empty keeps the agent model
+	Temperature  float64 `json:"temperature,omitempty"`  // 0 keeps the agent temperature
+	AgentVersion int     `json:"agentVersion,omitempty"` // Agent version whose prompt and settings are used, 0 for the published version
+}
+
+// AiExperimentM is an A/B experiment on an agent. At most one experiment of an agent runs at a time.
+type AiExperimentM struct {
+	ID        uint64                               `gorm:"primaryKey" json:"id"`
+	Name      string                               `gorm:"column:name;type:varchar(64);not null" json:"name"`
+	AgentID   string                               `gorm:"column:agent_id;type:varchar(32);index:idx_agent_id;not null" json:"agentId"`
+	Status    AiExperimentStatus                   `gorm:"column:status;type:varchar(16);not null;default:'draft'" json:"status"`
+	Arms      datatypes.JSONSlice[AiExperimentArm] `gorm:"column:arms;type:json" json:"arms"`
+	StartedAt *time.Time                           `gorm:"column:started_at;type:DATETIME(3)" json:"startedAt"`
+	StoppedAt *time.Time                           `gorm:"column:stopped_at;type:DATETIME(3)" json:"stoppedAt"`
+
+	CreatedAt time.Time `gorm:"type:DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3)" json:"createdAt"`
+	UpdatedAt time.Time `gorm:"type:DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3)" json:"updatedAt"`
+}
+
+func (*AiExperimentM) TableName() string {
+	return "ai_experiment"
+}
@@ -0,0 +1,47 @@
+// ABOUTME: AI reply feedback model definition.
+// ABOUTME: A user's thumbs up or down with optional reasons on an assistant message.
+
+package model
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+// AiFeedbackM is a user's rating of an assistant message. Model, AgentID and the experiment
+// arm are copied from the message and its session when rated, so aggregates need no joins.
+type AiFeedbackM struct {
+	ID            uint64                      `gorm:"primaryKey" json:"id"`
+	MessageID     uint64                      `gorm:"column:message_id;type:bigint unsigned;uniqueIndex:uk_message_uid;not null" json:"messageId"`
+	UID           string                      `gorm:"column:uid;type:varchar(64);uniqueIndex:uk_message_uid;not null" json:"uid"`
+	SessionID     string                      `gorm:"column:session_id;type:varchar(64);index:idx_session_id;not null" json:"sessionId"`
+	Rating        int                         `gorm:"column:rating;type:tinyint;not null" json:"rating"` // 1 for thumbs up, -1 for thumbs down
+	Reasons       datatypes.JSONSlice[string] `gorm:"column:reasons;type:json" json:"reasons"`
+	Comment       string                      `gorm:"column:comment;type:varchar(1000);not null;default:''" json:"comment"`
+	Model         string                      `gorm:"column:model;type:varchar(64);not null;default:''" json:"model"`
+	AgentID       string                      `gorm:"column:agent_id;type:varchar(64);not null;default:''" json:"agentId"`
+	ExperimentID  uint64                      `gorm:"column:experiment_id;type:bigint unsigned;index:idx_experiment_id;not null;default:0" json:"experimentId"`
+	ExperimentArm string                      `gorm:"column:experiment_arm;type:varchar(32);not null;default:''" json:"experimentArm"`
+
+	CreatedAt time.Time `gorm:"type:DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3);index:idx_created_at" json:"createdAt"`
+	UpdatedAt time.Time `gorm:"type:DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3)" json:"updatedAt"`
+}
+
+func (*AiFeedbackM) TableName() string {
+	return "ai_feedback"
+}
+
+// Feedback rating constants.
+const (
+	AiFeedbackRatingUp   = 1
+	AiFeedbackRatingDown = -1
+)
+
+// AiFeedbackSummary is an aggregate of feedback rows sharing a group key.
+type AiFeedbackSummary struct {
+	Key     string `gorm:"column:group_key" json:"key"`
+	Ratings int64  `gorm:"column:ratings" json:"ratings"`
+	Up      int64  `gorm:"column:up" json:"up"`
+	Down    int64  `gorm:"column:down" json:"down"`
+}
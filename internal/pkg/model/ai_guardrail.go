@@ -0,0 +1,74 @@
+// ABOUTME: AI guardrail model definitions.
+// ABOUTME: Per-agent guardrail rules and the flagged events kept for admin review.
+
+package model
+
+import (
+	"time"
+)
+
+// Guardrail stages
+const (
+	AiGuardrailStageInput  = "input"
+	AiGuardrailStageOutput = "output"
+)
+
+// AiGuardrailRule is a guard an agent applies to user input and model output.
+type AiGuardrailRule struct {
+	Type     string   `json:"type"`               // blocklist, pii, injection or moderation
+	Action   string   `json:"action"`             // block, redact or flag; redact applies to blocklist and pii only
+	Stages   []string `json:"stages,omitempty"`   // input, output; empty checks both
+	Keywords []string `json:"keywords,omitempty"` // Blocklist keywords, case-insensitive
+	Patterns []string `json:"patterns,omitempty"` // Blocklist regular expressions
+	PIITypes []string `json:"piiTypes,omitempty"` // PII types to detect, empty detects all
+	Model    string   `json:"model,omitempty"`    // Moderation model
+}
+
+// AppliesTo reports whether the rule checks the given stage.
+func (r AiGuardrailRule) AppliesTo(stage string) bool {
+	if len(r.Stages) == 0 {
+		return true
+	}
+	for _, s := range r.Stages {
+		if s == stage {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AiGuardrailEventStatus represents the review status of a guardrail event.
+type AiGuardrailEventStatus string
+
+const (
+	AiGuardrailEventStatusPending   AiGuardrailEventStatus = "pending"
+	AiGuardrailEventStatusConfirmed AiGuardrailEventStatus = "confirmed"
+	AiGuardrailEventStatusDismissed AiGuardrailEventStatus = "dismissed"
+)
+
+// AiGuardrailEventM records a guardrail match. Excerpt is the text around the match with
+// personal data masked, so reviewing events never exposes it.
+type AiGuardrailEventM struct {
+	ID         uint64                 `gorm:"primaryKey" json:"id"`
+	UID        string                 `gorm:"column:uid;type:varchar(64);not null;default:''" json:"uid"`
+	SessionID  string                 `gorm:"column:session_id;type:varchar(64);not null;default:''" json:"sessionId"`
+	AgentID    string                 `gorm:"column:agent_id;type:varchar(32);index:idx_agent_id;not null;default:''" json:"agentId"`
+	Model      string                 `gorm:"column:model;type:varchar(64);not null;default:''" json:"model"`
+	Stage      string                 `gorm:"column:stage;type:varchar(16);not null" json:"stage"`
+	Guard      string                 `gorm:"column:guard;type:varchar(16);not null" json:"guard"`
+	Rule       string                 `gorm:"column:rule;type:varchar(255);not null;default:''" json:"rule"`
+	Action     string                 `gorm:"column:action;type:varchar(16);not null" json:"action"`
+	Excerpt    string                 `gorm:"column:excerpt;type:text" json:"excerpt"`
+	Status     AiGuardrailEventStatus `gorm:"column:status;type:varchar(16);index:idx_status;not null;default:'pending'" json:"status"`
+	ReviewedBy string                 `gorm:"column:reviewed_by;type:varchar(64);not null;default:''" json:"reviewedBy"`
+	ReviewNote string                 `gorm:"column:review_note;type:varchar(1000);not null;default:''" json:"reviewNote"`
+	ReviewedAt *time.Time             `gorm:"column:reviewed_at;type:DATETIME(3)" json:"reviewedAt"`
+
+	CreatedAt time.Time `gorm:"type:DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3);index:idx_created_at" json:"createdAt"`
+	UpdatedAt time.Time `gorm:"type:DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3)" json:"updatedAt"`
+}
+
+func (*AiGuardrailEventM) TableName() string {
+	return "ai_guardrail_event"
+}
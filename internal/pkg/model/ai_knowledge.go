@@ -0,0 +1,54 @@
+// ABOUTME: AI knowledge base model definitions.
+// ABOUTME: Represents documents attached to agents and their embedded chunks.
+
+package model
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+)
+
+// AiKnowledgeDocStatus represents the indexing status of a knowledge document.
+type AiKnowledgeDocStatus string
+
+const (
+	AiKnowledgeDocStatusIndexing AiKnowledgeDocStatus = "indexing"
+	AiKnowledgeDocStatusReady    AiKnowledgeDocStatus = "ready"
+	AiKnowledgeDocStatusFailed   AiKnowledgeDocStatus = "failed"
+)
+
+// AiKnowledgeDocM represents a document in an agent's knowledge base.
+type AiKnowledgeDocM struct {
+	ID             uint64               `gorm:"primaryKey" json:"id"`
+	AgentID        string               `gorm:"column:agent_id;type:varchar(32);index:idx_agent_id;not null" json:"agentId"`
+	Title          string               `gorm:"column:title;type:varchar(255);not null" json:"title"`
+	EmbeddingModel string               `gorm:"column:embedding_model;type:varchar(64);not null;default:''" json:"embeddingModel"`
+	ChunkCount     int                  `gorm:"column:chunk_count;type:int;not null;default:0" json:"chunkCount"`
+	Chars          int                  `gorm:"column:chars;type:int;not null;default:0" json:"chars"`
+	Status         AiKnowledgeDocStatus `gorm:"column:status;type:varchar(16);not null;default:'indexing'" json:"status"`
+	Error          string               `gorm:"column:error;type:varchar(255);not null;default:''" json:"error"`
+
+	CreatedAt time.Time `gorm:"type:DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3)" json:"createdAt"`
+	UpdatedAt time.Time `gorm:"type:DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3)" json:"updatedAt"`
+}
+
+func (AiKnowledgeDocM) TableName() string {
+	return "ai_knowledge_doc"
+}
+
+// AiKnowledgeChunkM represents an embedded chunk of a knowledge document.
+type AiKnowledgeChunkM struct {
+	ID        uint64                       `gorm:"primaryKey" json:"id"`
+	DocID     uint64                       `gorm:"column:doc_id;type:bigint unsigned;index:idx_doc_id;not null" json:"docId"`
+	AgentID   string                       `gorm:"column:agent_id;type:varchar(32);index:idx_agent_id;not null" json:"agentId"`
+	Seq       int                          `gorm:"column:seq;type:int;not null;default:0" json:"seq"`
+	Content   string                       `gorm:"column:content;type:text;not null" json:"content"`
+	Embedding datatypes.JSONSlice[float32] `gorm:"column:embedding;type:json" json:"-"`
+
+	CreatedAt time.Time `gorm:"type:DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3)" json:"createdAt"`
+}
+
+func (AiKnowledgeChunkM) TableName() string {
+	return "ai_knowledge_chunk"
+}
@@ -1,18 +1,34 @@
 // ABOUTME: AI message model definition.
-// ABOUTME: Represents a single message in a chat session.
+// ABOUTME: Represents a single message in a chat session, linked to its parent as a tree of branches.
 
 package model
 
-import "time"
+import (
+	"time"
+
+	"gorm.io/datatypes"
+
+	"github.com/bingo-project/bingo/pkg/ai"
+)
 
 type AiMessageM struct {
-	ID        uint64    `gorm:"primaryKey" json:"id"`
-	SessionID string    `gorm:"column:session_id;type:varchar(64);index:idx_session_id;not null" json:"sessionId"`
-	Role      string    `gorm:"column:role;type:varchar(16);not null" json:"role"`
-	Content   string    `gorm:"column:content;type:text;not null" json:"content"`
-	Tokens    int       `gorm:"column:tokens;type:int;not null;default:0" json:"tokens"`
-	Model     string    `gorm:"column:model;type:varchar(64);not null;default:''" json:"model"`
-	CreatedAt time.Time `gorm:"type:DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3);index:idx_created_at" json:"createdAt"`
+	ID            uint64                              `gorm:"primaryKey" json:"id"`
+	SessionID     string                              `gorm:"column:session_id;type:varchar(64);index:idx_session_id;not null" json:"sessionId"`
+	ParentID      uint64                              `gorm:"column:parent_id;type:bigint unsigned;index:idx_parent_id;not null;default:0" json:"parentId"` // Previous message on the branch, 0 for a root
+	Role          string                              `gorm:"column:role;type:varchar(16);not null" json:"role"`
+	Content       string                              `gorm:"column:content;type:text;not null;index:idx_content,class:FULLTEXT,option:WITH PARSER ngram" json:"content"`
+	ContentParts  datatypes.JSONSlice[ai.ContentPart] `gorm:"column:content_parts;type:json" json:"contentParts"`
+	Name          string                              `gorm:"column:name;type:varchar(64);not null;default:''" json:"name"`
+	ToolCalls     datatypes.JSONSlice[ai.ToolCall]    `gorm:"column:tool_calls;type:json" json:"toolCalls"`
+	ToolCallID    string                              `gorm:"column:tool_call_id;type:varchar(64);not null;default:''" json:"toolCallId"`
+	Tokens        int                                 `gorm:"column:tokens;type:int;not null;default:0" json:"tokens"`
+	Model         string                              `gorm:"column:model;type:varchar(64);not null;default:''" json:"model"`
+	Kind          string                              `gorm:"column:kind;type:varchar(16);not null;default:'message'" json:"kind"`
+	FinishReason  string                              `gorm:"column:finish_reason;type:varchar(32);not null;default:''" json:"finishReason"`
+	AgentVersion  int                                 `gorm:"column:agent_version;type:int;not null;default:0" json:"agentVersion"`             // Agent version that produced an assistant message, 0 without an agent
+	ExperimentID  uint64                              `gorm:"column:experiment_id;type:bigint unsigned;not null;default:0" json:"experimentId"` // Experiment the turn ran in, 0 for none
+	ExperimentArm string                              `gorm:"column:experiment_arm;type:varchar(32);not null;default:''" json:"experimentArm"`  // Assigned arm of the experiment
+	CreatedAt     time.Time                           `gorm:"type:DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3);index:idx_created_at" json:"createdAt"`
 }
 
 func (*AiMessageM) TableName() string {
@@ -24,4 +40,11 @@ const (
 	AiMessageRoleSystem    = "system"
 	AiMessageRoleUser      = "user"
 	AiMessageRoleAssistant = "assistant"
+	AiMessageRoleTool      = "tool"
+)
+
+// Message kind constants.
+const (
+	AiMessageKindMessage = "message" // Conversation message
+	AiMessageKindSummary = "summary" // Rolling summary of messages up to the session summary cursor
 )
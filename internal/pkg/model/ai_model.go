@@ -10,6 +10,7 @@ type AiModelM struct {
 	ProviderName  string        `gorm:"column:provider_name;type:varchar(32);uniqueIndex:uk_provider_model;not null" json:"providerName"`
 	Model         string        `gorm:"column:model;type:varchar(64);uniqueIndex:uk_provider_model;not null" json:"model"`
 	DisplayName   string        `gorm:"column:display_name;type:varchar(64)" json:"displayName"`
+	Type          AiModelType   `gorm:"column:type;type:varchar(16);not null;default:chat" json:"type"`
 	MaxTokens     int           `gorm:"column:max_tokens;type:int;not null;default:4096" json:"maxTokens"`
 	InputPrice    float64       `gorm:"column:input_price;type:decimal(10,6);not null;default:0" json:"inputPrice"`
 	OutputPrice   float64       `gorm:"column:output_price;type:decimal(10,6);not null;default:0" json:"outputPrice"`
@@ -26,6 +27,13 @@ func (*AiModelM) TableName() string {
 	return "ai_model"
 }
 
+type AiModelType string
+
+const (
+	AiModelTypeChat      AiModelType = "chat"
+	AiModelTypeEmbedding AiModelType = "embedding"
+)
+
 type AiModelStatus string
 
 const (
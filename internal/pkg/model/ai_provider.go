@@ -17,10 +17,13 @@ type AiProviderM struct {
 	ID          uint             `gorm:"primaryKey" json:"id"`
 	Name        string           `gorm:"column:name;type:varchar(32);uniqueIndex:uk_name;not null" json:"name"`
 	DisplayName string           `gorm:"column:display_name;type:varchar(64)" json:"displayName"`
+	BaseURL     string           `gorm:"column:base_url;type:varchar(255);not null;default:''" json:"baseURL"`
+	APIKey      string           `gorm:"column:api_key;type:varchar(1024);not null;default:''" json:"-"` // Encrypted with the app key
 	Status      AiProviderStatus `gorm:"column:status;type:varchar(16);not null;default:active" json:"status"`
 	// Models field removed - models are now stored in ai_model table
-	IsDefault bool `gorm:"column:is_default;type:tinyint(1);not null;default:0" json:"isDefault"`
-	Sort      int  `gorm:"column:sort;type:int;not null;default:0" json:"sort"`
+	IsDefault   bool   `gorm:"column:is_default;type:tinyint(1);not null;default:0" json:"isDefault"`
+	Sort        int    `gorm:"column:sort;type:int;not null;default:0" json:"sort"`
+	KeyStrategy string `gorm:"column:key_strategy;type:varchar(16);not null;default:''" json:"keyStrategy"` // round_robin (default) or least_used
 
 	CreatedAt time.Time `gorm:"type:DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3)" json:"createdAt"`
 	UpdatedAt time.Time `gorm:"type:DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3)" json:"updatedAt"`
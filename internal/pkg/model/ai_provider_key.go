@@ -0,0 +1,31 @@
+// ABOUTME: AI provider API key model definition.
+// ABOUTME: Extra API keys a provider's requests are balanced across.
+
+package model
+
+import "time"
+
+// AiProviderKeyStatus represents the status of a provider API key.
+type AiProviderKeyStatus string
+
+const (
+	AiProviderKeyStatusActive   AiProviderKeyStatus = "active"
+	AiProviderKeyStatusDisabled AiProviderKeyStatus = "disabled"
+)
+
+// AiProviderKeyM is an API key of a provider. The key is stored encrypted with the app key.
+type AiProviderKeyM struct {
+	ID           uint                `gorm:"primaryKey" json:"id"`
+	ProviderName string              `gorm:"column:provider_name;type:varchar(32);uniqueIndex:uk_provider_key_name;not null" json:"providerName"`
+	Name         string              `gorm:"column:name;type:varchar(64);uniqueIndex:uk_provider_key_name;not null" json:"name"`
+	APIKey       string              `gorm:"column:api_key;type:varchar(1024);not null" json:"-"`
+	Weight       int                 `gorm:"column:weight;type:int;not null;default:1" json:"weight"`
+	Status       AiProviderKeyStatus `gorm:"column:status;type:varchar(16);not null;default:active" json:"status"`
+
+	CreatedAt time.Time `gorm:"type:DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3)" json:"createdAt"`
+	UpdatedAt time.Time `gorm:"type:DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3)" json:"updatedAt"`
+}
+
+func (*AiProviderKeyM) TableName() string {
+	return "ai_provider_key"
+}
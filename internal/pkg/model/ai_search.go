@@ -0,0 +1,18 @@
+// ABOUTME: AI conversation search result row.
+// ABOUTME: A session whose title matches a search, or a message whose content does.
+
+package model
+
+import "time"
+
+// AiSearchHit is a row of a conversation search. MessageID is 0 when the session title matched,
+// CreatedAt is then the time the session was last updated.
+type AiSearchHit struct {
+	SessionID string    `gorm:"column:session_id"`
+	UID       string    `gorm:"column:uid"`
+	Title     string    `gorm:"column:title"`
+	MessageID uint64    `gorm:"column:message_id"`
+	Role      string    `gorm:"column:role"`
+	Content   string    `gorm:"column:content"`
+	CreatedAt time.Time `gorm:"column:created_at"`
+}
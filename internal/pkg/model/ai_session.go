@@ -15,15 +15,18 @@ const (
 )
 
 type AiSessionM struct {
-	ID           uint            `gorm:"primaryKey" json:"id"`
-	SessionID    string          `gorm:"column:session_id;type:varchar(64);uniqueIndex:uk_session_id;not null" json:"sessionId"`
-	UID          string          `gorm:"column:uid;type:varchar(64);index:idx_uid;not null" json:"uid"`
-	AgentID      string          `gorm:"column:agent_id;type:varchar(64);index:idx_agent_id" json:"agentId"`
-	Title        string          `gorm:"column:title;type:varchar(255);not null;default:''" json:"title"`
-	Model        string          `gorm:"column:model;type:varchar(64);not null;default:''" json:"model"`
-	MessageCount int             `gorm:"column:message_count;type:int;not null;default:0" json:"messageCount"`
-	TotalTokens  int             `gorm:"column:total_tokens;type:int;not null;default:0" json:"totalTokens"`
-	Status       AiSessionStatus `gorm:"column:status;type:varchar(16);not null;default:'active'" json:"status"`
+	ID            uint            `gorm:"primaryKey" json:"id"`
+	SessionID     string          `gorm:"column:session_id;type:varchar(64);uniqueIndex:uk_session_id;not null" json:"sessionId"`
+	UID           string          `gorm:"column:uid;type:varchar(64);index:idx_uid;not null" json:"uid"`
+	AgentID       string          `gorm:"column:agent_id;type:varchar(64);index:idx_agent_id" json:"agentId"`
+	Title         string          `gorm:"column:title;type:varchar(255);not null;default:''" json:"title"`
+	Model         string          `gorm:"column:model;type:varchar(64);not null;default:''" json:"model"`
+	MessageCount  int             `gorm:"column:message_count;type:int;not null;default:0" json:"messageCount"`
+	TotalTokens   int             `gorm:"column:total_tokens;type:int;not null;default:0" json:"totalTokens"`
+	Status        AiSessionStatus `gorm:"column:status;type:varchar(16);not null;default:'active'" json:"status"`
+	SummaryCursor uint64          `gorm:"column:summary_cursor;type:bigint unsigned;not null;default:0" json:"-"`            // Last message ID covered by the summary
+	ActiveLeafID  uint64          `gorm:"column:active_leaf_id;type:bigint unsigned;not null;default:0" json:"activeLeafId"` // Last message of the active branch
+	AutoTitle     bool            `gorm:"column:auto_title;type:tinyint(1);not null;default:0" json:"-"`                     // Title is a placeholder to be generated
 
 	CreatedAt time.Time `gorm:"type:DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3)" json:"createdAt"`
 	UpdatedAt time.Time `gorm:"type:DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3) ON UPDATE CURRENT_TIMESTAMP(3)" json:"updatedAt"`
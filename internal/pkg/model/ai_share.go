@@ -0,0 +1,30 @@
+// ABOUTME: AI session share link model definition.
+// ABOUTME: A revocable public token for a read-only snapshot of a session branch.
+
+package model
+
+import "time"
+
+// AiShareM is a public share link of a session. The snapshot is the branch ending at
+// LeafID, stored messages never change, so later turns are not shared.
+type AiShareM struct {
+	ID        uint64     `gorm:"primaryKey" json:"id"`
+	Token     string     `gorm:"column:token;type:varchar(64);uniqueIndex:uk_token;not null" json:"token"`
+	SessionID string     `gorm:"column:session_id;type:varchar(64);index:idx_session_id;not null" json:"sessionId"`
+	UID       string     `gorm:"column:uid;type:varchar(64);not null" json:"uid"`
+	LeafID    uint64     `gorm:"column:leaf_id;type:bigint unsigned;not null;default:0" json:"leafId"`
+	Title     string     `gorm:"column:title;type:varchar(255);not null;default:''" json:"title"`
+	Views     int        `gorm:"column:views;type:int;not null;default:0" json:"views"`
+	ExpiresAt *time.Time `gorm:"column:expires_at;type:DATETIME(3)" json:"expiresAt"` // Never expires if nil
+
+	CreatedAt time.Time `gorm:"type:DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3)" json:"createdAt"`
+}
+
+func (*AiShareM) TableName() string {
+	return "ai_share"
+}
+
+// Expired reports whether the share link is past its expiry.
+func (m *AiShareM) Expired(now time.Time) bool {
+	return m.ExpiresAt != nil && !now.Before(*m.ExpiresAt)
+}
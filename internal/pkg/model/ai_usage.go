@@ -0,0 +1,52 @@
+// ABOUTME: AI usage ledger model definitions.
+// ABOUTME: One row per chat completion with tokens, cost, latency and status.
+
+package model
+
+import "time"
+
+const (
+	AiUsageStatusSuccess   = "success"
+	AiUsageStatusError     = "error"
+	AiUsageStatusCancelled = "cancelled"
+)
+
+// AiUsageM records the tokens and cost of a single chat completion.
+// Cost is computed from the model prices, which are per 1K tokens.
+type AiUsageM struct {
+	ID               uint64  `gorm:"primaryKey" json:"id"`
+	UID              string  `gorm:"column:uid;type:varchar(64);not null" json:"uid"`
+	SessionID        string  `gorm:"column:session_id;type:varchar(64);not null;default:''" json:"sessionId"`
+	AgentID          string  `gorm:"column:agent_id;type:varchar(32);not null;default:''" json:"agentId"`
+	ProviderName     string  `gorm:"column:provider_name;type:varchar(32);not null" json:"providerName"`
+	Model            string  `gorm:"column:model;type:varchar(64);not null" json:"model"`
+	Stream           bool    `gorm:"column:stream;type:tinyint(1);not null;default:0" json:"stream"`
+	Fallback         bool    `gorm:"column:fallback;type:tinyint(1);not null;default:0" json:"fallback"`
+	PromptTokens     int     `gorm:"column:prompt_tokens;type:int;not null;default:0" json:"promptTokens"`
+	CompletionTokens int     `gorm:"column:completion_tokens;type:int;not null;default:0" json:"completionTokens"`
+	TotalTokens      int     `gorm:"column:total_tokens;type:int;not null;default:0" json:"totalTokens"`
+	Cost             float64 `gorm:"column:cost;type:decimal(16,8);not null;default:0" json:"cost"`
+	LatencyMs        int64   `gorm:"column:latency_ms;type:bigint;not null;default:0" json:"latencyMs"`
+	Status           string  `gorm:"column:status;type:varchar(16);not null;default:'success'" json:"status"`
+	Error            string  `gorm:"column:error;type:varchar(255);not null;default:''" json:"error"`
+	ExperimentID     uint64  `gorm:"column:experiment_id;type:bigint unsigned;index:idx_experiment_id;not null;default:0" json:"experimentId"`
+	ExperimentArm    string  `gorm:"column:experiment_arm;type:varchar(32);not null;default:''" json:"experimentArm"`
+
+	CreatedAt time.Time `gorm:"type:DATETIME(3) NOT NULL DEFAULT CURRENT_TIMESTAMP(3)" json:"createdAt"`
+}
+
+func (*AiUsageM) TableName() string {
+	return "ai_usage"
+}
+
+// AiUsageSummary is an aggregate of usage rows sharing a group key.
+type AiUsageSummary struct {
+	Key              string  `gorm:"column:group_key" json:"key"`
+	Requests         int64   `gorm:"column:requests" json:"requests"`
+	Errors           int64   `gorm:"column:errors" json:"errors"`
+	PromptTokens     int64   `gorm:"column:prompt_tokens" json:"promptTokens"`
+	CompletionTokens int64   `gorm:"column:completion_tokens" json:"completionTokens"`
+	TotalTokens      int64   `gorm:"column:total_tokens" json:"totalTokens"`
+	Cost             float64 `gorm:"column:cost" json:"cost"`
+	AvgLatencyMs     float64 `gorm:"column:avg_latency_ms" json:"avgLatencyMs"`
+}
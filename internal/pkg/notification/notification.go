@@ -75,19 +75,25 @@ func sendInApp(ctx context.Context, msg *Message) error {
 	}
 
 	// Publish to Redis for real-time push
-	payload := map[string]any{
-		"method": "ntf.message",
-		"data": map[string]any{
-			"uuid":      ntfMsg.UUID,
-			"category":  ntfMsg.Category,
-			"type":      ntfMsg.Type,
-			"title":     ntfMsg.Title,
-			"content":   ntfMsg.Content,
-			"actionUrl": ntfMsg.ActionURL,
-		},
+	return Push(ctx, msg.UserID, "ntf.message", map[string]any{
+		"uuid":      ntfMsg.UUID,
+		"category":  ntfMsg.Category,
+		"type":      ntfMsg.Type,
+		"title":     ntfMsg.Title,
+		"content":   ntfMsg.Content,
+		"actionUrl": ntfMsg.ActionURL,
+	})
+}
+
+// Push publishes a real-time event to the WebSocket connections of a user.
+func Push(ctx context.Context, userID string, method string, data any) error {
+	payload, err := json.Marshal(map[string]any{
+		"method": method,
+		"data":   data,
+	})
+	if err != nil {
+		return err
 	}
-	data, _ := json.Marshal(payload)
-	channel := RedisUserChannelPrefix + msg.UserID
 
-	return facade.Redis.Publish(ctx, channel, data).Err()
+	return facade.Redis.Publish(ctx, RedisUserChannelPrefix+userID, payload).Err()
 }
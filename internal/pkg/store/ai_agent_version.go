@@ -0,0 +1,55 @@
+// ABOUTME: AI agent version data access layer.
+// ABOUTME: Provides creation and lookup of immutable agent version snapshots.
+
+package store
+
+import (
+	"context"
+
+	"github.com/bingo-project/bingo/internal/pkg/model"
+	genericstore "github.com/bingo-project/bingo/pkg/store"
+	"github.com/bingo-project/bingo/pkg/store/where"
+)
+
+type AiAgentVersionStore interface {
+	Create(ctx context.Context, obj *model.AiAgentVersionM) error
+	Get(ctx context.Context, opts *where.Options) (*model.AiAgentVersionM, error)
+	List(ctx context.Context, opts *where.Options) (int64, []*model.AiAgentVersionM, error)
+
+	AiAgentVersionExpansion
+}
+
+type AiAgentVersionExpansion interface {
+	GetVersion(ctx context.Context, agentID string, version int) (*model.AiAgentVersionM, error)
+	LatestVersion(ctx context.Context, agentID string) (int, error)
+}
+
+type aiAgentVersionStore struct {
+	*genericstore.Store[model.AiAgentVersionM]
+}
+
+var _ AiAgentVersionStore = (*aiAgentVersionStore)(nil)
+
+func NewAiAgentVersionStore(store *datastore) *aiAgentVersionStore {
+	return &aiAgentVersionStore{
+		Store: genericstore.NewStore[model.AiAgentVersionM](store, NewLogger()),
+	}
+}
+
+func (s *aiAgentVersionStore) GetVersion(ctx context.Context, agentID string, version int) (*model.AiAgentVersionM, error) {
+	var v model.AiAgentVersionM
+	err := s.DB(ctx).Where("agent_id = ? AND version = ?", agentID, version).First(&v).Error
+
+	return &v, err
+}
+
+// LatestVersion returns the highest version number of an agent, 0 if it has none.
+func (s *aiAgentVersionStore) LatestVersion(ctx context.Context, agentID string) (int, error) {
+	var version int
+	err := s.DB(ctx).Model(&model.AiAgentVersionM{}).
+		Where("agent_id = ?", agentID).
+		Select("COALESCE(MAX(version), 0)").
+		Scan(&version).Error
+
+	return version, err
+}
@@ -0,0 +1,48 @@
+// ABOUTME: AI experiment data access layer.
+// ABOUTME: Provides CRUD operations for A/B experiments and lookup of an agent's running experiment.
+
+package store
+
+import (
+	"context"
+
+	"github.com/bingo-project/bingo/internal/pkg/model"
+	genericstore "github.com/bingo-project/bingo/pkg/store"
+	"github.com/bingo-project/bingo/pkg/store/where"
+)
+
+type AiExperimentStore interface {
+	Create(ctx context.Context, obj *model.AiExperimentM) error
+	Update(ctx context.Context, obj *model.AiExperimentM, fields ...string) error
+	Delete(ctx context.Context, opts *where.Options) error
+	Get(ctx context.Context, opts *where.Options) (*model.AiExperimentM, error)
+	List(ctx context.Context, opts *where.Options) (int64, []*model.AiExperimentM, error)
+
+	AiExperimentExpansion
+}
+
+type AiExperimentExpansion interface {
+	GetRunning(ctx context.Context, agentID string) (*model.AiExperimentM, error)
+}
+
+type aiExperimentStore struct {
+	*genericstore.Store[model.AiExperimentM]
+}
+
+var _ AiExperimentStore = (*aiExperimentStore)(nil)
+
+func NewAiExperimentStore(store *datastore) *aiExperimentStore {
+	return &aiExperimentStore{
+		Store: genericstore.NewStore[model.AiExperimentM](store, NewLogger()),
+	}
+}
+
+// GetRunning returns the running experiment of an agent.
+func (s *aiExperimentStore) GetRunning(ctx context.Context, agentID string) (*model.AiExperimentM, error) {
+	var e model.AiExperimentM
+	err := s.DB(ctx).
+		Where("agent_id = ? AND status = ?", agentID, model.AiExperimentStatusRunning).
+		First(&e).Error
+
+	return &e, err
+}
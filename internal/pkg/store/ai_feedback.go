@@ -0,0 +1,79 @@
+// ABOUTME: AI reply feedback data access layer.
+// ABOUTME: Provides rating upserts, listing and grouped rating aggregates.
+
+package store
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bingo-project/bingo/internal/pkg/model"
+	genericstore "github.com/bingo-project/bingo/pkg/store"
+	"github.com/bingo-project/bingo/pkg/store/where"
+)
+
+// Group keys accepted by AiFeedbackStore.Summarize.
+const (
+	AiFeedbackGroupByModel = "model"
+	AiFeedbackGroupByAgent = "agent"
+	AiFeedbackGroupByDay   = "day"
+	AiFeedbackGroupByArm   = "arm" // Experiment arm, filter by experiment_id
+)
+
+// aiFeedbackGroupColumns maps group keys to the grouped SQL expression.
+var aiFeedbackGroupColumns = map[string]string{
+	AiFeedbackGroupByModel: "model",
+	AiFeedbackGroupByAgent: "agent_id",
+	AiFeedbackGroupByDay:   "DATE_FORMAT(created_at, '%Y-%m-%d')",
+	AiFeedbackGroupByArm:   "experiment_arm",
+}
+
+type AiFeedbackStore interface {
+	Delete(ctx context.Context, opts *where.Options) error
+	Get(ctx context.Context, opts *where.Options) (*model.AiFeedbackM, error)
+	List(ctx context.Context, opts *where.Options) (int64, []*model.AiFeedbackM, error)
+
+	AiFeedbackExpansion
+}
+
+type AiFeedbackExpansion interface {
+	Rate(ctx context.Context, obj *model.AiFeedbackM) error
+	Summarize(ctx context.Context, groupBy string, opts *where.Options) ([]*model.AiFeedbackSummary, error)
+}
+
+type aiFeedbackStore struct {
+	*genericstore.Store[model.AiFeedbackM]
+}
+
+var _ AiFeedbackStore = (*aiFeedbackStore)(nil)
+
+func NewAiFeedbackStore(store *datastore) *aiFeedbackStore {
+	return &aiFeedbackStore{
+		Store: genericstore.NewStore[model.AiFeedbackM](store, NewLogger()),
+	}
+}
+
+// Rate creates the user's feedback on a message, or replaces the rating, reasons and
+// comment of an earlier one.
+func (s *aiFeedbackStore) Rate(ctx context.Context, obj *model.AiFeedbackM) error {
+	return s.Upsert(ctx, obj, "rating", "reasons", "comment")
+}
+
+// Summarize aggregates the feedback rows matching opts by model, agent, day or experiment arm, ordered by key.
+func (s *aiFeedbackStore) Summarize(ctx context.Context, groupBy string, opts *where.Options) ([]*model.AiFeedbackSummary, error) {
+	col, ok := aiFeedbackGroupColumns[groupBy]
+	if !ok {
+		return nil, fmt.Errorf("unsupported feedback group %q", groupBy)
+	}
+
+	var ret []*model.AiFeedbackSummary
+	err := s.DB(ctx, opts).Model(&model.AiFeedbackM{}).
+		Select(col + " AS group_key, COUNT(*) AS ratings, " +
+			"SUM(CASE WHEN rating > 0 THEN 1 ELSE 0 END) AS up, " +
+			"SUM(CASE WHEN rating < 0 THEN 1 ELSE 0 END) AS down").
+		Group(col).
+		Order("group_key ASC").
+		Scan(&ret).Error
+
+	return ret, err
+}
@@ -0,0 +1,35 @@
+// ABOUTME: AI guardrail event data access layer.
+// ABOUTME: Provides writes, listing and review updates of flagged guardrail matches.
+
+package store
+
+import (
+	"context"
+
+	"github.com/bingo-project/bingo/internal/pkg/model"
+	genericstore "github.com/bingo-project/bingo/pkg/store"
+	"github.com/bingo-project/bingo/pkg/store/where"
+)
+
+type AiGuardrailEventStore interface {
+	Update(ctx context.Context, obj *model.AiGuardrailEventM, fields ...string) error
+	Get(ctx context.Context, opts *where.Options) (*model.AiGuardrailEventM, error)
+	List(ctx context.Context, opts *where.Options) (int64, []*model.AiGuardrailEventM, error)
+	CreateInBatch(ctx context.Context, objs []*model.AiGuardrailEventM, batchSize int) error
+
+	AiGuardrailEventExpansion
+}
+
+type AiGuardrailEventExpansion interface{}
+
+type aiGuardrailEventStore struct {
+	*genericstore.Store[model.AiGuardrailEventM]
+}
+
+var _ AiGuardrailEventStore = (*aiGuardrailEventStore)(nil)
+
+func NewAiGuardrailEventStore(store *datastore) *aiGuardrailEventStore {
+	return &aiGuardrailEventStore{
+		Store: genericstore.NewStore[model.AiGuardrailEventM](store, NewLogger()),
+	}
+}
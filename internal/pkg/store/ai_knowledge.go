@@ -0,0 +1,86 @@
+// ABOUTME: AI knowledge base data access layer.
+// ABOUTME: Provides CRUD operations for knowledge documents and embedded chunks.
+
+package store
+
+import (
+	"context"
+
+	"github.com/bingo-project/bingo/internal/pkg/model"
+	genericstore "github.com/bingo-project/bingo/pkg/store"
+	"github.com/bingo-project/bingo/pkg/store/where"
+)
+
+type AiKnowledgeDocStore interface {
+	Create(ctx context.Context, obj *model.AiKnowledgeDocM) error
+	Update(ctx context.Context, obj *model.AiKnowledgeDocM, fields ...string) error
+	Delete(ctx context.Context, opts *where.Options) error
+	Get(ctx context.Context, opts *where.Options) (*model.AiKnowledgeDocM, error)
+	List(ctx context.Context, opts *where.Options) (int64, []*model.AiKnowledgeDocM, error)
+
+	AiKnowledgeDocExpansion
+}
+
+type AiKnowledgeDocExpansion interface {
+	ListByAgentID(ctx context.Context, agentID string) ([]*model.AiKnowledgeDocM, error)
+}
+
+type aiKnowledgeDocStore struct {
+	*genericstore.Store[model.AiKnowledgeDocM]
+}
+
+var _ AiKnowledgeDocStore = (*aiKnowledgeDocStore)(nil)
+
+func NewAiKnowledgeDocStore(store *datastore) *aiKnowledgeDocStore {
+	return &aiKnowledgeDocStore{
+		Store: genericstore.NewStore[model.AiKnowledgeDocM](store, NewLogger()),
+	}
+}
+
+func (s *aiKnowledgeDocStore) ListByAgentID(ctx context.Context, agentID string) ([]*model.AiKnowledgeDocM, error) {
+	var docs []*model.AiKnowledgeDocM
+	err := s.DB(ctx).Where("agent_id = ?", agentID).Order("id ASC").Find(&docs).Error
+
+	return docs, err
+}
+
+type AiKnowledgeChunkStore interface {
+	Create(ctx context.Context, obj *model.AiKnowledgeChunkM) error
+	CreateInBatch(ctx context.Context, objs []*model.AiKnowledgeChunkM, batchSize int) error
+	Delete(ctx context.Context, opts *where.Options) error
+	List(ctx context.Context, opts *where.Options) (int64, []*model.AiKnowledgeChunkM, error)
+
+	AiKnowledgeChunkExpansion
+}
+
+type AiKnowledgeChunkExpansion interface {
+	ListReadyByAgent(ctx context.Context, agentID, embeddingModel string) ([]*model.AiKnowledgeChunkM, error)
+	DeleteByDocID(ctx context.Context, docID uint64) error
+}
+
+type aiKnowledgeChunkStore struct {
+	*genericstore.Store[model.AiKnowledgeChunkM]
+}
+
+var _ AiKnowledgeChunkStore = (*aiKnowledgeChunkStore)(nil)
+
+func NewAiKnowledgeChunkStore(store *datastore) *aiKnowledgeChunkStore {
+	return &aiKnowledgeChunkStore{
+		Store: genericstore.NewStore[model.AiKnowledgeChunkM](store, NewLogger()),
+	}
+}
+
+// ListReadyByAgent returns chunks of the agent's ready documents embedded with the given model.
+func (s *aiKnowledgeChunkStore) ListReadyByAgent(ctx context.Context, agentID, embeddingModel string) ([]*model.AiKnowledgeChunkM, error) {
+	docs := s.DB(ctx).Model(&model.AiKnowledgeDocM{}).Select("id").
+		Where("agent_id = ? AND status = ? AND embedding_model = ?", agentID, model.AiKnowledgeDocStatusReady, embeddingModel)
+
+	var chunks []*model.AiKnowledgeChunkM
+	err := s.DB(ctx).Where("doc_id IN (?)", docs).Order("doc_id ASC, seq ASC").Find(&chunks).Error
+
+	return chunks, err
+}
+
+func (s *aiKnowledgeChunkStore) DeleteByDocID(ctx context.Context, docID uint64) error {
+	return s.DB(ctx).Where("doc_id = ?", docID).Delete(&model.AiKnowledgeChunkM{}).Error
+}
@@ -1,10 +1,12 @@
 // ABOUTME: AI message data access layer.
-// ABOUTME: Provides CRUD operations for chat messages.
+// ABOUTME: Provides CRUD operations for chat messages, walks their branches and searches them.
 
 package store
 
 import (
 	"context"
+	"slices"
+	"strings"
 
 	"github.com/bingo-project/bingo/internal/pkg/model"
 	genericstore "github.com/bingo-project/bingo/pkg/store"
@@ -23,9 +25,27 @@ type AiMessageStore interface {
 
 type AiMessageExpansion interface {
 	ListBySessionID(ctx context.Context, sessionID string, limit int) ([]*model.AiMessageM, error)
+	ListBySessionIDAfter(ctx context.Context, sessionID string, afterID uint64, limit int) ([]*model.AiMessageM, error)
+	GetLatestSummary(ctx context.Context, sessionID string) (*model.AiMessageM, error)
 	DeleteBySessionID(ctx context.Context, sessionID string) error
+	ListBranch(ctx context.Context, sessionID string, leafID uint64, limit int) ([]*model.AiMessageM, error)
+	ListChildren(ctx context.Context, sessionID string, parentID uint64) ([]*model.AiMessageM, error)
+	GetLatestLeaf(ctx context.Context, sessionID string, messageID uint64) (uint64, error)
+	LinkSession(ctx context.Context, sessionID string) (uint64, error)
+	Search(ctx context.Context, uid string, terms []string, offset, limit int) (int64, []*model.AiSearchHit, error)
 }
 
+// maxBranchDepth bounds branch walks below the default MySQL recursion depth of 1000.
+const maxBranchDepth = 999
+
+var (
+	// likeEscaper escapes LIKE wildcards in search terms.
+	likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+	// fulltextQuoteRemover drops the phrase delimiter from FULLTEXT search terms.
+	fulltextQuoteRemover = strings.NewReplacer(`"`, "")
+)
+
 type aiMessageStore struct {
 	*genericstore.Store[model.AiMessageM]
 }
@@ -39,8 +59,15 @@ func NewAiMessageStore(store *datastore) *aiMessageStore {
 }
 
 func (s *aiMessageStore) ListBySessionID(ctx context.Context, sessionID string, limit int) ([]*model.AiMessageM, error) {
+	return s.ListBySessionIDAfter(ctx, sessionID, 0, limit)
+}
+
+// ListBySessionIDAfter lists conversation messages with ID greater than afterID, excluding summaries.
+func (s *aiMessageStore) ListBySessionIDAfter(ctx context.Context, sessionID string, afterID uint64, limit int) ([]*model.AiMessageM, error) {
 	var messages []*model.AiMessageM
-	db := s.DB(ctx).Where("session_id = ?", sessionID).Order("created_at ASC")
+	db := s.DB(ctx).
+		Where("session_id = ? AND kind = ? AND id > ?", sessionID, model.AiMessageKindMessage, afterID).
+		Order("created_at ASC, id ASC")
 	if limit > 0 {
 		db = db.Limit(limit)
 	}
@@ -49,6 +76,139 @@ func (s *aiMessageStore) ListBySessionID(ctx context.Context, sessionID string,
 	return messages, err
 }
 
+func (s *aiMessageStore) GetLatestSummary(ctx context.Context, sessionID string) (*model.AiMessageM, error) {
+	var message model.AiMessageM
+	err := s.DB(ctx).
+		Where("session_id = ? AND kind = ?", sessionID, model.AiMessageKindSummary).
+		Order("id DESC").
+		First(&message).Error
+
+	return &message, err
+}
+
 func (s *aiMessageStore) DeleteBySessionID(ctx context.Context, sessionID string) error {
 	return s.DB(ctx).Where("session_id = ?", sessionID).Delete(&model.AiMessageM{}).Error
 }
+
+// ListBranch lists the conversation messages from leafID up towards the root, newest first, at most limit.
+func (s *aiMessageStore) ListBranch(ctx context.Context, sessionID string, leafID uint64, limit int) ([]*model.AiMessageM, error) {
+	if limit <= 0 || limit > maxBranchDepth {
+		limit = maxBranchDepth
+	}
+
+	var messages []*model.AiMessageM
+	err := s.DB(ctx).Raw(`WITH RECURSIVE branch (id, parent_id, depth) AS (
+			SELECT id, parent_id, 1 FROM ai_message WHERE id = ? AND session_id = ? AND kind = ?
+			UNION ALL
+			SELECT m.id, m.parent_id, b.depth + 1 FROM ai_message m JOIN branch b ON m.id = b.parent_id WHERE b.depth < ?
+		)
+		SELECT ai_message.* FROM ai_message JOIN branch ON ai_message.id = branch.id ORDER BY ai_message.id DESC`,
+		leafID, sessionID, model.AiMessageKindMessage, limit).
+		Scan(&messages).Error
+
+	return messages, err
+}
+
+// ListChildren lists the conversation messages continuing parentID, oldest first.
+// A parentID of 0 lists the roots of the session.
+func (s *aiMessageStore) ListChildren(ctx context.Context, sessionID string, parentID uint64) ([]*model.AiMessageM, error) {
+	var messages []*model.AiMessageM
+	err := s.DB(ctx).
+		Where("session_id = ? AND kind = ? AND parent_id = ?", sessionID, model.AiMessageKindMessage, parentID).
+		Order("id ASC").
+		Find(&messages).Error
+
+	return messages, err
+}
+
+// GetLatestLeaf returns the newest message below messageID, or messageID itself if nothing continues it.
+// The newest message of a subtree has no children, so it ends the most recently used branch.
+func (s *aiMessageStore) GetLatestLeaf(ctx context.Context, sessionID string, messageID uint64) (uint64, error) {
+	var leafID uint64
+	err := s.DB(ctx).Raw(`WITH RECURSIVE subtree (id) AS (
+			SELECT id FROM ai_message WHERE id = ? AND session_id = ?
+			UNION ALL
+			SELECT m.id FROM ai_message m JOIN subtree t ON m.parent_id = t.id WHERE m.session_id = ? AND m.kind = ?
+		)
+		SELECT COALESCE(MAX(id), 0) FROM subtree`,
+		messageID, sessionID, sessionID, model.AiMessageKindMessage).
+		Scan(&leafID).Error
+
+	return leafID, err
+}
+
+// LinkSession chains the messages of a session saved before branching existed, each to the one
+// before it, and returns the last message ID, or 0 if the session has no messages.
+func (s *aiMessageStore) LinkSession(ctx context.Context, sessionID string) (uint64, error) {
+	err := s.DB(ctx).Exec(`UPDATE ai_message m JOIN (
+			SELECT id, LAG(id, 1, 0) OVER (ORDER BY id) AS prev FROM ai_message WHERE session_id = ? AND kind = ?
+		) chain ON m.id = chain.id
+		SET m.parent_id = chain.prev`,
+		sessionID, model.AiMessageKindMessage).Error
+	if err != nil {
+		return 0, err
+	}
+
+	var leafID uint64
+	err = s.DB(ctx).Model(&model.AiMessageM{}).
+		Where("session_id = ? AND kind = ?", sessionID, model.AiMessageKindMessage).
+		Select("COALESCE(MAX(id), 0)").
+		Scan(&leafID).Error
+
+	return leafID, err
+}
+
+// Search finds the sessions whose title contains every term and the user and assistant messages
+// matching every term in the FULLTEXT index, newest first. Deleted sessions are skipped and an
+// empty uid searches all users.
+func (s *aiMessageStore) Search(ctx context.Context, uid string, terms []string, offset, limit int) (int64, []*model.AiSearchHit, error) {
+	if len(terms) == 0 {
+		return 0, nil, nil
+	}
+
+	titleWhere := "s.status <> ?"
+	titleArgs := []any{model.AiSessionStatusDeleted}
+	phrases := make([]string, len(terms))
+	for i, t := range terms {
+		titleWhere += " AND s.title LIKE ?"
+		titleArgs = append(titleArgs, "%"+likeEscaper.Replace(t)+"%")
+		phrases[i] = `+"` + fulltextQuoteRemover.Replace(t) + `"`
+	}
+
+	messageWhere := "MATCH(m.content) AGAINST(? IN BOOLEAN MODE) AND m.kind = ? AND m.role IN ? AND s.status <> ?"
+	messageArgs := []any{
+		strings.Join(phrases, " "),
+		model.AiMessageKindMessage,
+		[]string{model.AiMessageRoleUser, model.AiMessageRoleAssistant},
+		model.AiSessionStatusDeleted,
+	}
+
+	if uid != "" {
+		titleWhere += " AND s.uid = ?"
+		titleArgs = append(titleArgs, uid)
+		messageWhere += " AND s.uid = ?"
+		messageArgs = append(messageArgs, uid)
+	}
+
+	hits := `SELECT s.session_id, s.uid, s.title, 0 AS message_id, '' AS role, '' AS content, s.updated_at AS created_at
+		FROM ai_session s WHERE ` + titleWhere + `
+		UNION ALL
+		SELECT m.session_id, s.uid, s.title, m.id, m.role, m.content, m.created_at
+		FROM ai_message m JOIN ai_session s ON s.session_id = m.session_id WHERE ` + messageWhere
+	args := slices.Concat(titleArgs, messageArgs)
+
+	var count int64
+	if err := s.DB(ctx).Raw("SELECT COUNT(*) FROM ("+hits+") hits", args...).Scan(&count).Error; err != nil {
+		return 0, nil, err
+	}
+	if count == 0 {
+		return 0, nil, nil
+	}
+
+	var ret []*model.AiSearchHit
+	err := s.DB(ctx).Raw("SELECT * FROM ("+hits+") hits ORDER BY created_at DESC, message_id DESC LIMIT ? OFFSET ?",
+		append(args, limit, offset)...).
+		Scan(&ret).Error
+
+	return count, ret, err
+}
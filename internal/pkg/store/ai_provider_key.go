@@ -0,0 +1,56 @@
+// ABOUTME: AI provider API key data access layer.
+// ABOUTME: Provides CRUD operations for the keys a provider's requests are balanced across.
+
+package store
+
+import (
+	"context"
+
+	"github.com/bingo-project/bingo/internal/pkg/model"
+	genericstore "github.com/bingo-project/bingo/pkg/store"
+	"github.com/bingo-project/bingo/pkg/store/where"
+)
+
+type AiProviderKeyStore interface {
+	Create(ctx context.Context, obj *model.AiProviderKeyM) error
+	Update(ctx context.Context, obj *model.AiProviderKeyM, fields ...string) error
+	Delete(ctx context.Context, opts *where.Options) error
+	Get(ctx context.Context, opts *where.Options) (*model.AiProviderKeyM, error)
+	List(ctx context.Context, opts *where.Options) (int64, []*model.AiProviderKeyM, error)
+
+	AiProviderKeyExpansion
+}
+
+type AiProviderKeyExpansion interface {
+	GetByName(ctx context.Context, providerName, name string) (*model.AiProviderKeyM, error)
+	ListActive(ctx context.Context) ([]*model.AiProviderKeyM, error)
+}
+
+type aiProviderKeyStore struct {
+	*genericstore.Store[model.AiProviderKeyM]
+}
+
+var _ AiProviderKeyStore = (*aiProviderKeyStore)(nil)
+
+func NewAiProviderKeyStore(store *datastore) *aiProviderKeyStore {
+	return &aiProviderKeyStore{
+		Store: genericstore.NewStore[model.AiProviderKeyM](store, NewLogger()),
+	}
+}
+
+func (s *aiProviderKeyStore) GetByName(ctx context.Context, providerName, name string) (*model.AiProviderKeyM, error) {
+	var key model.AiProviderKeyM
+	err := s.DB(ctx).Where("provider_name = ? AND name = ?", providerName, name).First(&key).Error
+
+	return &key, err
+}
+
+func (s *aiProviderKeyStore) ListActive(ctx context.Context) ([]*model.AiProviderKeyM, error) {
+	var keys []*model.AiProviderKeyM
+	err := s.DB(ctx).
+		Where("status = ?", model.AiProviderKeyStatusActive).
+		Order("id ASC").
+		Find(&keys).Error
+
+	return keys, err
+}
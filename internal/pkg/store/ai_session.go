@@ -27,6 +27,9 @@ type AiSessionExpansion interface {
 	GetBySessionID(ctx context.Context, sessionID string) (*model.AiSessionM, error)
 	ListByUID(ctx context.Context, uid string, status model.AiSessionStatus) ([]*model.AiSessionM, error)
 	IncrementMessageCount(ctx context.Context, sessionID string, tokens int) error
+	AdvanceSummaryCursor(ctx context.Context, sessionID string, from, to uint64) (bool, error)
+	UpdateActiveLeaf(ctx context.Context, sessionID string, leafID uint64) error
+	UpdateAutoTitle(ctx context.Context, sessionID string, title string) (bool, error)
 }
 
 type aiSessionStore struct {
@@ -68,3 +71,33 @@ func (s *aiSessionStore) IncrementMessageCount(ctx context.Context, sessionID st
 			"total_tokens":  gorm.Expr("total_tokens + ?", tokens),
 		}).Error
 }
+
+// AdvanceSummaryCursor moves the summary cursor from one message ID to another.
+// Returns false if the cursor was moved concurrently.
+func (s *aiSessionStore) AdvanceSummaryCursor(ctx context.Context, sessionID string, from, to uint64) (bool, error) {
+	result := s.DB(ctx).
+		Model(&model.AiSessionM{}).
+		Where("session_id = ? AND summary_cursor = ?", sessionID, from).
+		Update("summary_cursor", to)
+
+	return result.RowsAffected > 0, result.Error
+}
+
+// UpdateActiveLeaf points the session at the last message of the branch it continues.
+func (s *aiSessionStore) UpdateActiveLeaf(ctx context.Context, sessionID string, leafID uint64) error {
+	return s.DB(ctx).
+		Model(&model.AiSessionM{}).
+		Where("session_id = ?", sessionID).
+		Update("active_leaf_id", leafID).Error
+}
+
+// UpdateAutoTitle replaces the placeholder title of a session with a generated one.
+// Returns false if the session was renamed or titled meanwhile.
+func (s *aiSessionStore) UpdateAutoTitle(ctx context.Context, sessionID string, title string) (bool, error) {
+	result := s.DB(ctx).
+		Model(&model.AiSessionM{}).
+		Where("session_id = ? AND auto_title = ?", sessionID, true).
+		Updates(map[string]any{"title": title, "auto_title": false})
+
+	return result.RowsAffected > 0, result.Error
+}
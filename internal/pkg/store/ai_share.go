@@ -0,0 +1,54 @@
+// ABOUTME: AI session share link data access layer.
+// ABOUTME: Provides CRUD operations for share tokens and view counting.
+
+package store
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"github.com/bingo-project/bingo/internal/pkg/model"
+	genericstore "github.com/bingo-project/bingo/pkg/store"
+	"github.com/bingo-project/bingo/pkg/store/where"
+)
+
+type AiShareStore interface {
+	Create(ctx context.Context, obj *model.AiShareM) error
+	Delete(ctx context.Context, opts *where.Options) error
+	Get(ctx context.Context, opts *where.Options) (*model.AiShareM, error)
+	List(ctx context.Context, opts *where.Options) (int64, []*model.AiShareM, error)
+
+	AiShareExpansion
+}
+
+type AiShareExpansion interface {
+	GetByToken(ctx context.Context, token string) (*model.AiShareM, error)
+	IncrementViews(ctx context.Context, id uint64) error
+}
+
+type aiShareStore struct {
+	*genericstore.Store[model.AiShareM]
+}
+
+var _ AiShareStore = (*aiShareStore)(nil)
+
+func NewAiShareStore(store *datastore) *aiShareStore {
+	return &aiShareStore{
+		Store: genericstore.NewStore[model.AiShareM](store, NewLogger()),
+	}
+}
+
+func (s *aiShareStore) GetByToken(ctx context.Context, token string) (*model.AiShareM, error) {
+	var share model.AiShareM
+	err := s.DB(ctx).Where("token = ?", token).First(&share).Error
+
+	return &share, err
+}
+
+func (s *aiShareStore) IncrementViews(ctx context.Context, id uint64) error {
+	return s.DB(ctx).
+		Model(&model.AiShareM{}).
+		Where("id = ?", id).
+		Update("views", gorm.Expr("views + 1")).Error
+}
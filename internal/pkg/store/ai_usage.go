@@ -0,0 +1,72 @@
+// ABOUTME: AI usage ledger data access layer.
+// ABOUTME: Provides ledger writes, listing and grouped spend aggregates.
+
+package store
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/bingo-project/bingo/internal/pkg/model"
+	genericstore "github.com/bingo-project/bingo/pkg/store"
+	"github.com/bingo-project/bingo/pkg/store/where"
+)
+
+// Group keys accepted by AiUsageStore.Summarize.
+const (
+	AiUsageGroupByUser  = "user"
+	AiUsageGroupByModel = "model"
+	AiUsageGroupByDay   = "day"
+	AiUsageGroupByArm   = "arm" // Experiment arm, filter by experiment_id
+)
+
+// aiUsageGroupColumns maps group keys to the grouped SQL expression.
+var aiUsageGroupColumns = map[string]string{
+	AiUsageGroupByUser:  "uid",
+	AiUsageGroupByModel: "model",
+	AiUsageGroupByDay:   "DATE_FORMAT(created_at, '%Y-%m-%d')",
+	AiUsageGroupByArm:   "experiment_arm",
+}
+
+type AiUsageStore interface {
+	Create(ctx context.Context, obj *model.AiUsageM) error
+	List(ctx context.Context, opts *where.Options) (int64, []*model.AiUsageM, error)
+
+	AiUsageExpansion
+}
+
+type AiUsageExpansion interface {
+	Summarize(ctx context.Context, groupBy string, opts *where.Options) ([]*model.AiUsageSummary, error)
+}
+
+type aiUsageStore struct {
+	*genericstore.Store[model.AiUsageM]
+}
+
+var _ AiUsageStore = (*aiUsageStore)(nil)
+
+func NewAiUsageStore(store *datastore) *aiUsageStore {
+	return &aiUsageStore{
+		Store: genericstore.NewStore[model.AiUsageM](store, NewLogger()),
+	}
+}
+
+// Summarize aggregates the usage rows matching opts by user, model, day or experiment arm, ordered by key.
+func (s *aiUsageStore) Summarize(ctx context.Context, groupBy string, opts *where.Options) ([]*model.AiUsageSummary, error) {
+	col, ok := aiUsageGroupColumns[groupBy]
+	if !ok {
+		return nil, fmt.Errorf("unsupported usage group %q", groupBy)
+	}
+
+	var ret []*model.AiUsageSummary
+	err := s.DB(ctx, opts).Model(&model.AiUsageM{}).
+		Select(col + " AS group_key, COUNT(*) AS requests, " +
+			"SUM(CASE WHEN status = 'error' THEN 1 ELSE 0 END) AS errors, " +
+			"SUM(prompt_tokens) AS prompt_tokens, SUM(completion_tokens) AS completion_tokens, " +
+			"SUM(total_tokens) AS total_tokens, SUM(cost) AS cost, AVG(latency_ms) AS avg_latency_ms").
+		Group(col).
+		Order("group_key ASC").
+		Scan(&ret).Error
+
+	return ret, err
+}
@@ -83,6 +83,24 @@ type IStore interface {
 	AiMessage() AiMessageStore
 	// AiAgents returns the AI agent preset store.
 	AiAgents() AiAgentStore
+	// AiKnowledgeDoc returns the AI knowledge document store.
+	AiKnowledgeDoc() AiKnowledgeDocStore
+	// AiKnowledgeChunk returns the AI knowledge chunk store.
+	AiKnowledgeChunk() AiKnowledgeChunkStore
+	// AiUsage returns the AI usage ledger store.
+	AiUsage() AiUsageStore
+	// AiShare returns the AI session share link store.
+	AiShare() AiShareStore
+	// AiFeedback returns the AI reply feedback store.
+	AiFeedback() AiFeedbackStore
+	// AiAgentVersion returns the AI agent version store.
+	AiAgentVersion() AiAgentVersionStore
+	// AiExperiment returns the AI A/B experiment store.
+	AiExperiment() AiExperimentStore
+	// AiGuardrailEvent returns the AI guardrail event store.
+	AiGuardrailEvent() AiGuardrailEventStore
+	// AiProviderKey returns the AI provider API key store.
+	AiProviderKey() AiProviderKeyStore
 }
 
 // transactionKey used for context.
@@ -263,3 +281,48 @@ func (ds *datastore) AiMessage() AiMessageStore {
 func (ds *datastore) AiAgents() AiAgentStore {
 	return NewAiAgentStore(ds)
 }
+
+// AiKnowledgeDoc returns the AI knowledge document store.
+func (ds *datastore) AiKnowledgeDoc() AiKnowledgeDocStore {
+	return NewAiKnowledgeDocStore(ds)
+}
+
+// AiKnowledgeChunk returns the AI knowledge chunk store.
+func (ds *datastore) AiKnowledgeChunk() AiKnowledgeChunkStore {
+	return NewAiKnowledgeChunkStore(ds)
+}
+
+// AiUsage returns the AI usage ledger store.
+func (ds *datastore) AiUsage() AiUsageStore {
+	return NewAiUsageStore(ds)
+}
+
+// AiShare returns the AI session share link store.
+func (ds *datastore) AiShare() AiShareStore {
+	return NewAiShareStore(ds)
+}
+
+// AiFeedback returns the AI reply feedback store.
+func (ds *datastore) AiFeedback() AiFeedbackStore {
+	return NewAiFeedbackStore(ds)
+}
+
+// AiAgentVersion returns the AI agent version store.
+func (ds *datastore) AiAgentVersion() AiAgentVersionStore {
+	return NewAiAgentVersionStore(ds)
+}
+
+// AiExperiment returns the AI A/B experiment store.
+func (ds *datastore) AiExperiment() AiExperimentStore {
+	return NewAiExperimentStore(ds)
+}
+
+// AiGuardrailEvent returns the AI guardrail event store.
+func (ds *datastore) AiGuardrailEvent() AiGuardrailEventStore {
+	return NewAiGuardrailEventStore(ds)
+}
+
+// AiProviderKey returns the AI provider API key store.
+func (ds *datastore) AiProviderKey() AiProviderKeyStore {
+	return NewAiProviderKeyStore(ds)
+}
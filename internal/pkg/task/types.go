@@ -6,6 +6,7 @@ package task
 const (
 	EmailVerificationCode = "email:verification"
 	AnnouncementPublish   = "announcement:publish"
+	AiSessionTitle        = "ai:session:title"
 )
 
 type EmailVerificationCodePayload struct {
@@ -17,3 +18,9 @@ type EmailVerificationCodePayload struct {
 type AnnouncementPublishPayload struct {
 	AnnouncementID uint64 `json:"announcement_id"`
 }
+
+type AiSessionTitlePayload struct {
+	SessionID string `json:"session_id"`
+	Model     string `json:"model"`
+	Lang      string `json:"lang"`
+}
@@ -0,0 +1,70 @@
+// ABOUTME: Mock AI guardrail event store for testing.
+// ABOUTME: Provides an in-memory implementation of AiGuardrailEventStore interface.
+
+package store
+
+import (
+	"context"
+	"sync"
+
+	"gorm.io/gorm"
+
+	"github.com/bingo-project/bingo/internal/pkg/model"
+	"github.com/bingo-project/bingo/internal/pkg/store"
+	"github.com/bingo-project/bingo/pkg/store/where"
+)
+
+// AiGuardrailEventStore implements store.AiGuardrailEventStore for testing.
+type AiGuardrailEventStore struct {
+	mu     sync.Mutex
+	rows   []*model.AiGuardrailEventM
+	nextID uint64
+}
+
+var _ store.AiGuardrailEventStore = (*AiGuardrailEventStore)(nil)
+
+// NewAiGuardrailEventStore creates a new mock AI guardrail event store.
+func NewAiGuardrailEventStore() *AiGuardrailEventStore {
+	return &AiGuardrailEventStore{nextID: 1}
+}
+
+// CreateInBatch records guardrail events.
+func (m *AiGuardrailEventStore) CreateInBatch(ctx context.Context, objs []*model.AiGuardrailEventM, batchSize int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, obj := range objs {
+		obj.ID = m.nextID
+		m.nextID++
+		m.rows = append(m.rows, obj)
+	}
+
+	return nil
+}
+
+// Update is a no-op, rows are stored by pointer.
+func (m *AiGuardrailEventStore) Update(ctx context.Context, obj *model.AiGuardrailEventM, fields ...string) error {
+	return nil
+}
+
+// Get returns the first event.
+func (m *AiGuardrailEventStore) Get(ctx context.Context, opts *where.Options) (*model.AiGuardrailEventM, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if len(m.rows) == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	return m.rows[0], nil
+}
+
+// List lists all events.
+func (m *AiGuardrailEventStore) List(ctx context.Context, opts *where.Options) (int64, []*model.AiGuardrailEventM, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	rows := append([]*model.AiGuardrailEventM(nil), m.rows...)
+
+	return int64(len(rows)), rows, nil
+}
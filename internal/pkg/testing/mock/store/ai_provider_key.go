@@ -0,0 +1,109 @@
+// ABOUTME: Mock AI provider key store for testing.
+// ABOUTME: Provides an in-memory implementation of AiProviderKeyStore interface.
+
+package store
+
+import (
+	"context"
+	"sync"
+
+	"gorm.io/gorm"
+
+	"github.com/bingo-project/bingo/internal/pkg/model"
+	"github.com/bingo-project/bingo/internal/pkg/store"
+	"github.com/bingo-project/bingo/pkg/store/where"
+)
+
+// AiProviderKeyStore implements store.AiProviderKeyStore for testing.
+type AiProviderKeyStore struct {
+	mu     sync.Mutex
+	rows   []*model.AiProviderKeyM
+	nextID uint
+}
+
+var _ store.AiProviderKeyStore = (*AiProviderKeyStore)(nil)
+
+// NewAiProviderKeyStore creates a new mock AI provider key store.
+func NewAiProviderKeyStore() *AiProviderKeyStore {
+	return &AiProviderKeyStore{nextID: 1}
+}
+
+// Create creates a provider key.
+func (m *AiProviderKeyStore) Create(ctx context.Context, obj *model.AiProviderKeyM) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if obj.ID == 0 {
+		obj.ID = m.nextID
+		m.nextID++
+	}
+	m.rows = append(m.rows, obj)
+
+	return nil
+}
+
+// Update is a no-op, rows are stored by pointer.
+func (m *AiProviderKeyStore) Update(ctx context.Context, obj *model.AiProviderKeyM, fields ...string) error {
+	return nil
+}
+
+// Delete deletes all keys.
+func (m *AiProviderKeyStore) Delete(ctx context.Context, opts *where.Options) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.rows = nil
+
+	return nil
+}
+
+// Get returns the first key.
+func (m *AiProviderKeyStore) Get(ctx context.Context, opts *where.Options) (*model.AiProviderKeyM, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if len(m.rows) == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	return m.rows[0], nil
+}
+
+// List lists all keys.
+func (m *AiProviderKeyStore) List(ctx context.Context, opts *where.Options) (int64, []*model.AiProviderKeyM, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	rows := append([]*model.AiProviderKeyM(nil), m.rows...)
+
+	return int64(len(rows)), rows, nil
+}
+
+// GetByName returns a key of a provider by name.
+func (m *AiProviderKeyStore) GetByName(ctx context.Context, providerName, name string) (*model.AiProviderKeyM, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, k := range m.rows {
+		if k.ProviderName == providerName && k.Name == name {
+			return k, nil
+		}
+	}
+
+	return nil, gorm.ErrRecordNotFound
+}
+
+// ListActive lists the active keys.
+func (m *AiProviderKeyStore) ListActive(ctx context.Context) ([]*model.AiProviderKeyM, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var rows []*model.AiProviderKeyM
+	for _, k := range m.rows {
+		if k.Status == model.AiProviderKeyStatusActive {
+			rows = append(rows, k)
+		}
+	}
+
+	return rows, nil
+}
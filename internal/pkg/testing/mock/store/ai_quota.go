@@ -0,0 +1,206 @@
+// ABOUTME: Mock AI quota stores for testing.
+// ABOUTME: Provides in-memory implementations of AiUserQuotaStore and AiQuotaTierStore.
+
+package store
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"github.com/bingo-project/bingo/internal/pkg/model"
+	"github.com/bingo-project/bingo/internal/pkg/store"
+	"github.com/bingo-project/bingo/pkg/store/where"
+)
+
+// AiUserQuotaStore implements store.AiUserQuotaStore for testing.
+type AiUserQuotaStore struct {
+	// Configurable results for testing
+	GetByUIDErr    error
+	GetByUIDCalled int
+
+	// In-memory storage keyed by UID
+	quotas map[string]*model.AiUserQuotaM
+	nextID uint
+}
+
+var _ store.AiUserQuotaStore = (*AiUserQuotaStore)(nil)
+
+// NewAiUserQuotaStore creates a new mock AI user quota store.
+func NewAiUserQuotaStore() *AiUserQuotaStore {
+	return &AiUserQuotaStore{
+		quotas: make(map[string]*model.AiUserQuotaM),
+		nextID: 1,
+	}
+}
+
+// Create creates a new user quota.
+func (m *AiUserQuotaStore) Create(ctx context.Context, obj *model.AiUserQuotaM) error {
+	if obj.ID == 0 {
+		obj.ID = m.nextID
+		m.nextID++
+	}
+	m.quotas[obj.UID] = obj
+
+	return nil
+}
+
+// Update updates a user quota.
+func (m *AiUserQuotaStore) Update(ctx context.Context, obj *model.AiUserQuotaM, fields ...string) error {
+	if _, exists := m.quotas[obj.UID]; !exists {
+		return gorm.ErrRecordNotFound
+	}
+	m.quotas[obj.UID] = obj
+
+	return nil
+}
+
+// Delete deletes user quotas by options.
+func (m *AiUserQuotaStore) Delete(ctx context.Context, opts *where.Options) error {
+	for uid := range m.quotas {
+		delete(m.quotas, uid)
+	}
+
+	return nil
+}
+
+// Get gets a user quota by options.
+func (m *AiUserQuotaStore) Get(ctx context.Context, opts *where.Options) (*model.AiUserQuotaM, error) {
+	for _, quota := range m.quotas {
+		return quota, nil
+	}
+
+	return nil, gorm.ErrRecordNotFound
+}
+
+// List lists user quotas by options.
+func (m *AiUserQuotaStore) List(ctx context.Context, opts *where.Options) (int64, []*model.AiUserQuotaM, error) {
+	var quotas []*model.AiUserQuotaM
+	for _, quota := range m.quotas {
+		quotas = append(quotas, quota)
+	}
+
+	return int64(len(quotas)), quotas, nil
+}
+
+// GetByUID gets a user quota by UID.
+func (m *AiUserQuotaStore) GetByUID(ctx context.Context, uid string) (*model.AiUserQuotaM, error) {
+	m.GetByUIDCalled++
+
+	if m.GetByUIDErr != nil {
+		return nil, m.GetByUIDErr
+	}
+	if quota, ok := m.quotas[uid]; ok {
+		return quota, nil
+	}
+
+	return nil, gorm.ErrRecordNotFound
+}
+
+// IncrementTokens adds used tokens to a user quota.
+func (m *AiUserQuotaStore) IncrementTokens(ctx context.Context, uid string, tokens int) error {
+	quota, ok := m.quotas[uid]
+	if !ok {
+		return gorm.ErrRecordNotFound
+	}
+	quota.UsedTokensToday += tokens
+
+	return nil
+}
+
+// ResetDailyTokens resets the used tokens of a user quota.
+func (m *AiUserQuotaStore) ResetDailyTokens(ctx context.Context, uid string) error {
+	quota, ok := m.quotas[uid]
+	if !ok {
+		return gorm.ErrRecordNotFound
+	}
+	quota.UsedTokensToday = 0
+
+	return nil
+}
+
+// AiQuotaTierStore implements store.AiQuotaTierStore for testing.
+type AiQuotaTierStore struct {
+	// In-memory storage keyed by tier
+	tiers  map[string]*model.AiQuotaTierM
+	nextID uint
+}
+
+var _ store.AiQuotaTierStore = (*AiQuotaTierStore)(nil)
+
+// NewAiQuotaTierStore creates a new mock AI quota tier store.
+func NewAiQuotaTierStore() *AiQuotaTierStore {
+	return &AiQuotaTierStore{
+		tiers:  make(map[string]*model.AiQuotaTierM),
+		nextID: 1,
+	}
+}
+
+// Create creates a new quota tier.
+func (m *AiQuotaTierStore) Create(ctx context.Context, obj *model.AiQuotaTierM) error {
+	if obj.ID == 0 {
+		obj.ID = m.nextID
+		m.nextID++
+	}
+	m.tiers[obj.Tier] = obj
+
+	return nil
+}
+
+// Update updates a quota tier.
+func (m *AiQuotaTierStore) Update(ctx context.Context, obj *model.AiQuotaTierM, fields ...string) error {
+	if _, exists := m.tiers[obj.Tier]; !exists {
+		return gorm.ErrRecordNotFound
+	}
+	m.tiers[obj.Tier] = obj
+
+	return nil
+}
+
+// Delete deletes quota tiers by options.
+func (m *AiQuotaTierStore) Delete(ctx context.Context, opts *where.Options) error {
+	for tier := range m.tiers {
+		delete(m.tiers, tier)
+	}
+
+	return nil
+}
+
+// Get gets a quota tier by options.
+func (m *AiQuotaTierStore) Get(ctx context.Context, opts *where.Options) (*model.AiQuotaTierM, error) {
+	for _, tier := range m.tiers {
+		return tier, nil
+	}
+
+	return nil, gorm.ErrRecordNotFound
+}
+
+// List lists quota tiers by options.
+func (m *AiQuotaTierStore) List(ctx context.Context, opts *where.Options) (int64, []*model.AiQuotaTierM, error) {
+	var tiers []*model.AiQuotaTierM
+	for _, tier := range m.tiers {
+		tiers = append(tiers, tier)
+	}
+
+	return int64(len(tiers)), tiers, nil
+}
+
+// GetByTier gets a quota tier by name.
+func (m *AiQuotaTierStore) GetByTier(ctx context.Context, tier string) (*model.AiQuotaTierM, error) {
+	if t, ok := m.tiers[tier]; ok {
+		return t, nil
+	}
+
+	return nil, gorm.ErrRecordNotFound
+}
+
+// FirstOrCreate gets a quota tier or creates it if missing.
+func (m *AiQuotaTierStore) FirstOrCreate(ctx context.Context, where *model.AiQuotaTierM, obj *model.AiQuotaTierM) error {
+	if t, ok := m.tiers[where.Tier]; ok {
+		*obj = *t
+
+		return nil
+	}
+
+	return m.Create(ctx, obj)
+}
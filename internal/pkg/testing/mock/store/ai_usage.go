@@ -0,0 +1,54 @@
+// ABOUTME: Mock AI usage ledger store for testing.
+// ABOUTME: Provides an in-memory implementation of AiUsageStore interface.
+
+package store
+
+import (
+	"context"
+	"sync"
+
+	"github.com/bingo-project/bingo/internal/pkg/model"
+	"github.com/bingo-project/bingo/internal/pkg/store"
+	"github.com/bingo-project/bingo/pkg/store/where"
+)
+
+// AiUsageStore implements store.AiUsageStore for testing.
+type AiUsageStore struct {
+	mu     sync.Mutex
+	rows   []*model.AiUsageM
+	nextID uint64
+}
+
+var _ store.AiUsageStore = (*AiUsageStore)(nil)
+
+// NewAiUsageStore creates a new mock AI usage store.
+func NewAiUsageStore() *AiUsageStore {
+	return &AiUsageStore{nextID: 1}
+}
+
+// Create records a usage entry.
+func (m *AiUsageStore) Create(ctx context.Context, obj *model.AiUsageM) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	obj.ID = m.nextID
+	m.nextID++
+	m.rows = append(m.rows, obj)
+
+	return nil
+}
+
+// List lists all usage entries.
+func (m *AiUsageStore) List(ctx context.Context, opts *where.Options) (int64, []*model.AiUsageM, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	rows := append([]*model.AiUsageM(nil), m.rows...)
+
+	return int64(len(rows)), rows, nil
+}
+
+// Summarize returns no aggregates.
+func (m *AiUsageStore) Summarize(ctx context.Context, groupBy string, opts *where.Options) ([]*model.AiUsageSummary, error) {
+	return nil, nil
+}
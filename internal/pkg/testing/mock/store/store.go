@@ -14,8 +14,13 @@ import (
 
 // Store implements store.IStore for testing.
 type Store struct {
-	aiProvider *AiProviderStore
-	aiModel    *AiModelStore
+	aiProvider       *AiProviderStore
+	aiModel          *AiModelStore
+	aiUserQuota      *AiUserQuotaStore
+	aiQuotaTier      *AiQuotaTierStore
+	aiUsage          *AiUsageStore
+	aiGuardrailEvent *AiGuardrailEventStore
+	aiProviderKey    *AiProviderKeyStore
 }
 
 var _ store.IStore = (*Store)(nil)
@@ -23,8 +28,13 @@ var _ store.IStore = (*Store)(nil)
 // NewStore creates a new mock store.
 func NewStore() *Store {
 	return &Store{
-		aiProvider: NewAiProviderStore(),
-		aiModel:    NewAiModelStore(),
+		aiProvider:       NewAiProviderStore(),
+		aiModel:          NewAiModelStore(),
+		aiUserQuota:      NewAiUserQuotaStore(),
+		aiQuotaTier:      NewAiQuotaTierStore(),
+		aiUsage:          NewAiUsageStore(),
+		aiGuardrailEvent: NewAiGuardrailEventStore(),
+		aiProviderKey:    NewAiProviderKeyStore(),
 	}
 }
 
@@ -145,12 +155,12 @@ func (m *Store) AiModel() store.AiModelStore {
 
 // AiQuotaTier returns the AI quota tier store.
 func (m *Store) AiQuotaTier() store.AiQuotaTierStore {
-	return nil
+	return m.aiQuotaTier
 }
 
 // AiUserQuota returns the AI user quota store.
 func (m *Store) AiUserQuota() store.AiUserQuotaStore {
-	return nil
+	return m.aiUserQuota
 }
 
 // AiSession returns the AI session store.
@@ -167,3 +177,48 @@ func (m *Store) AiMessage() store.AiMessageStore {
 func (m *Store) AiAgents() store.AiAgentStore {
 	return nil
 }
+
+// AiKnowledgeDoc returns the AI knowledge document store.
+func (m *Store) AiKnowledgeDoc() store.AiKnowledgeDocStore {
+	return nil
+}
+
+// AiKnowledgeChunk returns the AI knowledge chunk store.
+func (m *Store) AiKnowledgeChunk() store.AiKnowledgeChunkStore {
+	return nil
+}
+
+// AiUsage returns the AI usage ledger store.
+func (m *Store) AiUsage() store.AiUsageStore {
+	return m.aiUsage
+}
+
+// AiShare returns the AI session share link store.
+func (m *Store) AiShare() store.AiShareStore {
+	return nil
+}
+
+// AiFeedback returns the AI reply feedback store.
+func (m *Store) AiFeedback() store.AiFeedbackStore {
+	return nil
+}
+
+// AiAgentVersion returns the AI agent version store.
+func (m *Store) AiAgentVersion() store.AiAgentVersionStore {
+	return nil
+}
+
+// AiExperiment returns the AI A/B experiment store.
+func (m *Store) AiExperiment() store.AiExperimentStore {
+	return nil
+}
+
+// AiGuardrailEvent returns the AI guardrail event store.
+func (m *Store) AiGuardrailEvent() store.AiGuardrailEventStore {
+	return m.aiGuardrailEvent
+}
+
+// AiProviderKey returns the AI provider API key store.
+func (m *Store) AiProviderKey() store.AiProviderKeyStore {
+	return m.aiProviderKey
+}
@@ -6,7 +6,9 @@ import (
 	"github.com/bingo-project/component-base/version/verflag"
 	"github.com/spf13/cobra"
 
+	"github.com/bingo-project/bingo/internal/pkg/ai"
 	"github.com/bingo-project/bingo/internal/pkg/bootstrap"
+	"github.com/bingo-project/bingo/internal/pkg/facade"
 	"github.com/bingo-project/bingo/internal/pkg/log"
 	"github.com/bingo-project/bingo/internal/pkg/store"
 )
@@ -58,6 +60,9 @@ func initConfig() {
 	// Init store
 	_ = store.NewStore(bootstrap.InitDB())
 
+	// Init AI for queued AI jobs (optional, logs error if fails)
+	_, _ = ai.InitAI(facade.Redis, store.S, ai.CredentialsFromConfig(facade.Config.AI.Credentials))
+
 	bootstrap.InitQueueWorker()
 	bootstrap.InitScheduler()
 }
@@ -0,0 +1,82 @@
+// ABOUTME: Asynq job handler for generating AI session titles.
+// ABOUTME: Titles a session from its first exchange and pushes the update to the user's WebSocket clients.
+
+package job
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+
+	"github.com/hibiken/asynq"
+
+	"github.com/bingo-project/bingo/internal/pkg/ai"
+	"github.com/bingo-project/bingo/internal/pkg/log"
+	"github.com/bingo-project/bingo/internal/pkg/model"
+	"github.com/bingo-project/bingo/internal/pkg/notification"
+	"github.com/bingo-project/bingo/internal/pkg/store"
+	"github.com/bingo-project/bingo/internal/pkg/task"
+)
+
+// titleInputMessages is the number of opening messages a title is generated from.
+const titleInputMessages = 4
+
+func HandleAiSessionTitleTask(ctx context.Context, t *asynq.Task) error {
+	var payload task.AiSessionTitlePayload
+	if err := json.Unmarshal(t.Payload(), &payload); err != nil {
+		log.Errorw("Failed to unmarshal AI session title payload", "err", err)
+
+		return err
+	}
+
+	session, err := store.S.AiSession().GetBySessionID(ctx, payload.SessionID)
+	if err != nil {
+		log.C(ctx).Errorw("Failed to get AI session", "err", err, "session_id", payload.SessionID)
+
+		return err
+	}
+
+	// Skip sessions renamed or titled meanwhile
+	if !session.AutoTitle || session.Status == model.AiSessionStatusDeleted {
+		return nil
+	}
+
+	branch, err := store.S.AiMessage().ListBranch(ctx, session.SessionID, session.ActiveLeafID, 0)
+	if err != nil {
+		log.C(ctx).Errorw("Failed to list AI session messages", "err", err, "session_id", session.SessionID)
+
+		return err
+	}
+	slices.Reverse(branch)
+	branch = branch[:min(len(branch), titleInputMessages)]
+
+	title, err := ai.NewTitleGenerator(store.S, ai.GetRegistry()).Generate(ctx, session, payload.Model, payload.Lang, branch)
+	if err != nil {
+		log.C(ctx).Errorw("Failed to generate AI session title", "err", err, "session_id", session.SessionID, "model", payload.Model)
+
+		return err
+	}
+
+	ok, err := store.S.AiSession().UpdateAutoTitle(ctx, session.SessionID, title)
+	if err != nil {
+		log.C(ctx).Errorw("Failed to update AI session title", "err", err, "session_id", session.SessionID)
+
+		return err
+	}
+	if !ok {
+		return nil
+	}
+
+	// Let open clients refresh the session list
+	err = notification.Push(ctx, session.UID, "ai.session.updated", map[string]any{
+		"sessionId": session.SessionID,
+		"title":     title,
+	})
+	if err != nil {
+		log.C(ctx).Warnw("Failed to push AI session update", "err", err, "session_id", session.SessionID)
+	}
+
+	log.C(ctx).Infow("AI session titled", "session_id", session.SessionID, "title", title)
+
+	return nil
+}
@@ -16,4 +16,7 @@ func Register(mux *asynq.ServeMux) {
 
 	// Publish announcement.
 	mux.HandleFunc(task.AnnouncementPublish, HandleAnnouncementPublishTask)
+
+	// Title AI session.
+	mux.HandleFunc(task.AiSessionTitle, HandleAiSessionTitleTask)
 }
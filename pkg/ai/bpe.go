@@ -0,0 +1,190 @@
+// ABOUTME: Exact BPE token counting for OpenAI models with the tiktoken vocabularies.
+// ABOUTME: Loads vocabularies in the background from a local cache or download, estimates are used until then.
+
+package ai
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkoukk/tiktoken-go"
+)
+
+// OpenAI BPE encodings
+const (
+	EncodingCL100K = "cl100k_base" // GPT-4, GPT-3.5 and text-embedding models
+	EncodingO200K  = "o200k_base"  // GPT-4o, GPT-4.1, GPT-5 and o-series models
+)
+
+const (
+	// bpeDownloadTimeout bounds downloading a vocabulary missing from the local cache.
+	bpeDownloadTimeout = 30 * time.Second
+
+	// bpeRetryInterval is how long counts stay estimated after a vocabulary failed to load.
+	bpeRetryInterval = 10 * time.Minute
+)
+
+// bpeEncoding is a BPE vocabulary loaded on first use.
+type bpeEncoding struct {
+	name     string
+	enc      atomic.Pointer[tiktoken.Tiktoken]
+	mu       sync.Mutex
+	loading  bool
+	failedAt time.Time
+}
+
+var bpeEncodings = map[string]*bpeEncoding{
+	EncodingCL100K: {name: EncodingCL100K},
+	EncodingO200K:  {name: EncodingO200K},
+}
+
+func init() {
+	tiktoken.SetBpeLoader(bpeLoader{})
+}
+
+// bpeEncodingOf returns the encoding of an OpenAI model, models before GPT-4o use cl100k.
+func bpeEncodingOf(model string) string {
+	m := strings.ToLower(model)
+	switch {
+	case strings.HasPrefix(m, "gpt-4o"), strings.HasPrefix(m, "gpt-4."):
+		return EncodingO200K
+	case strings.HasPrefix(m, "gpt-4"), strings.HasPrefix(m, "gpt-3.5"), strings.HasPrefix(m, "text-embedding"):
+		return EncodingCL100K
+	default:
+		return EncodingO200K
+	}
+}
+
+// get returns the loaded encoding. Until it is loaded it returns nil and loads it in the
+// background, so no request waits for a download.
+func (e *bpeEncoding) get() *tiktoken.Tiktoken {
+	if enc := e.enc.Load(); enc != nil {
+		return enc
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.loading || time.Since(e.failedAt) < bpeRetryInterval {
+		return nil
+	}
+	e.loading = true
+	go func() {
+		_, _ = e.load()
+	}()
+
+	return nil
+}
+
+// load loads the encoding and keeps it, or records the failure.
+func (e *bpeEncoding) load() (*tiktoken.Tiktoken, error) {
+	enc, err := tiktoken.GetEncoding(e.name)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.loading = false
+	if err != nil {
+		e.failedAt = time.Now()
+
+		return nil, err
+	}
+	e.enc.Store(enc)
+
+	return enc, nil
+}
+
+// bpeLoader reads vocabularies from TIKTOKEN_CACHE_DIR, downloading and caching missing ones.
+// Offline deployments place cl100k_base.tiktoken and o200k_base.tiktoken in that directory.
+type bpeLoader struct{}
+
+// LoadTiktokenBpe parses a .tiktoken file into token ranks.
+func (bpeLoader) LoadTiktokenBpe(url string) (map[string]int, error) {
+	data, err := readBPEFile(url)
+	if err != nil {
+		return nil, err
+	}
+
+	ranks := make(map[string]int)
+	for _, line := range strings.Split(string(data), "\n") {
+		if line == "" {
+			continue
+		}
+		token, rank, ok := strings.Cut(line, " ")
+		if !ok {
+			return nil, fmt.Errorf("invalid vocabulary line %q", line)
+		}
+		b, err := base64.StdEncoding.DecodeString(token)
+		if err != nil {
+			return nil, err
+		}
+		r, err := strconv.Atoi(rank)
+		if err != nil {
+			return nil, err
+		}
+		ranks[string(b)] = r
+	}
+
+	return ranks, nil
+}
+
+// readBPEFile returns the cached vocabulary of url, by file name or by the SHA-1 key tiktoken
+// uses, and downloads it into the cache when missing.
+func readBPEFile(url string) ([]byte, error) {
+	dir := bpeCacheDir()
+	for _, name := range []string{path.Base(url), fmt.Sprintf("%x", sha1.Sum([]byte(url)))} {
+		if data, err := os.ReadFile(filepath.Join(dir, name)); err == nil {
+			return data, nil
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), bpeDownloadTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("download vocabulary: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("download vocabulary: status %d", resp.StatusCode)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("download vocabulary: %w", err)
+	}
+
+	// Caching is best effort, the vocabulary is usable either way
+	if err := os.MkdirAll(dir, 0o755); err == nil {
+		tmp := filepath.Join(dir, path.Base(url)+".tmp")
+		if err := os.WriteFile(tmp, data, 0o644); err == nil {
+			_ = os.Rename(tmp, filepath.Join(dir, path.Base(url)))
+		}
+	}
+
+	return data, nil
+}
+
+// bpeCacheDir returns the vocabulary cache directory, honoring tiktoken's environment variables.
+func bpeCacheDir() string {
+	for _, env := range []string{"TIKTOKEN_CACHE_DIR", "DATA_GYM_CACHE_DIR"} {
+		if dir := strings.TrimSpace(os.Getenv(env)); dir != "" {
+			return dir
+		}
+	}
+
+	return filepath.Join(os.TempDir(), "data-gym-cache")
+}
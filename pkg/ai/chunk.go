@@ -0,0 +1,71 @@
+// ABOUTME: Text chunking for knowledge base indexing.
+// ABOUTME: Splits documents into overlapping chunks at natural boundaries.
+
+package ai
+
+import "strings"
+
+// Default chunking parameters, measured in characters
+const (
+	DefaultChunkSize    = 800
+	DefaultChunkOverlap = 100
+)
+
+// SplitText splits text into chunks of at most size characters.
+// Chunks prefer to end at paragraph, line or sentence boundaries and
+// consecutive chunks share up to overlap characters.
+func SplitText(text string, size, overlap int) []string {
+	if size <= 0 {
+		size = DefaultChunkSize
+	}
+	if overlap < 0 || overlap >= size {
+		overlap = 0
+	}
+
+	runes := []rune(strings.TrimSpace(text))
+	var chunks []string
+	for start := 0; start < len(runes); {
+		end := min(start+size, len(runes))
+		if end < len(runes) {
+			end = breakPoint(runes, start, end)
+		}
+		if chunk := strings.TrimSpace(string(runes[start:end])); chunk != "" {
+			chunks = append(chunks, chunk)
+		}
+		if end >= len(runes) {
+			break
+		}
+
+		next := end - overlap
+		if next <= start {
+			next = end
+		}
+		start = next
+	}
+
+	return chunks
+}
+
+// breakPoint finds the best chunk end within the last fifth of runes[start:end].
+// Returns end unchanged if no boundary is found.
+func breakPoint(runes []rune, start, end int) int {
+	lower := start + (end-start)*4/5
+	if lower <= start {
+		return end
+	}
+
+	boundaries := []func(i int) bool{
+		func(i int) bool { return runes[i-1] == '\n' && i >= 2 && runes[i-2] == '\n' },
+		func(i int) bool { return runes[i-1] == '\n' },
+		func(i int) bool { return strings.ContainsRune("。！？；.!?;", runes[i-1]) },
+	}
+	for _, isBoundary := range boundaries {
+		for i := end; i > lower; i-- {
+			if isBoundary(i) {
+				return i
+			}
+		}
+	}
+
+	return end
+}
@@ -0,0 +1,45 @@
+// ABOUTME: Unit tests for text chunking.
+// ABOUTME: Tests chunk sizes, overlap and boundary selection.
+
+package ai
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitText(t *testing.T) {
+	assert.Empty(t, SplitText("   ", 100, 10))
+	assert.Equal(t, []string{"hello world"}, SplitText("  hello world  ", 100, 10))
+
+	text := strings.Repeat("a", 250)
+	chunks := SplitText(text, 100, 20)
+	require.Len(t, chunks, 3)
+	for _, c := range chunks {
+		assert.LessOrEqual(t, utf8.RuneCountInString(c), 100)
+	}
+	assert.Equal(t, chunks[0][80:], chunks[1][:20])
+}
+
+func TestSplitTextPrefersBoundaries(t *testing.T) {
+	para1 := strings.Repeat("x", 85)
+	para2 := strings.Repeat("y", 50)
+	chunks := SplitText(para1+"\n\n"+para2, 100, 0)
+	require.Len(t, chunks, 2)
+	assert.Equal(t, para1, chunks[0])
+	assert.Equal(t, para2, chunks[1])
+
+	sentence := strings.Repeat("字", 90) + "。"
+	chunks = SplitText(sentence+strings.Repeat("文", 50), 100, 0)
+	require.Len(t, chunks, 2)
+	assert.Equal(t, sentence, chunks[0])
+}
+
+func TestSplitTextInvalidOverlap(t *testing.T) {
+	chunks := SplitText(strings.Repeat("a", 30), 10, 10)
+	assert.Len(t, chunks, 3)
+}
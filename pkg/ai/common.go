@@ -6,9 +6,14 @@ package ai
 import (
 	"crypto/rand"
 	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
 	"time"
 
+	"github.com/cloudwego/eino/components/model"
 	"github.com/cloudwego/eino/schema"
+	"github.com/eino-contrib/jsonschema"
 )
 
 // GenerateID generates a unique ID for chat completions.
@@ -29,20 +34,102 @@ func ConvertMessages(msgs []Message) []*schema.Message {
 			role = schema.System
 		case RoleAssistant:
 			role = schema.Assistant
+		case RoleTool:
+			role = schema.Tool
 		}
-		result[i] = &schema.Message{
-			Role:    role,
-			Content: m.Content,
+		msg := &schema.Message{
+			Role:      role,
+			Content:   m.Content,
+			ToolCalls: toSchemaToolCalls(m.ToolCalls),
 		}
+		if role == schema.User && len(m.Parts) > 0 {
+			msg.Content = ""
+			msg.UserInputMultiContent = ConvertContentParts(m.Parts)
+		}
+		if role == schema.Tool {
+			msg.ToolCallID = m.ToolCallID
+			msg.ToolName = m.Name
+		} else {
+			msg.Name = m.Name
+		}
+		result[i] = msg
 	}
 
 	return result
 }
 
+// ConvertTools converts ai.Tool definitions to Eino tool infos.
+func ConvertTools(tools []Tool) ([]*schema.ToolInfo, error) {
+	result := make([]*schema.ToolInfo, 0, len(tools))
+	for _, t := range tools {
+		info := &schema.ToolInfo{
+			Name: t.Function.Name,
+			Desc: t.Function.Description,
+		}
+		if len(t.Function.Parameters) > 0 {
+			raw, err := json.Marshal(t.Function.Parameters)
+			if err != nil {
+				return nil, fmt.Errorf("marshal parameters of tool %s: %w", t.Function.Name, err)
+			}
+			var s jsonschema.Schema
+			if err := json.Unmarshal(raw, &s); err != nil {
+				return nil, fmt.Errorf("invalid parameters schema of tool %s: %w", t.Function.Name, err)
+			}
+			info.ParamsOneOf = schema.NewParamsOneOfByJSONSchema(&s)
+		}
+		result = append(result, info)
+	}
+
+	return result, nil
+}
+
+// BuildOptions builds Eino model options from a chat request.
+func BuildOptions(req *ChatRequest) ([]model.Option, error) {
+	opts := []model.Option{
+		model.WithModel(req.Model),
+	}
+	if req.MaxTokens > 0 {
+		opts = append(opts, model.WithMaxTokens(req.MaxTokens))
+	}
+	if req.Temperature > 0 {
+		opts = append(opts, model.WithTemperature(float32(req.Temperature)))
+	}
+
+	if len(req.Tools) > 0 {
+		tools, err := ConvertTools(req.Tools)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, model.WithTools(tools))
+
+		if req.ToolChoice != nil {
+			switch req.ToolChoice.Mode {
+			case ToolChoiceNone:
+				opts = append(opts, model.WithToolChoice(schema.ToolChoiceForbidden))
+			case ToolChoiceRequired:
+				if req.ToolChoice.Name != "" {
+					opts = append(opts, model.WithToolChoice(schema.ToolChoiceForced, req.ToolChoice.Name))
+				} else {
+					opts = append(opts, model.WithToolChoice(schema.ToolChoiceForced))
+				}
+			default:
+				opts = append(opts, model.WithToolChoice(schema.ToolChoiceAllowed))
+			}
+		}
+	}
+
+	return opts, nil
+}
+
 // ConvertResponse converts Eino response to ai.ChatResponse.
 func ConvertResponse(resp *schema.Message, modelName string) *ChatResponse {
 	usage := ExtractUsage(resp)
 
+	var finishReason string
+	if resp.ResponseMeta != nil {
+		finishReason = resp.ResponseMeta.FinishReason
+	}
+
 	return &ChatResponse{
 		ID:      GenerateID(),
 		Object:  "chat.completion",
@@ -52,10 +139,11 @@ func ConvertResponse(resp *schema.Message, modelName string) *ChatResponse {
 			{
 				Index: 0,
 				Message: Message{
-					Role:    RoleAssistant,
-					Content: resp.Content,
+					Role:      RoleAssistant,
+					Content:   resp.Content,
+					ToolCalls: fromSchemaToolCalls(resp.ToolCalls),
 				},
-				FinishReason: "stop",
+				FinishReason: NormalizeFinishReason(finishReason, len(resp.ToolCalls) > 0),
 			},
 		},
 		Usage: usage,
@@ -73,8 +161,9 @@ func ConvertStreamChunk(msg *schema.Message, modelName string, id string) *Strea
 			{
 				Index: 0,
 				Delta: &Message{
-					Role:    RoleAssistant,
-					Content: msg.Content,
+					Role:      RoleAssistant,
+					Content:   msg.Content,
+					ToolCalls: fromSchemaToolCalls(msg.ToolCalls),
 				},
 			},
 		},
@@ -104,3 +193,68 @@ func ExtractUsage(msg *schema.Message) Usage {
 
 	return Usage{}
 }
+
+// NormalizeFinishReason maps provider-specific finish reasons to OpenAI-compatible values.
+func NormalizeFinishReason(reason string, hasToolCalls bool) string {
+	if hasToolCalls {
+		return FinishReasonToolCalls
+	}
+
+	switch strings.ToLower(reason) {
+	case "length", "max_tokens":
+		return FinishReasonLength
+	case "tool_calls", "tool_use", "function_call":
+		return FinishReasonToolCalls
+	case "content_filter", "safety", "blocklist", "prohibited_content":
+		return FinishReasonContentFilter
+	default:
+		return FinishReasonStop
+	}
+}
+
+// toSchemaToolCalls converts ai.ToolCall to schema.ToolCall.
+func toSchemaToolCalls(calls []ToolCall) []schema.ToolCall {
+	if len(calls) == 0 {
+		return nil
+	}
+
+	result := make([]schema.ToolCall, len(calls))
+	for i, c := range calls {
+		typ := c.Type
+		if typ == "" {
+			typ = ToolTypeFunction
+		}
+		result[i] = schema.ToolCall{
+			ID:   c.ID,
+			Type: typ,
+			Function: schema.FunctionCall{
+				Name:      c.Function.Name,
+				Arguments: c.Function.Arguments,
+			},
+		}
+	}
+
+	return result
+}
+
+// fromSchemaToolCalls converts schema.ToolCall to ai.ToolCall.
+func fromSchemaToolCalls(calls []schema.ToolCall) []ToolCall {
+	if len(calls) == 0 {
+		return nil
+	}
+
+	result := make([]ToolCall, len(calls))
+	for i, c := range calls {
+		result[i] = ToolCall{
+			Index: c.Index,
+			ID:    c.ID,
+			Type:  c.Type,
+			Function: FunctionCall{
+				Name:      c.Function.Name,
+				Arguments: c.Function.Arguments,
+			},
+		}
+	}
+
+	return result
+}
@@ -161,3 +161,119 @@ func TestConvertStreamChunk(t *testing.T) {
 		t.Errorf("Expected 15 total tokens, got %d", chunk.Usage.TotalTokens)
 	}
 }
+
+func TestConvertMessagesWithToolCalls(t *testing.T) {
+	msgs := []Message{
+		{Role: RoleUser, Content: "What's the weather?"},
+		{Role: RoleAssistant, ToolCalls: []ToolCall{
+			{ID: "call_1", Function: FunctionCall{Name: "get_weather", Arguments: `{"city":"Paris"}`}},
+		}},
+		{Role: RoleTool, Name: "get_weather", ToolCallID: "call_1", Content: `{"temp":20}`},
+	}
+
+	converted := ConvertMessages(msgs)
+
+	if len(converted[1].ToolCalls) != 1 {
+		t.Fatalf("Expected 1 tool call, got %d", len(converted[1].ToolCalls))
+	}
+	if converted[1].ToolCalls[0].Type != ToolTypeFunction {
+		t.Errorf("Expected default tool type 'function', got '%s'", converted[1].ToolCalls[0].Type)
+	}
+	if converted[2].Role != schema.Tool {
+		t.Errorf("Expected Tool role, got %v", converted[2].Role)
+	}
+	if converted[2].ToolCallID != "call_1" || converted[2].ToolName != "get_weather" {
+		t.Errorf("Unexpected tool message: id=%s name=%s", converted[2].ToolCallID, converted[2].ToolName)
+	}
+}
+
+func TestConvertTools(t *testing.T) {
+	tools := []Tool{
+		{Type: ToolTypeFunction, Function: FunctionDefinition{
+			Name:        "get_weather",
+			Description: "Get weather",
+			Parameters: map[string]any{
+				"type":       "object",
+				"properties": map[string]any{"city": map[string]any{"type": "string"}},
+				"required":   []string{"city"},
+			},
+		}},
+	}
+
+	infos, err := ConvertTools(tools)
+	if err != nil {
+		t.Fatalf("ConvertTools failed: %v", err)
+	}
+	if len(infos) != 1 || infos[0].Name != "get_weather" {
+		t.Fatalf("Unexpected tool infos: %+v", infos)
+	}
+
+	s, err := infos[0].ToJSONSchema()
+	if err != nil {
+		t.Fatalf("ToJSONSchema failed: %v", err)
+	}
+	if len(s.Required) != 1 || s.Required[0] != "city" {
+		t.Errorf("Expected required [city], got %v", s.Required)
+	}
+}
+
+func TestConvertResponseWithToolCalls(t *testing.T) {
+	resp := &schema.Message{
+		Role: schema.Assistant,
+		ToolCalls: []schema.ToolCall{
+			{ID: "call_1", Type: "function", Function: schema.FunctionCall{Name: "get_weather", Arguments: "{}"}},
+		},
+	}
+
+	result := ConvertResponse(resp, "gpt-4o")
+
+	if result.Choices[0].FinishReason != FinishReasonToolCalls {
+		t.Errorf("Expected finish reason 'tool_calls', got '%s'", result.Choices[0].FinishReason)
+	}
+	if len(result.Choices[0].Message.ToolCalls) != 1 {
+		t.Fatalf("Expected 1 tool call, got %d", len(result.Choices[0].Message.ToolCalls))
+	}
+}
+
+func TestNormalizeFinishReason(t *testing.T) {
+	cases := map[string]string{
+		"":           FinishReasonStop,
+		"end_turn":   FinishReasonStop,
+		"STOP":       FinishReasonStop,
+		"max_tokens": FinishReasonLength,
+		"MAX_TOKENS": FinishReasonLength,
+		"tool_use":   FinishReasonToolCalls,
+		"SAFETY":     FinishReasonContentFilter,
+	}
+
+	for in, want := range cases {
+		if got := NormalizeFinishReason(in, false); got != want {
+			t.Errorf("NormalizeFinishReason(%q) = %q, want %q", in, got, want)
+		}
+	}
+
+	if got := NormalizeFinishReason("stop", true); got != FinishReasonToolCalls {
+		t.Errorf("Expected tool_calls when tool calls present, got %q", got)
+	}
+}
+
+func TestConvertMessagesWithContentParts(t *testing.T) {
+	msgs := []Message{
+		{Role: RoleUser, Content: "What is in this image?", Parts: []ContentPart{
+			{Type: ContentPartText, Text: "What is in this image?"},
+			{Type: ContentPartImageURL, ImageURL: &ImageURL{URL: "https://example.com/cat.png"}},
+		}},
+	}
+
+	converted := ConvertMessages(msgs)
+
+	if converted[0].Content != "" {
+		t.Errorf("Expected empty text content for multimodal message, got '%s'", converted[0].Content)
+	}
+	if len(converted[0].UserInputMultiContent) != 2 {
+		t.Fatalf("Expected 2 input parts, got %d", len(converted[0].UserInputMultiContent))
+	}
+	if converted[0].UserInputMultiContent[1].Type != schema.ChatMessagePartTypeImageURL {
+		t.Errorf("Expected image part, got %v", converted[0].UserInputMultiContent[1].Type)
+	}
+}
@@ -0,0 +1,115 @@
+// ABOUTME: Multimodal message content parts.
+// ABOUTME: Defines text and image parts and converts them to Eino input parts.
+
+package ai
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cloudwego/eino/schema"
+)
+
+// Content part types
+const (
+	ContentPartText     = "text"
+	ContentPartImageURL = "image_url"
+)
+
+// ContentPart represents a single part of a multimodal message
+type ContentPart struct {
+	Type     string    `json:"type"`
+	Text     string    `json:"text,omitempty"`
+	ImageURL *ImageURL `json:"image_url,omitempty"`
+}
+
+// ImageURL references an image by http(s) URL or data URL
+type ImageURL struct {
+	URL    string `json:"url"`
+	Detail string `json:"detail,omitempty"` // auto, low, high
+}
+
+// TextOf joins the text parts of a multimodal message
+func TextOf(parts []ContentPart) string {
+	var texts []string
+	for _, p := range parts {
+		if p.Type == ContentPartText && p.Text != "" {
+			texts = append(texts, p.Text)
+		}
+	}
+
+	return strings.Join(texts, "\n")
+}
+
+// ParseDataURL splits a base64 data URL into its MIME type and raw base64 data.
+// Returns ok=false if the URL is not a base64 data URL.
+func ParseDataURL(url string) (mimeType, data string, ok bool) {
+	rest, found := strings.CutPrefix(url, "data:")
+	if !found {
+		return "", "", false
+	}
+
+	meta, data, found := strings.Cut(rest, ",")
+	if !found {
+		return "", "", false
+	}
+
+	mimeType, found = strings.CutSuffix(meta, ";base64")
+	if !found || mimeType == "" {
+		return "", "", false
+	}
+
+	return mimeType, data, true
+}
+
+// Validate checks that the part has a supported type and the matching payload
+func (p ContentPart) Validate() error {
+	switch p.Type {
+	case ContentPartText:
+		return nil
+	case ContentPartImageURL:
+		if p.ImageURL == nil || p.ImageURL.URL == "" {
+			return fmt.Errorf("image_url part must have a url")
+		}
+
+		return nil
+	default:
+		return fmt.Errorf("unsupported content part type: %s", p.Type)
+	}
+}
+
+// ConvertContentParts converts ai.ContentPart to Eino user input parts.
+// Invalid parts are skipped, callers should validate them beforehand.
+func ConvertContentParts(parts []ContentPart) []schema.MessageInputPart {
+	result := make([]schema.MessageInputPart, 0, len(parts))
+	for _, p := range parts {
+		if p.Validate() != nil {
+			continue
+		}
+
+		if p.Type == ContentPartText {
+			result = append(result, schema.MessageInputPart{
+				Type: schema.ChatMessagePartTypeText,
+				Text: p.Text,
+			})
+
+			continue
+		}
+
+		image := &schema.MessageInputImage{Detail: schema.ImageURLDetail(p.ImageURL.Detail)}
+		if mimeType, data, ok := ParseDataURL(p.ImageURL.URL); ok {
+			image.MIMEType = mimeType
+			image.Base64Data = &data
+		} else {
+			url := p.ImageURL.URL
+			image.URL = &url
+		}
+
+		result = append(result, schema.MessageInputPart{
+			Type:  schema.ChatMessagePartTypeImageURL,
+			Image: image,
+		})
+	}
+
+	return result
+}
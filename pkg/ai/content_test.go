@@ -0,0 +1,66 @@
+// ABOUTME: Unit tests for multimodal content parts.
+// ABOUTME: Tests data URL parsing and conversion to Eino input parts.
+
+package ai
+
+import (
+	"testing"
+
+	"github.com/cloudwego/eino/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDataURL(t *testing.T) {
+	mimeType, data, ok := ParseDataURL("data:image/png;base64,iVBORw0KGgo=")
+	require.True(t, ok)
+	assert.Equal(t, "image/png", mimeType)
+	assert.Equal(t, "iVBORw0KGgo=", data)
+
+	_, _, ok = ParseDataURL("https://example.com/cat.png")
+	assert.False(t, ok)
+
+	_, _, ok = ParseDataURL("data:text/plain,hello")
+	assert.False(t, ok)
+}
+
+func TestTextOf(t *testing.T) {
+	parts := []ContentPart{
+		{Type: ContentPartText, Text: "What is"},
+		{Type: ContentPartImageURL, ImageURL: &ImageURL{URL: "https://example.com/cat.png"}},
+		{Type: ContentPartText, Text: "in this image?"},
+	}
+
+	assert.Equal(t, "What is\nin this image?", TextOf(parts))
+}
+
+func TestContentPartValidate(t *testing.T) {
+	assert.NoError(t, ContentPart{Type: ContentPartText, Text: "hi"}.Validate())
+	assert.NoError(t, ContentPart{Type: ContentPartImageURL, ImageURL: &ImageURL{URL: "https://example.com/cat.png"}}.Validate())
+	assert.Error(t, ContentPart{Type: ContentPartImageURL}.Validate())
+	assert.Error(t, ContentPart{Type: "audio_url"}.Validate())
+}
+
+func TestConvertContentParts(t *testing.T) {
+	parts := []ContentPart{
+		{Type: ContentPartText, Text: "Describe"},
+		{Type: ContentPartImageURL, ImageURL: &ImageURL{URL: "https://example.com/cat.png", Detail: "high"}},
+		{Type: ContentPartImageURL, ImageURL: &ImageURL{URL: "data:image/jpeg;base64,/9j/4AAQ"}},
+		{Type: ContentPartImageURL}, // invalid, skipped
+	}
+
+	result := ConvertContentParts(parts)
+	require.Len(t, result, 3)
+
+	assert.Equal(t, schema.ChatMessagePartTypeText, result[0].Type)
+	assert.Equal(t, "Describe", result[0].Text)
+
+	require.NotNil(t, result[1].Image.URL)
+	assert.Equal(t, "https://example.com/cat.png", *result[1].Image.URL)
+	assert.Equal(t, schema.ImageURLDetailHigh, result[1].Image.Detail)
+
+	assert.Nil(t, result[2].Image.URL)
+	require.NotNil(t, result[2].Image.Base64Data)
+	assert.Equal(t, "/9j/4AAQ", *result[2].Image.Base64Data)
+	assert.Equal(t, "image/jpeg", result[2].Image.MIMEType)
+}
@@ -0,0 +1,41 @@
+// ABOUTME: Embedding request/response types and the Embedder capability.
+// ABOUTME: Providers that support embeddings implement Embedder alongside Provider.
+
+package ai
+
+import "context"
+
+// Model types
+const (
+	ModelTypeChat      = "chat"
+	ModelTypeEmbedding = "embedding"
+)
+
+// Embedder is an optional provider capability for text embeddings
+type Embedder interface {
+	// Embed creates embedding vectors for the input texts
+	Embed(ctx context.Context, req *EmbeddingRequest) (*EmbeddingResponse, error)
+}
+
+// EmbeddingRequest represents an embedding request
+type EmbeddingRequest struct {
+	Model      string   `json:"model"`
+	Input      []string `json:"input"`
+	Dimensions int      `json:"dimensions,omitempty"`
+	UID        string   `json:"-"` // Internal use only
+}
+
+// EmbeddingResponse represents an embedding response (OpenAI-compatible)
+type EmbeddingResponse struct {
+	Object string      `json:"object"`
+	Data   []Embedding `json:"data"`
+	Model  string      `json:"model"`
+	Usage  Usage       `json:"usage"`
+}
+
+// Embedding is a single embedding vector
+type Embedding struct {
+	Object    string    `json:"object"`
+	Index     int       `json:"index"`
+	Embedding []float32 `json:"embedding"`
+}
@@ -0,0 +1,216 @@
+// ABOUTME: Text guards for chat input and output filtering.
+// ABOUTME: Provides keyword and regex blocklists, PII detection, prompt-injection heuristics and redaction.
+
+package ai
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Guard kinds
+const (
+	GuardBlocklist  = "blocklist"
+	GuardPII        = "pii"
+	GuardInjection  = "injection"
+	GuardModeration = "moderation" // Checked by a model, not by a Guard
+)
+
+// Guard actions
+const (
+	GuardActionBlock  = "block"
+	GuardActionRedact = "redact"
+	GuardActionFlag   = "flag"
+)
+
+// PII types
+const (
+	PIIEmail    = "email"
+	PIIPhone    = "phone"
+	PIIIDCard   = "id_card"
+	PIIBankCard = "bank_card"
+)
+
+// GuardFinding is a match of a guard in a text.
+type GuardFinding struct {
+	Guard string // Guard kind
+	Rule  string // Matched keyword or pattern, PII type or injection heuristic
+	Start int    // Byte offset of the match
+	End   int
+}
+
+// Placeholder returns the text replacing the finding when redacted.
+func (f GuardFinding) Placeholder() string {
+	if f.Guard == GuardPII {
+		return "[" + strings.ToUpper(f.Rule) + "]"
+	}
+
+	return "[REDACTED]"
+}
+
+// Guard checks texts for unwanted content.
+type Guard interface {
+	// Check returns the matches in text ordered by position, overlapping matches are dropped.
+	Check(text string) []GuardFinding
+}
+
+type guardRule struct {
+	name  string
+	re    *regexp.Regexp
+	valid func(match string) bool // Optional check of a match, e.g. a checksum
+}
+
+type regexGuard struct {
+	kind  string
+	rules []guardRule
+}
+
+func (g *regexGuard) Check(text string) []GuardFinding {
+	var findings []GuardFinding
+	for _, r := range g.rules {
+		for _, loc := range r.re.FindAllStringIndex(text, -1) {
+			if loc[0] == loc[1] || (r.valid != nil && !r.valid(text[loc[0]:loc[1]])) {
+				continue
+			}
+			findings = append(findings, GuardFinding{Guard: g.kind, Rule: r.name, Start: loc[0], End: loc[1]})
+		}
+	}
+
+	return dropOverlaps(findings)
+}
+
+// NewBlocklistGuard creates a guard matching case-insensitive keywords and regular expressions.
+func NewBlocklistGuard(keywords []string, patterns []string) (Guard, error) {
+	g := &regexGuard{kind: GuardBlocklist}
+	for _, k := range keywords {
+		if strings.TrimSpace(k) == "" {
+			continue
+		}
+		g.rules = append(g.rules, guardRule{name: k, re: regexp.MustCompile("(?i)" + regexp.QuoteMeta(k))})
+	}
+	for _, p := range patterns {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid blocklist pattern %q: %w", p, err)
+		}
+		g.rules = append(g.rules, guardRule{name: p, re: re})
+	}
+
+	return g, nil
+}
+
+// piiRules detects personal data. Identity card numbers are listed before bank cards, so an
+// 18 digit identity number is not also reported as a card number.
+var piiRules = map[string][]guardRule{
+	PIIEmail: {{name: PIIEmail, re: regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)}},
+	PIIPhone: {
+		{name: PIIPhone, re: regexp.MustCompile(`\b1[3-9]\d{9}\b`)},          // Mainland China mobile
+		{name: PIIPhone, re: regexp.MustCompile(`\+\d{1,3}[- ]?\d{6,14}\b`)}, // International format
+	},
+	PIIIDCard: {{name: PIIIDCard, re: regexp.MustCompile(`\b[1-9]\d{5}(?:19|20)\d{2}(?:0[1-9]|1[0-2])(?:0[1-9]|[12]\d|3[01])\d{3}[\dXx]\b`)}},
+	PIIBankCard: {{name: PIIBankCard, re: regexp.MustCompile(`\b\d(?:[ -]?\d){12,18}\b`), valid: func(s string) bool {
+		return luhnValid(strings.NewReplacer(" ", "", "-", "").Replace(s))
+	}}},
+}
+
+// PIITypes lists the supported PII types in detection order.
+var PIITypes = []string{PIIEmail, PIIIDCard, PIIPhone, PIIBankCard}
+
+// NewPIIGuard creates a guard detecting the given PII types, all types when empty.
+func NewPIIGuard(types []string) (Guard, error) {
+	if len(types) == 0 {
+		types = PIITypes
+	}
+
+	g := &regexGuard{kind: GuardPII}
+	for _, t := range PIITypes {
+		if !slices.Contains(types, t) {
+			continue
+		}
+		g.rules = append(g.rules, piiRules[t]...)
+	}
+	for _, t := range types {
+		if _, ok := piiRules[t]; !ok {
+			return nil, fmt.Errorf("unsupported PII type %q", t)
+		}
+	}
+
+	return g, nil
+}
+
+// injectionRules are phrases common in prompt-injection attempts, in English and Chinese.
+var injectionRules = []guardRule{
+	{name: "ignore_instructions", re: regexp.MustCompile(`(?i)\b(ignore|disregard|forget)\s+(all\s+|any\s+|everything\s+)?(of\s+)?(the\s+|your\s+)?(previous|prior|above|earlier|preceding)\s+(instructions|prompts?|rules|directions)`)},
+	{name: "ignore_instructions", re: regexp.MustCompile(`(忽略|无视|忘记|忘掉)(你)?(之前|以上|上面|前面|先前)(的)?(所有|全部)?(的)?(指令|指示|规则|提示|要求|设定)`)},
+	{name: "reveal_prompt", re: regexp.MustCompile(`(?i)\b(reveal|show|print|repeat|output|tell\s+me)\s+(me\s+)?(your|the)\s+(system\s+prompt|initial\s+instructions|hidden\s+(prompt|instructions))`)},
+	{name: "reveal_prompt", re: regexp.MustCompile(`(输出|显示|告诉我|重复|打印|泄露)(一下)?(你的)?(系统提示词|系统提示|初始指令|系统指令|隐藏指令)`)},
+	{name: "jailbreak_persona", re: regexp.MustCompile(`(?i)\b(you\s+are\s+now\s+(DAN|jailbroken|unrestricted|unfiltered)|(enable|enter|activate)\s+developer\s+mode|do\s+anything\s+now)\b`)},
+	{name: "jailbreak_persona", re: regexp.MustCompile(`(你现在是|扮演|假装你是)(一个)?(没有|不受|无)(任何)?(限制|约束|审查)`)},
+}
+
+// NewInjectionGuard creates a guard matching common prompt-injection phrases.
+func NewInjectionGuard() Guard {
+	return &regexGuard{kind: GuardInjection, rules: injectionRules}
+}
+
+// Redact replaces the findings in text with their placeholders.
+func Redact(text string, findings []GuardFinding) string {
+	findings = dropOverlaps(findings)
+	if len(findings) == 0 {
+		return text
+	}
+
+	var sb strings.Builder
+	last := 0
+	for _, f := range findings {
+		sb.WriteString(text[last:f.Start])
+		sb.WriteString(f.Placeholder())
+		last = f.End
+	}
+	sb.WriteString(text[last:])
+
+	return sb.String()
+}
+
+// dropOverlaps sorts findings by position and drops those overlapping an earlier one,
+// keeping the first rule that matched at a position.
+func dropOverlaps(findings []GuardFinding) []GuardFinding {
+	sort.SliceStable(findings, func(i, j int) bool { return findings[i].Start < findings[j].Start })
+
+	ret := findings[:0]
+	end := -1
+	for _, f := range findings {
+		if f.Start < end {
+			continue
+		}
+		ret = append(ret, f)
+		end = f.End
+	}
+
+	return ret
+}
+
+// luhnValid reports whether a digit string passes the Luhn checksum of card numbers.
+func luhnValid(digits string) bool {
+	sum := 0
+	double := false
+	for i := len(digits) - 1; i >= 0; i-- {
+		d := int(digits[i] - '0')
+		if d < 0 || d > 9 {
+			return false
+		}
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+
+	return sum%10 == 0
+}
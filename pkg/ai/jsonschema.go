@@ -0,0 +1,319 @@
+// ABOUTME: Minimal JSON Schema validation for structured output.
+// ABOUTME: Checks decoded JSON values against the schema keywords structured output uses.
+
+package ai
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// ValidateJSONSchema checks a decoded JSON value against a JSON Schema. It covers the
+// keywords of structured output schemas: type, enum, const, properties, required,
+// additionalProperties, items, anyOf, oneOf, allOf, local $ref, string length and
+// pattern, numeric bounds and array length. Other keywords are not checked.
+func ValidateJSONSchema(value any, schema map[string]any) error {
+	v := &schemaValidator{root: schema}
+
+	return v.validate("$", value, schema, 0)
+}
+
+// maxSchemaDepth bounds $ref recursion of self-referencing schemas.
+const maxSchemaDepth = 64
+
+type schemaValidator struct {
+	root map[string]any
+}
+
+func (v *schemaValidator) validate(path string, value any, schema map[string]any, depth int) error {
+	if depth > maxSchemaDepth {
+		return fmt.Errorf("%s: schema nested too deeply", path)
+	}
+
+	if ref, ok := schema["$ref"].(string); ok {
+		target, err := v.resolve(ref)
+		if err != nil {
+			return err
+		}
+
+		return v.validate(path, value, target, depth+1)
+	}
+
+	if t, ok := schema["type"]; ok {
+		if err := checkType(path, value, t); err != nil {
+			return err
+		}
+	}
+	if enum, ok := schema["enum"].([]any); ok {
+		if !slicesContainJSON(enum, value) {
+			return fmt.Errorf("%s: must be one of %s", path, compactJSON(enum))
+		}
+	}
+	if c, ok := schema["const"]; ok && !equalJSON(c, value) {
+		return fmt.Errorf("%s: must be %s", path, compactJSON(c))
+	}
+
+	for _, key := range []string{"allOf", "anyOf", "oneOf"} {
+		subs, ok := schema[key].([]any)
+		if !ok {
+			continue
+		}
+		matched := 0
+		var firstErr error
+		for _, s := range subs {
+			sub, _ := s.(map[string]any)
+			if err := v.validate(path, value, sub, depth+1); err != nil {
+				if key == "allOf" {
+					return err
+				}
+				if firstErr == nil {
+					firstErr = err
+				}
+
+				continue
+			}
+			matched++
+		}
+		switch {
+		case key == "anyOf" && matched == 0:
+			return fmt.Errorf("%s: matches none of anyOf: %w", path, firstErr)
+		case key == "oneOf" && matched != 1:
+			return fmt.Errorf("%s: must match exactly one of oneOf, matched %d", path, matched)
+		}
+	}
+
+	switch val := value.(type) {
+	case map[string]any:
+		return v.validateObject(path, val, schema, depth)
+	case []any:
+		return v.validateArray(path, val, schema, depth)
+	case string:
+		return validateString(path, val, schema)
+	case float64:
+		return validateNumber(path, val, schema)
+	}
+
+	return nil
+}
+
+func (v *schemaValidator) validateObject(path string, obj map[string]any, schema map[string]any, depth int) error {
+	if required, ok := schema["required"].([]any); ok {
+		for _, r := range required {
+			name, _ := r.(string)
+			if _, ok := obj[name]; !ok {
+				return fmt.Errorf("%s: missing required property %q", path, name)
+			}
+		}
+	}
+
+	props, _ := schema["properties"].(map[string]any)
+	for name, val := range obj {
+		if p, ok := props[name].(map[string]any); ok {
+			if err := v.validate(path+"."+name, val, p, depth+1); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		switch extra := schema["additionalProperties"].(type) {
+		case bool:
+			if !extra {
+				return fmt.Errorf("%s: unexpected property %q", path, name)
+			}
+		case map[string]any:
+			if err := v.validate(path+"."+name, val, extra, depth+1); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (v *schemaValidator) validateArray(path string, arr []any, schema map[string]any, depth int) error {
+	if n, ok := schemaNumber(schema, "minItems"); ok && float64(len(arr)) < n {
+		return fmt.Errorf("%s: must have at least %v items", path, n)
+	}
+	if n, ok := schemaNumber(schema, "maxItems"); ok && float64(len(arr)) > n {
+		return fmt.Errorf("%s: must have at most %v items", path, n)
+	}
+
+	if items, ok := schema["items"].(map[string]any); ok {
+		for i, item := range arr {
+			if err := v.validate(fmt.Sprintf("%s[%d]", path, i), item, items, depth+1); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func validateString(path string, s string, schema map[string]any) error {
+	length := float64(utf8.RuneCountInString(s))
+	if n, ok := schemaNumber(schema, "minLength"); ok && length < n {
+		return fmt.Errorf("%s: must be at least %v characters", path, n)
+	}
+	if n, ok := schemaNumber(schema, "maxLength"); ok && length > n {
+		return fmt.Errorf("%s: must be at most %v characters", path, n)
+	}
+	if pattern, ok := schema["pattern"].(string); ok {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return fmt.Errorf("%s: invalid pattern %q in schema", path, pattern)
+		}
+		if !re.MatchString(s) {
+			return fmt.Errorf("%s: must match pattern %q", path, pattern)
+		}
+	}
+
+	return nil
+}
+
+func validateNumber(path string, n float64, schema map[string]any) error {
+	if m, ok := schemaNumber(schema, "minimum"); ok && n < m {
+		return fmt.Errorf("%s: must be >= %v", path, m)
+	}
+	if m, ok := schemaNumber(schema, "maximum"); ok && n > m {
+		return fmt.Errorf("%s: must be <= %v", path, m)
+	}
+	if m, ok := schemaNumber(schema, "exclusiveMinimum"); ok && n <= m {
+		return fmt.Errorf("%s: must be > %v", path, m)
+	}
+	if m, ok := schemaNumber(schema, "exclusiveMaximum"); ok && n >= m {
+		return fmt.Errorf("%s: must be < %v", path, m)
+	}
+
+	return nil
+}
+
+// resolve looks up a local reference such as #/$defs/Item.
+func (v *schemaValidator) resolve(ref string) (map[string]any, error) {
+	if ref == "#" {
+		return v.root, nil
+	}
+	rest, ok := strings.CutPrefix(ref, "#/")
+	if !ok {
+		return nil, fmt.Errorf("unsupported schema reference %q", ref)
+	}
+
+	var node any = v.root
+	for _, part := range strings.Split(rest, "/") {
+		part = strings.NewReplacer("~1", "/", "~0", "~").Replace(part)
+		m, ok := node.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("unresolvable schema reference %q", ref)
+		}
+		node = m[part]
+	}
+
+	target, ok := node.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("unresolvable schema reference %q", ref)
+	}
+
+	return target, nil
+}
+
+// checkType checks a value against a type keyword, a name or a list of names.
+func checkType(path string, value any, t any) error {
+	var names []string
+	switch tt := t.(type) {
+	case string:
+		names = []string{tt}
+	case []any:
+		for _, n := range tt {
+			if s, ok := n.(string); ok {
+				names = append(names, s)
+			}
+		}
+	}
+
+	for _, name := range names {
+		if isJSONType(value, name) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%s: expected %s, got %s", path, strings.Join(names, " or "), jsonTypeOf(value))
+}
+
+func isJSONType(value any, name string) bool {
+	switch name {
+	case "integer":
+		n, ok := value.(float64)
+
+		return ok && n == math.Trunc(n)
+	case "number":
+		_, ok := value.(float64)
+
+		return ok
+	}
+
+	return jsonTypeOf(value) == name
+}
+
+func jsonTypeOf(value any) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	}
+
+	return fmt.Sprintf("%T", value)
+}
+
+// schemaNumber returns a numeric keyword of a schema.
+func schemaNumber(schema map[string]any, key string) (float64, bool) {
+	switch n := schema[key].(type) {
+	case float64:
+		return n, true
+	case int:
+		return float64(n), true
+	}
+
+	return 0, false
+}
+
+func slicesContainJSON(values []any, value any) bool {
+	for _, v := range values {
+		if equalJSON(v, value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// equalJSON compares JSON values, numbers of schemas built in Go may be ints.
+func equalJSON(a, b any) bool {
+	if n, ok := a.(int); ok {
+		a = float64(n)
+	}
+	if n, ok := b.(int); ok {
+		b = float64(n)
+	}
+
+	return reflect.DeepEqual(a, b)
+}
+
+func compactJSON(v any) string {
+	data, _ := json.Marshal(v)
+
+	return string(data)
+}
@@ -0,0 +1,77 @@
+// ABOUTME: Tests for JSON Schema validation.
+// ABOUTME: Covers the keywords used by structured output schemas.
+
+package ai
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateJSONSchema(t *testing.T) {
+	schema := map[string]any{
+		"type": "object",
+		"properties": map[string]any{
+			"name":  map[string]any{"type": "string", "minLength": 1, "maxLength": 8},
+			"age":   map[string]any{"type": "integer", "minimum": 0, "maximum": 150},
+			"role":  map[string]any{"enum": []any{"admin", "user"}},
+			"email": map[string]any{"type": []any{"string", "null"}, "pattern": "^[^@]+@[^@]+$"},
+			"tags":  map[string]any{"type": "array", "items": map[string]any{"type": "string"}, "maxItems": 2},
+			"owner": map[string]any{"$ref": "#/$defs/person"},
+			"id":    map[string]any{"anyOf": []any{map[string]any{"type": "string"}, map[string]any{"type": "integer"}}},
+		},
+		"required":             []any{"name"},
+		"additionalProperties": false,
+		"$defs": map[string]any{
+			"person": map[string]any{
+				"type":       "object",
+				"properties": map[string]any{"name": map[string]any{"type": "string"}},
+				"required":   []any{"name"},
+			},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		value   string
+		wantErr string
+	}{
+		{name: "valid", value: `{"name":"Ann","age":30,"role":"admin","email":null,"tags":["a"],"owner":{"name":"Bob"},"id":7}`},
+		{name: "wrong type", value: `[]`, wantErr: "$: expected object, got array"},
+		{name: "missing required", value: `{}`, wantErr: `missing required property "name"`},
+		{name: "extra property", value: `{"name":"Ann","x":1}`, wantErr: `unexpected property "x"`},
+		{name: "too long", value: `{"name":"Annabelle!"}`, wantErr: "$.name: must be at most 8 characters"},
+		{name: "not integer", value: `{"name":"Ann","age":1.5}`, wantErr: "$.age: expected integer"},
+		{name: "out of range", value: `{"name":"Ann","age":200}`, wantErr: "$.age: must be <= 150"},
+		{name: "not in enum", value: `{"name":"Ann","role":"root"}`, wantErr: "$.role: must be one of"},
+		{name: "pattern", value: `{"name":"Ann","email":"nope"}`, wantErr: "$.email: must match pattern"},
+		{name: "item type", value: `{"name":"Ann","tags":[1]}`, wantErr: "$.tags[0]: expected string"},
+		{name: "too many items", value: `{"name":"Ann","tags":["a","b","c"]}`, wantErr: "$.tags: must have at most 2 items"},
+		{name: "ref", value: `{"name":"Ann","owner":{}}`, wantErr: `$.owner: missing required property "name"`},
+		{name: "any of", value: `{"name":"Ann","id":true}`, wantErr: "$.id: matches none of anyOf"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var value any
+			require.NoError(t, json.Unmarshal([]byte(tt.value), &value))
+
+			err := ValidateJSONSchema(value, schema)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateJSONSchema_RecursiveRef(t *testing.T) {
+	schema := map[string]any{"$ref": "#"}
+
+	err := ValidateJSONSchema(map[string]any{}, schema)
+	assert.ErrorContains(t, err, "nested too deeply")
+}
@@ -0,0 +1,415 @@
+// ABOUTME: Load balancing of one provider across several API keys.
+// ABOUTME: Selects keys by weighted round-robin or least use, and cools down rate limited or failing keys.
+
+package ai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Key selection strategies
+const (
+	KeyStrategyRoundRobin = "round_robin" // Smooth weighted round-robin
+	KeyStrategyLeastUsed  = "least_used"  // Fewest in-flight requests per weight
+)
+
+// Key request results reported to a KeyObserver
+const (
+	KeyResultSuccess     = "success"
+	KeyResultError       = "error"
+	KeyResultRateLimited = "rate_limited"
+)
+
+const (
+	// DefaultKeyCooldown is how long a rate limited key is skipped
+	DefaultKeyCooldown = time.Minute
+	// DefaultKeyMaxFailures is the number of failures in a row that cools a key down
+	DefaultKeyMaxFailures = 3
+)
+
+// PoolKey is one API key of a provider, served by its own client.
+type PoolKey struct {
+	Name     string   // Identifies the key in logs and metrics, never the key itself
+	Weight   int      // Share of requests relative to the other keys, defaults to 1
+	Provider Provider // Client using the key
+}
+
+// KeyEvent is the outcome of a request sent with a pooled key.
+type KeyEvent struct {
+	Provider string
+	Key      string
+	Result   string // success, error or rate_limited
+	Healthy  bool   // False while the key cools down
+}
+
+// KeyObserver is notified of every request sent with a pooled key.
+type KeyObserver func(KeyEvent)
+
+// KeyPoolConfig configures a key pool.
+type KeyPoolConfig struct {
+	Strategy    string        // round_robin (default) or least_used
+	Cooldown    time.Duration // Defaults to DefaultKeyCooldown
+	MaxFailures int           // Defaults to DefaultKeyMaxFailures
+	Observer    KeyObserver   // Optional
+	Previous    Provider      // Optional pool replaced by this one, the state of same-named keys carries over
+}
+
+// KeyStatus is a snapshot of a pooled key.
+type KeyStatus struct {
+	Name          string
+	Weight        int
+	Healthy       bool
+	CooldownUntil time.Time
+	InFlight      int
+	Requests      int64
+	Failures      int64
+}
+
+// KeyReporter is implemented by providers balancing several API keys.
+type KeyReporter interface {
+	// Keys returns the state of each key
+	Keys() []KeyStatus
+}
+
+type pooledKey struct {
+	PoolKey
+	current       int // Smooth round-robin weight
+	inFlight      int
+	requests      int64
+	failures      int64
+	failuresInRow int
+	cooldownUntil time.Time
+}
+
+// KeyPool is a provider spreading requests over the clients of several API keys. A key
+// answering 429 is skipped for the cooldown and the request is retried with another key,
+// a key failing MaxFailures times in a row is cooled down as well. When every key cools
+// down, the one available soonest is used.
+type KeyPool struct {
+	name string
+	cfg  KeyPoolConfig
+	keys []*pooledKey
+	mu   sync.Mutex
+	now  func() time.Time
+}
+
+// NewKeyPool creates a provider balancing the given keys of a provider. The pool supports
+// embeddings and model discovery when the key clients do.
+func NewKeyPool(name string, keys []PoolKey, cfg KeyPoolConfig) (Provider, error) {
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("provider %s has no keys", name)
+	}
+	switch cfg.Strategy {
+	case "":
+		cfg.Strategy = KeyStrategyRoundRobin
+	case KeyStrategyRoundRobin, KeyStrategyLeastUsed:
+	default:
+		return nil, fmt.Errorf("unsupported key strategy %q", cfg.Strategy)
+	}
+	if cfg.Cooldown <= 0 {
+		cfg.Cooldown = DefaultKeyCooldown
+	}
+	if cfg.MaxFailures <= 0 {
+		cfg.MaxFailures = DefaultKeyMaxFailures
+	}
+
+	p := &KeyPool{name: name, cfg: cfg, now: time.Now}
+	for _, k := range keys {
+		if k.Weight <= 0 {
+			k.Weight = 1
+		}
+		p.keys = append(p.keys, &pooledKey{PoolKey: k})
+	}
+	if prev, ok := cfg.Previous.(interface{ keyPool() *KeyPool }); ok {
+		p.carry(prev.keyPool())
+	}
+	p.cfg.Previous = nil
+
+	_, embeds := keys[0].Provider.(Embedder)
+	_, lists := keys[0].Provider.(ModelLister)
+	switch {
+	case embeds && lists:
+		return &fullKeyPool{p}, nil
+	case embeds:
+		return &embeddingKeyPool{p}, nil
+	case lists:
+		return &listingKeyPool{p}, nil
+	default:
+		return p, nil
+	}
+}
+
+// carry copies the cooldowns, failures and request counters of prev's keys to the keys
+// with the same name, so reloading the providers does not put cooling keys back in rotation.
+// In-flight requests finish on prev and are not carried.
+func (p *KeyPool) carry(prev *KeyPool) {
+	prev.mu.Lock()
+	defer prev.mu.Unlock()
+
+	for _, k := range p.keys {
+		for _, old := range prev.keys {
+			if old.Name != k.Name {
+				continue
+			}
+			k.requests = old.requests
+			k.failures = old.failures
+			k.failuresInRow = old.failuresInRow
+			k.cooldownUntil = old.cooldownUntil
+		}
+	}
+}
+
+func (p *KeyPool) keyPool() *KeyPool {
+	return p
+}
+
+func (p *KeyPool) Name() string {
+	return p.name
+}
+
+func (p *KeyPool) Models() []ModelInfo {
+	return p.keys[0].Provider.Models()
+}
+
+func (p *KeyPool) Chat(ctx context.Context, req *ChatRequest) (*ChatResponse, error) {
+	return keyPoolDo(ctx, p, func(provider Provider) (*ChatResponse, error) {
+		return provider.Chat(ctx, req)
+	})
+}
+
+// ChatStream opens the stream with a key, the key stays in use until the stream ends.
+func (p *KeyPool) ChatStream(ctx context.Context, req *ChatRequest) (*ChatStream, error) {
+	tried := make(map[*pooledKey]bool, len(p.keys))
+	for {
+		k := p.acquire(tried)
+		stream, err := k.Provider.ChatStream(ctx, req)
+		if err == nil {
+			return p.track(k, stream), nil
+		}
+		p.release(k, err)
+		if !p.failover(ctx, err, tried, k) {
+			return nil, err
+		}
+	}
+}
+
+// Keys returns the state of each key.
+func (p *KeyPool) Keys() []KeyStatus {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.now()
+	ret := make([]KeyStatus, len(p.keys))
+	for i, k := range p.keys {
+		ret[i] = KeyStatus{
+			Name:          k.Name,
+			Weight:        k.Weight,
+			Healthy:       !k.cooldownUntil.After(now),
+			CooldownUntil: k.cooldownUntil,
+			InFlight:      k.inFlight,
+			Requests:      k.requests,
+			Failures:      k.failures,
+		}
+	}
+
+	return ret
+}
+
+// keyPoolDo sends a request with a key, retrying with the other keys while it is rate limited.
+func keyPoolDo[T any](ctx context.Context, p *KeyPool, fn func(provider Provider) (T, error)) (T, error) {
+	tried := make(map[*pooledKey]bool, len(p.keys))
+	for {
+		k := p.acquire(tried)
+		resp, err := fn(k.Provider)
+		p.release(k, err)
+		if err == nil || !p.failover(ctx, err, tried, k) {
+			return resp, err
+		}
+	}
+}
+
+// failover reports whether a failed request should be retried with another key.
+func (p *KeyPool) failover(ctx context.Context, err error, tried map[*pooledKey]bool, k *pooledKey) bool {
+	if !IsRateLimited(err) || ctx.Err() != nil {
+		return false
+	}
+	tried[k] = true
+
+	return len(tried) < len(p.keys)
+}
+
+// acquire selects a key not in skip and marks it in use.
+func (p *KeyPool) acquire(skip map[*pooledKey]bool) *pooledKey {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.now()
+	var available []*pooledKey
+	var soonest *pooledKey
+	for _, k := range p.keys {
+		if skip[k] {
+			continue
+		}
+		if !k.cooldownUntil.After(now) {
+			available = append(available, k)
+		} else if soonest == nil || k.cooldownUntil.Before(soonest.cooldownUntil) {
+			soonest = k
+		}
+	}
+
+	var k *pooledKey
+	switch {
+	case len(available) == 0:
+		k = soonest
+	case p.cfg.Strategy == KeyStrategyLeastUsed:
+		k = leastUsed(available)
+	default:
+		k = nextRoundRobin(available)
+	}
+	k.inFlight++
+	k.requests++
+
+	return k
+}
+
+// nextRoundRobin selects a key by smooth weighted round-robin, spreading each key's share
+// evenly instead of sending its requests in a burst.
+func nextRoundRobin(keys []*pooledKey) *pooledKey {
+	total := 0
+	var best *pooledKey
+	for _, k := range keys {
+		k.current += k.Weight
+		total += k.Weight
+		if best == nil || k.current > best.current {
+			best = k
+		}
+	}
+	best.current -= total
+
+	return best
+}
+
+// leastUsed selects the key with the fewest in-flight requests per weight, ties go to the
+// key with the fewest requests per weight.
+func leastUsed(keys []*pooledKey) *pooledKey {
+	best := keys[0]
+	for _, k := range keys[1:] {
+		a, b := k.inFlight*best.Weight, best.inFlight*k.Weight
+		if a < b || (a == b && k.requests*int64(best.Weight) < best.requests*int64(k.Weight)) {
+			best = k
+		}
+	}
+
+	return best
+}
+
+// release records the result of a request sent with a key.
+func (p *KeyPool) release(k *pooledKey, err error) {
+	p.mu.Lock()
+	k.inFlight--
+	result := KeyResultSuccess
+	switch {
+	case err == nil:
+		k.failuresInRow = 0
+	case errors.Is(err, context.Canceled):
+		// The caller gave up, the key is not at fault
+	case IsRateLimited(err):
+		result = KeyResultRateLimited
+		k.failures++
+		k.cooldownUntil = p.now().Add(p.cfg.Cooldown)
+	default:
+		result = KeyResultError
+		k.failures++
+		k.failuresInRow++
+		if k.failuresInRow >= p.cfg.MaxFailures {
+			k.failuresInRow = 0
+			k.cooldownUntil = p.now().Add(p.cfg.Cooldown)
+		}
+	}
+	healthy := !k.cooldownUntil.After(p.now())
+	p.mu.Unlock()
+
+	if p.cfg.Observer != nil {
+		p.cfg.Observer(KeyEvent{Provider: p.name, Key: k.Name, Result: result, Healthy: healthy})
+	}
+}
+
+// track forwards a stream and releases its key once the stream ends.
+func (p *KeyPool) track(k *pooledKey, stream *ChatStream) *ChatStream {
+	out := NewChatStream(DefaultStreamBufferSize)
+	go func() {
+		for {
+			chunk, err := stream.Recv()
+			if err != nil {
+				if errors.Is(err, ErrStreamClosed) {
+					p.release(k, nil)
+					out.Close()
+
+					return
+				}
+				p.release(k, err)
+				out.CloseWithError(err)
+
+				return
+			}
+			out.Send(chunk)
+		}
+	}()
+
+	return out
+}
+
+func (p *KeyPool) embed(ctx context.Context, req *EmbeddingRequest) (*EmbeddingResponse, error) {
+	return keyPoolDo(ctx, p, func(provider Provider) (*EmbeddingResponse, error) {
+		return provider.(Embedder).Embed(ctx, req)
+	})
+}
+
+func (p *KeyPool) listModels(ctx context.Context) ([]ModelInfo, error) {
+	return keyPoolDo(ctx, p, func(provider Provider) ([]ModelInfo, error) {
+		return provider.(ModelLister).ListModels(ctx)
+	})
+}
+
+type embeddingKeyPool struct{ *KeyPool }
+
+func (p *embeddingKeyPool) Embed(ctx context.Context, req *EmbeddingRequest) (*EmbeddingResponse, error) {
+	return p.embed(ctx, req)
+}
+
+type listingKeyPool struct{ *KeyPool }
+
+func (p *listingKeyPool) ListModels(ctx context.Context) ([]ModelInfo, error) {
+	return p.listModels(ctx)
+}
+
+type fullKeyPool struct{ *KeyPool }
+
+func (p *fullKeyPool) Embed(ctx context.Context, req *EmbeddingRequest) (*EmbeddingResponse, error) {
+	return p.embed(ctx, req)
+}
+
+func (p *fullKeyPool) ListModels(ctx context.Context) ([]ModelInfo, error) {
+	return p.listModels(ctx)
+}
+
+// IsRateLimited reports whether a provider error is a rate limit (HTTP 429).
+func IsRateLimited(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	msg := strings.ToLower(err.Error())
+	for _, m := range []string{"429", "too many requests", "rate_limit", "rate limit"} {
+		if strings.Contains(msg, m) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,253 @@
+// ABOUTME: Tests for the API key pool.
+// ABOUTME: Verifies weighted selection, least-used selection, cooldown and failover on rate limits.
+
+package ai
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type keyStub struct {
+	name string
+	err  error
+	mu   sync.Mutex
+	hits int
+}
+
+func (s *keyStub) Name() string        { return "stub" }
+func (s *keyStub) Models() []ModelInfo { return []ModelInfo{{ID: "stub-chat"}} }
+
+func (s *keyStub) calls() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.hits
+}
+
+func (s *keyStub) fail(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.err = err
+}
+
+func (s *keyStub) call() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.hits++
+
+	return s.name, s.err
+}
+
+func (s *keyStub) Chat(_ context.Context, _ *ChatRequest) (*ChatResponse, error) {
+	name, err := s.call()
+	if err != nil {
+		return nil, err
+	}
+
+	return &ChatResponse{ID: name}, nil
+}
+
+func (s *keyStub) ChatStream(_ context.Context, _ *ChatRequest) (*ChatStream, error) {
+	name, err := s.call()
+	if err != nil {
+		return nil, err
+	}
+	stream := NewChatStream(1)
+	go func() {
+		stream.Send(&StreamChunk{ID: name})
+		stream.Close()
+	}()
+
+	return stream, nil
+}
+
+type embeddingKeyStub struct{ keyStub }
+
+func (s *embeddingKeyStub) Embed(_ context.Context, _ *EmbeddingRequest) (*EmbeddingResponse, error) {
+	name, err := s.call()
+
+	return &EmbeddingResponse{Model: name}, err
+}
+
+func newTestKeyPool(t *testing.T, cfg KeyPoolConfig, weights ...int) (*KeyPool, []*keyStub) {
+	t.Helper()
+
+	var keys []PoolKey
+	var stubs []*keyStub
+	for i, w := range weights {
+		s := &keyStub{name: string(rune('a' + i))}
+		stubs = append(stubs, s)
+		keys = append(keys, PoolKey{Name: s.name, Weight: w, Provider: s})
+	}
+	p, err := NewKeyPool("openai", keys, cfg)
+	require.NoError(t, err)
+
+	return p.(*KeyPool), stubs
+}
+
+func TestKeyPool_WeightedRoundRobin(t *testing.T) {
+	p, stubs := newTestKeyPool(t, KeyPoolConfig{}, 3, 1)
+
+	var order string
+	for range 8 {
+		resp, err := p.Chat(context.Background(), &ChatRequest{})
+		require.NoError(t, err)
+		order += resp.ID
+	}
+	assert.Equal(t, "aabaaaba", order, "requests are interleaved by weight")
+	assert.Equal(t, 6, stubs[0].calls())
+	assert.Equal(t, 2, stubs[1].calls())
+}
+
+func TestKeyPool_LeastUsed(t *testing.T) {
+	p, _ := newTestKeyPool(t, KeyPoolConfig{Strategy: KeyStrategyLeastUsed}, 1, 1)
+
+	// A key busy with a stream is avoided
+	stream, err := p.ChatStream(context.Background(), &ChatRequest{})
+	require.NoError(t, err)
+	resp, err := p.Chat(context.Background(), &ChatRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, "b", resp.ID)
+
+	chunk, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, "a", chunk.ID)
+	_, err = stream.Recv()
+	require.ErrorIs(t, err, ErrStreamClosed)
+	assert.Eventually(t, func() bool { return p.Keys()[0].InFlight == 0 }, time.Second, 5*time.Millisecond)
+}
+
+func TestKeyPool_RateLimitCooldown(t *testing.T) {
+	var events []KeyEvent
+	p, stubs := newTestKeyPool(t, KeyPoolConfig{
+		Cooldown: time.Minute,
+		Observer: func(e KeyEvent) { events = append(events, e) },
+	}, 1, 1)
+	now := time.Now()
+	p.now = func() time.Time { return now }
+
+	stubs[0].fail(errors.New("status 429: rate_limit_reached"))
+
+	// The rate limited key is retried with the other key and then skipped
+	for range 3 {
+		resp, err := p.Chat(context.Background(), &ChatRequest{})
+		require.NoError(t, err)
+		assert.Equal(t, "b", resp.ID)
+	}
+	assert.Equal(t, 1, stubs[0].calls())
+	require.NotEmpty(t, events)
+	assert.Equal(t, KeyEvent{Provider: "openai", Key: "a", Result: KeyResultRateLimited, Healthy: false}, events[0])
+
+	keys := p.Keys()
+	assert.False(t, keys[0].Healthy)
+	assert.Equal(t, int64(1), keys[0].Failures)
+	assert.True(t, keys[1].Healthy)
+
+	// The key is used again after the cooldown
+	stubs[0].fail(nil)
+	now = now.Add(time.Minute)
+	var ids []string
+	for range 2 {
+		resp, err := p.Chat(context.Background(), &ChatRequest{})
+		require.NoError(t, err)
+		ids = append(ids, resp.ID)
+	}
+	assert.Contains(t, ids, "a")
+}
+
+func TestKeyPool_AllKeysRateLimited(t *testing.T) {
+	p, stubs := newTestKeyPool(t, KeyPoolConfig{}, 1, 1)
+	for _, s := range stubs {
+		s.fail(errors.New("429 too many requests"))
+	}
+
+	_, err := p.Chat(context.Background(), &ChatRequest{})
+	require.Error(t, err)
+	assert.Equal(t, 1, stubs[0].calls())
+	assert.Equal(t, 1, stubs[1].calls())
+
+	// With every key cooling down the soonest available one is still used
+	_, err = p.Chat(context.Background(), &ChatRequest{})
+	require.Error(t, err)
+	assert.Equal(t, 4, stubs[0].calls()+stubs[1].calls())
+}
+
+func TestKeyPool_FailuresInRow(t *testing.T) {
+	p, stubs := newTestKeyPool(t, KeyPoolConfig{Strategy: KeyStrategyLeastUsed, MaxFailures: 2}, 1, 1)
+	stubs[0].fail(errors.New("401 unauthorized"))
+
+	// Other errors are returned without failover
+	for range 4 {
+		_, _ = p.Chat(context.Background(), &ChatRequest{})
+	}
+	assert.Equal(t, 2, stubs[0].calls(), "the failing key is cooled down after 2 failures")
+	assert.False(t, p.Keys()[0].Healthy)
+}
+
+func TestKeyPool_CarriesPreviousState(t *testing.T) {
+	prev, stubs := newTestKeyPool(t, KeyPoolConfig{}, 1, 1)
+	stubs[0].fail(errors.New("status 429: rate_limit_reached"))
+	_, err := prev.Chat(context.Background(), &ChatRequest{})
+	require.NoError(t, err)
+	require.False(t, prev.Keys()[0].Healthy)
+
+	// A reloaded pool keeps the cooldown of key a, key c is new
+	c := &keyStub{name: "c"}
+	p, err := NewKeyPool("openai", []PoolKey{
+		{Name: "a", Provider: &keyStub{name: "a"}},
+		{Name: "c", Provider: c},
+	}, KeyPoolConfig{Previous: &embeddingKeyPool{prev}})
+	require.NoError(t, err)
+
+	keys := p.(KeyReporter).Keys()
+	assert.False(t, keys[0].Healthy, "the cooling key is not put back in rotation")
+	assert.Equal(t, int64(1), keys[0].Failures)
+	assert.True(t, keys[1].Healthy)
+	assert.Zero(t, keys[1].Requests)
+
+	for range 2 {
+		resp, err := p.Chat(context.Background(), &ChatRequest{})
+		require.NoError(t, err)
+		assert.Equal(t, "c", resp.ID)
+	}
+	assert.Nil(t, p.(*KeyPool).cfg.Previous, "the replaced pool is not retained")
+}
+
+func TestKeyPool_Capabilities(t *testing.T) {
+	p, err := NewKeyPool("openai", []PoolKey{
+		{Name: "a", Provider: &embeddingKeyStub{keyStub: keyStub{name: "a"}}},
+		{Name: "b", Provider: &embeddingKeyStub{keyStub: keyStub{name: "b"}}},
+	}, KeyPoolConfig{})
+	require.NoError(t, err)
+	assert.Equal(t, "openai", p.Name())
+	assert.Len(t, p.Models(), 1)
+
+	embedder, ok := p.(Embedder)
+	require.True(t, ok)
+	resp, err := embedder.Embed(context.Background(), &EmbeddingRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, "a", resp.Model)
+	_, ok = p.(ModelLister)
+	assert.False(t, ok)
+	_, ok = p.(KeyReporter)
+	assert.True(t, ok)
+
+	_, err = NewKeyPool("openai", nil, KeyPoolConfig{})
+	require.Error(t, err)
+	_, err = NewKeyPool("openai", []PoolKey{{Name: "a", Provider: &keyStub{}}}, KeyPoolConfig{Strategy: "random"})
+	require.Error(t, err)
+}
+
+func TestIsRateLimited(t *testing.T) {
+	assert.True(t, IsRateLimited(errors.New("error, status code: 429, message: Rate limit reached")))
+	assert.True(t, IsRateLimited(errors.New("Too Many Requests")))
+	assert.False(t, IsRateLimited(errors.New("401 unauthorized")))
+	assert.False(t, IsRateLimited(nil))
+}
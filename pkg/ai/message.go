@@ -8,25 +8,49 @@ const (
 	RoleSystem    = "system"
 	RoleUser      = "user"
 	RoleAssistant = "assistant"
+	RoleTool      = "tool"
+)
+
+// Finish reasons
+const (
+	FinishReasonStop          = "stop"
+	FinishReasonLength        = "length"
+	FinishReasonToolCalls     = "tool_calls"
+	FinishReasonContentFilter = "content_filter"
+	FinishReasonCancelled     = "cancelled" // Stopped by the client before the model finished
 )
 
 // Message represents a chat message
 type Message struct {
-	Role    string `json:"role"`
-	Content string `json:"content"`
+	Role       string        `json:"role"`
+	Content    string        `json:"content"`
+	Parts      []ContentPart `json:"content_parts,omitempty"` // Multimodal user content, Content holds the text
+	Name       string        `json:"name,omitempty"`          // Tool name for tool messages
+	ToolCalls  []ToolCall    `json:"tool_calls,omitempty"`    // Assistant tool invocations
+	ToolCallID string        `json:"tool_call_id,omitempty"`  // Tool call this message answers
 }
 
 // ChatRequest represents a chat completion request
 type ChatRequest struct {
-	Model       string    `json:"model"`
-	Messages    []Message `json:"messages"`
-	MaxTokens   int       `json:"max_tokens,omitempty"`
-	Temperature float64   `json:"temperature,omitempty"`
-	Stream      bool      `json:"stream,omitempty"`
+	Model          string          `json:"model"`
+	Messages       []Message       `json:"messages"`
+	MaxTokens      int             `json:"max_tokens,omitempty"`
+	Temperature    float64         `json:"temperature,omitempty"`
+	Stream         bool            `json:"stream,omitempty"`
+	Tools          []Tool          `json:"tools,omitempty"`
+	ToolChoice     *ToolChoice     `json:"-"`
+	ResponseFormat *ResponseFormat `json:"response_format,omitempty"` // Constrains the reply to JSON, nil for text
 	// Extension fields
-	SessionID string `json:"session_id,omitempty"`
-	AgentID   string `json:"agent_id,omitempty"` // Renamed from RoleID
-	UID       string `json:"-"`                  // Internal use only
+	SessionID    string  `json:"session_id,omitempty"`
+	AgentID      string  `json:"agent_id,omitempty"` // Renamed from RoleID
+	UID          string  `json:"-"`                  // Internal use only
+	ParentID     *uint64 `json:"-"`                  // Internal use only, stored message a new branch continues, nil for the active branch
+	AgentVersion int     `json:"-"`                  // Internal use only, version of the agent whose settings were applied
+	// Internal use only, A/B experiment arm the request was assigned to
+	ExperimentID  uint64 `json:"-"`
+	ExperimentArm string `json:"-"`
+	// Internal use only, server tools the server itself offered to the model, only these are executed
+	ServerTools []string `json:"-"`
 }
 
 // ChatResponse represents a chat completion response
@@ -37,6 +61,7 @@ type ChatResponse struct {
 	Model   string   `json:"model"`
 	Choices []Choice `json:"choices"`
 	Usage   Usage    `json:"usage"`
+	Cached  bool     `json:"cached,omitempty"` // Extension field, served from the response cache
 }
 
 // Choice represents a completion choice
@@ -61,5 +86,6 @@ type StreamChunk struct {
 	Created int64    `json:"created"`
 	Model   string   `json:"model"`
 	Choices []Choice `json:"choices"`
-	Usage   *Usage   `json:"usage,omitempty"` // Final chunk may include usage stats
+	Usage   *Usage   `json:"usage,omitempty"`  // Final chunk may include usage stats
+	Cached  bool     `json:"cached,omitempty"` // Extension field, served from the response cache
 }
@@ -0,0 +1,34 @@
+// ABOUTME: System prompt templating.
+// ABOUTME: Fills {{variable}} placeholders of agent prompts from request values.
+
+package ai
+
+import (
+	"regexp"
+	"strings"
+)
+
+// promptVarPattern matches {{name}} placeholders, spaces inside the braces are allowed.
+var promptVarPattern = regexp.MustCompile(`\{\{\s*([a-zA-Z_][a-zA-Z0-9_]*)\s*\}\}`)
+
+// HasPromptVars reports whether a prompt contains placeholders.
+func HasPromptVars(prompt string) bool {
+	return strings.Contains(prompt, "{{") && promptVarPattern.MatchString(prompt)
+}
+
+// RenderPrompt replaces {{name}} placeholders with their values. Placeholders without a
+// value are kept as written so that typos stay visible.
+func RenderPrompt(prompt string, vars map[string]string) string {
+	if !strings.Contains(prompt, "{{") {
+		return prompt
+	}
+
+	return promptVarPattern.ReplaceAllStringFunc(prompt, func(m string) string {
+		name := promptVarPattern.FindStringSubmatch(m)[1]
+		if v, ok := vars[name]; ok {
+			return v
+		}
+
+		return m
+	})
+}
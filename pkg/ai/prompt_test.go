@@ -0,0 +1,37 @@
+// ABOUTME: Tests for system prompt templating.
+// ABOUTME: Verifies placeholder detection and substitution.
+
+package ai
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderPrompt(t *testing.T) {
+	vars := map[string]string{"nickname": "Ann", "date": "2026-10-17"}
+
+	tests := []struct {
+		name   string
+		prompt string
+		want   string
+	}{
+		{name: "no placeholders", prompt: "Be brief.", want: "Be brief."},
+		{name: "placeholders", prompt: "Hi {{nickname}}, today is {{ date }}.", want: "Hi Ann, today is 2026-10-17."},
+		{name: "unknown kept", prompt: "Speak {{locale}}.", want: "Speak {{locale}}."},
+		{name: "not a name", prompt: "{{ 1 + 1 }}", want: "{{ 1 + 1 }}"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, RenderPrompt(tt.prompt, vars))
+		})
+	}
+}
+
+func TestHasPromptVars(t *testing.T) {
+	assert.True(t, HasPromptVars("Hi {{nickname}}"))
+	assert.False(t, HasPromptVars("Hi {{"))
+	assert.False(t, HasPromptVars("Hi"))
+}
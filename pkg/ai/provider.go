@@ -20,11 +20,18 @@ type Provider interface {
 	Models() []ModelInfo
 }
 
+// ModelLister is an optional provider capability for discovering the models a server hosts
+type ModelLister interface {
+	// ListModels queries the provider for the models it currently serves
+	ListModels(ctx context.Context) ([]ModelInfo, error)
+}
+
 // ModelInfo contains model metadata
 type ModelInfo struct {
 	ID          string  `json:"id"`
 	Name        string  `json:"name"`
 	Provider    string  `json:"provider"`
+	Type        string  `json:"type,omitempty"` // chat (default) or embedding
 	MaxTokens   int     `json:"max_tokens"`
 	InputPrice  float64 `json:"input_price"`
 	OutputPrice float64 `json:"output_price"`
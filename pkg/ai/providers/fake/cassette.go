@@ -0,0 +1,280 @@
+// ABOUTME: Record/replay fixtures for the fake provider.
+// ABOUTME: Recorder captures real provider traffic into a cassette file that the fake provider replays.
+
+package fake
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/bingo-project/bingo/pkg/ai"
+)
+
+// Request identifies a recorded call, replay matches it by exact equality
+type Request struct {
+	Model    string       `json:"model"`
+	Messages []ai.Message `json:"messages,omitempty"`
+	Tools    []ai.Tool    `json:"tools,omitempty"`
+	Input    []string     `json:"input,omitempty"` // Embedding input
+}
+
+// Interaction is a recorded provider call and its outcome
+type Interaction struct {
+	Request      Request       `json:"request"`
+	Content      string        `json:"content,omitempty"`
+	ToolCalls    []ai.ToolCall `json:"tool_calls,omitempty"`
+	FinishReason string        `json:"finish_reason,omitempty"`
+	Usage        *ai.Usage     `json:"usage,omitempty"`
+	Chunks       []string      `json:"chunks,omitempty"` // Content deltas of a streamed call
+	Embeddings   [][]float32   `json:"embeddings,omitempty"`
+	Error        string        `json:"error,omitempty"`
+}
+
+// step converts the interaction into a step, errors keep their message for retry classification
+func (i *Interaction) step() Step {
+	s := Step{
+		Content:      i.Content,
+		ToolCalls:    i.ToolCalls,
+		FinishReason: i.FinishReason,
+		Usage:        i.Usage,
+		Chunks:       i.Chunks,
+		Embeddings:   i.Embeddings,
+	}
+	if i.Error != "" {
+		if len(i.Chunks) > 0 {
+			s.StreamErr = errors.New(i.Error)
+		} else {
+			s.Err = errors.New(i.Error)
+		}
+	}
+
+	return s
+}
+
+// Cassette is a set of recorded interactions stored as a JSON fixture file
+type Cassette struct {
+	Interactions []*Interaction `json:"interactions"`
+
+	mu   sync.Mutex
+	used map[int]bool
+}
+
+// LoadCassette reads a cassette from a fixture file
+func LoadCassette(path string) (*Cassette, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var c Cassette
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, fmt.Errorf("decode cassette %s: %w", path, err)
+	}
+
+	return &c, nil
+}
+
+// Save writes the cassette to a fixture file, creating parent directories
+func (c *Cassette) Save(path string) error {
+	c.mu.Lock()
+	data, err := json.MarshalIndent(c, "", "  ")
+	c.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// add appends a recorded interaction
+func (c *Cassette) add(it *Interaction) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.Interactions = append(c.Interactions, it)
+}
+
+// match returns the first unused interaction recorded for an equal request
+func (c *Cassette) match(req Request) (*Interaction, bool) {
+	key, _ := json.Marshal(req)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.used == nil {
+		c.used = make(map[int]bool)
+	}
+	for i, it := range c.Interactions {
+		if c.used[i] {
+			continue
+		}
+		if k, _ := json.Marshal(it.Request); string(k) == string(key) {
+			c.used[i] = true
+
+			return it, true
+		}
+	}
+
+	return nil, false
+}
+
+// Recorder wraps a real provider and records its traffic into a cassette.
+// It always implements ai.Embedder and fails embedding calls the wrapped provider cannot serve.
+type Recorder struct {
+	inner    ai.Provider
+	cassette *Cassette
+}
+
+var (
+	_ ai.Provider = (*Recorder)(nil)
+	_ ai.Embedder = (*Recorder)(nil)
+)
+
+// NewRecorder creates a recorder around a real provider
+func NewRecorder(inner ai.Provider) *Recorder {
+	return &Recorder{inner: inner, cassette: &Cassette{}}
+}
+
+// Name returns the wrapped provider's name
+func (r *Recorder) Name() string {
+	return r.inner.Name()
+}
+
+// Models returns the wrapped provider's models
+func (r *Recorder) Models() []ai.ModelInfo {
+	return r.inner.Models()
+}
+
+// Cassette returns the interactions recorded so far
+func (r *Recorder) Cassette() *Cassette {
+	return r.cassette
+}
+
+// Save writes the recorded interactions to a fixture file
+func (r *Recorder) Save(path string) error {
+	return r.cassette.Save(path)
+}
+
+// Chat performs and records a non-streaming chat completion
+func (r *Recorder) Chat(ctx context.Context, req *ai.ChatRequest) (*ai.ChatResponse, error) {
+	it := &Interaction{Request: chatRequestOf(req)}
+	defer r.cassette.add(it)
+
+	resp, err := r.inner.Chat(ctx, req)
+	if err != nil {
+		it.Error = err.Error()
+
+		return nil, err
+	}
+
+	usage := resp.Usage
+	it.Usage = &usage
+	if len(resp.Choices) > 0 {
+		it.Content = resp.Choices[0].Message.Content
+		it.ToolCalls = resp.Choices[0].Message.ToolCalls
+		it.FinishReason = resp.Choices[0].FinishReason
+	}
+
+	return resp, nil
+}
+
+// ChatStream performs a streaming chat completion and records it once the stream ends
+func (r *Recorder) ChatStream(ctx context.Context, req *ai.ChatRequest) (*ai.ChatStream, error) {
+	it := &Interaction{Request: chatRequestOf(req)}
+
+	stream, err := r.inner.ChatStream(ctx, req)
+	if err != nil {
+		it.Error = err.Error()
+		r.cassette.add(it)
+
+		return nil, err
+	}
+
+	out := ai.NewChatStream(ai.DefaultStreamBufferSize)
+	go func() {
+		defer r.cassette.add(it)
+
+		acc := ai.NewToolCallAccumulator()
+		var content strings.Builder
+		for {
+			chunk, err := stream.Recv()
+			if err != nil {
+				it.Content = content.String()
+				it.ToolCalls = acc.Calls()
+				if !errors.Is(err, ai.ErrStreamClosed) {
+					it.Error = err.Error()
+					out.CloseWithError(err)
+
+					return
+				}
+				out.Close()
+
+				return
+			}
+
+			if chunk.Usage != nil {
+				usage := *chunk.Usage
+				it.Usage = &usage
+			}
+			if len(chunk.Choices) > 0 {
+				choice := chunk.Choices[0]
+				if choice.Delta != nil {
+					if choice.Delta.Content != "" {
+						it.Chunks = append(it.Chunks, choice.Delta.Content)
+						content.WriteString(choice.Delta.Content)
+					}
+					acc.Add(choice.Delta.ToolCalls)
+				}
+				if choice.FinishReason != "" {
+					it.FinishReason = choice.FinishReason
+				}
+			}
+			out.Send(chunk)
+		}
+	}()
+
+	return out, nil
+}
+
+// Embed performs and records an embedding request
+func (r *Recorder) Embed(ctx context.Context, req *ai.EmbeddingRequest) (*ai.EmbeddingResponse, error) {
+	embedder, ok := r.inner.(ai.Embedder)
+	if !ok {
+		return nil, fmt.Errorf("provider %s does not support embeddings", r.inner.Name())
+	}
+
+	it := &Interaction{Request: Request{Model: req.Model, Input: req.Input}}
+	defer r.cassette.add(it)
+
+	resp, err := embedder.Embed(ctx, req)
+	if err != nil {
+		it.Error = err.Error()
+
+		return nil, err
+	}
+
+	usage := resp.Usage
+	it.Usage = &usage
+	for _, e := range resp.Data {
+		it.Embeddings = append(it.Embeddings, e.Embedding)
+	}
+
+	return resp, nil
+}
+
+// chatRequestOf extracts the replay key of a chat request
+func chatRequestOf(req *ai.ChatRequest) Request {
+	return Request{
+		Model:    req.Model,
+		Messages: req.Messages,
+		Tools:    req.Tools,
+	}
+}
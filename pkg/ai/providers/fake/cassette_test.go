@@ -0,0 +1,95 @@
+// ABOUTME: Record/replay unit tests.
+// ABOUTME: Tests recording a provider into a fixture file and replaying it.
+
+package fake
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bingo-project/bingo/pkg/ai"
+)
+
+func TestRecorder_RecordAndReplay(t *testing.T) {
+	// A scripted fake stands in for the real provider being recorded
+	upstream := New(DefaultConfig()).Enqueue(
+		Reply("first answer"),
+		Step{Content: "streamed answer", Chunks: []string{"streamed ", "answer"}},
+		Fail(ErrRateLimited),
+	)
+	rec := NewRecorder(upstream)
+	ctx := context.Background()
+
+	_, err := rec.Chat(ctx, chatRequest("one"))
+	require.NoError(t, err)
+
+	stream, err := rec.ChatStream(ctx, chatRequest("two"))
+	require.NoError(t, err)
+	_, _, err = drain(t, stream)
+	require.NoError(t, err)
+
+	_, err = rec.Chat(ctx, chatRequest("three"))
+	require.Error(t, err)
+
+	_, err = rec.Embed(ctx, &ai.EmbeddingRequest{Model: "fake-embedding", Input: []string{"doc"}})
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "fixtures", "chat.json")
+	require.NoError(t, rec.Save(path))
+
+	cassette, err := LoadCassette(path)
+	require.NoError(t, err)
+	require.Len(t, cassette.Interactions, 4)
+	assert.Equal(t, []string{"streamed ", "answer"}, cassette.Interactions[1].Chunks)
+
+	replay := New(DefaultConfig()).Replay(cassette)
+
+	// Replay is matched by request, not by order
+	_, err = replay.Chat(ctx, chatRequest("three"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "429")
+
+	stream, err = replay.ChatStream(ctx, chatRequest("two"))
+	require.NoError(t, err)
+	content, final, err := drain(t, stream)
+	require.NoError(t, err)
+	assert.Equal(t, "streamed answer", content)
+	assert.Equal(t, ai.FinishReasonStop, final.Choices[0].FinishReason)
+
+	resp, err := replay.Chat(ctx, chatRequest("one"))
+	require.NoError(t, err)
+	assert.Equal(t, "first answer", resp.Choices[0].Message.Content)
+
+	emb, err := replay.Embed(ctx, &ai.EmbeddingRequest{Model: "fake-embedding", Input: []string{"doc"}})
+	require.NoError(t, err)
+	assert.Equal(t, cassette.Interactions[3].Embeddings[0], emb.Data[0].Embedding)
+
+	// Each interaction is replayed once
+	_, err = replay.Chat(ctx, chatRequest("one"))
+	assert.Error(t, err)
+}
+
+func TestRecorder_EmbedUnsupported(t *testing.T) {
+	rec := NewRecorder(&chatOnly{New(DefaultConfig())})
+
+	_, err := rec.Embed(context.Background(), &ai.EmbeddingRequest{Model: "m", Input: []string{"x"}})
+	assert.Error(t, err)
+}
+
+// chatOnly hides the embedding capability of a provider
+type chatOnly struct {
+	p *Provider
+}
+
+func (c *chatOnly) Name() string           { return c.p.Name() }
+func (c *chatOnly) Models() []ai.ModelInfo { return c.p.Models() }
+func (c *chatOnly) Chat(ctx context.Context, req *ai.ChatRequest) (*ai.ChatResponse, error) {
+	return c.p.Chat(ctx, req)
+}
+func (c *chatOnly) ChatStream(ctx context.Context, req *ai.ChatRequest) (*ai.ChatStream, error) {
+	return c.p.ChatStream(ctx, req)
+}
@@ -0,0 +1,30 @@
+// ABOUTME: Fake provider configuration.
+// ABOUTME: Defines Config for the provider name, advertised models, and stream pacing.
+
+package fake
+
+import (
+	"time"
+
+	"github.com/bingo-project/bingo/pkg/ai"
+)
+
+// Config holds fake provider configuration
+type Config struct {
+	Name       string // Provider name, set it to impersonate a real provider in fallback tests
+	Models     []ai.ModelInfo
+	ChunkDelay time.Duration // Delay between stream chunks unless a step overrides it
+	Dimensions int           // Size of generated embedding vectors
+}
+
+// DefaultConfig returns default configuration for the fake provider
+func DefaultConfig() *Config {
+	return &Config{
+		Name: "fake",
+		Models: []ai.ModelInfo{
+			{ID: "fake-chat", Name: "Fake Chat", Provider: "fake", Type: ai.ModelTypeChat, MaxTokens: 8192},
+			{ID: "fake-embedding", Name: "Fake Embedding", Provider: "fake", Type: ai.ModelTypeEmbedding, MaxTokens: 8192},
+		},
+		Dimensions: 8,
+	}
+}
@@ -0,0 +1,364 @@
+// ABOUTME: Deterministic fake provider for tests and keyless development.
+// ABOUTME: Serves scripted steps, then recorded interactions, then echoes the last user message.
+
+package fake
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/bingo-project/bingo/pkg/ai"
+)
+
+// Provider implements ai.Provider and ai.Embedder without any network access.
+// Each call consumes the next scripted step; with none left it replays the cassette
+// if one is set, otherwise chat echoes the last user message and embeddings are
+// derived from a hash of the input.
+type Provider struct {
+	config *Config
+
+	mu                sync.Mutex
+	steps             []Step
+	cassette          *Cassette
+	requests          []ai.ChatRequest
+	embeddingRequests []ai.EmbeddingRequest
+}
+
+var (
+	_ ai.Provider = (*Provider)(nil)
+	_ ai.Embedder = (*Provider)(nil)
+)
+
+// New creates a new fake provider
+func New(cfg *Config) *Provider {
+	return &Provider{config: cfg}
+}
+
+// Name returns the provider name
+func (p *Provider) Name() string {
+	if p.config.Name != "" {
+		return p.config.Name
+	}
+
+	return "fake"
+}
+
+// Models returns available models
+func (p *Provider) Models() []ai.ModelInfo {
+	return p.config.Models
+}
+
+// Enqueue appends scripted steps
+func (p *Provider) Enqueue(steps ...Step) *Provider {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.steps = append(p.steps, steps...)
+
+	return p
+}
+
+// Replay serves calls from recorded interactions once the scripted steps run out
+func (p *Provider) Replay(c *Cassette) *Provider {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.cassette = c
+
+	return p
+}
+
+// Pending returns the number of scripted steps not consumed yet
+func (p *Provider) Pending() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return len(p.steps)
+}
+
+// Requests returns the chat requests received so far
+func (p *Provider) Requests() []ai.ChatRequest {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return append([]ai.ChatRequest(nil), p.requests...)
+}
+
+// EmbeddingRequests returns the embedding requests received so far
+func (p *Provider) EmbeddingRequests() []ai.EmbeddingRequest {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return append([]ai.EmbeddingRequest(nil), p.embeddingRequests...)
+}
+
+// Chat performs a non-streaming chat completion
+func (p *Provider) Chat(ctx context.Context, req *ai.ChatRequest) (*ai.ChatResponse, error) {
+	step, err := p.chatStep(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ai.ChatResponse{
+		ID:      ai.GenerateID(),
+		Object:  "chat.completion",
+		Created: time.Now().Unix(),
+		Model:   req.Model,
+		Choices: []ai.Choice{
+			{
+				Index: 0,
+				Message: ai.Message{
+					Role:      ai.RoleAssistant,
+					Content:   step.Content,
+					ToolCalls: step.ToolCalls,
+				},
+				FinishReason: finishReason(step),
+			},
+		},
+		Usage: usageOf(req, step),
+	}, nil
+}
+
+// ChatStream performs a streaming chat completion
+func (p *Provider) ChatStream(ctx context.Context, req *ai.ChatRequest) (*ai.ChatStream, error) {
+	step, err := p.chatStep(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	stream := ai.NewChatStream(ai.DefaultStreamBufferSize)
+	go p.pump(ctx, req, step, stream)
+
+	return stream, nil
+}
+
+// Embed creates embedding vectors
+func (p *Provider) Embed(ctx context.Context, req *ai.EmbeddingRequest) (*ai.EmbeddingResponse, error) {
+	p.mu.Lock()
+	p.embeddingRequests = append(p.embeddingRequests, *req)
+	p.mu.Unlock()
+
+	step, err := p.nextStep(ctx, Request{Model: req.Model, Input: req.Input}, Step{})
+	if err != nil {
+		return nil, err
+	}
+
+	dims := req.Dimensions
+	if dims <= 0 {
+		dims = p.config.Dimensions
+	}
+	if dims <= 0 {
+		dims = DefaultConfig().Dimensions
+	}
+
+	tok := ai.TokenizerFor(req.Model)
+	tokens := 0
+	data := make([]ai.Embedding, len(req.Input))
+	for i, text := range req.Input {
+		vec := vectorOf(text, dims)
+		if i < len(step.Embeddings) {
+			vec = step.Embeddings[i]
+		}
+		data[i] = ai.Embedding{Object: "embedding", Index: i, Embedding: vec}
+		tokens += tok.Count(text)
+	}
+
+	usage := ai.Usage{PromptTokens: tokens, TotalTokens: tokens}
+	if step.Usage != nil {
+		usage = *step.Usage
+	}
+
+	return &ai.EmbeddingResponse{
+		Object: "list",
+		Data:   data,
+		Model:  req.Model,
+		Usage:  usage,
+	}, nil
+}
+
+// chatStep records a chat request and resolves the step answering it
+func (p *Provider) chatStep(ctx context.Context, req *ai.ChatRequest) (Step, error) {
+	p.mu.Lock()
+	p.requests = append(p.requests, *req)
+	p.mu.Unlock()
+
+	return p.nextStep(ctx, chatRequestOf(req), Reply(lastUserText(req.Messages)))
+}
+
+// nextStep pops a scripted step, falls back to the cassette and then to the default,
+// and applies the step's delay and error.
+func (p *Provider) nextStep(ctx context.Context, key Request, fallback Step) (Step, error) {
+	p.mu.Lock()
+	var step Step
+	switch {
+	case len(p.steps) > 0:
+		step = p.steps[0]
+		p.steps = p.steps[1:]
+	case p.cassette != nil:
+		it, ok := p.cassette.match(key)
+		if !ok {
+			p.mu.Unlock()
+
+			return Step{}, fmt.Errorf("fake: no recorded interaction for a %s request", key.Model)
+		}
+		step = it.step()
+	default:
+		step = fallback
+	}
+	p.mu.Unlock()
+
+	if err := wait(ctx, step.Delay); err != nil {
+		return Step{}, err
+	}
+	if step.Err != nil {
+		return Step{}, step.Err
+	}
+
+	return step, nil
+}
+
+// pump streams a step as content deltas, one tool call chunk, and a final chunk with usage
+func (p *Provider) pump(ctx context.Context, req *ai.ChatRequest, step Step, stream *ai.ChatStream) {
+	id := ai.GenerateID()
+	delay := step.ChunkDelay
+	if delay <= 0 {
+		delay = p.config.ChunkDelay
+	}
+
+	chunks := step.Chunks
+	if chunks == nil && step.Content != "" {
+		chunks = strings.SplitAfter(step.Content, " ")
+	}
+	for _, text := range chunks {
+		if err := wait(ctx, delay); err != nil {
+			stream.CloseWithError(err)
+
+			return
+		}
+		stream.Send(newStreamChunk(id, req.Model, &ai.Message{Role: ai.RoleAssistant, Content: text}, ""))
+	}
+
+	if len(step.ToolCalls) > 0 {
+		calls := make([]ai.ToolCall, len(step.ToolCalls))
+		for i, c := range step.ToolCalls {
+			idx := i
+			calls[i] = c
+			calls[i].Index = &idx
+		}
+		stream.Send(newStreamChunk(id, req.Model, &ai.Message{Role: ai.RoleAssistant, ToolCalls: calls}, ""))
+	}
+
+	if step.StreamErr != nil {
+		stream.CloseWithError(step.StreamErr)
+
+		return
+	}
+
+	final := newStreamChunk(id, req.Model, &ai.Message{}, finishReason(step))
+	usage := usageOf(req, step)
+	final.Usage = &usage
+	stream.Send(final)
+	stream.Close()
+}
+
+// newStreamChunk builds an OpenAI-compatible stream chunk
+func newStreamChunk(id, modelName string, delta *ai.Message, finishReason string) *ai.StreamChunk {
+	return &ai.StreamChunk{
+		ID:      id,
+		Object:  "chat.completion.chunk",
+		Created: time.Now().Unix(),
+		Model:   modelName,
+		Choices: []ai.Choice{
+			{
+				Index:        0,
+				Delta:        delta,
+				FinishReason: finishReason,
+			},
+		},
+	}
+}
+
+// finishReason returns the scripted finish reason or derives it from the reply
+func finishReason(step Step) string {
+	if step.FinishReason != "" {
+		return step.FinishReason
+	}
+
+	return ai.NormalizeFinishReason("", len(step.ToolCalls) > 0)
+}
+
+// usageOf returns the scripted usage or estimates it with the model's tokenizer
+func usageOf(req *ai.ChatRequest, step Step) ai.Usage {
+	if step.Usage != nil {
+		return *step.Usage
+	}
+
+	tok := ai.TokenizerFor(req.Model)
+	prompt := tok.CountMessages(req.Messages)
+	completion := tok.CountMessage(ai.Message{Role: ai.RoleAssistant, Content: step.Content, ToolCalls: step.ToolCalls})
+
+	return ai.Usage{
+		PromptTokens:     prompt,
+		CompletionTokens: completion,
+		TotalTokens:      prompt + completion,
+	}
+}
+
+// lastUserText returns the text of the latest user message
+func lastUserText(msgs []ai.Message) string {
+	for i := len(msgs) - 1; i >= 0; i-- {
+		if msgs[i].Role != ai.RoleUser {
+			continue
+		}
+		if len(msgs[i].Parts) > 0 {
+			return ai.TextOf(msgs[i].Parts)
+		}
+
+		return msgs[i].Content
+	}
+
+	return ""
+}
+
+// vectorOf derives a deterministic unit vector from text, equal texts get equal vectors
+func vectorOf(text string, dims int) []float32 {
+	vec := make([]float32, dims)
+	var norm float64
+	for i := range vec {
+		h := fnv.New64a()
+		_, _ = h.Write([]byte{byte(i), byte(i >> 8)})
+		_, _ = h.Write([]byte(text))
+		x := float64(h.Sum64()%2001)/1000 - 1
+		vec[i] = float32(x)
+		norm += x * x
+	}
+
+	if norm > 0 {
+		scale := 1 / math.Sqrt(norm)
+		for i := range vec {
+			vec[i] *= float32(scale)
+		}
+	}
+
+	return vec
+}
+
+// wait sleeps for d unless the context ends first
+func wait(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
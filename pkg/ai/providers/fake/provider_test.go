@@ -0,0 +1,199 @@
+// ABOUTME: Fake provider unit tests.
+// ABOUTME: Tests scripted replies, streaming, error injection and embeddings.
+
+package fake
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bingo-project/bingo/pkg/ai"
+)
+
+func chatRequest(text string) *ai.ChatRequest {
+	return &ai.ChatRequest{
+		Model:    "fake-chat",
+		Messages: []ai.Message{{Role: ai.RoleUser, Content: text}},
+	}
+}
+
+// drain reads a stream to the end and returns its content and final chunk
+func drain(t *testing.T, stream *ai.ChatStream) (string, *ai.StreamChunk, error) {
+	t.Helper()
+
+	var content string
+	var final *ai.StreamChunk
+	for {
+		chunk, err := stream.Recv()
+		if errors.Is(err, ai.ErrStreamClosed) {
+			return content, final, nil
+		}
+		if err != nil {
+			return content, final, err
+		}
+		content += chunk.Choices[0].Delta.Content
+		if chunk.Choices[0].FinishReason != "" {
+			final = chunk
+		}
+	}
+}
+
+func TestProvider_Registry(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Name = "openai"
+
+	registry := ai.NewRegistry()
+	registry.Register(New(cfg))
+
+	p, ok := registry.Get("openai")
+	require.True(t, ok)
+	assert.Equal(t, "openai", p.Name())
+	_, ok = registry.GetEmbedder("openai")
+	assert.True(t, ok)
+	_, ok = registry.GetModel("fake-chat")
+	assert.True(t, ok)
+}
+
+func TestProvider_Chat_Echo(t *testing.T) {
+	p := New(DefaultConfig())
+
+	resp, err := p.Chat(context.Background(), chatRequest("hello there"))
+	require.NoError(t, err)
+	assert.Equal(t, "hello there", resp.Choices[0].Message.Content)
+	assert.Equal(t, ai.FinishReasonStop, resp.Choices[0].FinishReason)
+	assert.Positive(t, resp.Usage.PromptTokens)
+	assert.Equal(t, resp.Usage.PromptTokens+resp.Usage.CompletionTokens, resp.Usage.TotalTokens)
+	require.Len(t, p.Requests(), 1)
+}
+
+func TestProvider_Chat_Script(t *testing.T) {
+	p := New(DefaultConfig()).Enqueue(
+		Fail(ErrRateLimited),
+		ReplyToolCalls(ai.ToolCall{Function: ai.FunctionCall{Name: "lookup", Arguments: `{"q":"x"}`}}),
+		Step{Content: "done", Usage: &ai.Usage{PromptTokens: 1, CompletionTokens: 2, TotalTokens: 3}},
+	)
+
+	_, err := p.Chat(context.Background(), chatRequest("hi"))
+	require.ErrorIs(t, err, ErrRateLimited)
+	assert.Contains(t, err.Error(), "429")
+
+	resp, err := p.Chat(context.Background(), chatRequest("hi"))
+	require.NoError(t, err)
+	assert.Equal(t, ai.FinishReasonToolCalls, resp.Choices[0].FinishReason)
+	assert.Equal(t, "call_fake_0", resp.Choices[0].Message.ToolCalls[0].ID)
+
+	resp, err = p.Chat(context.Background(), chatRequest("hi"))
+	require.NoError(t, err)
+	assert.Equal(t, "done", resp.Choices[0].Message.Content)
+	assert.Equal(t, ai.Usage{PromptTokens: 1, CompletionTokens: 2, TotalTokens: 3}, resp.Usage)
+	assert.Zero(t, p.Pending())
+}
+
+func TestProvider_Chat_Timeout(t *testing.T) {
+	p := New(DefaultConfig()).Enqueue(Step{Content: "late", Delay: time.Second})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := p.Chat(ctx, chatRequest("hi"))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestProvider_ChatStream(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.ChunkDelay = time.Millisecond
+	p := New(cfg).Enqueue(Reply("one two three"))
+
+	stream, err := p.ChatStream(context.Background(), chatRequest("hi"))
+	require.NoError(t, err)
+
+	content, final, err := drain(t, stream)
+	require.NoError(t, err)
+	assert.Equal(t, "one two three", content)
+	require.NotNil(t, final)
+	assert.Equal(t, ai.FinishReasonStop, final.Choices[0].FinishReason)
+	assert.NotNil(t, final.Usage)
+}
+
+func TestProvider_ChatStream_ToolCalls(t *testing.T) {
+	p := New(DefaultConfig()).Enqueue(ReplyToolCalls(
+		ai.ToolCall{Function: ai.FunctionCall{Name: "a", Arguments: "{}"}},
+		ai.ToolCall{Function: ai.FunctionCall{Name: "b", Arguments: "{}"}},
+	))
+
+	stream, err := p.ChatStream(context.Background(), chatRequest("hi"))
+	require.NoError(t, err)
+
+	acc := ai.NewToolCallAccumulator()
+	var finishReason string
+	for {
+		chunk, err := stream.Recv()
+		if errors.Is(err, ai.ErrStreamClosed) {
+			break
+		}
+		require.NoError(t, err)
+		acc.Add(chunk.Choices[0].Delta.ToolCalls)
+		if chunk.Choices[0].FinishReason != "" {
+			finishReason = chunk.Choices[0].FinishReason
+		}
+	}
+
+	require.Len(t, acc.Calls(), 2)
+	assert.Equal(t, ai.FinishReasonToolCalls, finishReason)
+}
+
+func TestProvider_ChatStream_Errors(t *testing.T) {
+	p := New(DefaultConfig()).Enqueue(
+		Fail(ErrUnavailable),
+		Step{Chunks: []string{"par", "tial"}, StreamErr: ErrTimeout},
+	)
+
+	_, err := p.ChatStream(context.Background(), chatRequest("hi"))
+	require.ErrorIs(t, err, ErrUnavailable)
+
+	stream, err := p.ChatStream(context.Background(), chatRequest("hi"))
+	require.NoError(t, err)
+	content, final, err := drain(t, stream)
+	assert.ErrorIs(t, err, ErrTimeout)
+	assert.Equal(t, "partial", content)
+	assert.Nil(t, final)
+}
+
+func TestProvider_ChatStream_Cancel(t *testing.T) {
+	p := New(DefaultConfig()).Enqueue(Step{Content: "a b c", ChunkDelay: time.Second})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream, err := p.ChatStream(ctx, chatRequest("hi"))
+	require.NoError(t, err)
+	cancel()
+
+	_, _, err = drain(t, stream)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestProvider_Embed(t *testing.T) {
+	p := New(DefaultConfig())
+
+	resp, err := p.Embed(context.Background(), &ai.EmbeddingRequest{
+		Model: "fake-embedding",
+		Input: []string{"same", "other", "same"},
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Data, 3)
+	assert.Len(t, resp.Data[0].Embedding, 8)
+	assert.Equal(t, resp.Data[0].Embedding, resp.Data[2].Embedding)
+	assert.NotEqual(t, resp.Data[0].Embedding, resp.Data[1].Embedding)
+	assert.InDelta(t, 1.0, ai.CosineSimilarity(resp.Data[0].Embedding, resp.Data[2].Embedding), 1e-6)
+	assert.Positive(t, resp.Usage.TotalTokens)
+
+	p.Enqueue(Step{Embeddings: [][]float32{{1, 0}}})
+	resp, err = p.Embed(context.Background(), &ai.EmbeddingRequest{Model: "fake-embedding", Input: []string{"x"}})
+	require.NoError(t, err)
+	assert.Equal(t, []float32{1, 0}, resp.Data[0].Embedding)
+	assert.Len(t, p.EmbeddingRequests(), 2)
+}
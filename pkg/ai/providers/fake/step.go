@@ -0,0 +1,81 @@
+// ABOUTME: Scripted replies and injectable errors for the fake provider.
+// ABOUTME: Steps are consumed in call order and can delay, stream, or fail.
+
+package fake
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/bingo-project/bingo/pkg/ai"
+)
+
+// Injectable errors, their messages are classified like real provider errors by ai.Do and the health checker
+var (
+	ErrRateLimited = &StatusError{StatusCode: http.StatusTooManyRequests, Message: "rate_limit_reached"}
+	ErrUnavailable = &StatusError{StatusCode: http.StatusServiceUnavailable, Message: "service unavailable"}
+	ErrTimeout     = errors.New("fake: request timeout")
+)
+
+// StatusError is an HTTP-style provider error
+type StatusError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("fake: status %d: %s", e.StatusCode, e.Message)
+}
+
+// Step is one scripted provider reply
+type Step struct {
+	Content      string
+	ToolCalls    []ai.ToolCall
+	FinishReason string    // Derived from the reply when empty
+	Usage        *ai.Usage // Estimated with the model's tokenizer when nil
+
+	Chunks     []string      // Stream deltas, defaults to Content split after spaces
+	ChunkDelay time.Duration // Overrides Config.ChunkDelay
+	StreamErr  error         // Closes the stream with this error after the chunks
+
+	Embeddings [][]float32 // Embed reply, generated from the input when nil
+
+	Delay time.Duration // Wait before replying, a delay beyond the context deadline simulates a timeout
+	Err   error         // Returned instead of a reply
+}
+
+// Reply returns a step answering with content
+func Reply(content string) Step {
+	return Step{Content: content}
+}
+
+// ReplyToolCalls returns a step calling tools with JSON arguments
+func ReplyToolCalls(calls ...ai.ToolCall) Step {
+	for i := range calls {
+		if calls[i].ID == "" {
+			calls[i].ID = fmt.Sprintf("call_fake_%d", i)
+		}
+		if calls[i].Type == "" {
+			calls[i].Type = ai.ToolTypeFunction
+		}
+	}
+
+	return Step{ToolCalls: calls}
+}
+
+// Fail returns a step failing with err
+func Fail(err error) Step {
+	return Step{Err: err}
+}
+
+// Repeat returns n copies of step
+func Repeat(step Step, n int) []Step {
+	steps := make([]Step, n)
+	for i := range steps {
+		steps[i] = step
+	}
+
+	return steps
+}
@@ -0,0 +1,54 @@
+// ABOUTME: Gemini embeddings implementation.
+// ABOUTME: Uses the genai EmbedContent API for text embeddings.
+
+package gemini
+
+import (
+	"context"
+	"unicode/utf8"
+
+	"google.golang.org/genai"
+
+	"github.com/bingo-project/bingo/pkg/ai"
+)
+
+var _ ai.Embedder = (*Provider)(nil)
+
+// Embed creates embedding vectors via EmbedContent
+func (p *Provider) Embed(ctx context.Context, req *ai.EmbeddingRequest) (*ai.EmbeddingResponse, error) {
+	contents := make([]*genai.Content, len(req.Input))
+	for i, text := range req.Input {
+		contents[i] = genai.NewContentFromText(text, genai.RoleUser)
+	}
+
+	cfg := &genai.EmbedContentConfig{}
+	if req.Dimensions > 0 {
+		dims := int32(req.Dimensions)
+		cfg.OutputDimensionality = &dims
+	}
+
+	return ai.Do(ctx, ai.DefaultRetryConfig, func(ctx context.Context) (*ai.EmbeddingResponse, error) {
+		resp, err := p.genai.Models.EmbedContent(ctx, req.Model, contents, cfg)
+		if err != nil {
+			return nil, err
+		}
+
+		result := &ai.EmbeddingResponse{
+			Object: "list",
+			Model:  req.Model,
+			Data:   make([]ai.Embedding, len(resp.Embeddings)),
+		}
+		for i, e := range resp.Embeddings {
+			result.Data[i] = ai.Embedding{Object: "embedding", Index: i, Embedding: e.Values}
+		}
+
+		// Gemini API does not report token usage for embeddings, estimate ~4 chars per token
+		tokens := 0
+		for _, text := range req.Input {
+			tokens += (utf8.RuneCountInString(text) + 3) / 4
+		}
+		result.Usage = ai.Usage{PromptTokens: tokens, TotalTokens: tokens}
+
+		return result, nil
+	})
+}
@@ -5,11 +5,12 @@ package gemini
 
 import (
 	"context"
-	"io"
-	"time"
+	"encoding/json"
+	"fmt"
 
 	"github.com/cloudwego/eino-ext/components/model/gemini"
 	"github.com/cloudwego/eino/components/model"
+	"github.com/eino-contrib/jsonschema"
 	"google.golang.org/genai"
 
 	"github.com/bingo-project/bingo/pkg/ai"
@@ -19,6 +20,7 @@ import (
 type Provider struct {
 	config *Config
 	client *gemini.ChatModel
+	genai  *genai.Client
 }
 
 var _ ai.Provider = (*Provider)(nil)
@@ -45,6 +47,7 @@ func New(cfg *Config) (*Provider, error) {
 	return &Provider{
 		config: cfg,
 		client: client,
+		genai:  genaiClient,
 	}, nil
 }
 
@@ -60,16 +63,11 @@ func (p *Provider) Models() []ai.ModelInfo {
 
 // Chat performs a non-streaming chat completion
 func (p *Provider) Chat(ctx context.Context, req *ai.ChatRequest) (*ai.ChatResponse, error) {
-	messages := ai.ConvertMessages(req.Messages)
+	messages := ai.ConvertMessages(ai.WithFormatInstruction(req.Messages, req.ResponseFormat, true))
 
-	opts := []model.Option{
-		model.WithModel(req.Model),
-	}
-	if req.MaxTokens > 0 {
-		opts = append(opts, model.WithMaxTokens(req.MaxTokens))
-	}
-	if req.Temperature > 0 {
-		opts = append(opts, model.WithTemperature(float32(req.Temperature)))
+	opts, err := buildOptions(req)
+	if err != nil {
+		return nil, err
 	}
 
 	return ai.Do(ctx, ai.DefaultRetryConfig, func(ctx context.Context) (*ai.ChatResponse, error) {
@@ -84,16 +82,11 @@ func (p *Provider) Chat(ctx context.Context, req *ai.ChatRequest) (*ai.ChatRespo
 
 // ChatStream performs a streaming chat completion
 func (p *Provider) ChatStream(ctx context.Context, req *ai.ChatRequest) (*ai.ChatStream, error) {
-	messages := ai.ConvertMessages(req.Messages)
+	messages := ai.ConvertMessages(ai.WithFormatInstruction(req.Messages, req.ResponseFormat, true))
 
-	opts := []model.Option{
-		model.WithModel(req.Model),
-	}
-	if req.MaxTokens > 0 {
-		opts = append(opts, model.WithMaxTokens(req.MaxTokens))
-	}
-	if req.Temperature > 0 {
-		opts = append(opts, model.WithTemperature(float32(req.Temperature)))
+	opts, err := buildOptions(req)
+	if err != nil {
+		return nil, err
 	}
 
 	stream, err := p.client.Stream(ctx, messages, opts...)
@@ -101,57 +94,26 @@ func (p *Provider) ChatStream(ctx context.Context, req *ai.ChatRequest) (*ai.Cha
 		return nil, err
 	}
 
-	chatStream := ai.NewChatStream(ai.DefaultStreamBufferSize)
-
-	go func() {
-		defer chatStream.Close()
-
-		id := ai.GenerateID()
-		var lastUsage *ai.Usage
-
-		for {
-			select {
-			case <-ctx.Done():
-				chatStream.CloseWithError(ctx.Err())
-
-				return
-			default:
-				chunk, err := stream.Recv()
-				if err != nil {
-					if err == io.EOF {
-						chatStream.Send(&ai.StreamChunk{
-							ID:      id,
-							Object:  "chat.completion.chunk",
-							Created: time.Now().Unix(),
-							Model:   req.Model,
-							Choices: []ai.Choice{
-								{
-									Index:        0,
-									Delta:        &ai.Message{},
-									FinishReason: "stop",
-								},
-							},
-							Usage: lastUsage,
-						})
-					} else {
-						chatStream.CloseWithError(err)
-					}
-
-					return
-				}
-
-				if chunk.ResponseMeta != nil && chunk.ResponseMeta.Usage != nil {
-					lastUsage = &ai.Usage{
-						PromptTokens:     chunk.ResponseMeta.Usage.PromptTokens,
-						CompletionTokens: chunk.ResponseMeta.Usage.CompletionTokens,
-						TotalTokens:      chunk.ResponseMeta.Usage.TotalTokens,
-					}
-				}
-
-				chatStream.Send(ai.ConvertStreamChunk(chunk, req.Model, id))
-			}
+	return ai.PipeStream(ctx, stream, req.Model), nil
+}
+
+// buildOptions builds Eino model options, sending a JSON schema response format natively
+func buildOptions(req *ai.ChatRequest) ([]model.Option, error) {
+	opts, err := ai.BuildOptions(req)
+	if err != nil {
+		return nil, err
+	}
+	if f := req.ResponseFormat; f != nil && f.Type == ai.ResponseFormatJSONSchema {
+		raw, err := json.Marshal(f.Schema)
+		if err != nil {
+			return nil, fmt.Errorf("marshal response schema: %w", err)
 		}
-	}()
+		var s jsonschema.Schema
+		if err := json.Unmarshal(raw, &s); err != nil {
+			return nil, fmt.Errorf("invalid response schema: %w", err)
+		}
+		opts = append(opts, gemini.WithResponseJSONSchema(&s))
+	}
 
-	return chatStream, nil
+	return opts, nil
 }
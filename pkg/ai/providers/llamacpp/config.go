@@ -0,0 +1,22 @@
+// ABOUTME: llama.cpp server provider configuration.
+// ABOUTME: Defines Config for the local server URL, optional API key, and model settings.
+
+package llamacpp
+
+import "github.com/bingo-project/bingo/pkg/ai"
+
+// Config holds llama.cpp provider configuration
+type Config struct {
+	Name    string // Provider name, defaults to "llamacpp"
+	APIKey  string // Optional, only needed when the server runs with --api-key
+	BaseURL string // OpenAI-compatible base URL including /v1
+	Models  []ai.ModelInfo
+}
+
+// DefaultConfig returns default configuration for a local llama-server
+func DefaultConfig() *Config {
+	return &Config{
+		Name:    "llamacpp",
+		BaseURL: "http://localhost:8080/v1",
+	}
+}
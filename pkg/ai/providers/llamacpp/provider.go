@@ -0,0 +1,135 @@
+// ABOUTME: llama.cpp server provider implementation.
+// ABOUTME: Reuses the OpenAI-compatible client and adds discovery of the loaded models.
+
+package llamacpp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/bingo-project/bingo/pkg/ai"
+	"github.com/bingo-project/bingo/pkg/ai/providers/openai"
+)
+
+// discoveryClient is used for model discovery requests
+var discoveryClient = &http.Client{Timeout: 30 * time.Second}
+
+// Provider implements ai.Provider for llama.cpp's llama-server.
+// Chat, streaming and embeddings go through its OpenAI-compatible endpoints.
+type Provider struct {
+	*openai.Provider
+	config *Config
+}
+
+var (
+	_ ai.Provider    = (*Provider)(nil)
+	_ ai.Embedder    = (*Provider)(nil)
+	_ ai.ModelLister = (*Provider)(nil)
+)
+
+// New creates a new llama.cpp provider
+func New(cfg *Config) (*Provider, error) {
+	if cfg.BaseURL == "" {
+		return nil, errors.New("llamacpp base url is required")
+	}
+
+	name := cfg.Name
+	if name == "" {
+		name = "llamacpp"
+	}
+
+	p, err := openai.New(&openai.Config{
+		Name:    name,
+		APIKey:  cfg.APIKey,
+		BaseURL: cfg.BaseURL,
+		Models:  cfg.Models,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Provider{Provider: p, config: cfg}, nil
+}
+
+// ListModels lists the models loaded by the server.
+// The context size is the server's per-slot n_ctx, falling back to the trained context length.
+func (p *Provider) ListModels(ctx context.Context) ([]ai.ModelInfo, error) {
+	baseURL := strings.TrimSuffix(p.config.BaseURL, "/")
+
+	var list modelsResponse
+	if err := p.get(ctx, baseURL+"/models", &list); err != nil {
+		return nil, err
+	}
+
+	// /props lives at the server root and is optional, older servers lack it
+	var props propsResponse
+	_ = p.get(ctx, strings.TrimSuffix(baseURL, "/v1")+"/props", &props)
+
+	models := make([]ai.ModelInfo, 0, len(list.Data))
+	for _, m := range list.Data {
+		maxTokens := props.DefaultGenerationSettings.NCtx
+		if maxTokens <= 0 {
+			maxTokens = m.Meta.NCtxTrain
+		}
+		models = append(models, ai.ModelInfo{
+			ID:        m.ID,
+			Name:      m.ID,
+			Provider:  p.Name(),
+			Type:      ai.ModelTypeChat,
+			MaxTokens: maxTokens,
+		})
+	}
+
+	return models, nil
+}
+
+// get sends a GET request and decodes the JSON response
+func (p *Provider) get(ctx context.Context, url string, out any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	if p.config.APIKey != "" {
+		req.Header.Set("Authorization", "Bearer "+p.config.APIKey)
+	}
+
+	resp, err := discoveryClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+
+		return fmt.Errorf("llamacpp request failed with status %d: %s", resp.StatusCode, strings.TrimSpace(string(data)))
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decode llamacpp response: %w", err)
+	}
+
+	return nil
+}
+
+// modelsResponse is the /v1/models response body
+type modelsResponse struct {
+	Data []struct {
+		ID   string `json:"id"`
+		Meta struct {
+			NCtxTrain int `json:"n_ctx_train"`
+		} `json:"meta"`
+	} `json:"data"`
+}
+
+// propsResponse is the subset of /props used for discovery
+type propsResponse struct {
+	DefaultGenerationSettings struct {
+		NCtx int `json:"n_ctx"`
+	} `json:"default_generation_settings"`
+}
@@ -0,0 +1,72 @@
+// ABOUTME: llama.cpp provider unit tests.
+// ABOUTME: Tests provider creation and model discovery against a fake server.
+
+package llamacpp
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bingo-project/bingo/pkg/ai"
+)
+
+func TestProvider_Name(t *testing.T) {
+	p, err := New(DefaultConfig())
+	require.NoError(t, err)
+	assert.Equal(t, "llamacpp", p.Name())
+
+	var _ ai.Provider = p
+	var _ ai.ModelLister = p
+}
+
+func TestNew_RequiresBaseURL(t *testing.T) {
+	_, err := New(&Config{})
+	assert.Error(t, err)
+}
+
+func TestProvider_ListModels(t *testing.T) {
+	props := true
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/models":
+			_, _ = w.Write([]byte(`{"object":"list","data":[{"id":"qwen2.5-7b-instruct-q4_k_m.gguf","object":"model","meta":{"n_ctx_train":32768}}]}`))
+		case "/props":
+			if !props {
+				w.WriteHeader(http.StatusNotFound)
+
+				return
+			}
+			_, _ = w.Write([]byte(`{"default_generation_settings":{"n_ctx":8192}}`))
+		default:
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+
+	cfg := DefaultConfig()
+	cfg.BaseURL = srv.URL + "/v1"
+	p, err := New(cfg)
+	require.NoError(t, err)
+
+	models, err := p.ListModels(context.Background())
+	require.NoError(t, err)
+	require.Len(t, models, 1)
+	assert.Equal(t, ai.ModelInfo{
+		ID:        "qwen2.5-7b-instruct-q4_k_m.gguf",
+		Name:      "qwen2.5-7b-instruct-q4_k_m.gguf",
+		Provider:  "llamacpp",
+		Type:      ai.ModelTypeChat,
+		MaxTokens: 8192,
+	}, models[0])
+
+	// Falls back to the trained context length without /props
+	props = false
+	models, err = p.ListModels(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 32768, models[0].MaxTokens)
+}
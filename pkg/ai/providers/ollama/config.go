@@ -0,0 +1,28 @@
+// ABOUTME: Ollama provider configuration.
+// ABOUTME: Defines Config for the local server URL, optional API key, and model settings.
+
+package ollama
+
+import (
+	"time"
+
+	"github.com/bingo-project/bingo/pkg/ai"
+)
+
+// Config holds Ollama provider configuration
+type Config struct {
+	Name    string // Provider name, defaults to "ollama"
+	APIKey  string // Optional, only needed behind an authenticating proxy
+	BaseURL string
+	Timeout time.Duration // Timeout of non-streaming requests, local models may load slowly
+	Models  []ai.ModelInfo
+}
+
+// DefaultConfig returns default configuration for a local Ollama server
+func DefaultConfig() *Config {
+	return &Config{
+		Name:    "ollama",
+		BaseURL: "http://localhost:11434",
+		Timeout: 5 * time.Minute,
+	}
+}
@@ -0,0 +1,56 @@
+// ABOUTME: Ollama embeddings implementation.
+// ABOUTME: Calls the native /api/embed endpoint and maps it to the OpenAI-compatible response.
+
+package ollama
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/bingo-project/bingo/pkg/ai"
+)
+
+// Embed creates embedding vectors via the /api/embed endpoint
+func (p *Provider) Embed(ctx context.Context, req *ai.EmbeddingRequest) (*ai.EmbeddingResponse, error) {
+	body := embedRequest{
+		Model:      req.Model,
+		Input:      req.Input,
+		Dimensions: req.Dimensions,
+	}
+
+	return ai.Do(ctx, ai.DefaultRetryConfig, func(ctx context.Context) (*ai.EmbeddingResponse, error) {
+		var resp embedResponse
+		if err := p.call(ctx, http.MethodPost, "/api/embed", body, &resp); err != nil {
+			return nil, err
+		}
+
+		data := make([]ai.Embedding, len(resp.Embeddings))
+		for i, vec := range resp.Embeddings {
+			data[i] = ai.Embedding{Object: "embedding", Index: i, Embedding: vec}
+		}
+
+		return &ai.EmbeddingResponse{
+			Object: "list",
+			Data:   data,
+			Model:  req.Model,
+			Usage: ai.Usage{
+				PromptTokens: resp.PromptEvalCount,
+				TotalTokens:  resp.PromptEvalCount,
+			},
+		}, nil
+	})
+}
+
+// embedRequest is the /api/embed request body
+type embedRequest struct {
+	Model      string   `json:"model"`
+	Input      []string `json:"input"`
+	Dimensions int      `json:"dimensions,omitempty"`
+}
+
+// embedResponse is the /api/embed response body
+type embedResponse struct {
+	Model           string      `json:"model"`
+	Embeddings      [][]float32 `json:"embeddings"`
+	PromptEvalCount int         `json:"prompt_eval_count"`
+}
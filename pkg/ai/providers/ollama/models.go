@@ -0,0 +1,88 @@
+// ABOUTME: Ollama local model discovery.
+// ABOUTME: Lists pulled models with their context size and type for import into ai_model.
+
+package ollama
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/bingo-project/bingo/pkg/ai"
+)
+
+// ListModels lists the models pulled on the Ollama server.
+// Context size and type come from /api/show and are left unset if it fails.
+func (p *Provider) ListModels(ctx context.Context) ([]ai.ModelInfo, error) {
+	var tags tagsResponse
+	if err := p.call(ctx, http.MethodGet, "/api/tags", nil, &tags); err != nil {
+		return nil, err
+	}
+
+	models := make([]ai.ModelInfo, 0, len(tags.Models))
+	for _, m := range tags.Models {
+		info := ai.ModelInfo{
+			ID:       m.Name,
+			Name:     m.Name,
+			Provider: p.Name(),
+			Type:     ai.ModelTypeChat,
+		}
+
+		var show showResponse
+		if err := p.call(ctx, http.MethodPost, "/api/show", showRequest{Model: m.Name}, &show); err == nil {
+			info.MaxTokens = show.contextLength()
+			if show.isEmbedding() {
+				info.Type = ai.ModelTypeEmbedding
+			}
+		}
+		models = append(models, info)
+	}
+
+	return models, nil
+}
+
+// tagsResponse is the /api/tags response body
+type tagsResponse struct {
+	Models []struct {
+		Name string `json:"name"`
+	} `json:"models"`
+}
+
+// showRequest is the /api/show request body
+type showRequest struct {
+	Model string `json:"model"`
+}
+
+// showResponse is the subset of /api/show used for discovery
+type showResponse struct {
+	Parameters   string         `json:"parameters"`
+	ModelInfo    map[string]any `json:"model_info"`
+	Capabilities []string       `json:"capabilities"`
+}
+
+// contextLength prefers num_ctx set in the Modelfile over the trained context length
+func (r *showResponse) contextLength() int {
+	for _, line := range strings.Split(r.Parameters, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 2 && fields[0] == "num_ctx" {
+			if n, err := strconv.Atoi(fields[1]); err == nil && n > 0 {
+				return n
+			}
+		}
+	}
+
+	for key, value := range r.ModelInfo {
+		if n, ok := value.(float64); ok && strings.HasSuffix(key, ".context_length") {
+			return int(n)
+		}
+	}
+
+	return 0
+}
+
+// isEmbedding reports whether the model only produces embeddings
+func (r *showResponse) isEmbedding() bool {
+	return slices.Contains(r.Capabilities, "embedding") && !slices.Contains(r.Capabilities, "completion")
+}
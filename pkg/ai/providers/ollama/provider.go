@@ -0,0 +1,442 @@
+// ABOUTME: Ollama provider implementation over the native REST API.
+// ABOUTME: Supports chat completion, NDJSON streaming, tool calls and inline images.
+
+package ollama
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/bingo-project/bingo/pkg/ai"
+)
+
+// maxStreamLineBytes bounds a single NDJSON line of a streamed response
+const maxStreamLineBytes = 4 << 20
+
+// streamClient has no overall timeout, streams are bounded by the request context
+var streamClient = &http.Client{}
+
+// Provider implements ai.Provider for Ollama
+type Provider struct {
+	config *Config
+	client *http.Client
+}
+
+var (
+	_ ai.Provider    = (*Provider)(nil)
+	_ ai.Embedder    = (*Provider)(nil)
+	_ ai.ModelLister = (*Provider)(nil)
+)
+
+// New creates a new Ollama provider
+func New(cfg *Config) (*Provider, error) {
+	if cfg.BaseURL == "" {
+		return nil, errors.New("ollama base url is required")
+	}
+
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = DefaultConfig().Timeout
+	}
+
+	return &Provider{
+		config: cfg,
+		client: &http.Client{Timeout: timeout},
+	}, nil
+}
+
+// Name returns the provider name
+func (p *Provider) Name() string {
+	if p.config.Name != "" {
+		return p.config.Name
+	}
+
+	return "ollama"
+}
+
+// Models returns available models
+func (p *Provider) Models() []ai.ModelInfo {
+	return p.config.Models
+}
+
+// Chat performs a non-streaming chat completion
+func (p *Provider) Chat(ctx context.Context, req *ai.ChatRequest) (*ai.ChatResponse, error) {
+	body, err := p.buildRequest(req, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return ai.Do(ctx, ai.DefaultRetryConfig, func(ctx context.Context) (*ai.ChatResponse, error) {
+		var resp chatResponse
+		if err := p.call(ctx, http.MethodPost, "/api/chat", body, &resp); err != nil {
+			return nil, err
+		}
+
+		return convertResponse(&resp, req.Model), nil
+	})
+}
+
+// ChatStream performs a streaming chat completion
+func (p *Provider) ChatStream(ctx context.Context, req *ai.ChatRequest) (*ai.ChatStream, error) {
+	body, err := p.buildRequest(req, true)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := p.do(ctx, streamClient, http.MethodPost, "/api/chat", body)
+	if err != nil {
+		return nil, err
+	}
+
+	stream := ai.NewChatStream(ai.DefaultStreamBufferSize)
+	go pipeStream(resp.Body, stream, req.Model)
+
+	return stream, nil
+}
+
+// buildRequest converts a chat request to an /api/chat request body
+func (p *Provider) buildRequest(req *ai.ChatRequest, stream bool) (*chatRequest, error) {
+	messages, err := convertMessages(ai.WithFormatInstruction(req.Messages, req.ResponseFormat, true))
+	if err != nil {
+		return nil, err
+	}
+
+	body := &chatRequest{
+		Model:    req.Model,
+		Messages: messages,
+		Stream:   stream,
+		Options:  map[string]any{},
+	}
+	if req.ToolChoice == nil || req.ToolChoice.Mode != ai.ToolChoiceNone {
+		body.Tools = convertTools(req.Tools)
+	}
+	if f := req.ResponseFormat; f.Structured() {
+		body.Format = "json"
+		if f.Type == ai.ResponseFormatJSONSchema {
+			body.Format = f.Schema
+		}
+	}
+	if req.MaxTokens > 0 {
+		body.Options["num_predict"] = req.MaxTokens
+	}
+	if req.Temperature > 0 {
+		body.Options["temperature"] = req.Temperature
+	}
+
+	// Ollama truncates prompts to its own default context size unless told otherwise,
+	// so keep it in line with the window the chat biz budgets against.
+	for _, m := range p.config.Models {
+		if m.ID == req.Model && m.MaxTokens > 0 {
+			body.Options["num_ctx"] = m.MaxTokens
+
+			break
+		}
+	}
+
+	return body, nil
+}
+
+// call sends a JSON request with the non-streaming client and decodes the JSON response
+func (p *Provider) call(ctx context.Context, method, path string, in, out any) error {
+	resp, err := p.do(ctx, p.client, method, path, in)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decode ollama response: %w", err)
+	}
+
+	return nil
+}
+
+// do sends a request and returns the response if the server answered 200 OK
+func (p *Provider) do(ctx context.Context, client *http.Client, method, path string, in any) (*http.Response, error) {
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return nil, err
+		}
+		body = bytes.NewReader(data)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, method, strings.TrimSuffix(p.config.BaseURL, "/")+path, body)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		httpReq.Header.Set("Content-Type", "application/json")
+	}
+	if p.config.APIKey != "" {
+		httpReq.Header.Set("Authorization", "Bearer "+p.config.APIKey)
+	}
+
+	resp, err := client.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+
+		return nil, fmt.Errorf("ollama request failed with status %d: %s", resp.StatusCode, bytes.TrimSpace(data))
+	}
+
+	return resp, nil
+}
+
+// pipeStream pumps an NDJSON /api/chat response into a ChatStream.
+// The final chunk carries the finish reason and usage.
+func pipeStream(body io.ReadCloser, out *ai.ChatStream, modelName string) {
+	defer body.Close()
+
+	id := ai.GenerateID()
+	toolCalls := 0
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 0, 64<<10), maxStreamLineBytes)
+
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		var chunk chatResponse
+		if err := json.Unmarshal(line, &chunk); err != nil {
+			out.CloseWithError(fmt.Errorf("decode ollama stream: %w", err))
+
+			return
+		}
+		if chunk.Error != "" {
+			out.CloseWithError(fmt.Errorf("ollama stream error: %s", chunk.Error))
+
+			return
+		}
+
+		calls := convertToolCalls(chunk.Message.ToolCalls)
+		for i := range calls {
+			idx := toolCalls
+			calls[i].Index = &idx
+			toolCalls++
+		}
+		if chunk.Message.Content != "" || len(calls) > 0 {
+			out.Send(newStreamChunk(id, modelName, &ai.Message{
+				Role:      ai.RoleAssistant,
+				Content:   chunk.Message.Content,
+				ToolCalls: calls,
+			}, ""))
+		}
+
+		if chunk.Done {
+			final := newStreamChunk(id, modelName, &ai.Message{}, ai.NormalizeFinishReason(chunk.DoneReason, toolCalls > 0))
+			usage := chunk.usage()
+			final.Usage = &usage
+			out.Send(final)
+			out.Close()
+
+			return
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		out.CloseWithError(err)
+
+		return
+	}
+	out.CloseWithError(io.ErrUnexpectedEOF)
+}
+
+// newStreamChunk builds an OpenAI-compatible stream chunk
+func newStreamChunk(id, modelName string, delta *ai.Message, finishReason string) *ai.StreamChunk {
+	return &ai.StreamChunk{
+		ID:      id,
+		Object:  "chat.completion.chunk",
+		Created: time.Now().Unix(),
+		Model:   modelName,
+		Choices: []ai.Choice{
+			{
+				Index:        0,
+				Delta:        delta,
+				FinishReason: finishReason,
+			},
+		},
+	}
+}
+
+// convertMessages converts ai.Message to Ollama chat messages.
+// Images must be inline data URLs since Ollama does not fetch remote images.
+func convertMessages(msgs []ai.Message) ([]chatMessage, error) {
+	result := make([]chatMessage, len(msgs))
+	for i, m := range msgs {
+		msg := chatMessage{
+			Role:    m.Role,
+			Content: m.Content,
+		}
+
+		if m.Role == ai.RoleUser && len(m.Parts) > 0 {
+			msg.Content = ai.TextOf(m.Parts)
+			for _, part := range m.Parts {
+				if part.Type != ai.ContentPartImageURL || part.ImageURL == nil {
+					continue
+				}
+				_, data, ok := ai.ParseDataURL(part.ImageURL.URL)
+				if !ok {
+					return nil, errors.New("ollama only accepts inline base64 images")
+				}
+				msg.Images = append(msg.Images, data)
+			}
+		}
+
+		for _, c := range m.ToolCalls {
+			args := json.RawMessage(c.Function.Arguments)
+			if !json.Valid(args) {
+				args = json.RawMessage("{}")
+			}
+			msg.ToolCalls = append(msg.ToolCalls, toolCall{
+				Function: toolCallFunction{Name: c.Function.Name, Arguments: args},
+			})
+		}
+
+		if m.Role == ai.RoleTool {
+			msg.ToolName = m.Name
+		}
+		result[i] = msg
+	}
+
+	return result, nil
+}
+
+// convertTools fills in the tool type, Ollama otherwise accepts OpenAI tool definitions as-is
+func convertTools(tools []ai.Tool) []ai.Tool {
+	if len(tools) == 0 {
+		return nil
+	}
+
+	result := make([]ai.Tool, len(tools))
+	for i, t := range tools {
+		result[i] = t
+		if result[i].Type == "" {
+			result[i].Type = ai.ToolTypeFunction
+		}
+	}
+
+	return result
+}
+
+// convertResponse converts an Ollama chat response to ai.ChatResponse
+func convertResponse(resp *chatResponse, modelName string) *ai.ChatResponse {
+	calls := convertToolCalls(resp.Message.ToolCalls)
+
+	return &ai.ChatResponse{
+		ID:      ai.GenerateID(),
+		Object:  "chat.completion",
+		Created: time.Now().Unix(),
+		Model:   modelName,
+		Choices: []ai.Choice{
+			{
+				Index: 0,
+				Message: ai.Message{
+					Role:      ai.RoleAssistant,
+					Content:   resp.Message.Content,
+					ToolCalls: calls,
+				},
+				FinishReason: ai.NormalizeFinishReason(resp.DoneReason, len(calls) > 0),
+			},
+		},
+		Usage: resp.usage(),
+	}
+}
+
+// convertToolCalls converts Ollama tool calls, which carry no IDs, to ai.ToolCall
+func convertToolCalls(calls []toolCall) []ai.ToolCall {
+	if len(calls) == 0 {
+		return nil
+	}
+
+	result := make([]ai.ToolCall, len(calls))
+	for i, c := range calls {
+		args := string(c.Function.Arguments)
+		if args == "" || args == "null" {
+			args = "{}"
+		}
+		result[i] = ai.ToolCall{
+			ID:   newToolCallID(),
+			Type: ai.ToolTypeFunction,
+			Function: ai.FunctionCall{
+				Name:      c.Function.Name,
+				Arguments: args,
+			},
+		}
+	}
+
+	return result
+}
+
+// newToolCallID generates an OpenAI-style tool call ID
+func newToolCallID() string {
+	b := make([]byte, 12)
+	_, _ = rand.Read(b)
+
+	return "call_" + hex.EncodeToString(b)
+}
+
+// chatRequest is the /api/chat request body
+type chatRequest struct {
+	Model    string         `json:"model"`
+	Messages []chatMessage  `json:"messages"`
+	Tools    []ai.Tool      `json:"tools,omitempty"`
+	Stream   bool           `json:"stream"`
+	Format   any            `json:"format,omitempty"` // "json" or a JSON schema
+	Options  map[string]any `json:"options,omitempty"`
+}
+
+// chatMessage is a message in Ollama's native format
+type chatMessage struct {
+	Role      string     `json:"role"`
+	Content   string     `json:"content"`
+	Images    []string   `json:"images,omitempty"` // Raw base64 without the data URL prefix
+	ToolCalls []toolCall `json:"tool_calls,omitempty"`
+	ToolName  string     `json:"tool_name,omitempty"`
+}
+
+// toolCall is a tool invocation, arguments are a JSON object rather than a string
+type toolCall struct {
+	Function toolCallFunction `json:"function"`
+}
+
+type toolCallFunction struct {
+	Name      string          `json:"name"`
+	Arguments json.RawMessage `json:"arguments"`
+}
+
+// chatResponse is a /api/chat response or a single line of a streamed one
+type chatResponse struct {
+	Model           string      `json:"model"`
+	Message         chatMessage `json:"message"`
+	Done            bool        `json:"done"`
+	DoneReason      string      `json:"done_reason"`
+	PromptEvalCount int         `json:"prompt_eval_count"`
+	EvalCount       int         `json:"eval_count"`
+	Error           string      `json:"error"`
+}
+
+// usage returns the token usage reported on the final response
+func (r *chatResponse) usage() ai.Usage {
+	return ai.Usage{
+		PromptTokens:     r.PromptEvalCount,
+		CompletionTokens: r.EvalCount,
+		TotalTokens:      r.PromptEvalCount + r.EvalCount,
+	}
+}
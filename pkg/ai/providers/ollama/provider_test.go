@@ -0,0 +1,306 @@
+// ABOUTME: Ollama provider unit tests.
+// ABOUTME: Tests chat, streaming, embeddings and model discovery against a fake server.
+
+package ollama
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bingo-project/bingo/pkg/ai"
+)
+
+func newTestProvider(t *testing.T, handler http.HandlerFunc) *Provider {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	cfg := DefaultConfig()
+	cfg.BaseURL = srv.URL + "/"
+	cfg.Models = []ai.ModelInfo{{ID: "llama3.1", Provider: "ollama", MaxTokens: 8192}}
+	p, err := New(cfg)
+	require.NoError(t, err)
+
+	return p
+}
+
+func TestNew_RequiresBaseURL(t *testing.T) {
+	_, err := New(&Config{})
+	assert.Error(t, err)
+}
+
+func TestProvider_Chat(t *testing.T) {
+	p := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/chat", r.URL.Path)
+		assert.Empty(t, r.Header.Get("Authorization"))
+
+		var body chatRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.False(t, body.Stream)
+		assert.Equal(t, float64(8192), body.Options["num_ctx"])
+		assert.Equal(t, float64(256), body.Options["num_predict"])
+		require.Len(t, body.Messages, 4)
+		assert.Equal(t, "look", body.Messages[0].Content)
+		assert.Equal(t, []string{"aGVsbG8="}, body.Messages[0].Images)
+		assert.JSONEq(t, `{"city":"Paris"}`, string(body.Messages[1].ToolCalls[0].Function.Arguments))
+		assert.Equal(t, "get_weather", body.Messages[2].ToolName)
+		require.Len(t, body.Tools, 1)
+		assert.Equal(t, ai.ToolTypeFunction, body.Tools[0].Type)
+
+		_, _ = w.Write([]byte(`{"model":"llama3.1","message":{"role":"assistant","content":"",
+			"tool_calls":[{"function":{"name":"get_weather","arguments":{"city":"Berlin"}}}]},
+			"done":true,"done_reason":"stop","prompt_eval_count":20,"eval_count":5}`))
+	})
+
+	resp, err := p.Chat(context.Background(), &ai.ChatRequest{
+		Model:     "llama3.1",
+		MaxTokens: 256,
+		Messages: []ai.Message{
+			{Role: ai.RoleUser, Content: "look", Parts: []ai.ContentPart{
+				{Type: ai.ContentPartText, Text: "look"},
+				{Type: ai.ContentPartImageURL, ImageURL: &ai.ImageURL{URL: "data:image/png;base64,aGVsbG8="}},
+			}},
+			{Role: ai.RoleAssistant, ToolCalls: []ai.ToolCall{
+				{ID: "call_1", Type: ai.ToolTypeFunction, Function: ai.FunctionCall{Name: "get_weather", Arguments: `{"city":"Paris"}`}},
+			}},
+			{Role: ai.RoleTool, Name: "get_weather", ToolCallID: "call_1", Content: "sunny"},
+			{Role: ai.RoleUser, Content: "and Berlin?"},
+		},
+		Tools: []ai.Tool{{Function: ai.FunctionDefinition{Name: "get_weather"}}},
+	})
+	require.NoError(t, err)
+
+	require.Len(t, resp.Choices, 1)
+	assert.Equal(t, ai.FinishReasonToolCalls, resp.Choices[0].FinishReason)
+	require.Len(t, resp.Choices[0].Message.ToolCalls, 1)
+	call := resp.Choices[0].Message.ToolCalls[0]
+	assert.NotEmpty(t, call.ID)
+	assert.Equal(t, "get_weather", call.Function.Name)
+	assert.JSONEq(t, `{"city":"Berlin"}`, call.Function.Arguments)
+	assert.Equal(t, ai.Usage{PromptTokens: 20, CompletionTokens: 5, TotalTokens: 25}, resp.Usage)
+}
+
+func TestProvider_Chat_RemoteImage(t *testing.T) {
+	p := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("request should not be sent")
+	})
+
+	_, err := p.Chat(context.Background(), &ai.ChatRequest{
+		Model: "llama3.1",
+		Messages: []ai.Message{{Role: ai.RoleUser, Parts: []ai.ContentPart{
+			{Type: ai.ContentPartImageURL, ImageURL: &ai.ImageURL{URL: "https://example.com/cat.png"}},
+		}}},
+	})
+	assert.Error(t, err)
+}
+
+func TestProvider_Chat_ToolChoiceNone(t *testing.T) {
+	p := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		var body chatRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Empty(t, body.Tools)
+
+		_, _ = w.Write([]byte(`{"message":{"role":"assistant","content":"hi"},"done":true,"done_reason":"length"}`))
+	})
+
+	resp, err := p.Chat(context.Background(), &ai.ChatRequest{
+		Model:      "llama3.1",
+		Messages:   []ai.Message{{Role: ai.RoleUser, Content: "hi"}},
+		Tools:      []ai.Tool{{Function: ai.FunctionDefinition{Name: "get_weather"}}},
+		ToolChoice: &ai.ToolChoice{Mode: ai.ToolChoiceNone},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "hi", resp.Choices[0].Message.Content)
+	assert.Equal(t, ai.FinishReasonLength, resp.Choices[0].FinishReason)
+}
+
+func TestProvider_Chat_ResponseFormat(t *testing.T) {
+	p := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		var body chatRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, map[string]any{"type": "object"}, body.Format)
+		require.Len(t, body.Messages, 1)
+
+		_, _ = w.Write([]byte(`{"message":{"role":"assistant","content":"{}"},"done":true,"done_reason":"stop"}`))
+	})
+
+	_, err := p.Chat(context.Background(), &ai.ChatRequest{
+		Model:          "llama3.1",
+		Messages:       []ai.Message{{Role: ai.RoleUser, Content: "hi"}},
+		ResponseFormat: &ai.ResponseFormat{Type: ai.ResponseFormatJSONSchema, Schema: map[string]any{"type": "object"}},
+	})
+	require.NoError(t, err)
+}
+
+func TestProvider_Chat_Error(t *testing.T) {
+	p := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error":"model \"missing\" not found"}`))
+	})
+
+	_, err := p.Chat(context.Background(), &ai.ChatRequest{
+		Model:    "missing",
+		Messages: []ai.Message{{Role: ai.RoleUser, Content: "hi"}},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "404")
+	assert.Contains(t, err.Error(), "not found")
+}
+
+func TestProvider_ChatStream(t *testing.T) {
+	p := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		var body chatRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.True(t, body.Stream)
+
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		_, _ = w.Write([]byte(`{"message":{"role":"assistant","content":"Hel"},"done":false}
+{"message":{"role":"assistant","content":"lo"},"done":false}
+
+{"message":{"role":"assistant","content":""},"done":true,"done_reason":"stop","prompt_eval_count":3,"eval_count":2}
+`))
+	})
+
+	stream, err := p.ChatStream(context.Background(), &ai.ChatRequest{
+		Model:    "llama3.1",
+		Messages: []ai.Message{{Role: ai.RoleUser, Content: "hi"}},
+	})
+	require.NoError(t, err)
+
+	var content string
+	var final *ai.StreamChunk
+	for {
+		chunk, err := stream.Recv()
+		if errors.Is(err, ai.ErrStreamClosed) {
+			break
+		}
+		require.NoError(t, err)
+		content += chunk.Choices[0].Delta.Content
+		if chunk.Choices[0].FinishReason != "" {
+			final = chunk
+		}
+	}
+
+	assert.Equal(t, "Hello", content)
+	require.NotNil(t, final)
+	assert.Equal(t, ai.FinishReasonStop, final.Choices[0].FinishReason)
+	assert.Equal(t, &ai.Usage{PromptTokens: 3, CompletionTokens: 2, TotalTokens: 5}, final.Usage)
+}
+
+func TestProvider_ChatStream_ToolCalls(t *testing.T) {
+	p := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"message":{"role":"assistant","content":"","tool_calls":[{"function":{"name":"a","arguments":{}}},{"function":{"name":"b","arguments":{"x":1}}}]},"done":false}
+{"message":{"role":"assistant","content":""},"done":true,"done_reason":"stop"}
+`))
+	})
+
+	stream, err := p.ChatStream(context.Background(), &ai.ChatRequest{
+		Model:    "llama3.1",
+		Messages: []ai.Message{{Role: ai.RoleUser, Content: "hi"}},
+	})
+	require.NoError(t, err)
+
+	acc := ai.NewToolCallAccumulator()
+	var finishReason string
+	for {
+		chunk, err := stream.Recv()
+		if errors.Is(err, ai.ErrStreamClosed) {
+			break
+		}
+		require.NoError(t, err)
+		acc.Add(chunk.Choices[0].Delta.ToolCalls)
+		if chunk.Choices[0].FinishReason != "" {
+			finishReason = chunk.Choices[0].FinishReason
+		}
+	}
+
+	calls := acc.Calls()
+	require.Len(t, calls, 2)
+	assert.Equal(t, "a", calls[0].Function.Name)
+	assert.Equal(t, "b", calls[1].Function.Name)
+	assert.JSONEq(t, `{"x":1}`, calls[1].Function.Arguments)
+	assert.Equal(t, ai.FinishReasonToolCalls, finishReason)
+}
+
+func TestProvider_ChatStream_Error(t *testing.T) {
+	p := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"message":{"role":"assistant","content":"Hel"},"done":false}
+{"error":"model runner has unexpectedly stopped"}
+`))
+	})
+
+	stream, err := p.ChatStream(context.Background(), &ai.ChatRequest{
+		Model:    "llama3.1",
+		Messages: []ai.Message{{Role: ai.RoleUser, Content: "hi"}},
+	})
+	require.NoError(t, err)
+
+	_, err = stream.Recv()
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unexpectedly stopped")
+}
+
+func TestProvider_Embed(t *testing.T) {
+	p := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/embed", r.URL.Path)
+
+		var body embedRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "nomic-embed-text", body.Model)
+		assert.Equal(t, []string{"hello", "world"}, body.Input)
+
+		_, _ = w.Write([]byte(`{"model":"nomic-embed-text","embeddings":[[0.1,0.2],[0.3,0.4]],"prompt_eval_count":4}`))
+	})
+
+	resp, err := p.Embed(context.Background(), &ai.EmbeddingRequest{
+		Model: "nomic-embed-text",
+		Input: []string{"hello", "world"},
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Data, 2)
+	assert.Equal(t, 1, resp.Data[1].Index)
+	assert.Equal(t, []float32{0.3, 0.4}, resp.Data[1].Embedding)
+	assert.Equal(t, 4, resp.Usage.TotalTokens)
+}
+
+func TestProvider_ListModels(t *testing.T) {
+	p := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/tags":
+			_, _ = w.Write([]byte(`{"models":[{"name":"llama3.1:8b"},{"name":"nomic-embed-text:latest"},{"name":"qwen2.5:7b"}]}`))
+		case "/api/show":
+			var body showRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			switch body.Model {
+			case "llama3.1:8b":
+				_, _ = w.Write([]byte(`{"parameters":"num_ctx 16384\nstop \"<|eot_id|>\"",
+					"model_info":{"llama.context_length":131072},"capabilities":["completion","tools"]}`))
+			case "nomic-embed-text:latest":
+				_, _ = w.Write([]byte(`{"model_info":{"nomic-bert.context_length":2048},"capabilities":["embedding"]}`))
+			default:
+				w.WriteHeader(http.StatusInternalServerError)
+			}
+		default:
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+	})
+
+	models, err := p.ListModels(context.Background())
+	require.NoError(t, err)
+	require.Len(t, models, 3)
+
+	assert.Equal(t, ai.ModelInfo{ID: "llama3.1:8b", Name: "llama3.1:8b", Provider: "ollama", Type: ai.ModelTypeChat, MaxTokens: 16384}, models[0])
+	assert.Equal(t, ai.ModelTypeEmbedding, models[1].Type)
+	assert.Equal(t, 2048, models[1].MaxTokens)
+	assert.Equal(t, ai.ModelTypeChat, models[2].Type)
+	assert.Zero(t, models[2].MaxTokens)
+}
@@ -0,0 +1,92 @@
+// ABOUTME: OpenAI-compatible embeddings implementation.
+// ABOUTME: Calls the /embeddings endpoint shared by OpenAI-compatible APIs.
+
+package openai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/bingo-project/bingo/pkg/ai"
+)
+
+var _ ai.Embedder = (*Provider)(nil)
+
+// embeddingClient is shared by all OpenAI-compatible embedding calls
+var embeddingClient = &http.Client{Timeout: 60 * time.Second}
+
+// Embed creates embedding vectors via the /embeddings endpoint
+func (p *Provider) Embed(ctx context.Context, req *ai.EmbeddingRequest) (*ai.EmbeddingResponse, error) {
+	return CreateEmbeddings(ctx, p.config.BaseURL, p.config.APIKey, req)
+}
+
+// CreateEmbeddings calls an OpenAI-compatible /embeddings endpoint with retries
+func CreateEmbeddings(ctx context.Context, baseURL, apiKey string, req *ai.EmbeddingRequest) (*ai.EmbeddingResponse, error) {
+	body, err := json.Marshal(embeddingRequest{
+		Model:          req.Model,
+		Input:          req.Input,
+		Dimensions:     req.Dimensions,
+		EncodingFormat: "float",
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	url := strings.TrimSuffix(baseURL, "/") + "/embeddings"
+
+	return ai.Do(ctx, ai.DefaultRetryConfig, func(ctx context.Context) (*ai.EmbeddingResponse, error) {
+		httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+		if err != nil {
+			return nil, err
+		}
+		httpReq.Header.Set("Content-Type", "application/json")
+		httpReq.Header.Set("Authorization", "Bearer "+apiKey)
+
+		resp, err := embeddingClient.Do(httpReq)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+
+		data, err := io.ReadAll(io.LimitReader(resp.Body, 64<<20))
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("embeddings request failed with status %d: %s", resp.StatusCode, truncate(data, 512))
+		}
+
+		var result ai.EmbeddingResponse
+		if err := json.Unmarshal(data, &result); err != nil {
+			return nil, fmt.Errorf("decode embeddings response: %w", err)
+		}
+		if result.Model == "" {
+			result.Model = req.Model
+		}
+
+		return &result, nil
+	})
+}
+
+// embeddingRequest is the OpenAI-compatible request body
+type embeddingRequest struct {
+	Model          string   `json:"model"`
+	Input          []string `json:"input"`
+	Dimensions     int      `json:"dimensions,omitempty"`
+	EncodingFormat string   `json:"encoding_format"`
+}
+
+// truncate shortens an error body for logging
+func truncate(b []byte, n int) string {
+	if len(b) > n {
+		return string(b[:n]) + "..."
+	}
+
+	return string(b)
+}
@@ -0,0 +1,58 @@
+// ABOUTME: OpenAI-compatible embeddings unit tests.
+// ABOUTME: Tests request encoding and response decoding against a fake server.
+
+package openai
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/bingo-project/bingo/pkg/ai"
+)
+
+func TestCreateEmbeddings(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/embeddings", r.URL.Path)
+		assert.Equal(t, "Bearer test-key", r.Header.Get("Authorization"))
+
+		var body embeddingRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "text-embedding-3-small", body.Model)
+		assert.Equal(t, []string{"hello", "world"}, body.Input)
+
+		_, _ = w.Write([]byte(`{"object":"list","model":"text-embedding-3-small",
+			"data":[{"object":"embedding","index":0,"embedding":[0.1,0.2]},{"object":"embedding","index":1,"embedding":[0.3,0.4]}],
+			"usage":{"prompt_tokens":2,"total_tokens":2}}`))
+	}))
+	defer srv.Close()
+
+	resp, err := CreateEmbeddings(context.Background(), srv.URL+"/v1/", "test-key", &ai.EmbeddingRequest{
+		Model: "text-embedding-3-small",
+		Input: []string{"hello", "world"},
+	})
+	require.NoError(t, err)
+	require.Len(t, resp.Data, 2)
+	assert.Equal(t, []float32{0.3, 0.4}, resp.Data[1].Embedding)
+	assert.Equal(t, 2, resp.Usage.TotalTokens)
+}
+
+func TestCreateEmbeddings_Error(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error":{"message":"invalid model"}}`))
+	}))
+	defer srv.Close()
+
+	_, err := CreateEmbeddings(context.Background(), srv.URL, "test-key", &ai.EmbeddingRequest{
+		Model: "unknown",
+		Input: []string{"hello"},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "400")
+}
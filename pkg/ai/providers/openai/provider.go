@@ -5,7 +5,6 @@ package openai
 
 import (
 	"context"
-	"io"
 	"time"
 
 	"github.com/cloudwego/eino-ext/components/model/openai"
@@ -55,16 +54,11 @@ func (p *Provider) Models() []ai.ModelInfo {
 
 // Chat performs a non-streaming chat completion
 func (p *Provider) Chat(ctx context.Context, req *ai.ChatRequest) (*ai.ChatResponse, error) {
-	messages := ai.ConvertMessages(req.Messages)
+	messages := ai.ConvertMessages(ai.WithFormatInstruction(req.Messages, req.ResponseFormat, true))
 
-	opts := []model.Option{
-		model.WithModel(req.Model),
-	}
-	if req.MaxTokens > 0 {
-		opts = append(opts, model.WithMaxTokens(req.MaxTokens))
-	}
-	if req.Temperature > 0 {
-		opts = append(opts, model.WithTemperature(float32(req.Temperature)))
+	opts, err := buildOptions(req)
+	if err != nil {
+		return nil, err
 	}
 
 	return ai.Do(ctx, ai.DefaultRetryConfig, func(ctx context.Context) (*ai.ChatResponse, error) {
@@ -79,16 +73,11 @@ func (p *Provider) Chat(ctx context.Context, req *ai.ChatRequest) (*ai.ChatRespo
 
 // ChatStream performs a streaming chat completion
 func (p *Provider) ChatStream(ctx context.Context, req *ai.ChatRequest) (*ai.ChatStream, error) {
-	messages := ai.ConvertMessages(req.Messages)
+	messages := ai.ConvertMessages(ai.WithFormatInstruction(req.Messages, req.ResponseFormat, true))
 
-	opts := []model.Option{
-		model.WithModel(req.Model),
-	}
-	if req.MaxTokens > 0 {
-		opts = append(opts, model.WithMaxTokens(req.MaxTokens))
-	}
-	if req.Temperature > 0 {
-		opts = append(opts, model.WithTemperature(float32(req.Temperature)))
+	opts, err := buildOptions(req)
+	if err != nil {
+		return nil, err
 	}
 
 	stream, err := p.client.Stream(ctx, messages, opts...)
@@ -96,60 +85,18 @@ func (p *Provider) ChatStream(ctx context.Context, req *ai.ChatRequest) (*ai.Cha
 		return nil, err
 	}
 
-	chatStream := ai.NewChatStream(ai.DefaultStreamBufferSize)
-
-	// Start goroutine to read from Eino stream
-	go func() {
-		defer chatStream.Close()
-
-		id := ai.GenerateID()
-		var lastUsage *ai.Usage
-
-		for {
-			select {
-			case <-ctx.Done():
-				chatStream.CloseWithError(ctx.Err())
-
-				return
-			default:
-				chunk, err := stream.Recv()
-				if err != nil {
-					if err == io.EOF {
-						// Send final chunk with finish_reason and usage
-						chatStream.Send(&ai.StreamChunk{
-							ID:      id,
-							Object:  "chat.completion.chunk",
-							Created: time.Now().Unix(),
-							Model:   req.Model,
-							Choices: []ai.Choice{
-								{
-									Index:        0,
-									Delta:        &ai.Message{},
-									FinishReason: "stop",
-								},
-							},
-							Usage: lastUsage,
-						})
-					} else {
-						chatStream.CloseWithError(err)
-					}
-
-					return
-				}
-
-				// Track usage from chunks (Eino sends it in the last content chunk)
-				if chunk.ResponseMeta != nil && chunk.ResponseMeta.Usage != nil {
-					lastUsage = &ai.Usage{
-						PromptTokens:     chunk.ResponseMeta.Usage.PromptTokens,
-						CompletionTokens: chunk.ResponseMeta.Usage.CompletionTokens,
-						TotalTokens:      chunk.ResponseMeta.Usage.TotalTokens,
-					}
-				}
-
-				chatStream.Send(ai.ConvertStreamChunk(chunk, req.Model, id))
-			}
-		}
-	}()
+	return ai.PipeStream(ctx, stream, req.Model), nil
+}
+
+// buildOptions builds Eino model options, sending the response format natively
+func buildOptions(req *ai.ChatRequest) ([]model.Option, error) {
+	opts, err := ai.BuildOptions(req)
+	if err != nil {
+		return nil, err
+	}
+	if format := ai.OpenAIResponseFormat(req.ResponseFormat); format != nil {
+		opts = append(opts, openai.WithExtraFields(map[string]any{"response_format": format}))
+	}
 
-	return chatStream, nil
+	return opts, nil
 }
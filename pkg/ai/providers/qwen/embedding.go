@@ -0,0 +1,18 @@
+// ABOUTME: Qwen embeddings implementation.
+// ABOUTME: Uses DashScope's OpenAI-compatible /embeddings endpoint.
+
+package qwen
+
+import (
+	"context"
+
+	"github.com/bingo-project/bingo/pkg/ai"
+	"github.com/bingo-project/bingo/pkg/ai/providers/openai"
+)
+
+var _ ai.Embedder = (*Provider)(nil)
+
+// Embed creates embedding vectors via the compatible-mode /embeddings endpoint
+func (p *Provider) Embed(ctx context.Context, req *ai.EmbeddingRequest) (*ai.EmbeddingResponse, error) {
+	return openai.CreateEmbeddings(ctx, p.config.BaseURL, p.config.APIKey, req)
+}
@@ -37,6 +37,28 @@ func (r *Registry) Get(name string) (Provider, bool) {
 	return p, ok
 }
 
+// GetEmbedder returns a provider's embedding capability by name
+func (r *Registry) GetEmbedder(name string) (Embedder, bool) {
+	p, ok := r.Get(name)
+	if !ok {
+		return nil, false
+	}
+	e, ok := p.(Embedder)
+
+	return e, ok
+}
+
+// GetModelLister returns a provider's model discovery capability by name
+func (r *Registry) GetModelLister(name string) (ModelLister, bool) {
+	p, ok := r.Get(name)
+	if !ok {
+		return nil, false
+	}
+	l, ok := p.(ModelLister)
+
+	return l, ok
+}
+
 // ListProviders returns all registered provider names
 func (r *Registry) ListProviders() []string {
 	r.mu.RLock()
@@ -63,6 +85,17 @@ func (r *Registry) ListModels() []ModelInfo {
 	return models
 }
 
+// GetModel returns a registered model by ID
+func (r *Registry) GetModel(id string) (ModelInfo, bool) {
+	for _, m := range r.ListModels() {
+		if m.ID == id {
+			return m, true
+		}
+	}
+
+	return ModelInfo{}, false
+}
+
 // Clear removes all registered providers
 func (r *Registry) Clear() {
 	r.mu.Lock()
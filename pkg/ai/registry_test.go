@@ -92,3 +92,20 @@ func TestRegistry_Clear(t *testing.T) {
 	_, ok = r.Get("test")
 	require.False(t, ok, "provider should be removed after Clear")
 }
+
+func TestRegistry_GetModel(t *testing.T) {
+	r := NewRegistry()
+	r.Register(&mockProvider{
+		name: "test",
+		models: []ModelInfo{
+			{ID: "test-model", Provider: "test", MaxTokens: 8192},
+		},
+	})
+
+	m, ok := r.GetModel("test-model")
+	require.True(t, ok)
+	assert.Equal(t, 8192, m.MaxTokens)
+
+	_, ok = r.GetModel("unknown")
+	assert.False(t, ok)
+}
@@ -0,0 +1,191 @@
+// ABOUTME: Structured output response formats.
+// ABOUTME: Defines JSON and JSON schema formats, prompt fallbacks and reply validation.
+
+package ai
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Response format types
+const (
+	ResponseFormatText       = "text"
+	ResponseFormatJSONObject = "json_object"
+	ResponseFormatJSONSchema = "json_schema"
+)
+
+// defaultSchemaName names schemas for providers that require a name.
+const defaultSchemaName = "response"
+
+// schemaNamePattern is the schema name format accepted by OpenAI-compatible APIs.
+var schemaNamePattern = regexp.MustCompile(`^[a-zA-Z0-9_-]{1,64}$`)
+
+// ResponseFormat constrains the reply to JSON, optionally matching a schema
+type ResponseFormat struct {
+	Type   string         `json:"type"`             // text, json_object or json_schema
+	Name   string         `json:"name,omitempty"`   // Schema name, defaults to "response"
+	Schema map[string]any `json:"schema,omitempty"` // JSON Schema object, required for json_schema
+	Strict bool           `json:"strict,omitempty"` // Ask providers that support it to follow the schema strictly
+}
+
+// Structured reports whether the format asks for a JSON reply
+func (f *ResponseFormat) Structured() bool {
+	return f != nil && (f.Type == ResponseFormatJSONObject || f.Type == ResponseFormatJSONSchema)
+}
+
+// Validate checks the format itself
+func (f *ResponseFormat) Validate() error {
+	if f == nil {
+		return nil
+	}
+
+	switch f.Type {
+	case ResponseFormatText, ResponseFormatJSONObject:
+		return nil
+	case ResponseFormatJSONSchema:
+		if len(f.Schema) == 0 {
+			return errors.New("json_schema response format requires a schema")
+		}
+		if f.Name != "" && !schemaNamePattern.MatchString(f.Name) {
+			return fmt.Errorf("invalid schema name %q", f.Name)
+		}
+
+		return nil
+	}
+
+	return fmt.Errorf("unsupported response format %q", f.Type)
+}
+
+// SchemaName returns the schema name, defaulting to "response"
+func (f *ResponseFormat) SchemaName() string {
+	if f.Name != "" {
+		return f.Name
+	}
+
+	return defaultSchemaName
+}
+
+// OpenAIResponseFormat returns the response_format request field of OpenAI-compatible APIs,
+// nil for plain text.
+func OpenAIResponseFormat(f *ResponseFormat) map[string]any {
+	switch {
+	case !f.Structured():
+		return nil
+	case f.Type == ResponseFormatJSONObject:
+		return map[string]any{"type": ResponseFormatJSONObject}
+	}
+
+	return map[string]any{
+		"type": ResponseFormatJSONSchema,
+		"json_schema": map[string]any{
+			"name":   f.SchemaName(),
+			"schema": f.Schema,
+			"strict": f.Strict,
+		},
+	}
+}
+
+// FormatInstruction returns the prompt-level instruction asking for the format
+func FormatInstruction(f *ResponseFormat) string {
+	if !f.Structured() {
+		return ""
+	}
+
+	instruction := "Respond with a single valid JSON value only, without Markdown code fences or any other text."
+	if f.Type == ResponseFormatJSONSchema {
+		schema, _ := json.Marshal(f.Schema)
+		instruction += " The JSON must conform to this JSON Schema:\n" + string(schema)
+	}
+
+	return instruction
+}
+
+// WithFormatInstruction returns messages with the format instruction appended to the leading
+// system message, or a new one. Providers constraining json_schema replies natively
+// (nativeSchema) only need it for json_object, which several APIs require to be mentioned.
+func WithFormatInstruction(messages []Message, f *ResponseFormat, nativeSchema bool) []Message {
+	if !f.Structured() || (nativeSchema && f.Type == ResponseFormatJSONSchema) {
+		return messages
+	}
+
+	instruction := FormatInstruction(f)
+	result := make([]Message, 0, len(messages)+1)
+	if len(messages) > 0 && messages[0].Role == RoleSystem {
+		system := messages[0]
+		system.Content = strings.TrimSpace(system.Content + "\n\n" + instruction)
+		result = append(result, system)
+
+		return append(result, messages[1:]...)
+	}
+
+	result = append(result, Message{Role: RoleSystem, Content: instruction})
+
+	return append(result, messages...)
+}
+
+// ParseStructured extracts the JSON value of a reply and validates it against the format.
+// Returns the compacted JSON.
+func ParseStructured(content string, f *ResponseFormat) (string, error) {
+	raw := ExtractJSON(content)
+	if raw == "" {
+		return "", errors.New("reply contains no JSON value")
+	}
+
+	var value any
+	if err := json.Unmarshal([]byte(raw), &value); err != nil {
+		return "", fmt.Errorf("reply is not valid JSON: %w", err)
+	}
+	if f.Type == ResponseFormatJSONSchema {
+		if err := ValidateJSONSchema(value, f.Schema); err != nil {
+			return "", fmt.Errorf("reply does not match the schema: %w", err)
+		}
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+// ExtractJSON returns the JSON value in a reply, dropping Markdown code fences and any
+// text around the outermost object or array.
+func ExtractJSON(content string) string {
+	s := strings.TrimSpace(content)
+	if start := strings.Index(s, "```"); start >= 0 {
+		fenced := s[start+3:]
+		if nl := strings.IndexByte(fenced, '\n'); nl >= 0 {
+			fenced = fenced[nl+1:]
+		}
+		if end := strings.Index(fenced, "```"); end >= 0 {
+			s = strings.TrimSpace(fenced[:end])
+		}
+	}
+	if json.Valid([]byte(s)) {
+		return s
+	}
+
+	start := strings.IndexAny(s, "{[")
+	if start < 0 {
+		return s
+	}
+	closing := "}"
+	if s[start] == '[' {
+		closing = "]"
+	}
+	if end := strings.LastIndex(s, closing); end > start {
+		return s[start : end+1]
+	}
+
+	return s[start:]
+}
+
+// RepairPrompt returns the message asking the model to correct an invalid structured reply
+func RepairPrompt(err error) string {
+	return fmt.Sprintf("Your previous reply was rejected: %v. Reply again with only the corrected JSON, without any other text.", err)
+}
@@ -0,0 +1,113 @@
+// ABOUTME: Tests for structured output response formats.
+// ABOUTME: Verifies format validation, prompt fallbacks and JSON extraction from replies.
+
+package ai
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResponseFormat_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  *ResponseFormat
+		wantErr bool
+	}{
+		{name: "nil", format: nil},
+		{name: "text", format: &ResponseFormat{Type: ResponseFormatText}},
+		{name: "json object", format: &ResponseFormat{Type: ResponseFormatJSONObject}},
+		{name: "json schema", format: &ResponseFormat{Type: ResponseFormatJSONSchema, Name: "weather", Schema: map[string]any{"type": "object"}}},
+		{name: "missing schema", format: &ResponseFormat{Type: ResponseFormatJSONSchema}, wantErr: true},
+		{name: "invalid name", format: &ResponseFormat{Type: ResponseFormatJSONSchema, Name: "a b", Schema: map[string]any{"type": "object"}}, wantErr: true},
+		{name: "unknown type", format: &ResponseFormat{Type: "xml"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.format.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestOpenAIResponseFormat(t *testing.T) {
+	assert.Nil(t, OpenAIResponseFormat(nil))
+	assert.Nil(t, OpenAIResponseFormat(&ResponseFormat{Type: ResponseFormatText}))
+	assert.Equal(t, map[string]any{"type": "json_object"}, OpenAIResponseFormat(&ResponseFormat{Type: ResponseFormatJSONObject}))
+
+	schema := map[string]any{"type": "object"}
+	assert.Equal(t, map[string]any{
+		"type": "json_schema",
+		"json_schema": map[string]any{
+			"name":   "response",
+			"schema": schema,
+			"strict": true,
+		},
+	}, OpenAIResponseFormat(&ResponseFormat{Type: ResponseFormatJSONSchema, Schema: schema, Strict: true}))
+}
+
+func TestWithFormatInstruction(t *testing.T) {
+	schema := &ResponseFormat{Type: ResponseFormatJSONSchema, Schema: map[string]any{"type": "object"}}
+	user := Message{Role: RoleUser, Content: "hi"}
+
+	// Prepends a system message
+	got := WithFormatInstruction([]Message{user}, schema, false)
+	require.Len(t, got, 2)
+	assert.Equal(t, RoleSystem, got[0].Role)
+	assert.Contains(t, got[0].Content, `{"type":"object"}`)
+
+	// Appends to the leading system message without touching the input
+	input := []Message{{Role: RoleSystem, Content: "Be brief."}, user}
+	got = WithFormatInstruction(input, &ResponseFormat{Type: ResponseFormatJSONObject}, true)
+	require.Len(t, got, 2)
+	assert.Contains(t, got[0].Content, "Be brief.\n\nRespond with a single valid JSON value")
+	assert.Equal(t, "Be brief.", input[0].Content)
+
+	// Native schemas and text need no instruction
+	assert.Equal(t, []Message{user}, WithFormatInstruction([]Message{user}, schema, true))
+	assert.Equal(t, []Message{user}, WithFormatInstruction([]Message{user}, nil, false))
+}
+
+func TestExtractJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{name: "plain", content: ` {"a": 1} `, want: `{"a": 1}`},
+		{name: "fenced", content: "```json\n{\"a\": 1}\n```", want: `{"a": 1}`},
+		{name: "surrounding text", content: `Here you go: {"a": {"b": 2}} Hope it helps.`, want: `{"a": {"b": 2}}`},
+		{name: "array", content: `Result: [1, 2]`, want: `[1, 2]`},
+		{name: "scalar", content: `42`, want: `42`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ExtractJSON(tt.content))
+		})
+	}
+}
+
+func TestParseStructured(t *testing.T) {
+	schema := &ResponseFormat{Type: ResponseFormatJSONSchema, Schema: map[string]any{
+		"type":     "object",
+		"required": []any{"n"},
+	}}
+
+	got, err := ParseStructured("```\n{ \"n\": 1 }\n```", schema)
+	require.NoError(t, err)
+	assert.Equal(t, `{"n":1}`, got)
+
+	_, err = ParseStructured(`{"m": 1}`, schema)
+	assert.ErrorContains(t, err, "missing required property")
+
+	_, err = ParseStructured(`not json`, &ResponseFormat{Type: ResponseFormatJSONObject})
+	assert.Error(t, err)
+}
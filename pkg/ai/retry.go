@@ -27,9 +27,9 @@ var DefaultRetryConfig = RetryConfig{
 }
 
 // Do executes an AI operation with retries based on the provided configuration
-func Do(ctx context.Context, cfg RetryConfig, fn func(ctx context.Context) (*ChatResponse, error)) (*ChatResponse, error) {
+func Do[T any](ctx context.Context, cfg RetryConfig, fn func(ctx context.Context) (*T, error)) (*T, error) {
 	var lastErr error
-	var resp *ChatResponse
+	var resp *T
 
 	for attempt := 1; attempt <= cfg.MaxAttempts; attempt++ {
 		// Check context before each attempt
@@ -4,7 +4,13 @@
 package ai
 
 import (
+	"context"
+	"errors"
+	"io"
 	"sync"
+	"time"
+
+	"github.com/cloudwego/eino/schema"
 )
 
 const (
@@ -72,3 +78,74 @@ func (s *ChatStream) CloseWithError(err error) {
 		close(s.chunks)
 	}
 }
+
+// PipeStream pumps an Eino message stream into a ChatStream in a background goroutine.
+// The final chunk carries the finish reason and the last reported usage.
+func PipeStream(ctx context.Context, stream *schema.StreamReader[*schema.Message], modelName string) *ChatStream {
+	chatStream := NewChatStream(DefaultStreamBufferSize)
+
+	go func() {
+		defer chatStream.Close()
+		defer stream.Close()
+
+		id := GenerateID()
+		var lastUsage *Usage
+		var finishReason string
+		hasToolCalls := false
+
+		for {
+			select {
+			case <-ctx.Done():
+				chatStream.CloseWithError(ctx.Err())
+
+				return
+			default:
+				chunk, err := stream.Recv()
+				if err != nil {
+					if errors.Is(err, io.EOF) {
+						// Send final chunk with finish_reason and usage
+						chatStream.Send(&StreamChunk{
+							ID:      id,
+							Object:  "chat.completion.chunk",
+							Created: time.Now().Unix(),
+							Model:   modelName,
+							Choices: []Choice{
+								{
+									Index:        0,
+									Delta:        &Message{},
+									FinishReason: NormalizeFinishReason(finishReason, hasToolCalls),
+								},
+							},
+							Usage: lastUsage,
+						})
+					} else {
+						chatStream.CloseWithError(err)
+					}
+
+					return
+				}
+
+				// Track usage from chunks (Eino sends it in the last content chunk)
+				if chunk.ResponseMeta != nil {
+					if chunk.ResponseMeta.Usage != nil {
+						lastUsage = &Usage{
+							PromptTokens:     chunk.ResponseMeta.Usage.PromptTokens,
+							CompletionTokens: chunk.ResponseMeta.Usage.CompletionTokens,
+							TotalTokens:      chunk.ResponseMeta.Usage.TotalTokens,
+						}
+					}
+					if chunk.ResponseMeta.FinishReason != "" {
+						finishReason = chunk.ResponseMeta.FinishReason
+					}
+				}
+				if len(chunk.ToolCalls) > 0 {
+					hasToolCalls = true
+				}
+
+				chatStream.Send(ConvertStreamChunk(chunk, modelName, id))
+			}
+		}
+	}()
+
+	return chatStream
+}
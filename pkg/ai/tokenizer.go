@@ -0,0 +1,211 @@
+// ABOUTME: Token counting per model family for context budgeting and quota reservation.
+// ABOUTME: Counts OpenAI models exactly with their BPE vocabulary and approximates other providers' tokenizers.
+
+package ai
+
+import (
+	"encoding/json"
+	"math"
+	"strings"
+	"unicode"
+
+	"github.com/pkoukk/tiktoken-go"
+)
+
+// Token families with distinct tokenizers
+const (
+	TokenFamilyOpenAI  = "openai"
+	TokenFamilyClaude  = "claude"
+	TokenFamilyGemini  = "gemini"
+	TokenFamilyChinese = "chinese" // Qwen, DeepSeek, GLM and Kimi vocabularies optimized for Chinese
+	TokenFamilyDefault = "default"
+)
+
+// Fixed token costs of non-text content
+const (
+	imageTokensLow  = 85
+	imageTokensHigh = 765
+	toolCallTokens  = 3
+	toolDefTokens   = 8
+	replyTokens     = 3
+)
+
+// Tokenizer counts tokens for a model family. OpenAI models are counted with their BPE
+// vocabulary once it is loaded. Other families, and OpenAI until then, are estimated: text is
+// pre-tokenized like a BPE tokenizer (word, number, CJK, punctuation runs) and each run is
+// costed with family-specific ratios. Estimates can be off in either direction, callers
+// budgeting against a hard limit keep a safety margin.
+type Tokenizer struct {
+	Family          string
+	encoding        string  // BPE encoding counting text exactly, empty to always estimate
+	charsPerToken   float64 // letters per token within a word
+	tokensPerCJK    float64 // tokens per CJK character
+	messageOverhead int     // tokens added per message for role and separators
+}
+
+// cl100kTokenizer counts OpenAI models released before GPT-4o
+var cl100kTokenizer = &Tokenizer{Family: TokenFamilyOpenAI, encoding: EncodingCL100K, charsPerToken: 5, tokensPerCJK: 1.2, messageOverhead: 3}
+
+var tokenizers = map[string]*Tokenizer{
+	TokenFamilyOpenAI:  {Family: TokenFamilyOpenAI, encoding: EncodingO200K, charsPerToken: 5, tokensPerCJK: 0.9, messageOverhead: 3},
+	TokenFamilyClaude:  {Family: TokenFamilyClaude, charsPerToken: 4.5, tokensPerCJK: 1.2, messageOverhead: 4},
+	TokenFamilyGemini:  {Family: TokenFamilyGemini, charsPerToken: 5, tokensPerCJK: 0.8, messageOverhead: 4},
+	TokenFamilyChinese: {Family: TokenFamilyChinese, charsPerToken: 5, tokensPerCJK: 0.7, messageOverhead: 4},
+	TokenFamilyDefault: {Family: TokenFamilyDefault, charsPerToken: 4, tokensPerCJK: 1.2, messageOverhead: 4},
+}
+
+// TokenizerFor returns the tokenizer of the model's family
+func TokenizerFor(model string) *Tokenizer {
+	family := TokenFamilyOf(model)
+	if family == TokenFamilyOpenAI && bpeEncodingOf(model) == EncodingCL100K {
+		return cl100kTokenizer
+	}
+
+	return tokenizers[family]
+}
+
+// TokenFamilyOf maps a model name to its token family
+func TokenFamilyOf(model string) string {
+	m := strings.ToLower(model)
+	switch {
+	case strings.HasPrefix(m, "gpt-"), strings.HasPrefix(m, "chatgpt"), strings.HasPrefix(m, "text-embedding"),
+		len(m) > 1 && m[0] == 'o' && m[1] >= '0' && m[1] <= '9':
+		return TokenFamilyOpenAI
+	case strings.Contains(m, "claude"):
+		return TokenFamilyClaude
+	case strings.Contains(m, "gemini"), strings.Contains(m, "gemma"):
+		return TokenFamilyGemini
+	case strings.Contains(m, "qwen"), strings.Contains(m, "deepseek"), strings.Contains(m, "glm"),
+		strings.Contains(m, "kimi"), strings.Contains(m, "moonshot"):
+		return TokenFamilyChinese
+	default:
+		return TokenFamilyDefault
+	}
+}
+
+// Count counts the tokens in text, exactly when the BPE vocabulary is loaded
+func (t *Tokenizer) Count(text string) int {
+	if text == "" {
+		return 0
+	}
+	if enc := t.bpe(); enc != nil {
+		return len(enc.Encode(text, nil, nil))
+	}
+
+	return t.estimate(text)
+}
+
+// bpe returns the loaded BPE encoding of the tokenizer, nil when text is estimated
+func (t *Tokenizer) bpe() *tiktoken.Tiktoken {
+	if t.encoding == "" {
+		return nil
+	}
+
+	return bpeEncodings[t.encoding].get()
+}
+
+// estimate approximates the number of tokens in text
+func (t *Tokenizer) estimate(text string) int {
+	var tokens float64
+	runes := []rune(text)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		j := i + 1
+		switch {
+		case isCJK(r):
+			for j < len(runes) && isCJK(runes[j]) {
+				j++
+			}
+			tokens += math.Ceil(float64(j-i) * t.tokensPerCJK)
+		case unicode.IsLetter(r):
+			for j < len(runes) && unicode.IsLetter(runes[j]) && !isCJK(runes[j]) {
+				j++
+			}
+			tokens += math.Ceil(float64(j-i) / t.charsPerToken)
+		case unicode.IsDigit(r):
+			for j < len(runes) && unicode.IsDigit(runes[j]) {
+				j++
+			}
+			tokens += math.Ceil(float64(j-i) / 3)
+		case unicode.IsSpace(r):
+			newline := r == '\n'
+			for j < len(runes) && unicode.IsSpace(runes[j]) {
+				newline = newline || runes[j] == '\n'
+				j++
+			}
+			// Single spaces merge into the next word, line breaks are tokens of their own
+			if newline || j-i > 1 {
+				tokens++
+			}
+		case r < unicode.MaxASCII:
+			tokens++
+		default:
+			// Emoji and other symbols usually take several byte-level tokens
+			tokens += 2
+		}
+		i = j
+	}
+
+	return int(tokens)
+}
+
+// CountMessages estimates the prompt tokens of messages including per-message overhead
+func (t *Tokenizer) CountMessages(msgs []Message) int {
+	total := replyTokens
+	for _, m := range msgs {
+		total += t.CountMessage(m)
+	}
+
+	return total
+}
+
+// CountMessage estimates the tokens of a single message
+func (t *Tokenizer) CountMessage(m Message) int {
+	total := t.messageOverhead + t.Count(m.Name)
+	if len(m.Parts) > 0 {
+		for _, p := range m.Parts {
+			total += t.countPart(p)
+		}
+	} else {
+		total += t.Count(m.Content)
+	}
+	for _, c := range m.ToolCalls {
+		total += toolCallTokens + t.Count(c.Function.Name) + t.Count(c.Function.Arguments)
+	}
+
+	return total
+}
+
+// CountTools estimates the tokens of tool definitions sent with a request
+func (t *Tokenizer) CountTools(tools []Tool) int {
+	total := 0
+	for _, tool := range tools {
+		total += toolDefTokens + t.Count(tool.Function.Name) + t.Count(tool.Function.Description)
+		if len(tool.Function.Parameters) > 0 {
+			params, _ := json.Marshal(tool.Function.Parameters)
+			total += t.Count(string(params))
+		}
+	}
+
+	return total
+}
+
+func (t *Tokenizer) countPart(p ContentPart) int {
+	switch p.Type {
+	case ContentPartText:
+		return t.Count(p.Text)
+	case ContentPartImageURL:
+		if p.ImageURL != nil && p.ImageURL.Detail == "low" {
+			return imageTokensLow
+		}
+
+		return imageTokensHigh
+	default:
+		return 0
+	}
+}
+
+// isCJK reports whether r is a Chinese, Japanese or Korean character
+func isCJK(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul)
+}
@@ -0,0 +1,160 @@
+// ABOUTME: Unit tests for token counting.
+// ABOUTME: Tests family detection, counts for text, messages and tools, and counts against known BPE token counts.
+
+package ai
+
+import (
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenFamilyOf(t *testing.T) {
+	tests := map[string]string{
+		"gpt-4o":            TokenFamilyOpenAI,
+		"o3-mini":           TokenFamilyOpenAI,
+		"claude-sonnet-4.5": TokenFamilyClaude,
+		"gemini-3-flash":    TokenFamilyGemini,
+		"qwen3-max":         TokenFamilyChinese,
+		"deepseek-v3.2":     TokenFamilyChinese,
+		"glm-4.7":           TokenFamilyChinese,
+		"llama3":            TokenFamilyDefault,
+		"":                  TokenFamilyDefault,
+	}
+	for model, want := range tests {
+		assert.Equal(t, want, TokenFamilyOf(model), model)
+	}
+}
+
+// estimator returns the tokenizer of model without its BPE vocabulary, so counts are estimated
+func estimator(model string) *Tokenizer {
+	tok := *TokenizerFor(model)
+	tok.encoding = ""
+
+	return &tok
+}
+
+func TestBPEEncodingOf(t *testing.T) {
+	tests := map[string]string{
+		"gpt-4o":                 EncodingO200K,
+		"gpt-4o-mini":            EncodingO200K,
+		"gpt-4.1":                EncodingO200K,
+		"gpt-5":                  EncodingO200K,
+		"o3-mini":                EncodingO200K,
+		"gpt-4":                  EncodingCL100K,
+		"gpt-4-turbo":            EncodingCL100K,
+		"gpt-3.5-turbo":          EncodingCL100K,
+		"text-embedding-3-small": EncodingCL100K,
+	}
+	for model, want := range tests {
+		assert.Equal(t, want, TokenizerFor(model).encoding, model)
+	}
+	assert.Empty(t, TokenizerFor("claude-sonnet-4.5").encoding)
+}
+
+// knownCounts are token counts reported by OpenAI's tiktoken
+var knownCounts = map[string]map[string]int{
+	EncodingCL100K: {
+		"hello world":        2,
+		"Hello, world!":      4,
+		"tiktoken is great!": 6,
+		"The quick brown fox jumps over the lazy dog.": 10,
+		"1234567890": 4,
+	},
+	EncodingO200K: {
+		"hello world":   2,
+		"Hello, world!": 4,
+		"The quick brown fox jumps over the lazy dog.": 10,
+	},
+}
+
+func TestTokenizerKnownCounts(t *testing.T) {
+	models := map[string]string{EncodingCL100K: "gpt-4", EncodingO200K: "gpt-4o"}
+
+	t.Run("estimate", func(t *testing.T) {
+		for encoding, counts := range knownCounts {
+			tok := estimator(models[encoding])
+			for text, want := range counts {
+				assert.InDelta(t, want, tok.Count(text), max(1, float64(want)/5), "%s: %q", encoding, text)
+			}
+		}
+	})
+
+	t.Run("bpe", func(t *testing.T) {
+		for encoding, counts := range knownCounts {
+			if _, err := bpeEncodings[encoding].load(); err != nil {
+				t.Skipf("%s vocabulary unavailable: %v", encoding, err)
+			}
+			tok := TokenizerFor(models[encoding])
+			for text, want := range counts {
+				assert.Equal(t, want, tok.Count(text), "%s: %q", encoding, text)
+			}
+		}
+	})
+}
+
+func TestBPELoader_Cache(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("TIKTOKEN_CACHE_DIR", dir)
+	vocab := base64.StdEncoding.EncodeToString([]byte("hello")) + " 0\n" +
+		base64.StdEncoding.EncodeToString([]byte(" world")) + " 1\n"
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "cl100k_base.tiktoken"), []byte(vocab), 0o644))
+
+	ranks, err := bpeLoader{}.LoadTiktokenBpe("https://openaipublic.blob.core.windows.net/encodings/cl100k_base.tiktoken")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"hello": 0, " world": 1}, ranks)
+}
+
+func TestTokenizerCount(t *testing.T) {
+	tok := estimator("gpt-4o")
+
+	assert.Zero(t, tok.Count(""))
+	assert.Equal(t, 2, tok.Count("hello world"))
+	assert.Equal(t, 3, tok.Count("hello, world"))
+	assert.Equal(t, 2, tok.Count("123456"))
+	assert.Equal(t, 4, tok.Count("你好世界"))
+	assert.Equal(t, 3, tok.Count("a\n\nb"))
+
+	// Longer words take more tokens
+	assert.Equal(t, 4, tok.Count("internationalization"))
+
+	// Chinese-optimized vocabularies use fewer tokens for CJK text
+	text := strings.Repeat("数学老师", 100)
+	assert.Less(t, TokenizerFor("qwen3-max").Count(text), TokenizerFor("claude-sonnet-4.5").Count(text))
+}
+
+func TestTokenizerCountMessages(t *testing.T) {
+	tok := estimator("gpt-4o")
+
+	msgs := []Message{
+		{Role: RoleSystem, Content: "hello world"},
+		{Role: RoleUser, Parts: []ContentPart{
+			{Type: ContentPartText, Text: "hello"},
+			{Type: ContentPartImageURL, ImageURL: &ImageURL{URL: "https://example.com/a.png", Detail: "low"}},
+		}},
+		{Role: RoleAssistant, ToolCalls: []ToolCall{
+			{ID: "call_1", Function: FunctionCall{Name: "get_weather", Arguments: `{"city":"Paris"}`}},
+		}},
+	}
+
+	// reply + (3 + 2) + (3 + 1 + 85) + (3 + tool call)
+	toolCall := toolCallTokens + tok.Count("get_weather") + tok.Count(`{"city":"Paris"}`)
+	assert.Equal(t, replyTokens+5+89+3+toolCall, tok.CountMessages(msgs))
+}
+
+func TestTokenizerCountTools(t *testing.T) {
+	tok := TokenizerFor("gpt-4o")
+	assert.Zero(t, tok.CountTools(nil))
+
+	tools := []Tool{{Type: ToolTypeFunction, Function: FunctionDefinition{
+		Name:        "get_weather",
+		Description: "Get the weather",
+		Parameters:  map[string]any{"type": "object"},
+	}}}
+	assert.Greater(t, tok.CountTools(tools), toolDefTokens)
+}
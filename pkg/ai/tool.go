@@ -0,0 +1,223 @@
+// ABOUTME: Tool (function) calling types and server-side tool registry.
+// ABOUTME: Defines OpenAI-compatible tool structures and executable tool handlers.
+
+package ai
+
+import (
+	"context"
+	"sort"
+	"sync"
+)
+
+// ToolTypeFunction is the only tool type currently supported.
+const ToolTypeFunction = "function"
+
+// Tool choice modes
+const (
+	ToolChoiceAuto     = "auto"
+	ToolChoiceNone     = "none"
+	ToolChoiceRequired = "required"
+)
+
+// Tool represents a tool the model may call
+type Tool struct {
+	Type     string             `json:"type"`
+	Function FunctionDefinition `json:"function"`
+}
+
+// FunctionDefinition describes a callable function
+type FunctionDefinition struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description,omitempty"`
+	Parameters  map[string]any `json:"parameters,omitempty"` // JSON Schema object
+}
+
+// ToolCall represents a tool invocation requested by the model
+type ToolCall struct {
+	Index    *int         `json:"index,omitempty"` // Only set in stream deltas
+	ID       string       `json:"id"`
+	Type     string       `json:"type"`
+	Function FunctionCall `json:"function"`
+}
+
+// FunctionCall holds the function name and JSON-encoded arguments
+type FunctionCall struct {
+	Name      string `json:"name"`
+	Arguments string `json:"arguments"`
+}
+
+// ToolChoice controls whether and which tools the model calls
+type ToolChoice struct {
+	Mode string // auto, none, required
+	Name string // Forces a specific function when set
+}
+
+// ParseToolChoice parses an OpenAI-style tool_choice value.
+// Accepts "auto" / "none" / "required" or {"type":"function","function":{"name":"..."}}.
+func ParseToolChoice(v any) *ToolChoice {
+	switch c := v.(type) {
+	case string:
+		if c == "" {
+			return nil
+		}
+
+		return &ToolChoice{Mode: c}
+	case map[string]any:
+		fn, _ := c["function"].(map[string]any)
+		name, _ := fn["name"].(string)
+		if name == "" {
+			return nil
+		}
+
+		return &ToolChoice{Mode: ToolChoiceRequired, Name: name}
+	}
+
+	return nil
+}
+
+// ToolHandler is a tool executed on the server side
+type ToolHandler interface {
+	// Definition returns the tool definition sent to the model
+	Definition() Tool
+
+	// Call executes the tool with JSON-encoded arguments and returns the result content
+	Call(ctx context.Context, arguments string) (string, error)
+}
+
+// ToolFunc adapts a function into a ToolHandler
+type ToolFunc struct {
+	def Tool
+	fn  func(ctx context.Context, arguments string) (string, error)
+}
+
+var _ ToolHandler = (*ToolFunc)(nil)
+
+// NewToolFunc creates a ToolHandler from a definition and a function
+func NewToolFunc(def FunctionDefinition, fn func(ctx context.Context, arguments string) (string, error)) *ToolFunc {
+	return &ToolFunc{
+		def: Tool{Type: ToolTypeFunction, Function: def},
+		fn:  fn,
+	}
+}
+
+// Definition returns the tool definition
+func (t *ToolFunc) Definition() Tool {
+	return t.def
+}
+
+// Call executes the tool
+func (t *ToolFunc) Call(ctx context.Context, arguments string) (string, error) {
+	return t.fn(ctx, arguments)
+}
+
+// ToolRegistry manages server-side tools
+type ToolRegistry struct {
+	tools map[string]ToolHandler
+	mu    sync.RWMutex
+}
+
+// NewToolRegistry creates a new tool registry
+func NewToolRegistry() *ToolRegistry {
+	return &ToolRegistry{
+		tools: make(map[string]ToolHandler),
+	}
+}
+
+// Register registers a tool by its function name
+func (r *ToolRegistry) Register(t ToolHandler) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.tools[t.Definition().Function.Name] = t
+}
+
+// Get returns a tool by name
+func (r *ToolRegistry) Get(name string) (ToolHandler, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	t, ok := r.tools[name]
+
+	return t, ok
+}
+
+// Names returns all registered tool names in sorted order
+func (r *ToolRegistry) Names() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	names := make([]string, 0, len(r.tools))
+	for name := range r.tools {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// HandlesAll reports whether every tool call can be executed by this registry
+func (r *ToolRegistry) HandlesAll(calls []ToolCall) bool {
+	if len(calls) == 0 {
+		return false
+	}
+	for _, c := range calls {
+		if _, ok := r.Get(c.Function.Name); !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ToolCallAccumulator merges streamed tool call deltas into complete tool calls
+type ToolCallAccumulator struct {
+	calls []ToolCall
+	index map[int]int
+}
+
+// NewToolCallAccumulator creates a new accumulator
+func NewToolCallAccumulator() *ToolCallAccumulator {
+	return &ToolCallAccumulator{index: make(map[int]int)}
+}
+
+// Add merges tool call deltas from a stream chunk
+func (a *ToolCallAccumulator) Add(deltas []ToolCall) {
+	for _, d := range deltas {
+		// Deltas without index are complete calls (non-incremental providers)
+		if d.Index == nil {
+			a.calls = append(a.calls, d)
+
+			continue
+		}
+
+		pos, ok := a.index[*d.Index]
+		if !ok {
+			a.index[*d.Index] = len(a.calls)
+			a.calls = append(a.calls, ToolCall{ID: d.ID, Type: d.Type, Function: d.Function})
+
+			continue
+		}
+
+		c := &a.calls[pos]
+		if d.ID != "" {
+			c.ID = d.ID
+		}
+		if d.Type != "" {
+			c.Type = d.Type
+		}
+		if c.Function.Name == "" {
+			c.Function.Name = d.Function.Name
+		}
+		c.Function.Arguments += d.Function.Arguments
+	}
+}
+
+// Calls returns the merged tool calls
+func (a *ToolCallAccumulator) Calls() []ToolCall {
+	for i := range a.calls {
+		if a.calls[i].Type == "" {
+			a.calls[i].Type = ToolTypeFunction
+		}
+	}
+
+	return a.calls
+}
@@ -0,0 +1,60 @@
+// ABOUTME: Unit tests for tool calling types and registry.
+// ABOUTME: Tests tool registration, tool choice parsing, and stream delta merging.
+
+package ai
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToolRegistry(t *testing.T) {
+	r := NewToolRegistry()
+	r.Register(NewToolFunc(FunctionDefinition{Name: "echo"}, func(ctx context.Context, args string) (string, error) {
+		return args, nil
+	}))
+
+	tool, ok := r.Get("echo")
+	require.True(t, ok)
+	assert.Equal(t, ToolTypeFunction, tool.Definition().Type)
+
+	out, err := tool.Call(context.Background(), `{"a":1}`)
+	require.NoError(t, err)
+	assert.Equal(t, `{"a":1}`, out)
+
+	assert.Equal(t, []string{"echo"}, r.Names())
+	assert.True(t, r.HandlesAll([]ToolCall{{Function: FunctionCall{Name: "echo"}}}))
+	assert.False(t, r.HandlesAll([]ToolCall{{Function: FunctionCall{Name: "echo"}}, {Function: FunctionCall{Name: "other"}}}))
+	assert.False(t, r.HandlesAll(nil))
+}
+
+func TestParseToolChoice(t *testing.T) {
+	assert.Nil(t, ParseToolChoice(nil))
+	assert.Nil(t, ParseToolChoice(""))
+	assert.Equal(t, &ToolChoice{Mode: ToolChoiceAuto}, ParseToolChoice("auto"))
+	assert.Equal(t, &ToolChoice{Mode: ToolChoiceRequired, Name: "get_weather"}, ParseToolChoice(map[string]any{
+		"type":     "function",
+		"function": map[string]any{"name": "get_weather"},
+	}))
+}
+
+func TestToolCallAccumulator(t *testing.T) {
+	idx0, idx1 := 0, 1
+	acc := NewToolCallAccumulator()
+
+	acc.Add([]ToolCall{{Index: &idx0, ID: "call_1", Function: FunctionCall{Name: "get_weather", Arguments: `{"ci`}}})
+	acc.Add([]ToolCall{{Index: &idx0, Function: FunctionCall{Arguments: `ty":"Paris"}`}}})
+	acc.Add([]ToolCall{{Index: &idx1, ID: "call_2", Function: FunctionCall{Name: "get_time", Arguments: `{}`}}})
+
+	calls := acc.Calls()
+	require.Len(t, calls, 2)
+	assert.Equal(t, "call_1", calls[0].ID)
+	assert.Equal(t, "get_weather", calls[0].Function.Name)
+	assert.Equal(t, `{"city":"Paris"}`, calls[0].Function.Arguments)
+	assert.Equal(t, ToolTypeFunction, calls[0].Type)
+	assert.Nil(t, calls[0].Index)
+	assert.Equal(t, "get_time", calls[1].Function.Name)
+}
@@ -0,0 +1,60 @@
+// ABOUTME: Vector similarity helpers for embedding retrieval.
+// ABOUTME: Provides cosine similarity and brute-force top-k search.
+
+package ai
+
+import (
+	"math"
+	"sort"
+)
+
+// ScoredIndex is the position of a vector and its similarity to the query
+type ScoredIndex struct {
+	Index int
+	Score float64
+}
+
+// CosineSimilarity returns the cosine similarity of two vectors.
+// Returns 0 if the vectors differ in length or either has zero norm.
+func CosineSimilarity(a, b []float32) float64 {
+	if len(a) != len(b) || len(a) == 0 {
+		return 0
+	}
+
+	var dot, normA, normB float64
+	for i := range a {
+		x, y := float64(a[i]), float64(b[i])
+		dot += x * y
+		normA += x * x
+		normB += y * y
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
+
+// TopK returns up to k vectors most similar to query with score >= minScore,
+// ordered by descending score.
+func TopK(query []float32, vectors [][]float32, k int, minScore float64) []ScoredIndex {
+	if k <= 0 {
+		return nil
+	}
+
+	scored := make([]ScoredIndex, 0, len(vectors))
+	for i, v := range vectors {
+		if score := CosineSimilarity(query, v); score >= minScore {
+			scored = append(scored, ScoredIndex{Index: i, Score: score})
+		}
+	}
+
+	sort.SliceStable(scored, func(i, j int) bool {
+		return scored[i].Score > scored[j].Score
+	})
+	if len(scored) > k {
+		scored = scored[:k]
+	}
+
+	return scored
+}
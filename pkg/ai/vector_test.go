@@ -0,0 +1,38 @@
+// ABOUTME: Unit tests for vector similarity helpers.
+// ABOUTME: Tests cosine similarity and top-k ranking.
+
+package ai
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCosineSimilarity(t *testing.T) {
+	assert.InDelta(t, 1.0, CosineSimilarity([]float32{1, 2}, []float32{2, 4}), 1e-9)
+	assert.InDelta(t, 0.0, CosineSimilarity([]float32{1, 0}, []float32{0, 1}), 1e-9)
+	assert.InDelta(t, -1.0, CosineSimilarity([]float32{1, 0}, []float32{-1, 0}), 1e-9)
+	assert.Zero(t, CosineSimilarity([]float32{1, 0}, []float32{1, 0, 0}))
+	assert.Zero(t, CosineSimilarity([]float32{0, 0}, []float32{1, 0}))
+}
+
+func TestTopK(t *testing.T) {
+	vectors := [][]float32{
+		{0, 1},
+		{1, 0},
+		{1, 1},
+		{-1, 0},
+	}
+
+	hits := TopK([]float32{1, 0}, vectors, 2, 0)
+	require.Len(t, hits, 2)
+	assert.Equal(t, 1, hits[0].Index)
+	assert.Equal(t, 2, hits[1].Index)
+
+	hits = TopK([]float32{1, 0}, vectors, 10, 0.5)
+	assert.Len(t, hits, 2)
+
+	assert.Nil(t, TopK([]float32{1, 0}, vectors, 0, 0))
+}
@@ -5,30 +5,48 @@ package v1
 
 // CreateAiAgentRequest represents a request to create an AI agent.
 type CreateAiAgentRequest struct {
-	AgentID      string  `json:"agentId" binding:"required,max=32" example:"math_teacher"`
-	Name         string  `json:"name" binding:"required,max=64" example:"数学老师"`
-	Description  string  `json:"description,omitempty" binding:"max=255" example:"擅长小学数学辅导"`
-	Icon         string  `json:"icon,omitempty" binding:"max=255" example:"https://example.com/icon.png"`
-	Category     string  `json:"category,omitempty" binding:"omitempty,oneof=general education medical workplace creative" example:"education"`
-	SystemPrompt string  `json:"systemPrompt" binding:"required" example:"你是一位经验丰富的小学数学老师..."`
-	Model        string  `json:"model,omitempty" binding:"max=64" example:"gpt-4o"`
-	Temperature  float64 `json:"temperature,omitempty" example:"0.7"`
-	MaxTokens    int     `json:"maxTokens,omitempty" example:"2000"`
-	Sort         int     `json:"sort,omitempty" example:"1"`
+	AgentID        string   `json:"agentId" binding:"required,max=32" example:"math_teacher"`
+	Name           string   `json:"name" binding:"required,max=64" example:"数学老师"`
+	Description    string   `json:"description,omitempty" binding:"max=255" example:"擅长小学数学辅导"`
+	Icon           string   `json:"icon,omitempty" binding:"max=255" example:"https://example.com/icon.png"`
+	Category       string   `json:"category,omitempty" binding:"omitempty,oneof=general education medical workplace creative" example:"education"`
+	SystemPrompt   string   `json:"systemPrompt" binding:"required" example:"你是一位经验丰富的小学数学老师..."`
+	Model          string   `json:"model,omitempty" binding:"max=64" example:"gpt-4o"`
+	Temperature    float64  `json:"temperature,omitempty" example:"0.7"`
+	MaxTokens      int      `json:"maxTokens,omitempty" example:"2000"`
+	Tools          []string `json:"tools,omitempty" binding:"omitempty,max=32,dive,max=64" example:"get_user_profile"`
+	EmbeddingModel string   `json:"embeddingModel,omitempty" binding:"max=64" example:"text-embedding-3-small"`
+	Sort           int      `json:"sort,omitempty" example:"1"`
+	Note           string   `json:"note,omitempty" binding:"max=255" example:"Initial version"` // Change note of the first version
+	// Response cache, TTLs in seconds, 0 disables
+	CacheTTL               int     `json:"cacheTtl,omitempty" binding:"min=0,max=2592000" example:"3600"`
+	SemanticCacheTTL       int     `json:"semanticCacheTtl,omitempty" binding:"min=0,max=2592000" example:"3600"` // Needs embeddingModel
+	SemanticCacheThreshold float64 `json:"semanticCacheThreshold,omitempty" binding:"omitempty,gt=0,lte=1" example:"0.95"`
+	// Input and output guards, applied in order
+	Guardrails []AiGuardrailRule `json:"guardrails,omitempty" binding:"omitempty,max=20,dive"`
 }
 
 // UpdateAiAgentRequest represents a request to update an AI agent.
 type UpdateAiAgentRequest struct {
-	Name         string  `json:"name,omitempty" binding:"max=64"`
-	Description  string  `json:"description,omitempty" binding:"max=255"`
-	Icon         string  `json:"icon,omitempty" binding:"max=255"`
-	Category     string  `json:"category,omitempty" binding:"omitempty,oneof=general education medical workplace creative"`
-	SystemPrompt string  `json:"systemPrompt,omitempty"`
-	Model        string  `json:"model,omitempty" binding:"max=64"`
-	Temperature  float64 `json:"temperature,omitempty"`
-	MaxTokens    int     `json:"maxTokens,omitempty"`
-	Sort         int     `json:"sort,omitempty"`
-	Status       string  `json:"status,omitempty" binding:"omitempty,oneof=active disabled"`
+	Name           string   `json:"name,omitempty" binding:"max=64"`
+	Description    string   `json:"description,omitempty" binding:"max=255"`
+	Icon           string   `json:"icon,omitempty" binding:"max=255"`
+	Category       string   `json:"category,omitempty" binding:"omitempty,oneof=general education medical workplace creative"`
+	SystemPrompt   string   `json:"systemPrompt,omitempty"`
+	Model          string   `json:"model,omitempty" binding:"max=64"`
+	Temperature    float64  `json:"temperature,omitempty"`
+	MaxTokens      int      `json:"maxTokens,omitempty"`
+	Tools          []string `json:"tools,omitempty" binding:"omitempty,max=32,dive,max=64"` // nil keeps current tools, empty clears them
+	EmbeddingModel string   `json:"embeddingModel,omitempty" binding:"max=64"`
+	Sort           int      `json:"sort,omitempty"`
+	Status         string   `json:"status,omitempty" binding:"omitempty,oneof=active disabled"`
+	Note           string   `json:"note,omitempty" binding:"max=255"` // Change note of the version created when versioned settings change
+	// Response cache, TTLs in seconds, 0 disables, nil keeps the current value
+	CacheTTL               *int     `json:"cacheTtl,omitempty" binding:"omitempty,min=0,max=2592000"`
+	SemanticCacheTTL       *int     `json:"semanticCacheTtl,omitempty" binding:"omitempty,min=0,max=2592000"`
+	SemanticCacheThreshold *float64 `json:"semanticCacheThreshold,omitempty" binding:"omitempty,gt=0,lte=1"`
+	// Input and output guards, nil keeps the current rules, empty clears them
+	Guardrails []AiGuardrailRule `json:"guardrails,omitempty" binding:"omitempty,max=20,dive"`
 }
 
 // ListAiAgentRequest represents a request to list AI agents.
@@ -39,17 +57,26 @@ type ListAiAgentRequest struct {
 
 // AiAgentInfo represents AI agent information.
 type AiAgentInfo struct {
-	AgentID      string  `json:"agentId"`
-	Name         string  `json:"name"`
-	Description  string  `json:"description"`
-	Icon         string  `json:"icon"`
-	Category     string  `json:"category"`
-	SystemPrompt string  `json:"systemPrompt,omitempty"`
-	Model        string  `json:"model"`
-	Temperature  float64 `json:"temperature"`
-	MaxTokens    int     `json:"maxTokens"`
-	Sort         int     `json:"sort"`
-	Status       string  `json:"status"`
+	AgentID        string   `json:"agentId"`
+	Name           string   `json:"name"`
+	Description    string   `json:"description"`
+	Icon           string   `json:"icon"`
+	Category       string   `json:"category"`
+	SystemPrompt   string   `json:"systemPrompt,omitempty"`
+	Model          string   `json:"model"`
+	Temperature    float64  `json:"temperature"`
+	MaxTokens      int      `json:"maxTokens"`
+	Tools          []string `json:"tools,omitempty"`
+	EmbeddingModel string   `json:"embeddingModel,omitempty"`
+	Sort           int      `json:"sort"`
+	Status         string   `json:"status"`
+	Version        int      `json:"version,omitempty"` // Published version
+	// Response cache, TTLs in seconds, 0 disables
+	CacheTTL               int     `json:"cacheTtl"`
+	SemanticCacheTTL       int     `json:"semanticCacheTtl"`
+	SemanticCacheThreshold float64 `json:"semanticCacheThreshold"`
+	// Input and output guards
+	Guardrails []AiGuardrailRule `json:"guardrails,omitempty"`
 }
 
 // ListAiAgentResponse represents a response containing a list of AI agents.
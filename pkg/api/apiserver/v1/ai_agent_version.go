@@ -0,0 +1,73 @@
+// ABOUTME: AI agent version API request and response structures.
+// ABOUTME: Defines DTOs for listing, creating, publishing and diffing agent versions.
+
+package v1
+
+import "time"
+
+// CreateAiAgentVersionRequest creates a new version of an agent. Unset fields keep the
+// values of the published version.
+type CreateAiAgentVersionRequest struct {
+	SystemPrompt string   `json:"systemPrompt,omitempty" example:"你好 {{nickname}}，今天是 {{date}}。"`
+	Model        string   `json:"model,omitempty" binding:"max=64" example:"gpt-4o"`
+	Temperature  float64  `json:"temperature,omitempty" example:"0.7"`
+	MaxTokens    int      `json:"maxTokens,omitempty" example:"2000"`
+	Tools        []string `json:"tools,omitempty" binding:"omitempty,max=32,dive,max=64"` // nil keeps the published tools, empty clears them
+	Note         string   `json:"note,omitempty" binding:"max=255" example:"Shorter answers"`
+	Publish      bool     `json:"publish,omitempty"` // Publish the version immediately
+}
+
+// ListAiAgentVersionRequest represents a request to list the versions of an agent.
+type ListAiAgentVersionRequest struct {
+	Page     int `form:"page" binding:"omitempty,min=1"`
+	PageSize int `form:"pageSize" binding:"omitempty,min=1,max=100"`
+}
+
+// AiAgentVersionInfo represents an agent version.
+type AiAgentVersionInfo struct {
+	AgentID      string    `json:"agentId"`
+	Version      int       `json:"version"`
+	SystemPrompt string    `json:"systemPrompt"`
+	Model        string    `json:"model"`
+	Temperature  float64   `json:"temperature"`
+	MaxTokens    int       `json:"maxTokens"`
+	Tools        []string  `json:"tools,omitempty"`
+	Author       string    `json:"author"`
+	Note         string    `json:"note"`
+	Published    bool      `json:"published"` // Whether the agent currently serves this version
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+// ListAiAgentVersionResponse represents a page of agent versions, newest first.
+type ListAiAgentVersionResponse struct {
+	Total int64                `json:"total"`
+	Data  []AiAgentVersionInfo `json:"data"`
+}
+
+// DiffAiAgentVersionRequest selects the two versions to compare.
+type DiffAiAgentVersionRequest struct {
+	From int `form:"from" binding:"required,min=1"`
+	To   int `form:"to" binding:"required,min=1"`
+}
+
+// AiAgentVersionDiff describes the changes from one agent version to another.
+type AiAgentVersionDiff struct {
+	AgentID      string                `json:"agentId"`
+	From         int                   `json:"from"`
+	To           int                   `json:"to"`
+	Changes      []AiAgentFieldChange  `json:"changes"`      // Changed settings other than the system prompt
+	SystemPrompt []AiAgentPromptDiffOp `json:"systemPrompt"` // Line diff of the system prompt, empty if unchanged
+}
+
+// AiAgentFieldChange is a changed setting between two agent versions.
+type AiAgentFieldChange struct {
+	Field string `json:"field" example:"temperature"`
+	From  any    `json:"from"`
+	To    any    `json:"to"`
+}
+
+// AiAgentPromptDiffOp is a line of a system prompt diff.
+type AiAgentPromptDiffOp struct {
+	Op   string `json:"op" example:"insert"` // equal, insert or delete
+	Text string `json:"text"`
+}
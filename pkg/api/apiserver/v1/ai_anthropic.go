@@ -0,0 +1,206 @@
+// ABOUTME: Anthropic Messages API request, response and stream event structures.
+// ABOUTME: Defines DTOs for the Anthropic-compatible /v1/messages endpoint.
+
+package v1
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// Anthropic content block types.
+const (
+	AnthropicBlockText       = "text"
+	AnthropicBlockImage      = "image"
+	AnthropicBlockToolUse    = "tool_use"
+	AnthropicBlockToolResult = "tool_result"
+)
+
+// Anthropic stop reasons.
+const (
+	AnthropicStopEndTurn   = "end_turn"
+	AnthropicStopMaxTokens = "max_tokens"
+	AnthropicStopToolUse   = "tool_use"
+	AnthropicStopRefusal   = "refusal"
+)
+
+// AnthropicMessagesRequest represents a message creation request (Anthropic-compatible).
+type AnthropicMessagesRequest struct {
+	Model       string               `json:"model" example:"glm-4-flash"`
+	MaxTokens   int                  `json:"max_tokens" binding:"required,min=1" example:"1024"`
+	System      AnthropicContent     `json:"system,omitempty" swaggertype:"string" example:"You are a helpful assistant."` // String or array of text blocks
+	Messages    []AnthropicMessage   `json:"messages" binding:"required,min=1,dive"`
+	Temperature float64              `json:"temperature,omitempty" binding:"omitempty,min=0,max=1" example:"0.7"`
+	Stream      bool                 `json:"stream,omitempty" example:"false"`
+	Tools       []AnthropicTool      `json:"tools,omitempty" binding:"omitempty,max=64,dive"`
+	ToolChoice  *AnthropicToolChoice `json:"tool_choice,omitempty"`
+	Metadata    *AnthropicMetadata   `json:"metadata,omitempty"`
+	// Extension fields
+	SessionID string `json:"sessionId,omitempty"`
+}
+
+// AnthropicMessage represents a single user or assistant turn.
+type AnthropicMessage struct {
+	Role    string           `json:"role" binding:"required,oneof=user assistant" example:"user"`
+	Content AnthropicContent `json:"content" swaggertype:"string" example:"你好"` // String or array of content blocks
+}
+
+// AnthropicContent is message content, either plain text or content blocks.
+type AnthropicContent struct {
+	Text   string
+	Blocks []AnthropicContentBlock `binding:"omitempty,max=64,dive"`
+}
+
+// AnthropicContentBlock represents a text, image, tool_use or tool_result block.
+type AnthropicContentBlock struct {
+	Type string `json:"type" binding:"required,oneof=text image tool_use tool_result"`
+
+	// text
+	Text string `json:"text,omitempty" binding:"max=32768"`
+
+	// image
+	Source *AnthropicImageSource `json:"source,omitempty" binding:"required_if=Type image"`
+
+	// tool_use
+	ID    string          `json:"id,omitempty"`
+	Name  string          `json:"name,omitempty"`
+	Input json.RawMessage `json:"input,omitempty" swaggertype:"object"`
+
+	// tool_result
+	ToolUseID string            `json:"tool_use_id,omitempty" binding:"required_if=Type tool_result"`
+	Content   *AnthropicContent `json:"content,omitempty" swaggertype:"string"` // String or array of text blocks
+	IsError   bool              `json:"is_error,omitempty"`
+}
+
+// AnthropicImageSource holds an image as base64 data or a URL.
+type AnthropicImageSource struct {
+	Type      string `json:"type" binding:"required,oneof=base64 url"`
+	MediaType string `json:"media_type,omitempty" binding:"required_if=Type base64" example:"image/png"`
+	Data      string `json:"data,omitempty" binding:"required_if=Type base64"`
+	URL       string `json:"url,omitempty" binding:"required_if=Type url"`
+}
+
+// AnthropicTool represents a tool the model may call.
+type AnthropicTool struct {
+	Name        string         `json:"name" binding:"required,max=64" example:"get_weather"`
+	Description string         `json:"description,omitempty"`
+	InputSchema map[string]any `json:"input_schema" binding:"required"` // JSON Schema object
+}
+
+// AnthropicToolChoice controls whether and which tools the model calls.
+type AnthropicToolChoice struct {
+	Type string `json:"type" binding:"required,oneof=auto any tool none" example:"auto"`
+	Name string `json:"name,omitempty" binding:"required_if=Type tool"`
+}
+
+// AnthropicMetadata carries request metadata, accepted for compatibility and not used.
+type AnthropicMetadata struct {
+	UserID string `json:"user_id,omitempty"`
+}
+
+// AnthropicMessageResponse represents a created message (Anthropic-compatible).
+type AnthropicMessageResponse struct {
+	ID           string                  `json:"id"`
+	Type         string                  `json:"type"` // Always "message"
+	Role         string                  `json:"role"` // Always "assistant"
+	Model        string                  `json:"model"`
+	Content      []AnthropicContentBlock `json:"content"`
+	StopReason   *string                 `json:"stop_reason"`
+	StopSequence *string                 `json:"stop_sequence"`
+	Usage        AnthropicUsage          `json:"usage"`
+}
+
+// AnthropicUsage represents token usage.
+type AnthropicUsage struct {
+	InputTokens  int `json:"input_tokens"`
+	OutputTokens int `json:"output_tokens"`
+}
+
+// AnthropicStreamEvent is a server-sent event of a streamed message. Type is also
+// the SSE event name, only the fields of that event type are set.
+type AnthropicStreamEvent struct {
+	Type         string                    `json:"type"`
+	Message      *AnthropicMessageResponse `json:"message,omitempty"`       // message_start
+	Index        *int                      `json:"index,omitempty"`         // content_block_*
+	ContentBlock *AnthropicContentBlock    `json:"content_block,omitempty"` // content_block_start
+	Delta        *AnthropicStreamDelta     `json:"delta,omitempty"`         // content_block_delta, message_delta
+	Usage        *AnthropicUsage           `json:"usage,omitempty"`         // message_delta
+	Error        *AnthropicError           `json:"error,omitempty"`         // error
+}
+
+// AnthropicStreamDelta is the change carried by a delta event.
+type AnthropicStreamDelta struct {
+	Type         string  `json:"type,omitempty"` // text_delta or input_json_delta
+	Text         string  `json:"text,omitempty"`
+	PartialJSON  string  `json:"partial_json,omitempty"`
+	StopReason   *string `json:"stop_reason,omitempty"`
+	StopSequence *string `json:"stop_sequence,omitempty"`
+}
+
+// AnthropicError describes an error event.
+type AnthropicError struct {
+	Type    string `json:"type"`
+	Message string `json:"message"`
+}
+
+// NewAnthropicContent creates text content.
+func NewAnthropicContent(text string) AnthropicContent {
+	return AnthropicContent{Text: text}
+}
+
+// IsEmpty reports whether the content has neither text nor blocks.
+func (c AnthropicContent) IsEmpty() bool {
+	return c.Text == "" && len(c.Blocks) == 0
+}
+
+// MarshalJSON encodes text content as a string and block content as an array.
+func (c AnthropicContent) MarshalJSON() ([]byte, error) {
+	if len(c.Blocks) > 0 {
+		return json.Marshal(c.Blocks)
+	}
+
+	return json.Marshal(c.Text)
+}
+
+// MarshalJSON encodes the fields of the block type, required ones even when empty,
+// so a stream's opening text block carries "text": "" and a tool_use block an input.
+func (b AnthropicContentBlock) MarshalJSON() ([]byte, error) {
+	switch b.Type {
+	case AnthropicBlockText:
+		return json.Marshal(struct {
+			Type string `json:"type"`
+			Text string `json:"text"`
+		}{b.Type, b.Text})
+	case AnthropicBlockToolUse:
+		input := b.Input
+		if len(input) == 0 {
+			input = json.RawMessage("{}")
+		}
+
+		return json.Marshal(struct {
+			Type  string          `json:"type"`
+			ID    string          `json:"id"`
+			Name  string          `json:"name"`
+			Input json.RawMessage `json:"input"`
+		}{b.Type, b.ID, b.Name, input})
+	}
+
+	type block AnthropicContentBlock
+
+	return json.Marshal(block(b))
+}
+
+// UnmarshalJSON accepts a string, an array of content blocks or null.
+func (c *AnthropicContent) UnmarshalJSON(data []byte) error {
+	*c = AnthropicContent{}
+
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	if len(data) > 0 && data[0] == '[' {
+		return json.Unmarshal(data, &c.Blocks)
+	}
+
+	return json.Unmarshal(data, &c.Text)
+}
@@ -3,7 +3,11 @@
 
 package v1
 
-import "time"
+import (
+	"bytes"
+	"encoding/json"
+	"time"
+)
 
 // ChatCompletionRequest represents a chat completion request (OpenAI-compatible).
 type ChatCompletionRequest struct {
@@ -12,14 +16,119 @@ type ChatCompletionRequest struct {
 	MaxTokens   int           `json:"max_tokens,omitempty" example:"2048"`
 	Temperature float64       `json:"temperature,omitempty" example:"0.7"`
 	Stream      bool          `json:"stream,omitempty" example:"false"`
+	Tools       []ChatTool    `json:"tools,omitempty" binding:"omitempty,max=64,dive"`
+	ToolChoice  any           `json:"tool_choice,omitempty" swaggertype:"string" example:"auto"` // "auto", "none", "required" or {"type":"function","function":{"name":"..."}}
+	// Constrains the reply to JSON, not supported with stream
+	ResponseFormat *ChatResponseFormat `json:"response_format,omitempty"`
 	// Extension fields
 	SessionID string `json:"sessionId,omitempty"`
 }
 
+// CancelChatCompletionRequest represents a request to cancel a running streamed completion.
+type CancelChatCompletionRequest struct {
+	ID string `json:"id" binding:"required,max=64"` // ID of the stream chunks
+}
+
 // ChatMessage represents a single message.
 type ChatMessage struct {
-	Role    string `json:"role" binding:"required,oneof=system user assistant" example:"user"`
-	Content string `json:"content" binding:"required,max=32768" example:"你好"`
+	Role       string         `json:"role" binding:"required,oneof=system user assistant tool" example:"user"`
+	Content    ChatContent    `json:"content" swaggertype:"string" example:"你好"` // String or array of content parts
+	Name       string         `json:"name,omitempty"`
+	ToolCalls  []ChatToolCall `json:"tool_calls,omitempty"`
+	ToolCallID string         `json:"tool_call_id,omitempty" binding:"required_if=Role tool"`
+}
+
+// ChatContent is message content, either plain text or multimodal parts (OpenAI-compatible).
+type ChatContent struct {
+	Text  string            `binding:"max=32768"`
+	Parts []ChatContentPart `binding:"omitempty,max=16,dive"`
+}
+
+// ChatContentPart represents a single part of multimodal content.
+type ChatContentPart struct {
+	Type     string        `json:"type" binding:"required,oneof=text image_url" example:"image_url"`
+	Text     string        `json:"text,omitempty" binding:"max=32768"`
+	ImageURL *ChatImageURL `json:"image_url,omitempty" binding:"required_if=Type image_url"`
+}
+
+// ChatImageURL references an image by URL, data URL or uploaded file path.
+type ChatImageURL struct {
+	URL    string `json:"url" binding:"required" example:"storage/upload/2026/01/01/example.png"`
+	Detail string `json:"detail,omitempty" binding:"omitempty,oneof=auto low high"`
+}
+
+// NewChatContent creates text content.
+func NewChatContent(text string) ChatContent {
+	return ChatContent{Text: text}
+}
+
+// IsEmpty reports whether the content has neither text nor parts.
+func (c ChatContent) IsEmpty() bool {
+	return c.Text == "" && len(c.Parts) == 0
+}
+
+// MarshalJSON encodes text content as a string and multimodal content as an array.
+func (c ChatContent) MarshalJSON() ([]byte, error) {
+	if len(c.Parts) > 0 {
+		return json.Marshal(c.Parts)
+	}
+
+	return json.Marshal(c.Text)
+}
+
+// UnmarshalJSON accepts a string, an array of content parts or null.
+func (c *ChatContent) UnmarshalJSON(data []byte) error {
+	*c = ChatContent{}
+
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		return nil
+	}
+	if len(data) > 0 && data[0] == '[' {
+		return json.Unmarshal(data, &c.Parts)
+	}
+
+	return json.Unmarshal(data, &c.Text)
+}
+
+// ChatTool represents a tool the model may call.
+type ChatTool struct {
+	Type     string       `json:"type" binding:"required,eq=function" example:"function"`
+	Function ChatFunction `json:"function" binding:"required"`
+}
+
+// ChatFunction describes a callable function.
+type ChatFunction struct {
+	Name        string         `json:"name" binding:"required,max=64" example:"get_weather"`
+	Description string         `json:"description,omitempty"`
+	Parameters  map[string]any `json:"parameters,omitempty"` // JSON Schema object
+}
+
+// ChatResponseFormat constrains the reply format (OpenAI-compatible).
+type ChatResponseFormat struct {
+	Type       string          `json:"type" binding:"required,oneof=text json_object json_schema" example:"json_schema"`
+	JSONSchema *ChatJSONSchema `json:"json_schema,omitempty" binding:"required_if=Type json_schema"`
+}
+
+// ChatJSONSchema is the schema a json_schema reply must match.
+type ChatJSONSchema struct {
+	Name   string         `json:"name,omitempty" binding:"omitempty,max=64" example:"weather"`
+	Schema map[string]any `json:"schema" binding:"required"` // JSON Schema object
+	Strict bool           `json:"strict,omitempty"`
+}
+
+// ChatToolCall represents a tool call requested by the model.
+type ChatToolCall struct {
+	Index    *int             `json:"index,omitempty"` // Only set in stream deltas
+	ID       string           `json:"id"`
+	Type     string           `json:"type"`
+	Function ChatFunctionCall `json:"function"`
+}
+
+// ChatFunctionCall holds the function name and JSON-encoded arguments.
+type ChatFunctionCall struct {
+	Name      string `json:"name"`
+	Arguments string `json:"arguments"`
 }
 
 // ChatCompletionResponse represents a chat completion response (OpenAI-compatible).
@@ -59,6 +168,7 @@ type ModelInfo struct {
 	Object      string  `json:"object"`
 	Created     int64   `json:"created"`
 	OwnedBy     string  `json:"ownedBy"`
+	Type        string  `json:"type,omitempty"`
 	MaxTokens   int     `json:"maxTokens,omitempty"`
 	InputPrice  float64 `json:"inputPrice,omitempty"`
 	OutputPrice float64 `json:"outputPrice,omitempty"`
@@ -87,12 +197,51 @@ type SessionInfo struct {
 	MessageCount int       `json:"messageCount"`
 	TotalTokens  int       `json:"totalTokens"`
 	Status       string    `json:"status"`
+	ActiveLeafID uint64    `json:"activeLeafId"` // Last message of the active branch
 	CreatedAt    time.Time `json:"createdAt"`
 	UpdatedAt    time.Time `json:"updatedAt"`
 }
 
 // SessionHistoryResponse represents session history response.
 type SessionHistoryResponse struct {
-	SessionID string        `json:"sessionId"`
-	Messages  []ChatMessage `json:"messages"`
+	SessionID string           `json:"sessionId"`
+	Messages  []SessionMessage `json:"messages"` // Active branch, oldest first
+}
+
+// SessionMessage represents a stored message of a session.
+type SessionMessage struct {
+	ID       uint64 `json:"id"`
+	ParentID uint64 `json:"parentId"` // Previous message on the branch, 0 for the first message
+	ChatMessage
+	FinishReason string    `json:"finishReason,omitempty"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
+
+// EditMessageRequest represents a request to edit a user message into a new branch and answer it.
+type EditMessageRequest struct {
+	Content     ChatContent `json:"content" swaggertype:"string" example:"你好"` // String or array of content parts
+	Model       string      `json:"model,omitempty" example:"glm-4-flash"`
+	MaxTokens   int         `json:"max_tokens,omitempty" example:"2048"`
+	Temperature float64     `json:"temperature,omitempty" example:"0.7"`
+	Stream      bool        `json:"stream,omitempty" example:"false"`
+}
+
+// RegenerateMessageRequest represents a request to answer again on a new branch.
+type RegenerateMessageRequest struct {
+	Model       string  `json:"model,omitempty" example:"glm-4-flash"`
+	MaxTokens   int     `json:"max_tokens,omitempty" example:"2048"`
+	Temperature float64 `json:"temperature,omitempty" example:"0.7"`
+	Stream      bool    `json:"stream,omitempty" example:"false"`
+}
+
+// MessageVariantsResponse lists the variants of a message, the messages sharing its parent.
+type MessageVariantsResponse struct {
+	ParentID uint64           `json:"parentId"`
+	ActiveID uint64           `json:"activeId"` // Variant on the active branch, 0 if none
+	Messages []SessionMessage `json:"messages"` // Oldest first
+}
+
+// SwitchBranchRequest represents a request to continue the session on the branch of a message.
+type SwitchBranchRequest struct {
+	MessageID uint64 `json:"messageId" binding:"required"`
 }
@@ -0,0 +1,33 @@
+// ABOUTME: Embedding API request and response structures.
+// ABOUTME: Defines OpenAI-compatible DTOs for the embeddings endpoint.
+
+package v1
+
+// EmbeddingRequest represents an embedding request (OpenAI-compatible).
+type EmbeddingRequest struct {
+	Model          string `json:"model" binding:"required,max=64" example:"text-embedding-3-small"`
+	Input          any    `json:"input" binding:"required" swaggertype:"string" example:"你好"` // String or array of strings
+	Dimensions     int    `json:"dimensions,omitempty" binding:"omitempty,min=1" example:"1536"`
+	EncodingFormat string `json:"encoding_format,omitempty" binding:"omitempty,oneof=float" example:"float"`
+}
+
+// EmbeddingResponse represents an embedding response (OpenAI-compatible).
+type EmbeddingResponse struct {
+	Object string          `json:"object"`
+	Data   []EmbeddingData `json:"data"`
+	Model  string          `json:"model"`
+	Usage  EmbeddingUsage  `json:"usage"`
+}
+
+// EmbeddingData represents a single embedding vector.
+type EmbeddingData struct {
+	Object    string    `json:"object"`
+	Index     int       `json:"index"`
+	Embedding []float32 `json:"embedding"`
+}
+
+// EmbeddingUsage represents token usage of an embedding request.
+type EmbeddingUsage struct {
+	PromptTokens int `json:"prompt_tokens"`
+	TotalTokens  int `json:"total_tokens"`
+}
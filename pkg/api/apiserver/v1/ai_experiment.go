@@ -0,0 +1,80 @@
+// ABOUTME: AI experiment API request and response structures.
+// ABOUTME: Defines DTOs for managing A/B experiments on agents and comparing their arms.
+
+package v1
+
+import "time"
+
+// AiExperimentArm is a variant of an agent users are split across.
+type AiExperimentArm struct {
+	Key          string  `json:"key" binding:"required,max=32" example:"control"`
+	Weight       int     `json:"weight" binding:"required,min=1,max=100" example:"50"` // Relative share of users
+	Model        string  `json:"model,omitempty" binding:"max=64" example:"gpt-4o"`    // Empty keeps the agent model
+	Temperature  float64 `json:"temperature,omitempty" binding:"min=0,max=2"`          // 0 keeps the agent temperature
+	AgentVersion int     `json:"agentVersion,omitempty" binding:"min=0" example:"3"`   // 0 uses the published version
+}
+
+// CreateAiExperimentRequest creates a draft experiment on an agent.
+type CreateAiExperimentRequest struct {
+	Name    string            `json:"name" binding:"required,max=64" example:"GPT-4o vs Claude"`
+	AgentID string            `json:"agentId" binding:"required,max=32" example:"math_teacher"`
+	Arms    []AiExperimentArm `json:"arms" binding:"required,min=2,max=10,unique=Key,dive"`
+}
+
+// UpdateAiExperimentRequest updates an experiment. Arms can only be changed before it starts.
+type UpdateAiExperimentRequest struct {
+	Name *string           `json:"name" binding:"omitempty,max=64"`
+	Arms []AiExperimentArm `json:"arms" binding:"omitempty,min=2,max=10,unique=Key,dive"`
+}
+
+// ListAiExperimentRequest represents a request to list experiments.
+type ListAiExperimentRequest struct {
+	Page     int    `form:"page" binding:"omitempty,min=1"`
+	PageSize int    `form:"pageSize" binding:"omitempty,min=1,max=100"`
+	AgentID  string `form:"agentId" binding:"omitempty,max=32"`
+	Status   string `form:"status" binding:"omitempty,oneof=draft running stopped"`
+}
+
+// AiExperimentInfo represents an experiment.
+type AiExperimentInfo struct {
+	ID        uint64            `json:"id"`
+	Name      string            `json:"name"`
+	AgentID   string            `json:"agentId"`
+	Status    string            `json:"status"` // draft, running or stopped
+	Arms      []AiExperimentArm `json:"arms"`
+	StartedAt *time.Time        `json:"startedAt"`
+	StoppedAt *time.Time        `json:"stoppedAt"`
+	CreatedAt time.Time         `json:"createdAt"`
+	UpdatedAt time.Time         `json:"updatedAt"`
+}
+
+// ListAiExperimentResponse represents a page of experiments, newest first.
+type ListAiExperimentResponse struct {
+	Total int64              `json:"total"`
+	Data  []AiExperimentInfo `json:"data"`
+}
+
+// AiExperimentArmReport compares the usage and user feedback of one arm.
+type AiExperimentArmReport struct {
+	Key              string  `json:"key"`
+	Weight           int     `json:"weight"`
+	Requests         int64   `json:"requests"`
+	Errors           int64   `json:"errors"`
+	ErrorRate        float64 `json:"errorRate"` // Share of failed requests, 0 to 1
+	PromptTokens     int64   `json:"promptTokens"`
+	CompletionTokens int64   `json:"completionTokens"`
+	TotalTokens      int64   `json:"totalTokens"`
+	AvgTokens        float64 `json:"avgTokens"` // Total tokens per request
+	AvgLatencyMs     float64 `json:"avgLatencyMs"`
+	Cost             float64 `json:"cost"`
+	Ratings          int64   `json:"ratings"`
+	Up               int64   `json:"up"`
+	Down             int64   `json:"down"`
+	SatisfyRate      float64 `json:"satisfyRate"` // Share of thumbs up, 0 to 1
+}
+
+// AiExperimentReport compares the arms of an experiment.
+type AiExperimentReport struct {
+	Experiment AiExperimentInfo        `json:"experiment"`
+	Arms       []AiExperimentArmReport `json:"arms"`
+}
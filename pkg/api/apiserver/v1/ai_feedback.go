@@ -0,0 +1,102 @@
+// ABOUTME: AI reply feedback API request and response structures.
+// ABOUTME: Defines DTOs for rating assistant messages and the admin rating analytics.
+
+package v1
+
+import "time"
+
+// RateMessageRequest represents a thumbs up (1) or down (-1) on an assistant message.
+// Rating a message again replaces the earlier rating.
+type RateMessageRequest struct {
+	Rating  int      `json:"rating" binding:"required,oneof=1 -1" example:"-1"`
+	Reasons []string `json:"reasons,omitempty" binding:"omitempty,max=8,dive,oneof=helpful accurate clear inaccurate unhelpful incomplete off_topic unsafe too_long other" example:"inaccurate"`
+	Comment string   `json:"comment,omitempty" binding:"max=1000"`
+}
+
+// FeedbackInfo represents the user's feedback on a message.
+type FeedbackInfo struct {
+	MessageID uint64    `json:"messageId"`
+	SessionID string    `json:"sessionId"`
+	Rating    int       `json:"rating"`
+	Reasons   []string  `json:"reasons"`
+	Comment   string    `json:"comment"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// AiFeedbackInfo represents a feedback entry for admin.
+type AiFeedbackInfo struct {
+	ID        uint64    `json:"id"`
+	UID       string    `json:"uid"`
+	SessionID string    `json:"sessionId"`
+	MessageID uint64    `json:"messageId"`
+	Rating    int       `json:"rating"`
+	Reasons   []string  `json:"reasons"`
+	Comment   string    `json:"comment"`
+	Model     string    `json:"model"`
+	AgentID   string    `json:"agentId"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// ListAiFeedbackRequest represents a request to list feedback entries, newest first.
+// From and To are inclusive days.
+type ListAiFeedbackRequest struct {
+	Rating   string     `form:"rating" binding:"omitempty,oneof=up down"`
+	Model    string     `form:"model" binding:"omitempty,max=64"`
+	AgentID  string     `form:"agentId" binding:"omitempty,max=64"`
+	UID      string     `form:"uid" binding:"omitempty,max=64"`
+	From     *time.Time `form:"from" time_format:"2006-01-02"`
+	To       *time.Time `form:"to" time_format:"2006-01-02"`
+	Page     int        `form:"page" binding:"omitempty,min=1"`
+	PageSize int        `form:"pageSize" binding:"omitempty,min=1,max=100"`
+}
+
+// ListAiFeedbackResponse represents a response containing a list of feedback entries.
+type ListAiFeedbackResponse struct {
+	Total int64            `json:"total"`
+	Data  []AiFeedbackInfo `json:"data"`
+}
+
+// AiFeedbackMessage represents a message of the conversation leading to a rated reply.
+type AiFeedbackMessage struct {
+	ID        uint64    `json:"id"`
+	Role      string    `json:"role"`
+	Content   string    `json:"content"`
+	Model     string    `json:"model,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// AiFeedbackDetail represents a feedback entry with the conversation it rates.
+type AiFeedbackDetail struct {
+	AiFeedbackInfo
+	SessionTitle string              `json:"sessionTitle"`
+	Messages     []AiFeedbackMessage `json:"messages"` // Branch ending at the rated message, oldest first
+}
+
+// AiFeedbackSummaryRequest represents a request to aggregate ratings by model, agent or day.
+// From and To are inclusive days, the range defaults to the current month.
+type AiFeedbackSummaryRequest struct {
+	GroupBy string     `form:"groupBy" binding:"required,oneof=model agent day"`
+	Model   string     `form:"model" binding:"omitempty,max=64"`
+	AgentID string     `form:"agentId" binding:"omitempty,max=64"`
+	From    *time.Time `form:"from" time_format:"2006-01-02"`
+	To      *time.Time `form:"to" time_format:"2006-01-02"`
+}
+
+// AiFeedbackSummaryItem represents the aggregated ratings of one group.
+type AiFeedbackSummaryItem struct {
+	Key         string  `json:"key"`
+	Ratings     int64   `json:"ratings"`
+	Up          int64   `json:"up"`
+	Down        int64   `json:"down"`
+	SatisfyRate float64 `json:"satisfyRate"` // Share of thumbs up, 0 to 1
+}
+
+// AiFeedbackSummaryResponse represents aggregated ratings with totals over all groups.
+type AiFeedbackSummaryResponse struct {
+	GroupBy string                  `json:"groupBy"`
+	From    string                  `json:"from"`
+	To      string                  `json:"to"`
+	Data    []AiFeedbackSummaryItem `json:"data"`
+	Total   AiFeedbackSummaryItem   `json:"total"`
+}
@@ -0,0 +1,59 @@
+// ABOUTME: AI guardrail API request and response structures.
+// ABOUTME: Defines agent guardrail rules and DTOs for reviewing flagged guardrail events.
+
+package v1
+
+import "time"
+
+// AiGuardrailRule is a guard an agent applies to user input and model output.
+type AiGuardrailRule struct {
+	Type     string   `json:"type" binding:"required,oneof=blocklist pii injection moderation" example:"pii"`
+	Action   string   `json:"action" binding:"required,oneof=block redact flag" example:"redact"` // redact applies to blocklist and pii only
+	Stages   []string `json:"stages,omitempty" binding:"omitempty,max=2,dive,oneof=input output"` // Empty checks both
+	Keywords []string `json:"keywords,omitempty" binding:"omitempty,max=500,dive,min=1,max=128"`  // Blocklist keywords, case-insensitive
+	Patterns []string `json:"patterns,omitempty" binding:"omitempty,max=100,dive,min=1,max=512"`  // Blocklist regular expressions
+	PIITypes []string `json:"piiTypes,omitempty" binding:"omitempty,dive,oneof=email phone id_card bank_card"`
+	Model    string   `json:"model,omitempty" binding:"max=64" example:"gpt-4o-mini"` // Moderation model
+}
+
+// ListAiGuardrailEventRequest represents a request to list guardrail events.
+type ListAiGuardrailEventRequest struct {
+	Page     int    `form:"page" binding:"omitempty,min=1"`
+	PageSize int    `form:"pageSize" binding:"omitempty,min=1,max=100"`
+	AgentID  string `form:"agentId" binding:"omitempty,max=32"`
+	Stage    string `form:"stage" binding:"omitempty,oneof=input output"`
+	Guard    string `form:"guard" binding:"omitempty,oneof=blocklist pii injection moderation"`
+	Action   string `form:"action" binding:"omitempty,oneof=block redact flag"`
+	Status   string `form:"status" binding:"omitempty,oneof=pending confirmed dismissed"`
+}
+
+// AiGuardrailEventInfo represents a guardrail match. Personal data in the excerpt is masked.
+type AiGuardrailEventInfo struct {
+	ID         uint64     `json:"id"`
+	UID        string     `json:"uid"`
+	SessionID  string     `json:"sessionId"`
+	AgentID    string     `json:"agentId"`
+	Model      string     `json:"model"`
+	Stage      string     `json:"stage"`
+	Guard      string     `json:"guard"`
+	Rule       string     `json:"rule"` // Matched keyword or pattern, PII type, injection heuristic or moderation categories
+	Action     string     `json:"action"`
+	Excerpt    string     `json:"excerpt"`
+	Status     string     `json:"status"` // pending, confirmed or dismissed
+	ReviewedBy string     `json:"reviewedBy"`
+	ReviewNote string     `json:"reviewNote"`
+	ReviewedAt *time.Time `json:"reviewedAt"`
+	CreatedAt  time.Time  `json:"createdAt"`
+}
+
+// ListAiGuardrailEventResponse represents a page of guardrail events, newest first.
+type ListAiGuardrailEventResponse struct {
+	Total int64                  `json:"total"`
+	Data  []AiGuardrailEventInfo `json:"data"`
+}
+
+// ReviewAiGuardrailEventRequest confirms or dismisses a guardrail event.
+type ReviewAiGuardrailEventRequest struct {
+	Status string `json:"status" binding:"required,oneof=confirmed dismissed" example:"confirmed"`
+	Note   string `json:"note,omitempty" binding:"max=1000" example:"Dosage question, correctly blocked"`
+}
@@ -0,0 +1,33 @@
+// ABOUTME: AI knowledge base API request and response structures.
+// ABOUTME: Defines DTOs for managing documents attached to AI agents.
+
+package v1
+
+import "time"
+
+// CreateAiKnowledgeDocRequest represents a request to add a document to an agent's knowledge base.
+// Content is plain text or markdown, PDFs must be converted to text beforehand.
+type CreateAiKnowledgeDocRequest struct {
+	Title   string `json:"title" binding:"required,max=255" example:"退款政策"`
+	Content string `json:"content" binding:"required,max=200000" example:"订单签收后 7 天内可申请无理由退款..."`
+}
+
+// AiKnowledgeDocInfo represents a knowledge base document.
+type AiKnowledgeDocInfo struct {
+	ID             uint64    `json:"id"`
+	AgentID        string    `json:"agentId"`
+	Title          string    `json:"title"`
+	EmbeddingModel string    `json:"embeddingModel"`
+	ChunkCount     int       `json:"chunkCount"`
+	Chars          int       `json:"chars"`
+	Status         string    `json:"status"`
+	Error          string    `json:"error,omitempty"`
+	CreatedAt      time.Time `json:"createdAt"`
+	UpdatedAt      time.Time `json:"updatedAt"`
+}
+
+// ListAiKnowledgeDocResponse represents a response containing knowledge base documents.
+type ListAiKnowledgeDocResponse struct {
+	Total int64                `json:"total"`
+	Data  []AiKnowledgeDocInfo `json:"data"`
+}
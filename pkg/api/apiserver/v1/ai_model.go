@@ -11,6 +11,7 @@ type AiModelInfo struct {
 	ProviderName  string    `json:"providerName"`
 	Model         string    `json:"model"`
 	DisplayName   string    `json:"displayName"`
+	Type          string    `json:"type"`
 	MaxTokens     int       `json:"maxTokens"`
 	InputPrice    float64   `json:"inputPrice"`
 	OutputPrice   float64   `json:"outputPrice"`
@@ -39,6 +40,7 @@ type CreateAiModelRequest struct {
 	ProviderName  string  `json:"providerName" binding:"required,max=32" example:"openai"`
 	Model         string  `json:"model" binding:"required,max=64" example:"gpt-4o"`
 	DisplayName   string  `json:"displayName" binding:"required,max=64" example:"GPT-4 Omni"`
+	Type          string  `json:"type,omitempty" binding:"omitempty,oneof=chat embedding" example:"chat"`
 	MaxTokens     int     `json:"maxTokens,omitempty" binding:"omitempty,min=1" example:"8192"`
 	InputPrice    float64 `json:"inputPrice,omitempty" binding:"omitempty,min=0" example:"0.005"`
 	OutputPrice   float64 `json:"outputPrice,omitempty" binding:"omitempty,min=0" example:"0.015"`
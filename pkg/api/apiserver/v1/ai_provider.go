@@ -10,9 +10,12 @@ type AiProviderInfo struct {
 	ID          uint      `json:"id"`
 	Name        string    `json:"name"`
 	DisplayName string    `json:"displayName"`
+	BaseURL     string    `json:"baseURL"`
+	APIKey      string    `json:"apiKey" example:"sk-****abcd"` // Masked, empty when not set
 	Status      string    `json:"status"`
 	IsDefault   bool      `json:"isDefault"`
 	Sort        int       `json:"sort"`
+	KeyStrategy string    `json:"keyStrategy"` // round_robin or least_used, how requests are spread over the provider's keys
 	CreatedAt   time.Time `json:"createdAt"`
 	UpdatedAt   time.Time `json:"updatedAt"`
 }
@@ -28,10 +31,51 @@ type ListAiProviderResponse struct {
 	Data  []AiProviderInfo `json:"data"`
 }
 
+// CreateAiProviderRequest represents a request to onboard an AI provider.
+type CreateAiProviderRequest struct {
+	Name        string `json:"name" binding:"required,max=32,alphanum,lowercase" example:"mistral"`
+	DisplayName string `json:"displayName,omitempty" binding:"omitempty,max=64" example:"Mistral"`
+	BaseURL     string `json:"baseURL,omitempty" binding:"omitempty,url,max=255" example:"https://api.mistral.ai/v1"` // Required for providers without a built-in endpoint
+	APIKey      string `json:"apiKey,omitempty" binding:"omitempty,max=512"`
+	Status      string `json:"status,omitempty" binding:"omitempty,oneof=active disabled" example:"active"`
+	IsDefault   bool   `json:"isDefault,omitempty"`
+	Sort        int    `json:"sort,omitempty"`
+	KeyStrategy string `json:"keyStrategy,omitempty" binding:"omitempty,oneof=round_robin least_used"`
+}
+
 // UpdateAiProviderRequest represents a request to update an AI provider.
 type UpdateAiProviderRequest struct {
-	DisplayName string `json:"displayName,omitempty" binding:"omitempty,max=64"`
-	Status      string `json:"status,omitempty" binding:"omitempty,oneof=active disabled"`
-	IsDefault   *bool  `json:"isDefault,omitempty"`
-	Sort        *int   `json:"sort,omitempty"`
+	DisplayName string  `json:"displayName,omitempty" binding:"omitempty,max=64"`
+	BaseURL     *string `json:"baseURL,omitempty" binding:"omitempty,max=255"` // Empty clears the base URL
+	APIKey      *string `json:"apiKey,omitempty" binding:"omitempty,max=512"`  // Replaces the key, empty clears it
+	Status      string  `json:"status,omitempty" binding:"omitempty,oneof=active disabled"`
+	IsDefault   *bool   `json:"isDefault,omitempty"`
+	Sort        *int    `json:"sort,omitempty"`
+	KeyStrategy string  `json:"keyStrategy,omitempty" binding:"omitempty,oneof=round_robin least_used"`
+}
+
+// AiLocalModelInfo represents a model served by a self-hosted provider.
+type AiLocalModelInfo struct {
+	Model     string `json:"model"`
+	Type      string `json:"type"`
+	MaxTokens int    `json:"maxTokens"`
+	Imported  bool   `json:"imported"`
+}
+
+// ListAiLocalModelResponse represents a response containing the models served by a provider.
+type ListAiLocalModelResponse struct {
+	Total int64              `json:"total"`
+	Data  []AiLocalModelInfo `json:"data"`
+}
+
+// ImportAiLocalModelRequest represents a request to import served models into ai_model.
+type ImportAiLocalModelRequest struct {
+	Models []string `json:"models,omitempty" binding:"omitempty,max=100,dive,max=64" example:"llama3.1:8b"` // Empty imports all
+	Status string   `json:"status,omitempty" binding:"omitempty,oneof=active disabled" example:"active"`
+}
+
+// ImportAiLocalModelResponse represents the result of a model import.
+type ImportAiLocalModelResponse struct {
+	Imported []AiModelInfo `json:"imported"`
+	Skipped  []string      `json:"skipped"` // Already imported, not served, or name too long
 }
@@ -0,0 +1,39 @@
+// ABOUTME: AI provider API key request and response structures.
+// ABOUTME: Defines DTOs for managing the keys a provider's requests are balanced across.
+
+package v1
+
+import "time"
+
+// AiProviderKeyInfo represents a provider API key, the key itself is masked.
+type AiProviderKeyInfo struct {
+	ID           uint      `json:"id"`
+	ProviderName string    `json:"providerName"`
+	Name         string    `json:"name"`
+	APIKey       string    `json:"apiKey" example:"sk-****abcd"`
+	Weight       int       `json:"weight"`
+	Status       string    `json:"status"`
+	CreatedAt    time.Time `json:"createdAt"`
+	UpdatedAt    time.Time `json:"updatedAt"`
+}
+
+// ListAiProviderKeyResponse represents a response containing the keys of a provider.
+type ListAiProviderKeyResponse struct {
+	Total int64               `json:"total"`
+	Data  []AiProviderKeyInfo `json:"data"`
+}
+
+// CreateAiProviderKeyRequest represents a request to add an API key to a provider.
+type CreateAiProviderKeyRequest struct {
+	Name   string `json:"name" binding:"required,max=64" example:"team-b"`
+	APIKey string `json:"apiKey" binding:"required,max=512"`
+	Weight int    `json:"weight,omitempty" binding:"omitempty,min=1,max=100" example:"1"`
+	Status string `json:"status,omitempty" binding:"omitempty,oneof=active disabled" example:"active"`
+}
+
+// UpdateAiProviderKeyRequest represents a request to update a provider API key.
+type UpdateAiProviderKeyRequest struct {
+	APIKey string `json:"apiKey,omitempty" binding:"omitempty,max=512"` // Replaces the key when set
+	Weight *int   `json:"weight,omitempty" binding:"omitempty,min=1,max=100"`
+	Status string `json:"status,omitempty" binding:"omitempty,oneof=active disabled"`
+}
@@ -1,5 +1,5 @@
 // ABOUTME: AI Quota API request and response structures.
-// ABOUTME: Defines DTOs for AI User Quota management operations.
+// ABOUTME: Defines DTOs for AI User Quota and quota tier management operations.
 
 package v1
 
@@ -37,3 +37,20 @@ type UpdateAiUserQuotaRequest struct {
 	RPM  *int   `json:"rpm,omitempty" binding:"omitempty,min=0"`
 	TPD  *int   `json:"tpd,omitempty" binding:"omitempty,min=0"`
 }
+
+// AiQuotaTierInfo represents quota tier information.
+type AiQuotaTierInfo struct {
+	Tier        string    `json:"tier"`
+	DisplayName string    `json:"displayName"`
+	RPM         int       `json:"rpm"`
+	TPD         int       `json:"tpd"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+// UpdateAiQuotaTierRequest represents a request to update a quota tier.
+type UpdateAiQuotaTierRequest struct {
+	DisplayName string `json:"displayName,omitempty" binding:"omitempty,max=64"`
+	RPM         *int   `json:"rpm,omitempty" binding:"omitempty,min=0"`
+	TPD         *int   `json:"tpd,omitempty" binding:"omitempty,min=0"`
+}
@@ -0,0 +1,44 @@
+// ABOUTME: AI conversation search API request and response structures.
+// ABOUTME: Defines DTOs for searching session titles and message content with highlights.
+
+package v1
+
+import "time"
+
+// AiSearchRequest represents a conversation search. Every word of Q must match.
+type AiSearchRequest struct {
+	Q        string `form:"q" binding:"required,max=200" example:"退款"`
+	Page     int    `form:"page" binding:"omitempty,min=1"`
+	PageSize int    `form:"pageSize" binding:"omitempty,min=1,max=100"`
+}
+
+// AdminAiSearchRequest represents a conversation search across users.
+type AdminAiSearchRequest struct {
+	AiSearchRequest
+	UID string `form:"uid" binding:"omitempty,max=64"` // Searches all users if empty
+}
+
+// AiSearchHighlight is a match as rune offsets into the highlighted text, end exclusive.
+type AiSearchHighlight struct {
+	Start int `json:"start"`
+	End   int `json:"end"`
+}
+
+// AiSearchHit represents a session whose title matches, or a message whose content matches.
+type AiSearchHit struct {
+	SessionID       string              `json:"sessionId"`
+	UID             string              `json:"uid"`
+	Title           string              `json:"title"`
+	TitleHighlights []AiSearchHighlight `json:"titleHighlights"`
+	MessageID       uint64              `json:"messageId,omitempty"` // Empty when the session title matched
+	Role            string              `json:"role,omitempty"`
+	Snippet         string              `json:"snippet,omitempty"` // Excerpt of the message around the first match
+	Highlights      []AiSearchHighlight `json:"highlights,omitempty"`
+	CreatedAt       time.Time           `json:"createdAt"`
+}
+
+// AiSearchResponse represents conversation search hits, newest first.
+type AiSearchResponse struct {
+	Total int64         `json:"total"`
+	Data  []AiSearchHit `json:"data"`
+}
@@ -0,0 +1,49 @@
+// ABOUTME: Session transcript and share link API structures.
+// ABOUTME: Defines DTOs for exporting, importing and publicly sharing chat sessions.
+
+package v1
+
+import "time"
+
+// ExportSessionRequest represents a session export request.
+type ExportSessionRequest struct {
+	Format string `form:"format" binding:"omitempty,oneof=json markdown" example:"markdown"` // Defaults to json
+}
+
+// SessionTranscript is the portable JSON form of a session's active branch.
+// An exported transcript can be imported as is.
+type SessionTranscript struct {
+	Title     string              `json:"title" binding:"max=255"`
+	Model     string              `json:"model,omitempty" binding:"max=64"`
+	CreatedAt *time.Time          `json:"createdAt,omitempty"` // Ignored on import
+	Messages  []TranscriptMessage `json:"messages" binding:"required,min=1,max=500,dive"`
+}
+
+// TranscriptMessage represents a message of a transcript.
+type TranscriptMessage struct {
+	ChatMessage
+	Model     string     `json:"model,omitempty"`     // Model of an assistant message, ignored on import
+	CreatedAt *time.Time `json:"createdAt,omitempty"` // Ignored on import
+}
+
+// CreateShareRequest represents a share link creation request.
+type CreateShareRequest struct {
+	ExpireDays int `json:"expireDays,omitempty" binding:"omitempty,min=1,max=365" example:"7"` // Never expires if empty
+}
+
+// ShareInfo represents a share link of a session.
+type ShareInfo struct {
+	Token     string     `json:"token"`
+	SessionID string     `json:"sessionId"`
+	Title     string     `json:"title"`
+	Views     int        `json:"views"`
+	ExpiresAt *time.Time `json:"expiresAt"`
+	CreatedAt time.Time  `json:"createdAt"`
+}
+
+// SharedSessionResponse represents the public read-only snapshot behind a share link.
+type SharedSessionResponse struct {
+	SessionTranscript
+	Views    int       `json:"views"`
+	SharedAt time.Time `json:"sharedAt"`
+}
@@ -0,0 +1,75 @@
+// ABOUTME: AI Usage API request and response structures.
+// ABOUTME: Defines DTOs for the AI usage ledger and its spend aggregates.
+
+package v1
+
+import "time"
+
+// AiUsageInfo represents a single usage ledger entry.
+type AiUsageInfo struct {
+	ID               uint64    `json:"id"`
+	UID              string    `json:"uid"`
+	SessionID        string    `json:"sessionId"`
+	AgentID          string    `json:"agentId"`
+	ProviderName     string    `json:"providerName"`
+	Model            string    `json:"model"`
+	Stream           bool      `json:"stream"`
+	Fallback         bool      `json:"fallback"`
+	PromptTokens     int       `json:"promptTokens"`
+	CompletionTokens int       `json:"completionTokens"`
+	TotalTokens      int       `json:"totalTokens"`
+	Cost             float64   `json:"cost"`
+	LatencyMs        int64     `json:"latencyMs"`
+	Status           string    `json:"status"`
+	Error            string    `json:"error,omitempty"`
+	CreatedAt        time.Time `json:"createdAt"`
+}
+
+// ListAiUsageRequest represents a request to list usage ledger entries.
+// From and To are inclusive days.
+type ListAiUsageRequest struct {
+	UID          string     `form:"uid" binding:"omitempty,max=64"`
+	Model        string     `form:"model" binding:"omitempty,max=64"`
+	ProviderName string     `form:"providerName" binding:"omitempty,max=32"`
+	Status       string     `form:"status" binding:"omitempty,oneof=success error"`
+	From         *time.Time `form:"from" time_format:"2006-01-02"`
+	To           *time.Time `form:"to" time_format:"2006-01-02"`
+	Page         int        `form:"page" binding:"omitempty,min=1"`
+	PageSize     int        `form:"pageSize" binding:"omitempty,min=1,max=100"`
+}
+
+// ListAiUsageResponse represents a response containing a list of usage ledger entries.
+type ListAiUsageResponse struct {
+	Total int64         `json:"total"`
+	Data  []AiUsageInfo `json:"data"`
+}
+
+// AiUsageSummaryRequest represents a request to aggregate usage by user, model or day.
+// From and To are inclusive days, the range defaults to the current month.
+type AiUsageSummaryRequest struct {
+	GroupBy string     `form:"groupBy" binding:"required,oneof=user model day"`
+	UID     string     `form:"uid" binding:"omitempty,max=64"`
+	Model   string     `form:"model" binding:"omitempty,max=64"`
+	From    *time.Time `form:"from" time_format:"2006-01-02"`
+	To      *time.Time `form:"to" time_format:"2006-01-02"`
+}
+
+// AiUsageSummaryItem represents the aggregated usage of one group.
+type AiUsageSummaryItem struct {
+	Key              string  `json:"key"`
+	Requests         int64   `json:"requests"`
+	Errors           int64   `json:"errors"`
+	PromptTokens     int64   `json:"promptTokens"`
+	CompletionTokens int64   `json:"completionTokens"`
+	TotalTokens      int64   `json:"totalTokens"`
+	Cost             float64 `json:"cost"`
+}
+
+// AiUsageSummaryResponse represents aggregated usage with totals over all groups.
+type AiUsageSummaryResponse struct {
+	GroupBy string               `json:"groupBy"`
+	From    string               `json:"from"`
+	To      string               `json:"to"`
+	Data    []AiUsageSummaryItem `json:"data"`
+	Total   AiUsageSummaryItem   `json:"total"`
+}